@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 )
 
 type (
@@ -25,19 +26,41 @@ type (
 		Level    string
 	}
 
-	// Storage - storage config.
+	// Storage - storage config. Backend is a kind of persistent storage, either SqliteBackend
+	// which keeps data in Filename database or JournalBackend which keeps it in Journal text file.
+	// Profile is a name of profile to open at startup, when it is empty the profile is picked by
+	// user. TrashRetention is a number of days deleted items are kept in trash, zero keeps them
+	// forever.
 	Storage struct {
-		Path     string
-		Filename string
+		Backend        string
+		Path           string
+		Filename       string
+		Journal        string
+		Attachments    string
+		Profiles       string
+		Profile        string
+		TrashRetention int
 	}
 )
 
+// Available storage backends.
+const (
+	SqliteBackend  = "sqlite"
+	JournalBackend = "journal"
+)
+
 func overwriteStrIfEnv(targetValue *string, envKey string) {
 	if envValue := os.Getenv(envKey); envValue != "" {
 		*targetValue = envValue
 	}
 }
 
+func overwriteIntIfEnv(targetValue *int, envKey string) {
+	if envValue, err := strconv.Atoi(os.Getenv(envKey)); err == nil {
+		*targetValue = envValue
+	}
+}
+
 func postprocess(c *Config) {
 	// Logger path
 	overwriteStrIfEnv(&c.Logger.Path, "GMON_DATA_DIR")
@@ -52,4 +75,13 @@ func postprocess(c *Config) {
 	// Storage path
 	overwriteStrIfEnv(&c.Storage.Path, "GMON_DATA_DIR")
 	c.Storage.Path = os.ExpandEnv(c.Storage.Path)
+
+	// Storage backend
+	overwriteStrIfEnv(&c.Storage.Backend, "GMON_BACKEND")
+
+	// Storage profile
+	overwriteStrIfEnv(&c.Storage.Profile, "GMON_PROFILE")
+
+	// Trash retention
+	overwriteIntIfEnv(&c.Storage.TrashRetention, "GMON_TRASH_RETENTION")
 }
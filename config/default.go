@@ -16,8 +16,14 @@ func Default() *Config {
 			Level:    "",
 		},
 		Storage: Storage{
-			Path:     defaultPath,
-			Filename: "data.sqlite3",
+			Backend:        SqliteBackend,
+			Path:           defaultPath,
+			Filename:       "data.sqlite3",
+			Journal:        "journal.txt",
+			Attachments:    "attachments",
+			Profiles:       "profiles",
+			Profile:        "",
+			TrashRetention: 30,
 		},
 	}
 
@@ -0,0 +1,86 @@
+package adapter
+
+import (
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+)
+
+// inmemoryStorage adapts inmemory storage to inmemory storage used by service.
+type inmemoryStorage struct {
+	*inmemory.InmemoryStorage
+}
+
+// Inmemory returns inmemory storage as inmemory storage of service.
+func Inmemory(s *inmemory.InmemoryStorage) service.InmemoryStorage {
+	return inmemoryStorage{s}
+}
+
+// Category returns category inmemory storage.
+func (s inmemoryStorage) Category() service.CategoryInmemoryStorage {
+	return s.InmemoryStorage.Category()
+}
+
+// Currency returns currency inmemory storage.
+func (s inmemoryStorage) Currency() service.CurrencyInmemoryStorage {
+	return s.InmemoryStorage.Currency()
+}
+
+// Account returns account inmemory storage.
+func (s inmemoryStorage) Account() service.AccountInmemoryStorage {
+	return s.InmemoryStorage.Account()
+}
+
+// Transaction returns transaction inmemory storage.
+func (s inmemoryStorage) Transaction() service.TransactionInmemoryStorage {
+	return s.InmemoryStorage.Transaction()
+}
+
+// Transfer returns transfer inmemory storage.
+func (s inmemoryStorage) Transfer() service.TransferInmemoryStorage {
+	return s.InmemoryStorage.Transfer()
+}
+
+// ExchangeRate returns exchange rate inmemory storage.
+func (s inmemoryStorage) ExchangeRate() service.ExchangeRateInmemoryStorage {
+	return s.InmemoryStorage.ExchangeRate()
+}
+
+// Budget returns budget inmemory storage.
+func (s inmemoryStorage) Budget() service.BudgetInmemoryStorage {
+	return s.InmemoryStorage.Budget()
+}
+
+// Recurrence returns recurrence inmemory storage.
+func (s inmemoryStorage) Recurrence() service.RecurrenceInmemoryStorage {
+	return s.InmemoryStorage.Recurrence()
+}
+
+// Tag returns tag inmemory storage.
+func (s inmemoryStorage) Tag() service.TagInmemoryStorage {
+	return s.InmemoryStorage.Tag()
+}
+
+// Payee returns payee inmemory storage.
+func (s inmemoryStorage) Payee() service.PayeeInmemoryStorage {
+	return s.InmemoryStorage.Payee()
+}
+
+// Attachment returns attachment inmemory storage.
+func (s inmemoryStorage) Attachment() service.AttachmentInmemoryStorage {
+	return s.InmemoryStorage.Attachment()
+}
+
+// Goal returns goal inmemory storage.
+func (s inmemoryStorage) Goal() service.GoalInmemoryStorage {
+	return s.InmemoryStorage.Goal()
+}
+
+// Counterparty returns counterparty inmemory storage.
+func (s inmemoryStorage) Counterparty() service.CounterpartyInmemoryStorage {
+	return s.InmemoryStorage.Counterparty()
+}
+
+// Debt returns debt inmemory storage.
+func (s inmemoryStorage) Debt() service.DebtInmemoryStorage {
+	return s.InmemoryStorage.Debt()
+}
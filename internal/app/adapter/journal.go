@@ -0,0 +1,93 @@
+// Package adapter adapts storages to interfaces used by service, so storage packages
+// don't depend on service.
+package adapter
+
+import (
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/journal"
+)
+
+// journalStorage adapts journal to persistent storage used by service.
+type journalStorage struct {
+	*journal.Journal
+}
+
+// Journal returns journal as persistent storage of service.
+func Journal(j *journal.Journal) service.PersistentStorage {
+	return journalStorage{j}
+}
+
+// Category returns category journal storage.
+func (s journalStorage) Category() service.CategoryPersistentStorage {
+	return s.Journal.Category()
+}
+
+// Currency returns currency journal storage.
+func (s journalStorage) Currency() service.CurrencyPersistentStorage {
+	return s.Journal.Currency()
+}
+
+// Account returns account journal storage.
+func (s journalStorage) Account() service.AccountPersistentStorage {
+	return s.Journal.Account()
+}
+
+// Transaction returns transaction journal storage.
+func (s journalStorage) Transaction() service.TransactionPersistentStorage {
+	return s.Journal.Transaction()
+}
+
+// Transfer returns transfer journal storage.
+func (s journalStorage) Transfer() service.TransferPersistentStorage {
+	return s.Journal.Transfer()
+}
+
+// ExchangeRate returns exchange rate journal storage.
+func (s journalStorage) ExchangeRate() service.ExchangeRatePersistentStorage {
+	return s.Journal.ExchangeRate()
+}
+
+// Budget returns budget journal storage.
+func (s journalStorage) Budget() service.BudgetPersistentStorage {
+	return s.Journal.Budget()
+}
+
+// Recurrence returns recurrence journal storage.
+func (s journalStorage) Recurrence() service.RecurrencePersistentStorage {
+	return s.Journal.Recurrence()
+}
+
+// Tag returns tag journal storage.
+func (s journalStorage) Tag() service.TagPersistentStorage {
+	return s.Journal.Tag()
+}
+
+// Split returns split journal storage.
+func (s journalStorage) Split() service.SplitPersistentStorage {
+	return s.Journal.Split()
+}
+
+// Payee returns payee journal storage.
+func (s journalStorage) Payee() service.PayeePersistentStorage {
+	return s.Journal.Payee()
+}
+
+// Attachment returns attachment journal storage.
+func (s journalStorage) Attachment() service.AttachmentPersistentStorage {
+	return s.Journal.Attachment()
+}
+
+// Goal returns goal journal storage.
+func (s journalStorage) Goal() service.GoalPersistentStorage {
+	return s.Journal.Goal()
+}
+
+// Counterparty returns counterparty journal storage.
+func (s journalStorage) Counterparty() service.CounterpartyPersistentStorage {
+	return s.Journal.Counterparty()
+}
+
+// Debt returns debt journal storage.
+func (s journalStorage) Debt() service.DebtPersistentStorage {
+	return s.Journal.Debt()
+}
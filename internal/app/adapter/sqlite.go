@@ -0,0 +1,91 @@
+package adapter
+
+import (
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+)
+
+// sqliteStorage adapts sqlite storage to persistent storage used by service.
+type sqliteStorage struct {
+	*sqlite.SqliteStorage
+}
+
+// Sqlite returns sqlite storage as persistent storage of service.
+func Sqlite(s *sqlite.SqliteStorage) service.PersistentStorage {
+	return sqliteStorage{s}
+}
+
+// Category returns category sqlite storage.
+func (s sqliteStorage) Category() service.CategoryPersistentStorage {
+	return s.SqliteStorage.Category()
+}
+
+// Currency returns currency sqlite storage.
+func (s sqliteStorage) Currency() service.CurrencyPersistentStorage {
+	return s.SqliteStorage.Currency()
+}
+
+// Account returns account sqlite storage.
+func (s sqliteStorage) Account() service.AccountPersistentStorage {
+	return s.SqliteStorage.Account()
+}
+
+// Transaction returns transaction sqlite storage.
+func (s sqliteStorage) Transaction() service.TransactionPersistentStorage {
+	return s.SqliteStorage.Transaction()
+}
+
+// Transfer returns transfer sqlite storage.
+func (s sqliteStorage) Transfer() service.TransferPersistentStorage {
+	return s.SqliteStorage.Transfer()
+}
+
+// ExchangeRate returns exchange rate sqlite storage.
+func (s sqliteStorage) ExchangeRate() service.ExchangeRatePersistentStorage {
+	return s.SqliteStorage.ExchangeRate()
+}
+
+// Budget returns budget sqlite storage.
+func (s sqliteStorage) Budget() service.BudgetPersistentStorage {
+	return s.SqliteStorage.Budget()
+}
+
+// Recurrence returns recurrence sqlite storage.
+func (s sqliteStorage) Recurrence() service.RecurrencePersistentStorage {
+	return s.SqliteStorage.Recurrence()
+}
+
+// Tag returns tag sqlite storage.
+func (s sqliteStorage) Tag() service.TagPersistentStorage {
+	return s.SqliteStorage.Tag()
+}
+
+// Split returns split sqlite storage.
+func (s sqliteStorage) Split() service.SplitPersistentStorage {
+	return s.SqliteStorage.Split()
+}
+
+// Payee returns payee sqlite storage.
+func (s sqliteStorage) Payee() service.PayeePersistentStorage {
+	return s.SqliteStorage.Payee()
+}
+
+// Attachment returns attachment sqlite storage.
+func (s sqliteStorage) Attachment() service.AttachmentPersistentStorage {
+	return s.SqliteStorage.Attachment()
+}
+
+// Goal returns goal sqlite storage.
+func (s sqliteStorage) Goal() service.GoalPersistentStorage {
+	return s.SqliteStorage.Goal()
+}
+
+// Counterparty returns counterparty sqlite storage.
+func (s sqliteStorage) Counterparty() service.CounterpartyPersistentStorage {
+	return s.SqliteStorage.Counterparty()
+}
+
+// Debt returns debt sqlite storage.
+func (s sqliteStorage) Debt() service.DebtPersistentStorage {
+	return s.SqliteStorage.Debt()
+}
@@ -1,16 +1,10 @@
 package app
 
 import (
-	"database/sql"
 	"fmt"
 	"os"
-	"path"
 
 	"github.com/kotlw/gentlemoney/config"
-	"github.com/kotlw/gentlemoney/internal/presenter"
-	"github.com/kotlw/gentlemoney/internal/service"
-	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
-	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
 	"github.com/kotlw/gentlemoney/internal/tui"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -24,53 +18,38 @@ func Run() {
 	log := InitLogger(cfg.Logger.Level, cfg.Logger.Path, cfg.Logger.Filename)
 	log.Debug("Config has initialized.")
 
-	// DB connection
+	// Storage folder
 	err := os.MkdirAll(cfg.Storage.Path, os.ModePerm)
 	if err != nil {
 		log.WithField("path", cfg.Storage.Path).Info(fmt.Errorf("Failed to create floder: %w", err))
 	}
-	p := path.Join(cfg.Storage.Path, cfg.Storage.Filename)
-
-	db, err := sql.Open("sqlite3", p)
-	if err != nil {
-		log.Fatal(fmt.Errorf("app: Run: sql.Open: %w", err))
-	}
-	log.WithField("path", p).Debug("sql.DB has Opened")
-
-	if err = db.Ping(); err != nil {
-		log.Fatal(fmt.Errorf("app: Run: db.Ping: %w", err))
-	}
 
+	// Profiles
+	profiles := NewProfiles(cfg, log)
 	defer func() {
-		if err = db.Close(); err != nil {
-			log.Fatal(fmt.Errorf("app: Run: db.Close: %w", err))
+		if err = profiles.Close(); err != nil {
+			log.Fatal(fmt.Errorf("app: Run: profiles.Close: %w", err))
 		}
 	}()
 
-	// Persistent Storage
-	persistenrStorage, err := sqlite.New(db)
-	if err != nil {
-		log.Fatal(fmt.Errorf("app: Run: sqlite.New: %w", err))
+	// profile is picked by user only if there is something to pick.
+	name := cfg.Storage.Profile
+	if name == "" {
+		names, err := profiles.List()
+		if err != nil {
+			log.Fatal(fmt.Errorf("app: Run: profiles.List: %w", err))
+		}
+		if len(names) == 1 {
+			name = names[0]
+		}
 	}
-	log.Debug("SqliteStorage has initialized.")
-
-	// Inmemory Storage
-	inmemoryStorage := inmemory.New()
-	log.Debug("InmemoryStorage has initialized.")
+	log.Debug("Profiles have initialized.")
 
-	// Service
-	service, err := service.New(persistenrStorage, inmemoryStorage)
+	// Terminal user interface.
+	t, err := tui.New(profiles, name)
 	if err != nil {
-		log.Fatal(fmt.Errorf("app: Run: service.New: %w", err))
+		log.Fatal(fmt.Errorf("app: Run: tui.New: %w", err))
 	}
-	log.Debug("Service has initialized.")
-
-	// Presenter
-	presenter := presenter.New(service)
-	log.Debug("Presenter has initialized.")
-
-	// Terminal user interface.
-	t := tui.New(service, presenter)
 	log.Debug("TviewApplication has initialized.")
 	if err := t.Run(); err != nil {
 		t.Stop()
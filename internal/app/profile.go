@@ -0,0 +1,193 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/kotlw/gentlemoney/config"
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/journal"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultProfile is a name of profile which is stored right in the data dir. It keeps the data
+// created before profiles were introduced.
+const DefaultProfile = "default"
+
+// profileNameRe is a pattern of valid profile name, since the name is used as a folder name.
+var profileNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Profiles is a registry of profiles. Each profile is a separate ledger with its own database or
+// journal file and attachments folder, only one profile is open at a time.
+type Profiles struct {
+	cfg     *config.Config
+	log     *logrus.Logger
+	db      *sql.DB
+	current string
+}
+
+// NewProfiles returns new Profiles.
+func NewProfiles(cfg *config.Config, log *logrus.Logger) *Profiles {
+	return &Profiles{cfg: cfg, log: log}
+}
+
+// List returns names of existing profiles sorted alphabetically, default profile is always listed.
+func (p *Profiles) List() ([]string, error) {
+	res := []string{DefaultProfile}
+
+	entries, err := os.ReadDir(path.Join(p.cfg.Storage.Path, p.cfg.Storage.Profiles))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("os.ReadDir: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != DefaultProfile && profileNameRe.MatchString(e.Name()) {
+			res = append(res, e.Name())
+		}
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
+
+// Current returns name of open profile, it is empty if none of profiles is open yet.
+func (p *Profiles) Current() string {
+	return p.current
+}
+
+// Backend returns kind of persistent storage profiles are kept in.
+func (p *Profiles) Backend() string {
+	return p.cfg.Storage.Backend
+}
+
+// Open opens profile with given name, missing profile is created. Previously open profile is
+// closed only when the new one is initialized successfully, so failed attempt keeps it usable.
+func (p *Profiles) Open(name string) (*service.Service, *presenter.Presenter, error) {
+	if !profileNameRe.MatchString(name) {
+		return nil, nil, fmt.Errorf("invalid profile name %q", name)
+	}
+
+	dir := p.dir(name)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, nil, fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	persistentStorage, db, err := p.openStorage(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s, err := p.init(persistentStorage, dir)
+	if err != nil {
+		if db != nil {
+			db.Close()
+		}
+		return nil, nil, err
+	}
+
+	if err := p.Close(); err != nil {
+		p.log.Error(fmt.Errorf("app: Profiles.Open: p.Close: %w", err))
+	}
+	p.db, p.current = db, name
+	p.log.WithField("profile", name).Debug("Profile has opened.")
+
+	return s, presenter.New(s), nil
+}
+
+// Close closes database of open profile, if any.
+func (p *Profiles) Close() error {
+	if p.db != nil {
+		if err := p.db.Close(); err != nil {
+			return fmt.Errorf("p.db.Close: %w", err)
+		}
+	}
+
+	p.db, p.current = nil, ""
+
+	return nil
+}
+
+// openStorage opens persistent storage of profile in given dir with configured backend. Database
+// is returned only by sqlite backend, it should be closed along with the profile.
+func (p *Profiles) openStorage(dir string) (service.PersistentStorage, *sql.DB, error) {
+	switch backend := p.cfg.Storage.Backend; backend {
+	case config.SqliteBackend:
+		dbPath := path.Join(dir, p.cfg.Storage.Filename)
+		// foreign keys are enforced, so rows which other rows refer to can't be removed.
+		db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on")
+		if err != nil {
+			return nil, nil, fmt.Errorf("sql.Open: %w", err)
+		}
+		p.log.WithField("path", dbPath).Debug("sql.DB has Opened")
+
+		if err = db.Ping(); err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("db.Ping: %w", err)
+		}
+
+		persistentStorage, err := sqlite.New(db)
+		if err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("sqlite.New: %w", err)
+		}
+
+		return adapter.Sqlite(persistentStorage), db, nil
+	case config.JournalBackend:
+		journalPath := path.Join(dir, p.cfg.Storage.Journal)
+		persistentStorage, err := journal.New(journalPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("journal.New: %w", err)
+		}
+		p.log.WithField("path", journalPath).Debug("Journal has opened")
+
+		return adapter.Journal(persistentStorage), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
+
+// init initializes service on top of given persistent storage, generates due recurring
+// transactions and purges items kept in trash longer than retention period.
+func (p *Profiles) init(persistentStorage service.PersistentStorage, dir string) (*service.Service, error) {
+	s, err := service.New(persistentStorage, adapter.Inmemory(inmemory.New()), path.Join(dir, p.cfg.Storage.Attachments))
+	if err != nil {
+		return nil, fmt.Errorf("service.New: %w", err)
+	}
+
+	n, err := s.Recurrence().Generate(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("s.Recurrence().Generate: %w", err)
+	}
+	p.log.WithField("count", n).Debug("Recurring transactions have generated.")
+
+	if days := p.cfg.Storage.TrashRetention; days > 0 {
+		n, err = s.Trash().PurgeBefore(time.Now().AddDate(0, 0, -days))
+		if err != nil {
+			return nil, fmt.Errorf("s.Trash().PurgeBefore: %w", err)
+		}
+		p.log.WithField("count", n).Debug("Trash has purged.")
+	}
+
+	return s, nil
+}
+
+// dir returns folder where data of profile is stored.
+func (p *Profiles) dir(name string) string {
+	if name == DefaultProfile {
+		return p.cfg.Storage.Path
+	}
+
+	return path.Join(p.cfg.Storage.Path, p.cfg.Storage.Profiles, name)
+}
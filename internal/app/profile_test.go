@@ -0,0 +1,126 @@
+package app_test
+
+import (
+	"io"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/config"
+	"github.com/kotlw/gentlemoney/internal/app"
+	"github.com/kotlw/gentlemoney/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfilesTestSuite struct {
+	suite.Suite
+	cfg      *config.Config
+	profiles *app.Profiles
+}
+
+func (s *ProfilesTestSuite) SetupTest() {
+	s.cfg = config.Default()
+	s.cfg.Storage.Path = s.T().TempDir()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	s.profiles = app.NewProfiles(s.cfg, log)
+}
+
+func (s *ProfilesTestSuite) TestList() {
+	names, err := s.profiles.List()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{app.DefaultProfile}, names)
+
+	_, _, err = s.profiles.Open("work")
+	require.NoError(s.T(), err)
+	_, _, err = s.profiles.Open("another")
+	require.NoError(s.T(), err)
+
+	names, err = s.profiles.List()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"another", app.DefaultProfile, "work"}, names)
+}
+
+func (s *ProfilesTestSuite) TestOpen() {
+	service, presenter, err := s.profiles.Open(app.DefaultProfile)
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), presenter)
+	assert.Equal(s.T(), app.DefaultProfile, s.profiles.Current())
+	assert.FileExists(s.T(), path.Join(s.cfg.Storage.Path, s.cfg.Storage.Filename))
+
+	require.NoError(s.T(), service.Currency().Insert(&model.Currency{Abbreviation: "USD", IsMain: true}))
+
+	// data of one profile isn't visible in another one.
+	service, _, err = s.profiles.Open("work")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "work", s.profiles.Current())
+	assert.FileExists(s.T(), path.Join(s.cfg.Storage.Path, s.cfg.Storage.Profiles, "work", s.cfg.Storage.Filename))
+	assert.Empty(s.T(), service.Currency().GetAll())
+
+	service, _, err = s.profiles.Open(app.DefaultProfile)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), service.Currency().GetAll(), 1)
+}
+
+func (s *ProfilesTestSuite) TestOpenNegative() {
+	_, _, err := s.profiles.Open(app.DefaultProfile)
+	require.NoError(s.T(), err)
+
+	_, _, err = s.profiles.Open("../work")
+	assert.EqualError(s.T(), err, `invalid profile name "../work"`)
+
+	// database can't be created in place of existing file.
+	require.NoError(s.T(), os.MkdirAll(path.Join(s.cfg.Storage.Path, s.cfg.Storage.Profiles, "broken", s.cfg.Storage.Filename), os.ModePerm))
+	_, _, err = s.profiles.Open("broken")
+	assert.Error(s.T(), err)
+
+	// failed attempt keeps previous profile open.
+	assert.Equal(s.T(), app.DefaultProfile, s.profiles.Current())
+
+	s.cfg.Storage.Backend = "csv"
+	_, _, err = s.profiles.Open("work")
+	assert.EqualError(s.T(), err, `unknown storage backend "csv"`)
+}
+
+func (s *ProfilesTestSuite) TestOpenJournal() {
+	s.cfg.Storage.Backend = config.JournalBackend
+
+	service, _, err := s.profiles.Open(app.DefaultProfile)
+	require.NoError(s.T(), err)
+
+	currency := &model.Currency{Abbreviation: "USD", IsMain: true, Precision: 2}
+	account := &model.Account{Name: "Cash", Currency: currency, Type: model.Cash}
+	category := &model.Category{Title: "Grocery", Kind: model.Expense}
+	require.NoError(s.T(), service.Currency().Insert(currency))
+	require.NoError(s.T(), service.Account().Insert(account))
+	require.NoError(s.T(), service.Category().Insert(category))
+	require.NoError(s.T(), service.Transaction().Insert(&model.Transaction{
+		Date: time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC), Account: account, Category: category,
+		Amount: -1250, Note: "bread",
+	}))
+	assert.FileExists(s.T(), path.Join(s.cfg.Storage.Path, s.cfg.Storage.Journal))
+	assert.NoFileExists(s.T(), path.Join(s.cfg.Storage.Path, s.cfg.Storage.Filename))
+
+	// data is read back from journal
+	service, _, err = s.profiles.Open(app.DefaultProfile)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), service.Transaction().GetAll(), 1)
+	transaction := service.Transaction().GetAll()[0]
+	assert.Equal(s.T(), "bread", transaction.Note)
+	assert.Same(s.T(), service.Account().GetByName("Cash"), transaction.Account)
+	assert.Same(s.T(), service.Currency().GetByAbbreviation("USD"), transaction.Account.Currency)
+}
+
+func (s *ProfilesTestSuite) TearDownTest() {
+	require.NoError(s.T(), s.profiles.Close())
+}
+
+func TestProfilesTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfilesTestSuite))
+}
@@ -1,10 +1,39 @@
 package model
 
-// Account is a model of transaction account field.
+import "time"
+
+// AccountType is a type of account.
+type AccountType string
+
+// Available account types.
+const (
+	Cash       AccountType = "cash"
+	Checking   AccountType = "checking"
+	CreditCard AccountType = "credit card"
+	Savings    AccountType = "savings"
+	Investment AccountType = "investment"
+	Loan       AccountType = "loan"
+)
+
+// AccountTypes returns all available account types.
+func AccountTypes() []AccountType {
+	return []AccountType{Cash, Checking, CreditCard, Savings, Investment, Loan}
+}
+
+// Account is a model of transaction account field. OpeningBalance is the balance of the account at
+// OpeningDate, zero OpeningDate means that account is tracked from the very beginning. Zero
+// CreditLimit means account has no credit limit. Archived accounts are closed ones, they are kept
+// only for historical transactions. DeletedAt is set only for account in trash.
 type Account struct {
-	ID       int64
-	Name     string
-	Currency *Currency
+	ID             int64
+	Name           string
+	Currency       *Currency
+	OpeningBalance int64
+	OpeningDate    time.Time
+	Type           AccountType
+	CreditLimit    int64
+	Archived       bool
+	DeletedAt      time.Time
 }
 
 // NewEmptyAccount returns an empty Account with non nil nested structure. The purpose of this func
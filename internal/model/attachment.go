@@ -0,0 +1,16 @@
+package model
+
+// Attachment is a document, e.g. receipt scan, attached to transaction. The file itself is kept in
+// managed folder under the name derived from its content Hash, so equal files are stored once.
+type Attachment struct {
+	ID            int64
+	TransactionID int64
+	Name          string
+	Hash          string
+}
+
+// NewEmptyAttachment returns an empty Attachment. This function for consistancy with NewEmptyAccount and
+// NewEmptyTransaction.
+func NewEmptyAttachment() *Attachment {
+	return &Attachment{}
+}
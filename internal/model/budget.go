@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+// Budget is a model of spending limit for a category within a period. Period is the first day of
+// a month which budget is set for.
+type Budget struct {
+	ID       int64
+	Category *Category
+	Period   time.Time
+	Limit    int64
+}
+
+// NewEmptyBudget returns an empty Budget with non nil nested structures. The purpose of this func
+// to avoid erros when calling nested fields when they points to nil.
+func NewEmptyBudget() *Budget {
+	return &Budget{Category: NewEmptyCategory()}
+}
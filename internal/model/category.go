@@ -1,9 +1,36 @@
 package model
 
-// Category is a model of transaction category field.
+import "time"
+
+// CategoryKind tells whether transactions of category are incomes, expenses or could be both.
+type CategoryKind string
+
+// Available category kinds.
+const (
+	Income  CategoryKind = "income"
+	Expense CategoryKind = "expense"
+	Both    CategoryKind = "both"
+)
+
+// CategoryKinds returns all available category kinds.
+func CategoryKinds() []CategoryKind {
+	return []CategoryKind{Income, Expense, Both}
+}
+
+// Allows reports whether amount with given sign is expected for category of the kind, zero amount
+// is allowed for any kind.
+func (k CategoryKind) Allows(amount int64) bool {
+	return !(k == Income && amount < 0) && !(k == Expense && amount > 0)
+}
+
+// Category is a model of transaction category field. Categories form a tree, Parent is nil for top
+// level categories. DeletedAt is set only for category in trash.
 type Category struct {
-	ID    int64
-	Title string
+	ID        int64
+	Title     string
+	Parent    *Category
+	Kind      CategoryKind
+	DeletedAt time.Time
 }
 
 // NewEmptyCategory returns an empty Category. This function for consistancy with NewEmptyAccount
@@ -1,10 +1,26 @@
 package model
 
-// Currency is a model of account currency field.
+import (
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/money"
+)
+
+// DefaultPrecision is a number of minor digits of currency which precision isn't specified.
+const DefaultPrecision = 2
+
+// MaxPrecision is a maximal supported number of minor digits of currency.
+const MaxPrecision = 8
+
+// Currency is a model of account currency field. Precision is a number of minor digits, amounts
+// in this currency are kept as integer number of minor units. DeletedAt is set only for currency
+// in trash.
 type Currency struct {
 	ID           int64
 	Abbreviation string
 	IsMain       bool
+	Precision    int64
+	DeletedAt    time.Time
 }
 
 // NewEmptyCurrency returns an empty Currency. This function for consistancy with NewEmptyAccount
@@ -12,3 +28,23 @@ type Currency struct {
 func NewEmptyCurrency() *Currency {
 	return &Currency{}
 }
+
+// Money returns given amount of minor units as money in the currency. Amount of unknown currency
+// has default precision.
+func (c *Currency) Money(amount int64) money.Money {
+	if c == nil {
+		return money.New(amount, "", DefaultPrecision)
+	}
+
+	return money.New(amount, c.Abbreviation, c.Precision)
+}
+
+// ParseMoney parses decimal number like "-12.34" to money in the currency. Amount of unknown
+// currency has default precision.
+func (c *Currency) ParseMoney(value string) (money.Money, error) {
+	if c == nil {
+		return money.Parse(value, "", DefaultPrecision)
+	}
+
+	return money.Parse(value, c.Abbreviation, c.Precision)
+}
@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// DebtDirection is a direction of debt, it shows who is the lender.
+type DebtDirection string
+
+// Available debt directions.
+const (
+	Given DebtDirection = "given"
+	Taken DebtDirection = "taken"
+)
+
+// DebtDirections returns all available debt directions.
+func DebtDirections() []DebtDirection {
+	return []DebtDirection{Given, Taken}
+}
+
+// Counterparty is a model of person or organization with whom debts are made.
+type Counterparty struct {
+	ID   int64
+	Name string
+}
+
+// NewEmptyCounterparty returns an empty Counterparty. This function for consistancy with
+// NewEmptyAccount and NewEmptyTransaction.
+func NewEmptyCounterparty() *Counterparty {
+	return &Counterparty{}
+}
+
+// Debt is a model of loan given to or taken from counterparty. InterestRate is an annual rate in
+// percents. Installments is a number of monthly repayments starting a month after Date, zero
+// Installments means debt has no repayment schedule.
+type Debt struct {
+	ID           int64
+	Counterparty *Counterparty
+	Direction    DebtDirection
+	Principal    int64
+	Currency     *Currency
+	InterestRate float64
+	Date         time.Time
+	Installments int64
+	Note         string
+}
+
+// NewEmptyDebt returns an empty Debt with non nil nested structure. The purpose of this func to
+// avoid erros when calling nested fields when they points to nil.
+func NewEmptyDebt() *Debt {
+	return &Debt{Counterparty: NewEmptyCounterparty(), Currency: NewEmptyCurrency()}
+}
+
+// DebtRepayment is a link between debt and transaction which repays it.
+type DebtRepayment struct {
+	DebtID        int64
+	TransactionID int64
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+)
+
+// ExchangeRate is a model of exchange rate between two currencies on a certain date. Rate is an
+// amount of To currency which could be bought for one unit of From currency.
+type ExchangeRate struct {
+	ID   int64
+	Date time.Time
+	From *Currency
+	To   *Currency
+	Rate float64
+}
+
+// NewEmptyExchangeRate returns an empty ExchangeRate with non nil nested structures. The purpose of
+// this func to avoid erros when calling nested fields when they points to nil.
+func NewEmptyExchangeRate() *ExchangeRate {
+	return &ExchangeRate{From: NewEmptyCurrency(), To: NewEmptyCurrency()}
+}
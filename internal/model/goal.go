@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+)
+
+// Goal is a model of saving target which should be reached by deadline. Progress of goal is tracked
+// either by balance of linked Account or by amounts put into linked Category, only one of them is
+// set.
+type Goal struct {
+	ID       int64
+	Name     string
+	Target   int64
+	Currency *Currency
+	Deadline time.Time
+	Account  *Account
+	Category *Category
+}
+
+// NewEmptyGoal returns an empty Goal with non nil currency. The purpose of this func to avoid erros
+// when calling nested fields when they points to nil.
+func NewEmptyGoal() *Goal {
+	return &Goal{Currency: NewEmptyCurrency()}
+}
@@ -2,5 +2,6 @@ package model
 
 // Any is an interface for using in generic functions.
 type Any interface {
-	Category | Currency | Account | Transaction
+	Category | Currency | Account | Transaction | Transfer | ExchangeRate | Budget | Recurrence |
+		Tag | TransactionTag | Split | Payee | Attachment | Goal | Counterparty | Debt | DebtRepayment
 }
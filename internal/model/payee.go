@@ -0,0 +1,15 @@
+package model
+
+// Payee is a model of transaction payee, e.g. merchant. Category is a default category of payee
+// transactions, it could be nil.
+type Payee struct {
+	ID       int64
+	Name     string
+	Category *Category
+}
+
+// NewEmptyPayee returns an empty Payee. Category is left nil since payee may have no default
+// category.
+func NewEmptyPayee() *Payee {
+	return &Payee{}
+}
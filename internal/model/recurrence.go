@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+)
+
+// Rule is a rule of recurrence which defines how often transaction repeats.
+type Rule string
+
+// Available recurrence rules.
+const (
+	Daily   Rule = "daily"
+	Weekly  Rule = "weekly"
+	Monthly Rule = "monthly"
+	Yearly  Rule = "yearly"
+)
+
+// Rules returns all available recurrence rules.
+func Rules() []Rule {
+	return []Rule{Daily, Weekly, Monthly, Yearly}
+}
+
+// Recurrence is a model of scheduled transaction. Template is used to create real transactions
+// starting from Start up to End, zero End means recurrence never ends. Next is the date of the
+// next occurrence which isn't created yet.
+type Recurrence struct {
+	ID       int64
+	Rule     Rule
+	Start    time.Time
+	End      time.Time
+	Next     time.Time
+	Paused   bool
+	Template *Transaction
+}
+
+// NewEmptyRecurrence returns an empty Recurrence with non nil nested structures. The purpose of
+// this func to avoid erros when calling nested fields when they points to nil.
+func NewEmptyRecurrence() *Recurrence {
+	return &Recurrence{Template: NewEmptyTransaction()}
+}
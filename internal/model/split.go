@@ -0,0 +1,17 @@
+package model
+
+// Split is a part of transaction amount attributed to its own category. Sum of split amounts
+// equals to the amount of transaction.
+type Split struct {
+	ID            int64
+	TransactionID int64
+	Category      *Category
+	Amount        int64
+	Note          string
+}
+
+// NewEmptySplit returns an empty Split with non nil nested structure. The purpose of this func to
+// avoid erros when calling nested fields when they points to nil.
+func NewEmptySplit() *Split {
+	return &Split{Category: NewEmptyCategory()}
+}
@@ -0,0 +1,19 @@
+package model
+
+// Tag is a model of transaction tag. Unlike category, transaction can have any number of tags.
+type Tag struct {
+	ID   int64
+	Name string
+}
+
+// NewEmptyTag returns an empty Tag. This function for consistancy with NewEmptyAccount and
+// NewEmptyTransaction.
+func NewEmptyTag() *Tag {
+	return &Tag{}
+}
+
+// TransactionTag is a link between transaction and tag.
+type TransactionTag struct {
+	TransactionID int64
+	TagID         int64
+}
@@ -2,16 +2,56 @@ package model
 
 import (
 	"time"
+
+	"github.com/kotlw/gentlemoney/internal/money"
+)
+
+// TransactionStatus is a status of matching transaction with bank statement.
+type TransactionStatus string
+
+// Available transaction statuses. Cleared transaction is confirmed by bank, reconciled one is
+// matched with statement and locked from changes.
+const (
+	Pending    TransactionStatus = "pending"
+	Cleared    TransactionStatus = "cleared"
+	Reconciled TransactionStatus = "reconciled"
 )
 
-// Transaction is a model of transaction which is main entitty of the app.
+// TransactionStatuses returns all available transaction statuses.
+func TransactionStatuses() []TransactionStatus {
+	return []TransactionStatus{Pending, Cleared, Reconciled}
+}
+
+// IsCleared reports whether transaction with the status is confirmed by bank.
+func (s TransactionStatus) IsCleared() bool {
+	return s == Cleared || s == Reconciled
+}
+
+// Transaction is a model of transaction which is main entitty of the app. Payee is optional and could
+// be nil. Amount is a number of minor units in currency of the account, the currency is kept only
+// by account, so amount is used as money through Money. DeletedAt is set only for transaction in
+// trash.
 type Transaction struct {
-	ID       int64
-	Date     time.Time
-	Account  *Account
-	Category *Category
-	Amount   int64
-	Note     string
+	ID        int64
+	Date      time.Time
+	Account   *Account
+	Category  *Category
+	Payee     *Payee
+	Amount    int64
+	Note      string
+	Status    TransactionStatus
+	Tags      []*Tag
+	Splits    []*Split
+	DeletedAt time.Time
+}
+
+// Money returns amount of transaction as money in currency of its account.
+func (t *Transaction) Money() money.Money {
+	if t.Account == nil {
+		return (*Currency)(nil).Money(t.Amount)
+	}
+
+	return t.Account.Currency.Money(t.Amount)
 }
 
 // NewEmptyTransaction returns an empty Transaction with non nil nested structures. The purpose of
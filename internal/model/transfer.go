@@ -0,0 +1,15 @@
+package model
+
+// Transfer is a model of money movement between two accounts. It links outgoing and incoming
+// transactions, so they are always changed together.
+type Transfer struct {
+	ID   int64
+	From *Transaction
+	To   *Transaction
+}
+
+// NewEmptyTransfer returns an empty Transfer with non nil nested structures. The purpose of this
+// func to avoid erros when calling nested fields when they points to nil.
+func NewEmptyTransfer() *Transfer {
+	return &Transfer{From: NewEmptyTransaction(), To: NewEmptyTransaction()}
+}
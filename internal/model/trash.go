@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// TrashKind tells which model an item in trash belongs to.
+type TrashKind string
+
+// Available trash kinds.
+const (
+	TrashTransaction TrashKind = "transaction"
+	TrashAccount     TrashKind = "account"
+	TrashCategory    TrashKind = "category"
+	TrashCurrency    TrashKind = "currency"
+)
+
+// TrashItem is a model of deleted transaction, account, category or currency kept in trash. ID is
+// the id of the deleted item within its kind.
+type TrashItem struct {
+	Kind      TrashKind
+	ID        int64
+	Title     string
+	DeletedAt time.Time
+}
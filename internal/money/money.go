@@ -0,0 +1,318 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrCurrencyMismatch is returned when operation is applied to amounts of different currencies.
+	ErrCurrencyMismatch = errors.New("currencies of amounts don't match")
+
+	// ErrOverflow is returned when result of operation doesn't fit into amount.
+	ErrOverflow = errors.New("amount overflows")
+
+	// ErrInvalidRatios is returned when money can't be allocated by given ratios.
+	ErrInvalidRatios = errors.New("ratios should be non-negative with positive sum")
+)
+
+// RoundingMode is a way of rounding amount which doesn't fit into minor units.
+type RoundingMode int
+
+// Available rounding modes.
+const (
+	// HalfUp rounds to the nearest minor unit, half is rounded away from zero.
+	HalfUp RoundingMode = iota
+	// HalfEven rounds to the nearest minor unit, half is rounded to the even one.
+	HalfEven
+	// Down rounds toward zero.
+	Down
+	// Up rounds away from zero.
+	Up
+)
+
+// Money is an amount of currency. Amount is kept as integer number of minor units, precision is
+// a number of minor digits of the currency, e.g. 1234 USD with precision 2 is 12.34 USD. Currency
+// is identified by its code, empty code stands for an unspecified currency.
+type Money struct {
+	amount    int64
+	currency  string
+	precision int64
+}
+
+// New returns money of given amount of minor units of currency with given code and precision.
+func New(amount int64, currency string, precision int64) Money {
+	return Money{amount: amount, currency: currency, precision: precision}
+}
+
+// Amount returns number of minor units.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns code of currency.
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// Precision returns number of minor digits of currency.
+func (m Money) Precision() int64 {
+	return m.precision
+}
+
+// IsZero reports whether amount is zero.
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsNegative reports whether amount is less than zero.
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Neg returns money with opposite sign. The least amount has no opposite one, so it overflows.
+func (m Money) Neg() (Money, error) {
+	if m.amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+
+	m.amount = -m.amount
+	return m, nil
+}
+
+// Add returns sum of money, both of them should be in the same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.checkSame(o); err != nil {
+		return Money{}, err
+	}
+
+	res := m.amount + o.amount
+	if (o.amount > 0 && res < m.amount) || (o.amount < 0 && res > m.amount) {
+		return Money{}, ErrOverflow
+	}
+
+	m.amount = res
+	return m, nil
+}
+
+// Sub returns difference of money, both of them should be in the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.checkSame(o); err != nil {
+		return Money{}, err
+	}
+
+	res := m.amount - o.amount
+	if (o.amount > 0 && res > m.amount) || (o.amount < 0 && res < m.amount) {
+		return Money{}, ErrOverflow
+	}
+
+	m.amount = res
+	return m, nil
+}
+
+// Allocate splits money into parts proportional to given ratios without losing minor units. Minor
+// units left after proportional split are given one by one to the first parts.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	total := big.NewInt(0)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, ErrInvalidRatios
+		}
+		total.Add(total, big.NewInt(r))
+	}
+	if total.Sign() == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	res := make([]Money, len(ratios))
+	left := m.amount
+
+	for i, r := range ratios {
+		share := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(r))
+		share.Quo(share, total)
+
+		res[i] = New(share.Int64(), m.currency, m.precision)
+		left -= share.Int64()
+	}
+
+	// left is less than number of parts, since each part is truncated by less than one unit.
+	unit := int64(1)
+	if left < 0 {
+		unit = -1
+	}
+	for i := 0; left != 0; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+		res[i].amount += unit
+		left -= unit
+	}
+
+	return res, nil
+}
+
+// Split splits money into n equal parts without losing minor units, the first parts get the
+// units left after equal split.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+
+	return m.Allocate(ratios...)
+}
+
+// Rescale returns the same money with different precision, amount is rounded with given mode
+// if precision decreases.
+func (m Money) Rescale(precision int64, mode RoundingMode) (Money, error) {
+	r := new(big.Rat).SetInt64(m.amount)
+	r.Mul(r, pow10(precision-m.precision))
+
+	amount, err := round(r, mode)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return New(amount, m.currency, precision), nil
+}
+
+// Convert converts money to another currency by given rate, which is an amount of another
+// currency for one unit of this one. Result is rounded with given mode. The rate is taken by its
+// shortest decimal representation, so 0.1 is exactly one tenth.
+func (m Money) Convert(rate float64, currency string, precision int64, mode RoundingMode) (Money, error) {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	if !ok {
+		return Money{}, fmt.Errorf("invalid rate %v", rate)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt64(m.amount))
+	r.Mul(r, pow10(precision-m.precision))
+
+	amount, err := round(r, mode)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return New(amount, currency, precision), nil
+}
+
+// String represents money as a decimal number with precision digits after point, e.g. "-12.34",
+// without currency and regardless of locale.
+func (m Money) String() string {
+	res := strconv.FormatInt(m.amount, 10)
+	sign := ""
+
+	// check if the value is negative and store the sign.
+	if res[0] == '-' {
+		res = res[1:]
+		sign = "-"
+	}
+
+	if m.precision <= 0 {
+		return sign + res
+	}
+
+	// pad with zeros to have at least one digit before point.
+	if n := int(m.precision) + 1 - len(res); n > 0 {
+		res = strings.Repeat("0", n) + res
+	}
+
+	return sign + res[:len(res)-int(m.precision)] + "." + res[len(res)-int(m.precision):]
+}
+
+// Parse parses decimal number like "-12.34" to money of currency with given code and precision.
+// Number may have a sign and point, but can't have more digits after point than precision. The
+// format is the same regardless of locale, so neither comma nor digits grouping is allowed.
+func Parse(value, currency string, precision int64) (Money, error) {
+	s := value
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+
+	integer, fraction, hasPoint := strings.Cut(s, ".")
+	if integer+fraction == "" || !isDigits(integer) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	if hasPoint && int64(len(fraction)) > precision {
+		return Money{}, fmt.Errorf("%q has more than %d digits after point", value, precision)
+	}
+
+	digits := integer + fraction
+	for i := int64(len(fraction)); i < precision; i++ {
+		digits += "0"
+	}
+
+	amount, err := strconv.ParseInt(sign+digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%q: %w", value, ErrOverflow)
+	}
+
+	return New(amount, currency, precision), nil
+}
+
+// checkSame returns error if money is in different currency.
+func (m Money) checkSame(o Money) error {
+	if m.currency != o.currency || m.precision != o.precision {
+		return fmt.Errorf("%s and %s: %w", m.currency, o.currency, ErrCurrencyMismatch)
+	}
+	return nil
+}
+
+// isDigits reports whether string consists of decimal digits only.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// pow10 returns 10 to the power of n as rational number, n may be negative.
+func pow10(n int64) *big.Rat {
+	if n < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(-n), nil))
+	}
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil))
+}
+
+// round rounds rational number to integer with given mode.
+func round(r *big.Rat, mode RoundingMode) (int64, error) {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+
+	if rem.Sign() != 0 {
+		// compare doubled remainder with denominator to find out if it is more than half.
+		half := new(big.Int).Abs(rem)
+		half.Lsh(half, 1)
+		cmp := half.Cmp(r.Denom())
+
+		away := false
+		switch mode {
+		case HalfUp:
+			away = cmp >= 0
+		case HalfEven:
+			away = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+		case Up:
+			away = true
+		}
+
+		if away {
+			q.Add(q, big.NewInt(int64(r.Sign())))
+		}
+	}
+
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return q.Int64(), nil
+}
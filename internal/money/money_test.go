@@ -0,0 +1,243 @@
+package money_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MoneyTestSuite struct {
+	suite.Suite
+}
+
+func (s *MoneyTestSuite) TestAddSub() {
+	a := money.New(1050, "USD", 2)
+	b := money.New(-250, "USD", 2)
+
+	res, err := a.Add(b)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), money.New(800, "USD", 2), res)
+
+	res, err = a.Sub(b)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), money.New(1300, "USD", 2), res)
+
+	res, err = a.Neg()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), money.New(-1050, "USD", 2), res)
+	assert.True(s.T(), res.IsNegative())
+	assert.True(s.T(), money.New(0, "USD", 2).IsZero())
+
+	_, err = money.New(math.MinInt64, "USD", 2).Neg()
+	assert.ErrorIs(s.T(), err, money.ErrOverflow)
+}
+
+func (s *MoneyTestSuite) TestAddSubNegative() {
+	for _, tc := range []struct {
+		name string
+		a, b money.Money
+		err  error
+	}{
+		{name: "DifferentCurrency", a: money.New(1, "USD", 2), b: money.New(1, "EUR", 2), err: money.ErrCurrencyMismatch},
+		{name: "DifferentPrecision", a: money.New(1, "USD", 2), b: money.New(1, "USD", 3), err: money.ErrCurrencyMismatch},
+		{name: "Overflow", a: money.New(1<<63-1, "USD", 2), b: money.New(2, "USD", 2), err: money.ErrOverflow},
+	} {
+		s.Run(tc.name, func() {
+			_, err := tc.a.Add(tc.b)
+			assert.ErrorIs(s.T(), err, tc.err)
+
+			neg, err := tc.a.Neg()
+			require.NoError(s.T(), err)
+			_, err = neg.Sub(tc.b)
+			assert.ErrorIs(s.T(), err, tc.err)
+		})
+	}
+}
+
+func (s *MoneyTestSuite) TestAllocate() {
+	for _, tc := range []struct {
+		name   string
+		amount int64
+		ratios []int64
+		expect []int64
+	}{
+		{name: "Even", amount: 100, ratios: []int64{1, 1}, expect: []int64{50, 50}},
+		{name: "Remainder", amount: 100, ratios: []int64{1, 1, 1}, expect: []int64{34, 33, 33}},
+		{name: "Negative", amount: -100, ratios: []int64{1, 1, 1}, expect: []int64{-34, -33, -33}},
+		{name: "Proportional", amount: 5, ratios: []int64{70, 30}, expect: []int64{4, 1}},
+		{name: "ZeroRatio", amount: 5, ratios: []int64{0, 1, 1}, expect: []int64{0, 3, 2}},
+	} {
+		s.Run(tc.name, func() {
+			res, err := money.New(tc.amount, "USD", 2).Allocate(tc.ratios...)
+			require.NoError(s.T(), err)
+
+			actual := make([]int64, len(res))
+			for i, m := range res {
+				assert.Equal(s.T(), "USD", m.Currency())
+				actual[i] = m.Amount()
+			}
+			assert.Equal(s.T(), tc.expect, actual)
+		})
+	}
+
+	_, err := money.New(100, "USD", 2).Allocate(1, -1)
+	assert.ErrorIs(s.T(), err, money.ErrInvalidRatios)
+	_, err = money.New(100, "USD", 2).Allocate(0, 0)
+	assert.ErrorIs(s.T(), err, money.ErrInvalidRatios)
+	_, err = money.New(100, "USD", 2).Split(0)
+	assert.ErrorIs(s.T(), err, money.ErrInvalidRatios)
+
+	res, err := money.New(1000, "USD", 2).Split(3)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []money.Money{money.New(334, "USD", 2), money.New(333, "USD", 2), money.New(333, "USD", 2)}, res)
+}
+
+func (s *MoneyTestSuite) TestRescale() {
+	for _, tc := range []struct {
+		name   string
+		amount int64
+		mode   money.RoundingMode
+		expect int64
+	}{
+		{name: "HalfUp", amount: 125, mode: money.HalfUp, expect: 13},
+		{name: "HalfUpNegative", amount: -125, mode: money.HalfUp, expect: -13},
+		{name: "HalfEvenDown", amount: 125, mode: money.HalfEven, expect: 12},
+		{name: "HalfEvenUp", amount: 135, mode: money.HalfEven, expect: 14},
+		{name: "HalfEvenAboveHalf", amount: 126, mode: money.HalfEven, expect: 13},
+		{name: "Down", amount: -129, mode: money.Down, expect: -12},
+		{name: "Up", amount: -121, mode: money.Up, expect: -13},
+		{name: "Exact", amount: 120, mode: money.Up, expect: 12},
+	} {
+		s.Run(tc.name, func() {
+			res, err := money.New(tc.amount, "USD", 2).Rescale(1, tc.mode)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), money.New(tc.expect, "USD", 1), res)
+		})
+	}
+
+	res, err := money.New(12, "USD", 2).Rescale(4, money.HalfUp)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), money.New(1200, "USD", 4), res)
+
+	_, err = money.New(1<<62, "USD", 0).Rescale(2, money.HalfUp)
+	assert.ErrorIs(s.T(), err, money.ErrOverflow)
+}
+
+func (s *MoneyTestSuite) TestConvert() {
+	// 0.0067 isn't exact in binary, but the rate is taken by its decimal representation.
+	res, err := money.New(1000, "JPY", 0).Convert(0.0067, "USD", 2, money.Up)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), money.New(670, "USD", 2), res)
+
+	res, err = money.New(-333, "USD", 2).Convert(1.2, "EUR", 2, money.HalfUp)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), money.New(-400, "EUR", 2), res)
+
+	res, err = money.New(1050, "USD", 2).Convert(0.308, "KWD", 3, money.HalfEven)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), money.New(3234, "KWD", 3), res)
+}
+
+func (s *MoneyTestSuite) TestString() {
+	for _, tc := range []struct {
+		amount    int64
+		precision int64
+		expect    string
+	}{
+		{amount: 1, precision: 2, expect: "0.01"},
+		{amount: -12, precision: 2, expect: "-0.12"},
+		{amount: 0, precision: 2, expect: "0.00"},
+		{amount: 1234, precision: 2, expect: "12.34"},
+		{amount: 1234, precision: 0, expect: "1234"},
+		{amount: -1, precision: 3, expect: "-0.001"},
+		{amount: -1 << 63, precision: 2, expect: "-92233720368547758.08"},
+	} {
+		assert.Equal(s.T(), tc.expect, money.New(tc.amount, "USD", tc.precision).String())
+	}
+}
+
+func (s *MoneyTestSuite) TestParse() {
+	for _, tc := range []struct {
+		value     string
+		precision int64
+		expect    int64
+	}{
+		{value: "12.34", precision: 2, expect: 1234},
+		{value: "12.3", precision: 2, expect: 1230},
+		{value: "12", precision: 2, expect: 1200},
+		{value: "-0.01", precision: 2, expect: -1},
+		{value: "-.5", precision: 2, expect: -50},
+		{value: "+5", precision: 0, expect: 5},
+		{value: "1.234", precision: 3, expect: 1234},
+		{value: "-92233720368547758.08", precision: 2, expect: -1 << 63},
+	} {
+		res, err := money.Parse(tc.value, "USD", tc.precision)
+		require.NoError(s.T(), err, tc.value)
+		assert.Equal(s.T(), money.New(tc.expect, "USD", tc.precision), res)
+	}
+}
+
+func (s *MoneyTestSuite) TestParseNegative() {
+	for _, tc := range []struct {
+		value     string
+		precision int64
+		expect    string
+	}{
+		{value: "", precision: 2, expect: `invalid amount ""`},
+		{value: "x", precision: 2, expect: `invalid amount "x"`},
+		{value: "1,5", precision: 2, expect: `invalid amount "1,5"`},
+		{value: "1 000", precision: 2, expect: `invalid amount "1 000"`},
+		{value: ".", precision: 2, expect: `invalid amount "."`},
+		{value: "-", precision: 2, expect: `invalid amount "-"`},
+		{value: "1.2.3", precision: 2, expect: `invalid amount "1.2.3"`},
+		{value: "--1", precision: 2, expect: `invalid amount "--1"`},
+		{value: "1.234", precision: 2, expect: `"1.234" has more than 2 digits after point`},
+		{value: "1.5", precision: 0, expect: `"1.5" has more than 0 digits after point`},
+		{value: "92233720368547758.08", precision: 2, expect: `"92233720368547758.08": amount overflows`},
+	} {
+		_, err := money.Parse(tc.value, "USD", tc.precision)
+		assert.EqualError(s.T(), err, tc.expect)
+	}
+}
+
+func TestMoneyTestSuite(t *testing.T) {
+	suite.Run(t, new(MoneyTestSuite))
+}
+
+func FuzzFormatParse(f *testing.F) {
+	f.Add(int64(0), uint8(2))
+	f.Add(int64(-1), uint8(3))
+	f.Add(int64(1234), uint8(0))
+	f.Add(int64(-1<<63), uint8(8))
+
+	f.Fuzz(func(t *testing.T, amount int64, precision uint8) {
+		m := money.New(amount, "USD", int64(precision%19))
+
+		res, err := money.Parse(m.String(), "USD", m.Precision())
+		require.NoError(t, err)
+		assert.Equal(t, m, res)
+	})
+}
+
+func FuzzParseFormat(f *testing.F) {
+	f.Add("12.34", uint8(2))
+	f.Add("-0.5", uint8(2))
+	f.Add("+7", uint8(0))
+	f.Add("1.", uint8(3))
+
+	f.Fuzz(func(t *testing.T, value string, precision uint8) {
+		m, err := money.Parse(value, "USD", int64(precision%19))
+		if err != nil {
+			return
+		}
+
+		res, err := money.Parse(m.String(), "USD", m.Precision())
+		require.NoError(t, err)
+		assert.Equal(t, m, res)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/money"
 	"github.com/kotlw/gentlemoney/internal/service"
 )
 
@@ -21,13 +22,20 @@ func NewAccount(currencyService *service.Currency) *Account {
 // ToMap converts model.Account to map[string]string. It doesn't handles ID field.
 func (p *Account) ToMap(a *model.Account) map[string]string {
 	return map[string]string{
-		"ID":       strconv.Itoa(int(a.ID)),
-		"Name":     a.Name,
-		"Currency": a.Currency.Abbreviation,
+		"ID":              strconv.Itoa(int(a.ID)),
+		"Name":            a.Name,
+		"Currency":        a.Currency.Abbreviation,
+		"Opening Balance": a.Currency.Money(a.OpeningBalance).String(),
+		"Opening Date":    reprDate(a.OpeningDate),
+		"Type":            string(a.Type),
+		"Credit Limit":    p.reprCreditLimit(a.Currency.Money(a.CreditLimit)),
+		"Archived":        strconv.FormatBool(a.Archived),
 	}
 }
 
-// FromMap parses map[string]string to model.Account. It doesn't handles ID field.
+// FromMap parses map[string]string to model.Account. It doesn't handles ID field. Keys "Opening Balance",
+// "Opening Date", "Type", "Credit Limit" and "Archived" are optional, missing or empty values are parsed
+// as zero.
 func (p *Account) FromMap(m map[string]string) (*model.Account, error) {
 	if err := checkKeys(m, []string{"Name", "Currency"}); err != nil {
 		return nil, fmt.Errorf("checkKeys: %w", err)
@@ -38,9 +46,56 @@ func (p *Account) FromMap(m map[string]string) (*model.Account, error) {
 		return nil, fmt.Errorf("getID: %w", err)
 	}
 
+	currency := p.currencyService.GetByAbbreviation(m["Currency"])
+
+	var openingBalance money.Money
+	if v := m["Opening Balance"]; v != "" {
+		if openingBalance, err = currency.ParseMoney(v); err != nil {
+			return nil, fmt.Errorf("currency.ParseMoney: %w", err)
+		}
+	}
+
+	openingDate, err := parseDate(m["Opening Date"])
+	if err != nil {
+		return nil, fmt.Errorf("parseDate: %w", err)
+	}
+
+	var creditLimit money.Money
+	if v := m["Credit Limit"]; v != "" {
+		if creditLimit, err = currency.ParseMoney(v); err != nil {
+			return nil, fmt.Errorf("currency.ParseMoney: %w", err)
+		}
+	}
+
+	archived := false
+	if v, ok := m["Archived"]; ok {
+		if archived, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("strconv.ParseBool: %w", err)
+		}
+	}
+
 	return &model.Account{
-		ID:       id,
-		Name:     m["Name"],
-		Currency: p.currencyService.GetByAbbreviation(m["Currency"]),
+		ID:             id,
+		Name:           m["Name"],
+		Currency:       currency,
+		OpeningBalance: openingBalance.Amount(),
+		OpeningDate:    openingDate,
+		Type:           model.AccountType(m["Type"]),
+		CreditLimit:    creditLimit.Amount(),
+		Archived:       archived,
 	}, nil
 }
+
+// reprCreditLimit represents credit limit as money, zero limit means there is no limit and it is
+// represented as empty string.
+func (*Account) reprCreditLimit(value money.Money) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.String()
+}
+
+// ReprBalance represents balance of given account along with the account currency.
+func (p *Account) ReprBalance(a *model.Account, balance int64) string {
+	return a.Currency.Money(balance).String() + " " + a.Currency.Abbreviation
+}
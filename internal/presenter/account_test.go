@@ -3,6 +3,7 @@ package presenter_test
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/kotlw/gentlemoney/internal/model"
 	"github.com/kotlw/gentlemoney/internal/presenter"
@@ -24,29 +25,60 @@ type AccountPresenterTestSuite struct {
 }
 
 func (s *AccountPresenterTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
-	currencyPersistentStorage, err := sqlite.NewCurrency(db)
+	err = sqlite.Migrate(db)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
+	currencyPersistentStorage := sqlite.NewCurrency(sqlite.NewDB(db))
+
 	currencyService, err := service.NewCurrency(currencyPersistentStorage, inmemory.NewCurrency())
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.presenter = presenter.NewAccount(currencyService)
 
-	s.initCurrency = &model.Currency{Abbreviation: "USD"}
+	s.initCurrency = &model.Currency{Abbreviation: "USD", Precision: 2}
 	err = currencyService.Insert(s.initCurrency)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 }
 
 func (s *AccountPresenterTestSuite) TestToMap() {
 	account := &model.Account{Name: "Card1", Currency: s.initCurrency}
-	expected := map[string]string{"ID": "0", "Name": "Card1", "Currency": s.initCurrency.Abbreviation}
+	expected := map[string]string{"ID": "0", "Name": "Card1", "Currency": s.initCurrency.Abbreviation,
+		"Opening Balance": "0.00", "Opening Date": "", "Type": "", "Credit Limit": "", "Archived": "false"}
 	actual := s.presenter.ToMap(account)
 	assert.Equal(s.T(), expected, actual)
 }
 
+func (s *AccountPresenterTestSuite) TestTypeAndArchived() {
+	account := &model.Account{Name: "Card1", Currency: s.initCurrency, Type: model.CreditCard, CreditLimit: 500000,
+		Archived: true}
+	m := map[string]string{"ID": "0", "Name": "Card1", "Currency": "USD", "Opening Balance": "0.00",
+		"Opening Date": "", "Type": "credit card", "Credit Limit": "5000.00", "Archived": "true"}
+
+	assert.Equal(s.T(), m, s.presenter.ToMap(account))
+
+	actual, err := s.presenter.FromMap(m)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), account, actual)
+}
+
+func (s *AccountPresenterTestSuite) TestOpeningBalance() {
+	account := &model.Account{Name: "Card1", Currency: s.initCurrency,
+		OpeningBalance: -1050, OpeningDate: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)}
+	m := map[string]string{"ID": "0", "Name": "Card1", "Currency": "USD",
+		"Opening Balance": "-10.50", "Opening Date": "2022-03-01", "Type": "", "Credit Limit": "", "Archived": "false"}
+
+	assert.Equal(s.T(), m, s.presenter.ToMap(account))
+
+	actual, err := s.presenter.FromMap(m)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), account, actual)
+
+	assert.Equal(s.T(), "-10.50 USD", s.presenter.ReprBalance(account, account.OpeningBalance))
+}
+
 func (s *AccountPresenterTestSuite) TestFromMapPositive() {
 	for _, tc := range []struct {
 		name     string
@@ -99,6 +131,21 @@ func (s *AccountPresenterTestSuite) TestFromMapNegative() {
 			give:     map[string]string{"Name": "Card1"},
 			expected: `checkKeys: key "Currency" is missing`,
 		},
+		{
+			name:     "InvalidOpeningBalance",
+			give:     map[string]string{"Name": "Card1", "Currency": "USD", "Opening Balance": "x"},
+			expected: `currency.ParseMoney: invalid amount "x"`,
+		},
+		{
+			name:     "InvalidArchived",
+			give:     map[string]string{"Name": "Card1", "Currency": "USD", "Archived": "x"},
+			expected: `strconv.ParseBool: strconv.ParseBool: parsing "x": invalid syntax`,
+		},
+		{
+			name:     "InvalidOpeningDate",
+			give:     map[string]string{"Name": "Card1", "Currency": "USD", "Opening Date": "x"},
+			expected: `parseDate: parsing time "x" as "2006-01-02": cannot parse "x" as "2006"`,
+		},
 	} {
 		s.Run(tc.name, func() {
 			_, err := s.presenter.FromMap(tc.give)
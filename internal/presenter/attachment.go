@@ -0,0 +1,50 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// Attachment presenter contains logic related to UI.
+type Attachment struct {
+	attachmentService *service.Attachment
+}
+
+// NewAttachment returns Attachment presenter.
+func NewAttachment(attachmentService *service.Attachment) *Attachment {
+	return &Attachment{attachmentService: attachmentService}
+}
+
+// ToMap converts model.Attachment to map[string]string. Key "Path" is the path of attached file in
+// managed folder.
+func (p *Attachment) ToMap(a *model.Attachment) map[string]string {
+	return map[string]string{
+		"ID":             strconv.Itoa(int(a.ID)),
+		"Transaction ID": strconv.Itoa(int(a.TransactionID)),
+		"Name":           a.Name,
+		"Hash":           a.Hash,
+		"Path":           p.attachmentService.Path(a),
+	}
+}
+
+// FromMap parses map[string]string to model.Attachment.
+func (p *Attachment) FromMap(m map[string]string) (*model.Attachment, error) {
+	if err := checkKeys(m, []string{"Transaction ID", "Name", "Hash"}); err != nil {
+		return nil, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	id, err := getID(m)
+	if err != nil {
+		return nil, fmt.Errorf("getID: %w", err)
+	}
+
+	transactionID, err := strconv.Atoi(m["Transaction ID"])
+	if err != nil {
+		return nil, fmt.Errorf("strconv.Atoi: %w", err)
+	}
+
+	return &model.Attachment{ID: id, TransactionID: int64(transactionID), Name: m["Name"], Hash: m["Hash"]}, nil
+}
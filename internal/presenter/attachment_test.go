@@ -0,0 +1,97 @@
+package presenter_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentPresenterTestSuite struct {
+	suite.Suite
+	db        *sql.DB
+	presenter *presenter.Attachment
+	dir       string
+}
+
+func (s *AttachmentPresenterTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.dir = s.T().TempDir()
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.dir)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.presenter = presenter.NewAttachment(service.Attachment())
+}
+
+func (s *AttachmentPresenterTestSuite) TestToMap() {
+	give := &model.Attachment{ID: 1, TransactionID: 2, Name: "receipt.PDF", Hash: "abc"}
+	expected := map[string]string{
+		"ID": "1", "Transaction ID": "2", "Name": "receipt.PDF", "Hash": "abc", "Path": filepath.Join(s.dir, "abc.pdf"),
+	}
+
+	assert.Equal(s.T(), expected, s.presenter.ToMap(give))
+}
+
+func (s *AttachmentPresenterTestSuite) TestFromMapPositive() {
+	give := map[string]string{"ID": "1", "Transaction ID": "2", "Name": "receipt.PDF", "Hash": "abc", "Path": "abc.pdf"}
+	expected := &model.Attachment{ID: 1, TransactionID: 2, Name: "receipt.PDF", Hash: "abc"}
+
+	actual, err := s.presenter.FromMap(give)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *AttachmentPresenterTestSuite) TestFromMapNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name:     "MissingHash",
+			give:     map[string]string{"Transaction ID": "2", "Name": "receipt.pdf"},
+			expected: `checkKeys: key "Hash" is missing`,
+		},
+		{
+			name:     "InvalidID",
+			give:     map[string]string{"ID": "x", "Transaction ID": "2", "Name": "receipt.pdf", "Hash": "abc"},
+			expected: `getID: strconv.Atoi: parsing "x": invalid syntax`,
+		},
+		{
+			name:     "InvalidTransactionID",
+			give:     map[string]string{"Transaction ID": "x", "Name": "receipt.pdf", "Hash": "abc"},
+			expected: `strconv.Atoi: strconv.Atoi: parsing "x": invalid syntax`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, err := s.presenter.FromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *AttachmentPresenterTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestAttachmentPresenterTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentPresenterTestSuite))
+}
@@ -0,0 +1,87 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// Budget presenter contains logic related to UI. Amounts of budgets are in the main currency.
+type Budget struct {
+	categoryService *service.Category
+	currencyService *service.Currency
+}
+
+// NewBudget returns Budget presenter.
+func NewBudget(categoryService *service.Category, currencyService *service.Currency) *Budget {
+	return &Budget{categoryService: categoryService, currencyService: currencyService}
+}
+
+// ToMap converts model.Budget to map[string]string.
+func (p *Budget) ToMap(b *model.Budget) map[string]string {
+	return map[string]string{
+		"ID":       strconv.Itoa(int(b.ID)),
+		"Period":   b.Period.Format("2006-01"),
+		"Category": p.categoryService.Path(b.Category),
+		"Limit":    p.currencyService.GetMain().Money(b.Limit).String(),
+	}
+}
+
+// ToProgressMap converts model.Budget to map[string]string along with spent and remaining amounts
+// and progress bar. Overspent budget is highlighted in red.
+func (p *Budget) ToProgressMap(b *model.Budget, spent int64) map[string]string {
+	m := p.ToMap(b)
+	m["Spent"] = p.currencyService.GetMain().Money(spent).String()
+	m["Remaining"] = p.currencyService.GetMain().Money(b.Limit - spent).String()
+	m["Progress"] = p.reprProgress(spent, b.Limit)
+
+	if spent > b.Limit {
+		m["Category"] = "[red]" + m["Category"] + "[white]"
+		m["Remaining"] = "[red]" + m["Remaining"] + "[white]"
+	}
+
+	return m
+}
+
+// FromMap parses map[string]string to model.Budget.
+func (p *Budget) FromMap(m map[string]string) (*model.Budget, error) {
+	if err := checkKeys(m, []string{"Period", "Category", "Limit"}); err != nil {
+		return nil, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	id, err := getID(m)
+	if err != nil {
+		return nil, fmt.Errorf("getID: %w", err)
+	}
+
+	period, err := time.Parse("2006-01", m["Period"])
+	if err != nil {
+		return nil, fmt.Errorf("time.Parse: %w", err)
+	}
+
+	limit, err := p.currencyService.GetMain().ParseMoney(m["Limit"])
+	if err != nil {
+		return nil, fmt.Errorf("p.currencyService.GetMain().ParseMoney: %w", err)
+	}
+
+	return &model.Budget{
+		ID:       id,
+		Period:   period,
+		Category: p.categoryService.GetByPath(m["Category"]),
+		Limit:    limit.Amount(),
+	}, nil
+}
+
+// reprProgress represents spent part of limit as a bar followed by percentage. The bar is green
+// while spent is within limit and red otherwise.
+func (*Budget) reprProgress(spent, limit int64) string {
+	color := "[green]"
+	if spent > limit {
+		color = "[red]"
+	}
+
+	return reprBar(spent, limit, color)
+}
@@ -0,0 +1,128 @@
+package presenter_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetPresenterTestSuite struct {
+	suite.Suite
+	db           *sql.DB
+	presenter    *presenter.Budget
+	initCategory *model.Category
+}
+
+func (s *BudgetPresenterTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.presenter = presenter.NewBudget(service.Category(), service.Currency())
+
+	s.initCategory = &model.Category{Title: "Rent"}
+	err = service.Category().Insert(s.initCategory)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+}
+
+func (s *BudgetPresenterTestSuite) TestToMap() {
+	budget := &model.Budget{ID: 5, Category: s.initCategory, Period: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), Limit: 50000}
+	expected := map[string]string{"ID": "5", "Period": "2022-02", "Category": "Rent", "Limit": "500.00"}
+
+	actual := s.presenter.ToMap(budget)
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *BudgetPresenterTestSuite) TestToProgressMap() {
+	budget := &model.Budget{ID: 5, Category: s.initCategory, Period: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), Limit: 1000}
+
+	for _, tc := range []struct {
+		name     string
+		spent    int64
+		expected map[string]string
+	}{
+		{
+			name:  "WithinLimit",
+			spent: 250,
+			expected: map[string]string{
+				"ID": "5", "Period": "2022-02", "Category": "Rent", "Limit": "10.00",
+				"Spent": "2.50", "Remaining": "7.50",
+				"Progress": "[green]█████[white]░░░░░░░░░░░░░░░ 25%",
+			},
+		},
+		{
+			name:  "Overspent",
+			spent: 1500,
+			expected: map[string]string{
+				"ID": "5", "Period": "2022-02", "Category": "[red]Rent[white]", "Limit": "10.00",
+				"Spent": "15.00", "Remaining": "[red]-5.00[white]",
+				"Progress": "[red]████████████████████[white] 150%",
+			},
+		},
+	} {
+		s.Run(tc.name, func() {
+			actual := s.presenter.ToProgressMap(budget, tc.spent)
+			assert.Equal(s.T(), tc.expected, actual)
+		})
+	}
+}
+
+func (s *BudgetPresenterTestSuite) TestFromMapPositive() {
+	give := map[string]string{"ID": "5", "Period": "2022-02", "Category": "Rent", "Limit": "500"}
+	expected := &model.Budget{ID: 5, Category: s.initCategory, Period: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), Limit: 50000}
+
+	actual, err := s.presenter.FromMap(give)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *BudgetPresenterTestSuite) TestFromMapNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name:     "MissingLimit",
+			give:     map[string]string{"Period": "2022-02", "Category": "Rent"},
+			expected: `checkKeys: key "Limit" is missing`,
+		},
+		{
+			name:     "InvalidPeriod",
+			give:     map[string]string{"Period": "02.2022", "Category": "Rent", "Limit": "1"},
+			expected: `time.Parse: parsing time "02.2022" as "2006-01": cannot parse "02.2022" as "2006"`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, err := s.presenter.FromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *BudgetPresenterTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestBudgetPresenterTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetPresenterTestSuite))
+}
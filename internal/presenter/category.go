@@ -5,22 +5,33 @@ import (
 	"strconv"
 
 	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
 )
 
 // Category presenter contains logic related to UI.
-type Category struct{}
+type Category struct {
+	categoryService *service.Category
+}
 
 // NewCategory returns Category presenter.
-func NewCategory() *Category {
-	return &Category{}
+func NewCategory(categoryService *service.Category) *Category {
+	return &Category{categoryService: categoryService}
 }
 
-// ToMap converts model.Category to map[string]string. It doesn't handles ID field.
+// ToMap converts model.Category to map[string]string. Parent is represented by its path, top level
+// category has empty parent.
 func (p *Category) ToMap(c *model.Category) map[string]string {
-	return map[string]string{"ID": strconv.Itoa(int(c.ID)), "Title": c.Title}
+	parent := ""
+	if c.Parent != nil {
+		parent = p.categoryService.Path(c.Parent)
+	}
+
+	return map[string]string{"ID": strconv.Itoa(int(c.ID)), "Title": c.Title, "Parent": parent, "Kind": string(c.Kind)}
 }
 
-// FromMap parses map[string]string to model.Category. It doesn't handles ID field.
+// FromMap parses map[string]string to model.Category. Keys "Parent" and "Kind" are optional,
+// missing or empty parent is parsed as top level category, missing kind is left for service to
+// fill.
 func (p *Category) FromMap(m map[string]string) (*model.Category, error) {
 	if err := checkKeys(m, []string{"Title"}); err != nil {
 		return nil, fmt.Errorf("checkKeys: %w", err)
@@ -31,5 +42,12 @@ func (p *Category) FromMap(m map[string]string) (*model.Category, error) {
 		return nil, fmt.Errorf("getID: %w", err)
 	}
 
-	return &model.Category{ID: id, Title: m["Title"]}, nil
+	var parent *model.Category
+	if m["Parent"] != "" {
+		if parent = p.categoryService.GetByPath(m["Parent"]); parent == nil {
+			return nil, fmt.Errorf("parent category %q not found", m["Parent"])
+		}
+	}
+
+	return &model.Category{ID: id, Title: m["Title"], Parent: parent, Kind: model.CategoryKind(m["Kind"])}, nil
 }
@@ -1,10 +1,15 @@
 package presenter_test
 
 import (
+	"database/sql"
 	"testing"
 
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
 	"github.com/kotlw/gentlemoney/internal/model"
 	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
@@ -14,18 +19,51 @@ import (
 
 type CategoryPresenterTestSuite struct {
 	suite.Suite
-	presenter *presenter.Category
+	db         *sql.DB
+	presenter  *presenter.Category
+	initParent *model.Category
 }
 
 func (s *CategoryPresenterTestSuite) SetupSuite() {
-	s.presenter = presenter.NewCategory()
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.presenter = presenter.NewCategory(service.Category())
+
+	s.initParent = &model.Category{Title: "Leisure"}
+	err = service.Category().Insert(s.initParent)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
 }
 
 func (s *CategoryPresenterTestSuite) TestToMap() {
-	category := &model.Category{Title: "Health"}
-	expected := map[string]string{"ID": "0", "Title": "Health"}
-	actual := s.presenter.ToMap(category)
-	assert.Equal(s.T(), expected, actual)
+	for _, tc := range []struct {
+		name     string
+		give     *model.Category
+		expected map[string]string
+	}{
+		{
+			name:     "TopLevel",
+			give:     &model.Category{Title: "Health", Kind: model.Expense},
+			expected: map[string]string{"ID": "0", "Title": "Health", "Parent": "", "Kind": "expense"},
+		},
+		{
+			name:     "WithParent",
+			give:     &model.Category{Title: "Cinema", Parent: s.initParent},
+			expected: map[string]string{"ID": "0", "Title": "Cinema", "Parent": "Leisure", "Kind": ""},
+		},
+	} {
+		s.Run(tc.name, func() {
+			actual := s.presenter.ToMap(tc.give)
+			assert.Equal(s.T(), tc.expected, actual)
+		})
+	}
 }
 
 func (s *CategoryPresenterTestSuite) TestFromMapPositive() {
@@ -44,6 +82,16 @@ func (s *CategoryPresenterTestSuite) TestFromMapPositive() {
 			give:     map[string]string{"Title": "Health"},
 			expected: &model.Category{ID: 0, Title: "Health"},
 		},
+		{
+			name:     "WithParent",
+			give:     map[string]string{"Title": "Cinema", "Parent": "Leisure"},
+			expected: &model.Category{ID: 0, Title: "Cinema", Parent: s.initParent},
+		},
+		{
+			name:     "WithKind",
+			give:     map[string]string{"Title": "Salary", "Kind": "income"},
+			expected: &model.Category{ID: 0, Title: "Salary", Kind: model.Income},
+		},
 	} {
 		s.Run(tc.name, func() {
 			actual, err := s.presenter.FromMap(tc.give)
@@ -56,6 +104,14 @@ func (s *CategoryPresenterTestSuite) TestFromMapPositive() {
 func (s *CategoryPresenterTestSuite) TestFromMapNegative() {
 	_, err := s.presenter.FromMap(map[string]string{"Name": "Health"})
 	assert.EqualError(s.T(), err, `checkKeys: key "Title" is missing`)
+
+	_, err = s.presenter.FromMap(map[string]string{"Title": "Cinema", "Parent": "Hobby"})
+	assert.EqualError(s.T(), err, `parent category "Hobby" not found`)
+}
+
+func (s *CategoryPresenterTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
 }
 
 func TestCategoryPresenterTestSuite(t *testing.T) {
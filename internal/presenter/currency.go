@@ -20,10 +20,13 @@ func (p *Currency) ToMap(c *model.Currency) map[string]string {
 	return map[string]string{
 		"ID":           strconv.Itoa(int(c.ID)),
 		"Abbreviation": c.Abbreviation,
+		"Main":         strconv.FormatBool(c.IsMain),
+		"Precision":    strconv.Itoa(int(c.Precision)),
 	}
 }
 
-// FromMap parses map[string]string to model.Currency. It doesn't handles ID field.
+// FromMap parses map[string]string to model.Currency. It doesn't handles ID field. Keys "Main" and
+// "Precision" are optional, missing or empty precision is parsed as the default one.
 func (p *Currency) FromMap(m map[string]string) (*model.Currency, error) {
 	if err := checkKeys(m, []string{"Abbreviation"}); err != nil {
 		return nil, fmt.Errorf("checkKeys: %w", err)
@@ -34,8 +37,25 @@ func (p *Currency) FromMap(m map[string]string) (*model.Currency, error) {
 		return nil, fmt.Errorf("getID: %w", err)
 	}
 
+	// main flag is optional, currency isn't main if it's missing
+	isMain := false
+	if v, ok := m["Main"]; ok {
+		if isMain, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("strconv.ParseBool: %w", err)
+		}
+	}
+
+	precision := model.DefaultPrecision
+	if v := m["Precision"]; v != "" {
+		if precision, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("strconv.Atoi: %w", err)
+		}
+	}
+
 	return &model.Currency{
 		ID:           id,
 		Abbreviation: m["Abbreviation"],
+		IsMain:       isMain,
+		Precision:    int64(precision),
 	}, nil
 }
@@ -22,8 +22,8 @@ func (s *CurrencyPresenterTestSuite) SetupSuite() {
 }
 
 func (s *CurrencyPresenterTestSuite) TestToMap() {
-	currency := &model.Currency{Abbreviation: "USD"}
-	expected := map[string]string{"ID": "0", "Abbreviation": "USD"}
+	currency := &model.Currency{Abbreviation: "USD", IsMain: true, Precision: 2}
+	expected := map[string]string{"ID": "0", "Abbreviation": "USD", "Main": "true", "Precision": "2"}
 	actual := s.presenter.ToMap(currency)
 	assert.Equal(s.T(), expected, actual)
 }
@@ -37,12 +37,22 @@ func (s *CurrencyPresenterTestSuite) TestFromMapPositive() {
 		{
 			name:     "ExistingID",
 			give:     map[string]string{"ID": "99", "Abbreviation": "USD"},
-			expected: &model.Currency{ID: int64(99), Abbreviation: "USD"},
+			expected: &model.Currency{ID: int64(99), Abbreviation: "USD", Precision: 2},
+		},
+		{
+			name:     "Main",
+			give:     map[string]string{"ID": "99", "Abbreviation": "USD", "Main": "true"},
+			expected: &model.Currency{ID: int64(99), Abbreviation: "USD", IsMain: true, Precision: 2},
+		},
+		{
+			name:     "Precision",
+			give:     map[string]string{"Abbreviation": "JPY", "Precision": "0"},
+			expected: &model.Currency{Abbreviation: "JPY", Precision: 0},
 		},
 		{
 			name:     "NotExistingID",
 			give:     map[string]string{"Abbreviation": "USD"},
-			expected: &model.Currency{ID: int64(0), Abbreviation: "USD"},
+			expected: &model.Currency{ID: int64(0), Abbreviation: "USD", Precision: 2},
 		},
 	} {
 		s.Run(tc.name, func() {
@@ -54,8 +64,32 @@ func (s *CurrencyPresenterTestSuite) TestFromMapPositive() {
 }
 
 func (s *CurrencyPresenterTestSuite) TestFromMapNegative() {
-	_, err := s.presenter.FromMap(map[string]string{"Name": "None"})
-	assert.EqualError(s.T(), err, `checkKeys: key "Abbreviation" is missing`)
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name:     "MissingAbbreviation",
+			give:     map[string]string{"Name": "None"},
+			expected: `checkKeys: key "Abbreviation" is missing`,
+		},
+		{
+			name:     "InvalidMain",
+			give:     map[string]string{"Abbreviation": "USD", "Main": "yes"},
+			expected: `strconv.ParseBool: strconv.ParseBool: parsing "yes": invalid syntax`,
+		},
+		{
+			name:     "InvalidPrecision",
+			give:     map[string]string{"Abbreviation": "USD", "Precision": "two"},
+			expected: `strconv.Atoi: strconv.Atoi: parsing "two": invalid syntax`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, err := s.presenter.FromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
 }
 
 func TestCurrencyPresenterTestSuite(t *testing.T) {
@@ -0,0 +1,137 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// Debt presenter contains logic related to UI.
+type Debt struct {
+	currencyService     *service.Currency
+	counterpartyService *service.Counterparty
+}
+
+// NewDebt returns Debt presenter.
+func NewDebt(currencyService *service.Currency, counterpartyService *service.Counterparty) *Debt {
+	return &Debt{currencyService: currencyService, counterpartyService: counterpartyService}
+}
+
+// ToMap converts model.Debt to map[string]string.
+func (p *Debt) ToMap(d *model.Debt) map[string]string {
+	return map[string]string{
+		"ID":            strconv.Itoa(int(d.ID)),
+		"Counterparty":  d.Counterparty.Name,
+		"Direction":     string(d.Direction),
+		"Principal":     d.Currency.Money(d.Principal).String(),
+		"Currency":      d.Currency.Abbreviation,
+		"Interest Rate": strconv.FormatFloat(d.InterestRate, 'f', -1, 64),
+		"Date":          reprDate(d.Date),
+		"Installments":  strconv.Itoa(int(d.Installments)),
+		"Note":          d.Note,
+	}
+}
+
+// ToStatusMap converts model.Debt to map[string]string along with repaid and outstanding amounts
+// and repayment progress gauge. The gauge turns green when debt is repaid.
+func (p *Debt) ToStatusMap(d *model.Debt, repaid, outstanding int64) map[string]string {
+	m := p.ToMap(d)
+	m["Repaid"] = d.Currency.Money(repaid).String()
+	m["Outstanding"] = d.Currency.Money(outstanding).String()
+
+	color := "[yellow]"
+	if outstanding <= 0 {
+		color = "[green]"
+	}
+	m["Progress"] = reprBar(repaid, repaid+outstanding, color)
+
+	return m
+}
+
+// FromMap parses map[string]string to model.Debt. Keys "Interest Rate", "Installments" and "Note"
+// are optional, missing or empty rate and installments are parsed as zero. Counterparty which
+// doesn't exist yet is parsed as a new one.
+func (p *Debt) FromMap(m map[string]string) (*model.Debt, error) {
+	if err := checkKeys(m, []string{"Counterparty", "Direction", "Principal", "Currency", "Date"}); err != nil {
+		return nil, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	id, err := getID(m)
+	if err != nil {
+		return nil, fmt.Errorf("getID: %w", err)
+	}
+
+	currency := p.currencyService.GetByAbbreviation(m["Currency"])
+
+	principal, err := currency.ParseMoney(m["Principal"])
+	if err != nil {
+		return nil, fmt.Errorf("currency.ParseMoney: %w", err)
+	}
+
+	date, err := parseDate(m["Date"])
+	if err != nil {
+		return nil, fmt.Errorf("parseDate: %w", err)
+	}
+
+	var rate float64
+	if m["Interest Rate"] != "" {
+		if rate, err = strconv.ParseFloat(m["Interest Rate"], 64); err != nil {
+			return nil, fmt.Errorf("strconv.ParseFloat: %w", err)
+		}
+	}
+
+	installments := 0
+	if m["Installments"] != "" {
+		if installments, err = strconv.Atoi(m["Installments"]); err != nil {
+			return nil, fmt.Errorf("strconv.Atoi: %w", err)
+		}
+	}
+
+	counterparty := p.counterpartyService.GetByName(m["Counterparty"])
+	if counterparty == nil {
+		counterparty = &model.Counterparty{Name: m["Counterparty"]}
+	}
+
+	return &model.Debt{
+		ID:           id,
+		Counterparty: counterparty,
+		Direction:    model.DebtDirection(m["Direction"]),
+		Principal:    principal.Amount(),
+		Currency:     currency,
+		InterestRate: rate,
+		Date:         date,
+		Installments: int64(installments),
+		Note:         m["Note"],
+	}, nil
+}
+
+// BalanceToMap converts service.CounterpartyBalance to map[string]string. Depending on the sign of
+// balance its amount is put either to "Owes me" or to "I owe", the other one is empty.
+func (*Debt) BalanceToMap(b *service.CounterpartyBalance) map[string]string {
+	owesMe, iOwe := "", ""
+	if b.Amount > 0 {
+		owesMe = b.Currency.Money(b.Amount).String()
+	} else {
+		iOwe = b.Currency.Money(-b.Amount).String()
+	}
+
+	return map[string]string{
+		"Counterparty": b.Counterparty.Name,
+		"Currency":     b.Currency.Abbreviation,
+		"Owes me":      owesMe,
+		"I owe":        iOwe,
+	}
+}
+
+// InstallmentToMap converts service.Installment of debt in given currency to map[string]string,
+// amount is split into principal and interest parts.
+func (*Debt) InstallmentToMap(i *service.Installment, c *model.Currency) map[string]string {
+	return map[string]string{
+		"Date":      reprDate(i.Date),
+		"Amount":    c.Money(i.Amount).String(),
+		"Principal": c.Money(i.Amount - i.Interest).String(),
+		"Interest":  c.Money(i.Interest).String(),
+	}
+}
@@ -0,0 +1,189 @@
+package presenter_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DebtPresenterTestSuite struct {
+	suite.Suite
+	db               *sql.DB
+	presenter        *presenter.Debt
+	initCurrency     *model.Currency
+	initCounterparty *model.Counterparty
+}
+
+func (s *DebtPresenterTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.presenter = presenter.NewDebt(service.Currency(), service.Counterparty())
+
+	s.initCurrency = &model.Currency{Abbreviation: "USD", Precision: 2}
+	err = service.Currency().Insert(s.initCurrency)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initCounterparty = &model.Counterparty{Name: "John"}
+	err = service.Counterparty().Insert(s.initCounterparty)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+}
+
+func (s *DebtPresenterTestSuite) TestToMap() {
+	debt := &model.Debt{ID: 1, Counterparty: s.initCounterparty, Direction: model.Taken, Principal: 120000,
+		Currency: s.initCurrency, InterestRate: 12.5, Date: time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC),
+		Installments: 12, Note: "car"}
+	expected := map[string]string{"ID": "1", "Counterparty": "John", "Direction": "taken", "Principal": "1200.00",
+		"Currency": "USD", "Interest Rate": "12.5", "Date": "2022-01-15", "Installments": "12", "Note": "car"}
+
+	assert.Equal(s.T(), expected, s.presenter.ToMap(debt))
+}
+
+func (s *DebtPresenterTestSuite) TestToStatusMap() {
+	debt := &model.Debt{ID: 1, Counterparty: s.initCounterparty, Direction: model.Given, Principal: 1000,
+		Currency: s.initCurrency, Date: time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC)}
+
+	for _, tc := range []struct {
+		name        string
+		repaid      int64
+		outstanding int64
+		expected    map[string]string
+	}{
+		{
+			name:        "InProgress",
+			repaid:      250,
+			outstanding: 750,
+			expected: map[string]string{
+				"ID": "1", "Counterparty": "John", "Direction": "given", "Principal": "10.00", "Currency": "USD",
+				"Interest Rate": "0", "Date": "2022-01-15", "Installments": "0", "Note": "", "Repaid": "2.50",
+				"Outstanding": "7.50", "Progress": "[yellow]█████[white]░░░░░░░░░░░░░░░ 25%",
+			},
+		},
+		{
+			name:   "Repaid",
+			repaid: 1000,
+			expected: map[string]string{
+				"ID": "1", "Counterparty": "John", "Direction": "given", "Principal": "10.00", "Currency": "USD",
+				"Interest Rate": "0", "Date": "2022-01-15", "Installments": "0", "Note": "", "Repaid": "10.00",
+				"Outstanding": "0.00", "Progress": "[green]████████████████████[white] 100%",
+			},
+		},
+	} {
+		s.Run(tc.name, func() {
+			assert.Equal(s.T(), tc.expected, s.presenter.ToStatusMap(debt, tc.repaid, tc.outstanding))
+		})
+	}
+}
+
+func (s *DebtPresenterTestSuite) TestFromMapPositive() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected *model.Debt
+	}{
+		{
+			name: "ExistingCounterparty",
+			give: map[string]string{"ID": "1", "Counterparty": "John", "Direction": "taken", "Principal": "1200.00",
+				"Currency": "USD", "Interest Rate": "12.5", "Date": "2022-01-15", "Installments": "12", "Note": "car"},
+			expected: &model.Debt{ID: 1, Counterparty: s.initCounterparty, Direction: model.Taken, Principal: 120000,
+				Currency: s.initCurrency, InterestRate: 12.5, Date: time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC),
+				Installments: 12, Note: "car"},
+		},
+		{
+			name: "NewCounterpartyWithoutOptional",
+			give: map[string]string{"Counterparty": "Alice", "Direction": "given", "Principal": "10",
+				"Currency": "USD", "Date": "2022-01-15"},
+			expected: &model.Debt{Counterparty: &model.Counterparty{Name: "Alice"}, Direction: model.Given,
+				Principal: 1000, Currency: s.initCurrency, Date: time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC)},
+		},
+	} {
+		s.Run(tc.name, func() {
+			actual, err := s.presenter.FromMap(tc.give)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, actual)
+		})
+	}
+}
+
+func (s *DebtPresenterTestSuite) TestFromMapNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name:     "MissingDate",
+			give:     map[string]string{"Counterparty": "John", "Direction": "given", "Principal": "10", "Currency": "USD"},
+			expected: `checkKeys: key "Date" is missing`,
+		},
+		{
+			name: "InvalidPrincipal",
+			give: map[string]string{"Counterparty": "John", "Direction": "given", "Principal": "ten",
+				"Currency": "USD", "Date": "2022-01-15"},
+			expected: `currency.ParseMoney: invalid amount "ten"`,
+		},
+		{
+			name: "InvalidInterestRate",
+			give: map[string]string{"Counterparty": "John", "Direction": "given", "Principal": "10",
+				"Currency": "USD", "Date": "2022-01-15", "Interest Rate": "5%"},
+			expected: `strconv.ParseFloat: strconv.ParseFloat: parsing "5%": invalid syntax`,
+		},
+		{
+			name: "InvalidInstallments",
+			give: map[string]string{"Counterparty": "John", "Direction": "given", "Principal": "10",
+				"Currency": "USD", "Date": "2022-01-15", "Installments": "many"},
+			expected: `strconv.Atoi: strconv.Atoi: parsing "many": invalid syntax`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, err := s.presenter.FromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *DebtPresenterTestSuite) TestBalanceToMap() {
+	assert.Equal(s.T(),
+		map[string]string{"Counterparty": "John", "Currency": "USD", "Owes me": "12.00", "I owe": ""},
+		s.presenter.BalanceToMap(&service.CounterpartyBalance{
+			Counterparty: s.initCounterparty, Currency: s.initCurrency, Amount: 1200}))
+	assert.Equal(s.T(),
+		map[string]string{"Counterparty": "John", "Currency": "USD", "Owes me": "", "I owe": "12.00"},
+		s.presenter.BalanceToMap(&service.CounterpartyBalance{
+			Counterparty: s.initCounterparty, Currency: s.initCurrency, Amount: -1200}))
+}
+
+func (s *DebtPresenterTestSuite) TestInstallmentToMap() {
+	installment := &service.Installment{Date: time.Date(2022, 2, 15, 0, 0, 0, 0, time.UTC), Amount: 10662, Interest: 1200}
+	expected := map[string]string{"Date": "2022-02-15", "Amount": "106.62", "Principal": "94.62", "Interest": "12.00"}
+
+	assert.Equal(s.T(), expected, s.presenter.InstallmentToMap(installment, s.initCurrency))
+}
+
+func (s *DebtPresenterTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestDebtPresenterTestSuite(t *testing.T) {
+	suite.Run(t, new(DebtPresenterTestSuite))
+}
@@ -0,0 +1,70 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// ExchangeRate presenter contains logic related to UI.
+type ExchangeRate struct {
+	currencyService *service.Currency
+}
+
+// NewExchangeRate returns ExchangeRate presenter.
+func NewExchangeRate(currencyService *service.Currency) *ExchangeRate {
+	return &ExchangeRate{currencyService: currencyService}
+}
+
+// ToMap converts model.ExchangeRate to map[string]string.
+func (p *ExchangeRate) ToMap(r *model.ExchangeRate) map[string]string {
+	return map[string]string{
+		"ID":   strconv.Itoa(int(r.ID)),
+		"Date": r.Date.Format("2006-01-02"),
+		"From": r.From.Abbreviation,
+		"To":   r.To.Abbreviation,
+		"Rate": strconv.FormatFloat(r.Rate, 'f', -1, 64),
+	}
+}
+
+// FromMap parses map[string]string to model.ExchangeRate.
+func (p *ExchangeRate) FromMap(m map[string]string) (*model.ExchangeRate, error) {
+	if err := checkKeys(m, []string{"Date", "From", "To", "Rate"}); err != nil {
+		return nil, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	id, err := getID(m)
+	if err != nil {
+		return nil, fmt.Errorf("getID: %w", err)
+	}
+
+	date, err := time.Parse("2006-01-02", m["Date"])
+	if err != nil {
+		return nil, fmt.Errorf("time.Parse: %w", err)
+	}
+
+	rate, err := strconv.ParseFloat(m["Rate"], 64)
+	if err != nil {
+		return nil, fmt.Errorf("strconv.ParseFloat: %w", err)
+	}
+
+	return &model.ExchangeRate{
+		ID:   id,
+		Date: date,
+		From: p.currencyService.GetByAbbreviation(m["From"]),
+		To:   p.currencyService.GetByAbbreviation(m["To"]),
+		Rate: rate,
+	}, nil
+}
+
+// ReprInMain represents amount in the main currency, e.g. "-12.00 USD".
+func (p *ExchangeRate) ReprInMain(amount int64) string {
+	main := p.currencyService.GetMain()
+	if main == nil {
+		return main.Money(amount).String()
+	}
+	return main.Money(amount).String() + " " + main.Abbreviation
+}
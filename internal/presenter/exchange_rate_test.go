@@ -0,0 +1,108 @@
+package presenter_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExchangeRatePresenterTestSuite struct {
+	suite.Suite
+	db             *sql.DB
+	presenter      *presenter.ExchangeRate
+	initCurrencies []*model.Currency
+	mainCurrency   *model.Currency
+}
+
+func (s *ExchangeRatePresenterTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.presenter = presenter.NewExchangeRate(service.Currency())
+
+	s.initCurrencies = []*model.Currency{{Abbreviation: "GBP", Precision: 2}, {Abbreviation: "CHF", Precision: 2}}
+	for _, c := range s.initCurrencies {
+		err = service.Currency().Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+	s.mainCurrency = service.Currency().GetMain()
+}
+
+func (s *ExchangeRatePresenterTestSuite) TestToMap() {
+	rate := &model.ExchangeRate{
+		ID: 3, Date: time.Date(2022, 5, 6, 0, 0, 0, 0, time.UTC),
+		From: s.initCurrencies[0], To: s.initCurrencies[1], Rate: 1.125,
+	}
+	expected := map[string]string{"ID": "3", "Date": "2022-05-06", "From": "GBP", "To": "CHF", "Rate": "1.125"}
+
+	actual := s.presenter.ToMap(rate)
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *ExchangeRatePresenterTestSuite) TestFromMapPositive() {
+	give := map[string]string{"ID": "3", "Date": "2022-05-06", "From": "GBP", "To": "CHF", "Rate": "1.125"}
+	expected := &model.ExchangeRate{
+		ID: 3, Date: time.Date(2022, 5, 6, 0, 0, 0, 0, time.UTC),
+		From: s.initCurrencies[0], To: s.initCurrencies[1], Rate: 1.125,
+	}
+
+	actual, err := s.presenter.FromMap(give)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *ExchangeRatePresenterTestSuite) TestFromMapNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name:     "MissingRate",
+			give:     map[string]string{"Date": "2022-05-06", "From": "GBP", "To": "CHF"},
+			expected: `checkKeys: key "Rate" is missing`,
+		},
+		{
+			name:     "InvalidRate",
+			give:     map[string]string{"Date": "2022-05-06", "From": "GBP", "To": "CHF", "Rate": "abc"},
+			expected: `strconv.ParseFloat: strconv.ParseFloat: parsing "abc": invalid syntax`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, err := s.presenter.FromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *ExchangeRatePresenterTestSuite) TestReprInMain() {
+	assert.Equal(s.T(), "-12.50 "+s.mainCurrency.Abbreviation, s.presenter.ReprInMain(-1250))
+}
+
+func (s *ExchangeRatePresenterTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestExchangeRatePresenterTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRatePresenterTestSuite))
+}
@@ -0,0 +1,111 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// Goal presenter contains logic related to UI.
+type Goal struct {
+	currencyService *service.Currency
+	accountService  *service.Account
+	categoryService *service.Category
+}
+
+// NewGoal returns Goal presenter.
+func NewGoal(currencyService *service.Currency, accountService *service.Account, categoryService *service.Category) *Goal {
+	return &Goal{currencyService: currencyService, accountService: accountService, categoryService: categoryService}
+}
+
+// ToMap converts model.Goal to map[string]string. Linked category is represented by its path, the one
+// of "Account" and "Category" which isn't linked is empty.
+func (p *Goal) ToMap(g *model.Goal) map[string]string {
+	account := ""
+	if g.Account != nil {
+		account = g.Account.Name
+	}
+
+	category := ""
+	if g.Category != nil {
+		category = p.categoryService.Path(g.Category)
+	}
+
+	return map[string]string{
+		"ID":       strconv.Itoa(int(g.ID)),
+		"Name":     g.Name,
+		"Target":   g.Currency.Money(g.Target).String(),
+		"Currency": g.Currency.Abbreviation,
+		"Deadline": reprDate(g.Deadline),
+		"Account":  account,
+		"Category": category,
+	}
+}
+
+// ToProgressMap converts model.Goal to map[string]string along with saved and remaining amounts,
+// required monthly contribution and progress gauge. The gauge turns green when target is reached.
+func (p *Goal) ToProgressMap(g *model.Goal, saved, monthly int64) map[string]string {
+	m := p.ToMap(g)
+	m["Saved"] = g.Currency.Money(saved).String()
+	m["Remaining"] = g.Currency.Money(g.Target - saved).String()
+	m["Monthly"] = g.Currency.Money(monthly).String()
+
+	color := "[yellow]"
+	if saved >= g.Target {
+		color = "[green]"
+	}
+	m["Progress"] = reprBar(saved, g.Target, color)
+
+	return m
+}
+
+// FromMap parses map[string]string to model.Goal. Keys "Account" and "Category" are optional,
+// missing or empty values are parsed as not linked.
+func (p *Goal) FromMap(m map[string]string) (*model.Goal, error) {
+	if err := checkKeys(m, []string{"Name", "Target", "Currency", "Deadline"}); err != nil {
+		return nil, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	id, err := getID(m)
+	if err != nil {
+		return nil, fmt.Errorf("getID: %w", err)
+	}
+
+	currency := p.currencyService.GetByAbbreviation(m["Currency"])
+
+	target, err := currency.ParseMoney(m["Target"])
+	if err != nil {
+		return nil, fmt.Errorf("currency.ParseMoney: %w", err)
+	}
+
+	deadline, err := parseDate(m["Deadline"])
+	if err != nil {
+		return nil, fmt.Errorf("parseDate: %w", err)
+	}
+
+	var account *model.Account
+	if m["Account"] != "" {
+		if account = p.accountService.GetByName(m["Account"]); account == nil {
+			return nil, fmt.Errorf("account %q not found", m["Account"])
+		}
+	}
+
+	var category *model.Category
+	if m["Category"] != "" {
+		if category = p.categoryService.GetByPath(m["Category"]); category == nil {
+			return nil, fmt.Errorf("category %q not found", m["Category"])
+		}
+	}
+
+	return &model.Goal{
+		ID:       id,
+		Name:     m["Name"],
+		Target:   target.Amount(),
+		Currency: currency,
+		Deadline: deadline,
+		Account:  account,
+		Category: category,
+	}, nil
+}
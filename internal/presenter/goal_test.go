@@ -0,0 +1,200 @@
+package presenter_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type GoalPresenterTestSuite struct {
+	suite.Suite
+	db           *sql.DB
+	presenter    *presenter.Goal
+	initCurrency *model.Currency
+	initAccount  *model.Account
+	initCategory *model.Category
+}
+
+func (s *GoalPresenterTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.presenter = presenter.NewGoal(service.Currency(), service.Account(), service.Category())
+
+	s.initCurrency = &model.Currency{Abbreviation: "USD", Precision: 2}
+	err = service.Currency().Insert(s.initCurrency)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initAccount = &model.Account{Name: "Deposit", Currency: s.initCurrency}
+	err = service.Account().Insert(s.initAccount)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	savings := &model.Category{Title: "Savings"}
+	err = service.Category().Insert(savings)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initCategory = &model.Category{Title: "Car", Parent: savings}
+	err = service.Category().Insert(s.initCategory)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+}
+
+func (s *GoalPresenterTestSuite) TestToMap() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Goal
+		expected map[string]string
+	}{
+		{
+			name: "Account",
+			give: &model.Goal{ID: 1, Name: "Emergency fund", Target: 300000, Currency: s.initCurrency,
+				Deadline: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), Account: s.initAccount},
+			expected: map[string]string{"ID": "1", "Name": "Emergency fund", "Target": "3000.00", "Currency": "USD",
+				"Deadline": "2023-12-31", "Account": "Deposit", "Category": ""},
+		},
+		{
+			name: "Category",
+			give: &model.Goal{ID: 2, Name: "Car", Target: 1000000, Currency: s.initCurrency,
+				Deadline: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Category: s.initCategory},
+			expected: map[string]string{"ID": "2", "Name": "Car", "Target": "10000.00", "Currency": "USD",
+				"Deadline": "2024-06-01", "Account": "", "Category": "Savings / Car"},
+		},
+	} {
+		s.Run(tc.name, func() {
+			assert.Equal(s.T(), tc.expected, s.presenter.ToMap(tc.give))
+		})
+	}
+}
+
+func (s *GoalPresenterTestSuite) TestToProgressMap() {
+	goal := &model.Goal{ID: 1, Name: "Car", Target: 1000, Currency: s.initCurrency,
+		Deadline: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Category: s.initCategory}
+
+	for _, tc := range []struct {
+		name     string
+		saved    int64
+		monthly  int64
+		expected map[string]string
+	}{
+		{
+			name:    "InProgress",
+			saved:   250,
+			monthly: 50,
+			expected: map[string]string{
+				"ID": "1", "Name": "Car", "Target": "10.00", "Currency": "USD", "Deadline": "2024-06-01",
+				"Account": "", "Category": "Savings / Car", "Saved": "2.50", "Remaining": "7.50", "Monthly": "0.50",
+				"Progress": "[yellow]█████[white]░░░░░░░░░░░░░░░ 25%",
+			},
+		},
+		{
+			name:  "Reached",
+			saved: 1200,
+			expected: map[string]string{
+				"ID": "1", "Name": "Car", "Target": "10.00", "Currency": "USD", "Deadline": "2024-06-01",
+				"Account": "", "Category": "Savings / Car", "Saved": "12.00", "Remaining": "-2.00", "Monthly": "0.00",
+				"Progress": "[green]████████████████████[white] 120%",
+			},
+		},
+	} {
+		s.Run(tc.name, func() {
+			assert.Equal(s.T(), tc.expected, s.presenter.ToProgressMap(goal, tc.saved, tc.monthly))
+		})
+	}
+}
+
+func (s *GoalPresenterTestSuite) TestFromMapPositive() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected *model.Goal
+	}{
+		{
+			name: "Account",
+			give: map[string]string{"ID": "1", "Name": "Emergency fund", "Target": "3000.00", "Currency": "USD",
+				"Deadline": "2023-12-31", "Account": "Deposit", "Category": ""},
+			expected: &model.Goal{ID: 1, Name: "Emergency fund", Target: 300000, Currency: s.initCurrency,
+				Deadline: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), Account: s.initAccount},
+		},
+		{
+			name: "Category",
+			give: map[string]string{"Name": "Car", "Target": "10000", "Currency": "USD", "Deadline": "2024-06-01",
+				"Category": "Savings / Car"},
+			expected: &model.Goal{Name: "Car", Target: 1000000, Currency: s.initCurrency,
+				Deadline: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Category: s.initCategory},
+		},
+	} {
+		s.Run(tc.name, func() {
+			actual, err := s.presenter.FromMap(tc.give)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, actual)
+		})
+	}
+}
+
+func (s *GoalPresenterTestSuite) TestFromMapNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name:     "MissingDeadline",
+			give:     map[string]string{"Name": "Car", "Target": "10.00", "Currency": "USD"},
+			expected: `checkKeys: key "Deadline" is missing`,
+		},
+		{
+			name:     "InvalidTarget",
+			give:     map[string]string{"Name": "Car", "Target": "ten", "Currency": "USD", "Deadline": "2024-06-01"},
+			expected: `currency.ParseMoney: invalid amount "ten"`,
+		},
+		{
+			name:     "InvalidDeadline",
+			give:     map[string]string{"Name": "Car", "Target": "10.00", "Currency": "USD", "Deadline": "June"},
+			expected: `parseDate: parsing time "June" as "2006-01-02": cannot parse "June" as "2006"`,
+		},
+		{
+			name: "AccountNotFound",
+			give: map[string]string{"Name": "Car", "Target": "10.00", "Currency": "USD", "Deadline": "2024-06-01",
+				"Account": "Wallet"},
+			expected: `account "Wallet" not found`,
+		},
+		{
+			name: "CategoryNotFound",
+			give: map[string]string{"Name": "Car", "Target": "10.00", "Currency": "USD", "Deadline": "2024-06-01",
+				"Category": "Vacation"},
+			expected: `category "Vacation" not found`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, err := s.presenter.FromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *GoalPresenterTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestGoalPresenterTestSuite(t *testing.T) {
+	suite.Run(t, new(GoalPresenterTestSuite))
+}
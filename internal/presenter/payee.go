@@ -0,0 +1,52 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// Payee presenter contains logic related to UI.
+type Payee struct {
+	categoryService *service.Category
+}
+
+// NewPayee returns Payee presenter.
+func NewPayee(categoryService *service.Category) *Payee {
+	return &Payee{categoryService: categoryService}
+}
+
+// ToMap converts model.Payee to map[string]string. Default category is represented by its path,
+// payee without default category has empty one.
+func (p *Payee) ToMap(e *model.Payee) map[string]string {
+	category := ""
+	if e.Category != nil {
+		category = p.categoryService.Path(e.Category)
+	}
+
+	return map[string]string{"ID": strconv.Itoa(int(e.ID)), "Name": e.Name, "Category": category}
+}
+
+// FromMap parses map[string]string to model.Payee. Key "Category" is optional, missing or empty
+// category is parsed as payee without default category.
+func (p *Payee) FromMap(m map[string]string) (*model.Payee, error) {
+	if err := checkKeys(m, []string{"Name"}); err != nil {
+		return nil, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	id, err := getID(m)
+	if err != nil {
+		return nil, fmt.Errorf("getID: %w", err)
+	}
+
+	var category *model.Category
+	if m["Category"] != "" {
+		if category = p.categoryService.GetByPath(m["Category"]); category == nil {
+			return nil, fmt.Errorf("category %q not found", m["Category"])
+		}
+	}
+
+	return &model.Payee{ID: id, Name: m["Name"], Category: category}, nil
+}
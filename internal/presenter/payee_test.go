@@ -0,0 +1,124 @@
+package presenter_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PayeePresenterTestSuite struct {
+	suite.Suite
+	db           *sql.DB
+	presenter    *presenter.Payee
+	initCategory *model.Category
+}
+
+func (s *PayeePresenterTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.presenter = presenter.NewPayee(service.Category())
+
+	s.initCategory = &model.Category{Title: "Groceries"}
+	err = service.Category().Insert(s.initCategory)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+}
+
+func (s *PayeePresenterTestSuite) TestToMap() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Payee
+		expected map[string]string
+	}{
+		{
+			name:     "WithoutCategory",
+			give:     &model.Payee{ID: 1, Name: "Landlord"},
+			expected: map[string]string{"ID": "1", "Name": "Landlord", "Category": ""},
+		},
+		{
+			name:     "WithCategory",
+			give:     &model.Payee{Name: "Supermarket", Category: s.initCategory},
+			expected: map[string]string{"ID": "0", "Name": "Supermarket", "Category": "Groceries"},
+		},
+	} {
+		s.Run(tc.name, func() {
+			assert.Equal(s.T(), tc.expected, s.presenter.ToMap(tc.give))
+		})
+	}
+}
+
+func (s *PayeePresenterTestSuite) TestFromMapPositive() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected *model.Payee
+	}{
+		{
+			name:     "WithoutCategory",
+			give:     map[string]string{"ID": "1", "Name": "Landlord"},
+			expected: &model.Payee{ID: 1, Name: "Landlord"},
+		},
+		{
+			name:     "WithCategory",
+			give:     map[string]string{"Name": "Supermarket", "Category": "Groceries"},
+			expected: &model.Payee{Name: "Supermarket", Category: s.initCategory},
+		},
+	} {
+		s.Run(tc.name, func() {
+			actual, err := s.presenter.FromMap(tc.give)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, actual)
+		})
+	}
+}
+
+func (s *PayeePresenterTestSuite) TestFromMapNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name:     "MissingName",
+			give:     map[string]string{"Category": "Groceries"},
+			expected: `checkKeys: key "Name" is missing`,
+		},
+		{
+			name:     "CategoryNotFound",
+			give:     map[string]string{"Name": "Supermarket", "Category": "Health"},
+			expected: `category "Health" not found`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, err := s.presenter.FromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *PayeePresenterTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestPayeePresenterTestSuite(t *testing.T) {
+	suite.Run(t, new(PayeePresenterTestSuite))
+}
@@ -4,25 +4,49 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/money"
 	"github.com/kotlw/gentlemoney/internal/service"
 )
 
+// progressWidth is a number of cells of progress bar.
+const progressWidth = 20
+
 // Presenter is a facade structure which aggregates all Presenters. It is used for convenience.
 type Presenter struct {
-	category    *Category
-	currency    *Currency
-	account     *Account
-	transaction *Transaction
+	category     *Category
+	currency     *Currency
+	account      *Account
+	transaction  *Transaction
+	transfer     *Transfer
+	exchangeRate *ExchangeRate
+	budget       *Budget
+	recurrence   *Recurrence
+	payee        *Payee
+	attachment   *Attachment
+	goal         *Goal
+	debt         *Debt
+	trash        *Trash
 }
 
 // New returns new Presenter.
 func New(service *service.Service) *Presenter {
 	return &Presenter{
-		category:    NewCategory(),
-		currency:    NewCurrency(),
-		account:     NewAccount(service.Currency()),
-		transaction: NewTransaction(service.Account(), service.Category()),
+		category:     NewCategory(service.Category()),
+		currency:     NewCurrency(),
+		account:      NewAccount(service.Currency()),
+		transaction:  NewTransaction(service.Account(), service.Category(), service.Tag(), service.Payee()),
+		transfer:     NewTransfer(service.Account(), service.Category()),
+		exchangeRate: NewExchangeRate(service.Currency()),
+		budget:       NewBudget(service.Category(), service.Currency()),
+		recurrence:   NewRecurrence(service.Account(), service.Category()),
+		payee:        NewPayee(service.Category()),
+		attachment:   NewAttachment(service.Attachment()),
+		goal:         NewGoal(service.Currency(), service.Account(), service.Category()),
+		debt:         NewDebt(service.Currency(), service.Counterparty()),
+		trash:        NewTrash(),
 	}
 }
 
@@ -46,6 +70,51 @@ func (p *Presenter) Transaction() *Transaction {
 	return p.transaction
 }
 
+// Transfer returns transfer presenter.
+func (p *Presenter) Transfer() *Transfer {
+	return p.transfer
+}
+
+// ExchangeRate returns exchange rate presenter.
+func (p *Presenter) ExchangeRate() *ExchangeRate {
+	return p.exchangeRate
+}
+
+// Budget returns budget presenter.
+func (p *Presenter) Budget() *Budget {
+	return p.budget
+}
+
+// Recurrence returns recurrence presenter.
+func (p *Presenter) Recurrence() *Recurrence {
+	return p.recurrence
+}
+
+// Payee returns payee presenter.
+func (p *Presenter) Payee() *Payee {
+	return p.payee
+}
+
+// Attachment returns attachment presenter.
+func (p *Presenter) Attachment() *Attachment {
+	return p.attachment
+}
+
+// Goal returns goal presenter.
+func (p *Presenter) Goal() *Goal {
+	return p.goal
+}
+
+// Debt returns debt presenter.
+func (p *Presenter) Debt() *Debt {
+	return p.debt
+}
+
+// Trash returns trash presenter.
+func (p *Presenter) Trash() *Trash {
+	return p.trash
+}
+
 // checkKeys checks if all given keys are exist.
 func checkKeys(m map[string]string, keys []string) error {
 	for _, k := range keys {
@@ -56,42 +125,19 @@ func checkKeys(m map[string]string, keys []string) error {
 	return nil
 }
 
-// reprMoney converts int64 to string money format 0.00. Last 0-2 digits always will be after ".",
-// so value 1 becomes to "0.01", -12 to "-0.12", 0 to "0.00".
-func reprMoney(value int64) string {
-	res := strconv.Itoa(int(value))
-	sign := ""
-
-	// check if the value is negative and store the sign.
-	if res[0] == '-' {
-		res = res[1:]
-		sign = "-"
+// accountCurrency returns currency of given account, unknown account has unknown currency which
+// amounts have default precision.
+func accountCurrency(a *model.Account) *model.Currency {
+	if a == nil {
+		return nil
 	}
-
-	// handle edge cases
-	if len(res) == 1 {
-		return sign + "0.0" + res
-	} else if len(res) == 2 {
-		return sign + "0." + res
-	}
-
-	return sign + res[:len(res)-2] + "." + res[len(res)-2:]
+	return a.Currency
 }
 
-// parseMoney parses strings like "13.41" to int64 value of 1341.
-func parseMoney(value string) (int64, error) {
-	multiplier := 1
-	if !strings.Contains(value, ".") {
-		multiplier = 100
-	}
-	value = strings.Replace(value, ".", "", 1)
-
-	res, err := strconv.Atoi(value)
-	if err != nil {
-		return 0, err
-	}
-
-	return int64(res) * int64(multiplier), nil
+// accountMoney returns given amount as money in currency of given account, amount of unknown
+// account has default precision.
+func accountMoney(amount int64, a *model.Account) money.Money {
+	return accountCurrency(a).Money(amount)
 }
 
 // getID returns id from given map, returns 0 if key "ID" is missing in map.
@@ -106,3 +152,38 @@ func getID(m map[string]string) (int64, error) {
 	}
 	return int64(id), nil
 }
+
+// reprDate represents date in format 2006-01-02, zero date is represented as empty string.
+func reprDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+// parseDate parses date in format 2006-01-02, empty string is parsed as zero date.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// reprBar represents part of whole as a bar of given color followed by percentage. The bar is
+// clamped to its width, while percentage isn't.
+func reprBar(part, whole int64, color string) string {
+	percent := int64(0)
+	if whole > 0 {
+		percent = part * 100 / whole
+	}
+
+	filled := int(percent * progressWidth / 100)
+	if filled < 0 {
+		filled = 0
+	} else if filled > progressWidth {
+		filled = progressWidth
+	}
+
+	return color + strings.Repeat("█", filled) + "[white]" + strings.Repeat("░", progressWidth-filled) +
+		" " + strconv.Itoa(int(percent)) + "%"
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
 	"github.com/kotlw/gentlemoney/internal/presenter"
 	"github.com/kotlw/gentlemoney/internal/service"
 	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
@@ -19,7 +20,7 @@ type PresenterTestSuite struct {
 }
 
 func (s *PresenterTestSuite) TestPresenterGet() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
 	persistentStorage, err := sqlite.New(db)
@@ -27,7 +28,7 @@ func (s *PresenterTestSuite) TestPresenterGet() {
 
 	inmemoryStorage := inmemory.New()
 
-	service, err := service.New(persistentStorage, inmemoryStorage)
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemoryStorage), s.T().TempDir())
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
 	presenter := presenter.New(service)
@@ -36,6 +37,14 @@ func (s *PresenterTestSuite) TestPresenterGet() {
 	presenter.Currency()
 	presenter.Account()
 	presenter.Transaction()
+	presenter.Transfer()
+	presenter.ExchangeRate()
+	presenter.Budget()
+	presenter.Recurrence()
+	presenter.Payee()
+	presenter.Attachment()
+	presenter.Goal()
+	presenter.Debt()
 }
 
 func TestInmemoryStorageTestSuite(t *testing.T) {
@@ -0,0 +1,94 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// Recurrence presenter contains logic related to UI.
+type Recurrence struct {
+	accountService  *service.Account
+	categoryService *service.Category
+}
+
+// NewRecurrence returns Recurrence presenter.
+func NewRecurrence(accountService *service.Account, categoryService *service.Category) *Recurrence {
+	return &Recurrence{accountService: accountService, categoryService: categoryService}
+}
+
+// ToMap converts model.Recurrence to map[string]string. Zero end date is represented as empty string.
+func (p *Recurrence) ToMap(r *model.Recurrence) map[string]string {
+	return map[string]string{
+		"ID":       strconv.Itoa(int(r.ID)),
+		"Rule":     string(r.Rule),
+		"Start":    r.Start.Format("2006-01-02"),
+		"End":      reprDate(r.End),
+		"Next":     reprDate(r.Next),
+		"Paused":   strconv.FormatBool(r.Paused),
+		"Account":  r.Template.Account.Name,
+		"Category": p.categoryService.Path(r.Template.Category),
+		"Amount":   accountMoney(r.Template.Amount, r.Template.Account).String(),
+		"Note":     r.Template.Note,
+	}
+}
+
+// FromMap parses map[string]string to model.Recurrence. Keys "End", "Next" and "Paused" are
+// optional, missing or empty dates are parsed as zero.
+func (p *Recurrence) FromMap(m map[string]string) (*model.Recurrence, error) {
+	if err := checkKeys(m, []string{"Rule", "Start", "Account", "Category", "Amount", "Note"}); err != nil {
+		return nil, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	id, err := getID(m)
+	if err != nil {
+		return nil, fmt.Errorf("getID: %w", err)
+	}
+
+	start, err := time.Parse("2006-01-02", m["Start"])
+	if err != nil {
+		return nil, fmt.Errorf("time.Parse: %w", err)
+	}
+
+	end, err := parseDate(m["End"])
+	if err != nil {
+		return nil, fmt.Errorf("parseDate: %w", err)
+	}
+
+	next, err := parseDate(m["Next"])
+	if err != nil {
+		return nil, fmt.Errorf("parseDate: %w", err)
+	}
+
+	paused := false
+	if v, ok := m["Paused"]; ok {
+		if paused, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("strconv.ParseBool: %w", err)
+		}
+	}
+
+	account := p.accountService.GetByName(m["Account"])
+
+	amount, err := accountCurrency(account).ParseMoney(m["Amount"])
+	if err != nil {
+		return nil, fmt.Errorf("accountCurrency(account).ParseMoney: %w", err)
+	}
+
+	return &model.Recurrence{
+		ID:     id,
+		Rule:   model.Rule(m["Rule"]),
+		Start:  start,
+		End:    end,
+		Next:   next,
+		Paused: paused,
+		Template: &model.Transaction{
+			Account:  account,
+			Category: p.categoryService.GetByPath(m["Category"]),
+			Amount:   amount.Amount(),
+			Note:     m["Note"],
+		},
+	}, nil
+}
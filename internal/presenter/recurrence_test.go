@@ -0,0 +1,144 @@
+package presenter_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecurrencePresenterTestSuite struct {
+	suite.Suite
+	db           *sql.DB
+	presenter    *presenter.Recurrence
+	initCategory *model.Category
+	initAccount  *model.Account
+}
+
+func (s *RecurrencePresenterTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.presenter = presenter.NewRecurrence(service.Account(), service.Category())
+
+	s.initCategory = &model.Category{Title: "Salary"}
+	err = service.Category().Insert(s.initCategory)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	currency := &model.Currency{Abbreviation: "JPY", Precision: 2}
+	err = service.Currency().Insert(currency)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initAccount = &model.Account{Name: "Payroll", Currency: currency}
+	err = service.Account().Insert(s.initAccount)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+}
+
+func (s *RecurrencePresenterTestSuite) TestToMap() {
+	expected := map[string]string{
+		"ID": "4", "Rule": "monthly", "Start": "2022-01-31", "End": "", "Next": "2022-02-28", "Paused": "false",
+		"Account": "Payroll", "Category": "Salary", "Amount": "1000.00", "Note": "salary",
+	}
+
+	actual := s.presenter.ToMap(s.newRecurrence())
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *RecurrencePresenterTestSuite) TestFromMapPositive() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected *model.Recurrence
+	}{
+		{
+			name: "AllKeys",
+			give: map[string]string{
+				"ID": "4", "Rule": "monthly", "Start": "2022-01-31", "End": "", "Next": "2022-02-28", "Paused": "false",
+				"Account": "Payroll", "Category": "Salary", "Amount": "1000", "Note": "salary",
+			},
+			expected: s.newRecurrence(),
+		},
+		{
+			name: "OptionalKeysMissing",
+			give: map[string]string{
+				"ID": "4", "Rule": "monthly", "Start": "2022-01-31",
+				"Account": "Payroll", "Category": "Salary", "Amount": "1000", "Note": "salary",
+			},
+			expected: func() *model.Recurrence {
+				r := s.newRecurrence()
+				r.Next = time.Time{}
+				return r
+			}(),
+		},
+	} {
+		s.Run(tc.name, func() {
+			actual, err := s.presenter.FromMap(tc.give)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, actual)
+		})
+	}
+}
+
+func (s *RecurrencePresenterTestSuite) TestFromMapNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name: "MissingRule",
+			give: map[string]string{
+				"Start": "2022-01-31", "Account": "Payroll", "Category": "Salary", "Amount": "1000", "Note": ""},
+			expected: `checkKeys: key "Rule" is missing`,
+		},
+		{
+			name: "InvalidEnd",
+			give: map[string]string{"Rule": "daily", "Start": "2022-01-31", "End": "never",
+				"Account": "Payroll", "Category": "Salary", "Amount": "1000", "Note": ""},
+			expected: `parseDate: parsing time "never" as "2006-01-02": cannot parse "never" as "2006"`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, err := s.presenter.FromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *RecurrencePresenterTestSuite) newRecurrence() *model.Recurrence {
+	return &model.Recurrence{
+		ID:    4,
+		Rule:  model.Monthly,
+		Start: time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC),
+		Next:  time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC),
+		Template: &model.Transaction{
+			Account: s.initAccount, Category: s.initCategory, Amount: 100000, Note: "salary"},
+	}
+}
+
+func (s *RecurrencePresenterTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestRecurrencePresenterTestSuite(t *testing.T) {
+	suite.Run(t, new(RecurrencePresenterTestSuite))
+}
@@ -3,9 +3,11 @@ package presenter
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/money"
 	"github.com/kotlw/gentlemoney/internal/service"
 )
 
@@ -13,27 +15,47 @@ import (
 type Transaction struct {
 	accountService  *service.Account
 	categoryService *service.Category
+	tagService      *service.Tag
+	payeeService    *service.Payee
 }
 
 // NewTransaction returns Transaction presenter.
-func NewTransaction(accountService *service.Account, categoryService *service.Category) *Transaction {
-	return &Transaction{accountService: accountService, categoryService: categoryService}
+func NewTransaction(
+	accountService *service.Account,
+	categoryService *service.Category,
+	tagService *service.Tag,
+	payeeService *service.Payee) *Transaction {
+
+	return &Transaction{
+		accountService:  accountService,
+		categoryService: categoryService,
+		tagService:      tagService,
+		payeeService:    payeeService,
+	}
 }
 
-// ToMap converts model.Transaction to map[string]string. It doesn't handles ID field.
+// ToMap converts model.Transaction to map[string]string. Category is represented by its path, tags
+// are represented by names separated by comma, splits are represented by their number, transaction
+// without payee has empty one.
 func (p *Transaction) ToMap(t *model.Transaction) map[string]string {
 	return map[string]string{
 		"ID":       strconv.Itoa(int(t.ID)),
 		"Date":     t.Date.Format("2006-01-02"),
-		"Account":  t.Account.Name,
-		"Category": t.Category.Title,
-		"Amount":   p.reprAmount(t.Amount),
-		"Currency": t.Account.Currency.Abbreviation,
+		"Account":  p.reprAccount(t.Account),
+		"Category": p.reprCategory(t.Category),
+		"Payee":    p.reprPayee(t.Payee),
+		"Amount":   p.reprAmount(t.Money()),
+		"Currency": p.reprCurrency(t.Account),
 		"Note":     t.Note,
+		"Status":   string(t.Status),
+		"Tags":     p.reprTags(t.Tags),
+		"Splits":   p.reprSplits(t.Splits),
 	}
 }
 
-// FromMap parses map[string]string to model.Transaction. It doesn't handles ID field.
+// FromMap parses map[string]string to model.Transaction. Keys "Tags", "Payee" and "Status" are
+// optional, tags and payee which don't exist yet are returned with zero ID, missing status is
+// left for service to fill. Amount without sign gets the sign of its category kind.
 func (p *Transaction) FromMap(m map[string]string) (*model.Transaction, error) {
 	if err := checkKeys(m, []string{"Date", "Account", "Category", "Amount", "Note"}); err != nil {
 		return nil, fmt.Errorf("checkKeys: %w", err)
@@ -49,25 +71,205 @@ func (p *Transaction) FromMap(m map[string]string) (*model.Transaction, error) {
 		return nil, fmt.Errorf("time.Parse: %w", err)
 	}
 
-	amount, err := parseMoney(m["Amount"])
+	account := p.accountService.GetByName(m["Account"])
+	category := p.categoryService.GetByPath(m["Category"])
+
+	amount, err := accountCurrency(account).ParseMoney(m["Amount"])
 	if err != nil {
-		return nil, fmt.Errorf("parseMoney: %w", err)
+		return nil, fmt.Errorf("accountCurrency(account).ParseMoney: %w", err)
+	}
+
+	if amount, err = p.applyKind(m["Amount"], amount, category); err != nil {
+		return nil, fmt.Errorf("p.applyKind: %w", err)
 	}
 
 	return &model.Transaction{
 		ID:       int64(id),
 		Date:     date,
-		Account:  p.accountService.GetByName(m["Account"]),
-		Category: p.categoryService.GetByTitle(m["Category"]),
-		Amount:   amount,
+		Account:  account,
+		Category: category,
+		Payee:    p.parsePayee(m["Payee"]),
+		Amount:   amount.Amount(),
 		Note:     m["Note"],
+		Status:   model.TransactionStatus(m["Status"]),
+		Tags:     p.parseTags(m["Tags"]),
 	}, nil
 }
 
-func (*Transaction) reprAmount(value int64) string {
+// SplitsToMaps converts splits of transaction of given account to slice of map[string]string with
+// keys "Category", "Amount" and "Note".
+func (p *Transaction) SplitsToMaps(ss []*model.Split, a *model.Account) []map[string]string {
+	res := make([]map[string]string, len(ss))
+
+	for i, e := range ss {
+		res[i] = map[string]string{
+			"Category": p.categoryService.Path(e.Category),
+			"Amount":   p.reprAmount(accountMoney(e.Amount, a)),
+			"Note":     e.Note,
+		}
+	}
+
+	return res
+}
+
+// SplitsFromMaps parses slice of map[string]string to splits of transaction of given account.
+// Amount without sign gets the sign of its category kind.
+func (p *Transaction) SplitsFromMaps(mm []map[string]string, a *model.Account) ([]*model.Split, error) {
+	var res []*model.Split
+
+	for _, m := range mm {
+		if err := checkKeys(m, []string{"Category", "Amount", "Note"}); err != nil {
+			return nil, fmt.Errorf("checkKeys: %w", err)
+		}
+
+		category := p.categoryService.GetByPath(m["Category"])
+
+		amount, err := accountCurrency(a).ParseMoney(m["Amount"])
+		if err != nil {
+			return nil, fmt.Errorf("accountCurrency(a).ParseMoney: %w", err)
+		}
+
+		if amount, err = p.applyKind(m["Amount"], amount, category); err != nil {
+			return nil, fmt.Errorf("p.applyKind: %w", err)
+		}
+
+		res = append(res, &model.Split{
+			Category: category,
+			Amount:   amount.Amount(),
+			Note:     m["Note"],
+		})
+	}
+
+	return res, nil
+}
+
+// StatementFromMap parses bank statement which account is reconciled against from
+// map[string]string with keys "Account", "Statement Date" and "Statement Balance".
+func (p *Transaction) StatementFromMap(m map[string]string) (*model.Account, time.Time, int64, error) {
+	if err := checkKeys(m, []string{"Account", "Statement Date", "Statement Balance"}); err != nil {
+		return nil, time.Time{}, 0, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	a := p.accountService.GetByName(m["Account"])
+	if a == nil {
+		return nil, time.Time{}, 0, fmt.Errorf("account %q not found", m["Account"])
+	}
+
+	date, err := time.Parse("2006-01-02", m["Statement Date"])
+	if err != nil {
+		return nil, time.Time{}, 0, fmt.Errorf("time.Parse: %w", err)
+	}
+
+	balance, err := a.Currency.ParseMoney(m["Statement Balance"])
+	if err != nil {
+		return nil, time.Time{}, 0, fmt.Errorf("a.Currency.ParseMoney: %w", err)
+	}
+
+	return a, date, balance.Amount(), nil
+}
+
+// reprAccount represents account by its name, missing account is represented as empty string.
+func (*Transaction) reprAccount(a *model.Account) string {
+	if a == nil {
+		return ""
+	}
+	return a.Name
+}
+
+// reprCurrency represents currency of account by its abbreviation, currency of missing account is
+// represented as empty string.
+func (*Transaction) reprCurrency(a *model.Account) string {
+	if a == nil || a.Currency == nil {
+		return ""
+	}
+	return a.Currency.Abbreviation
+}
+
+// reprCategory represents category by its path, missing category is represented as empty string.
+func (p *Transaction) reprCategory(c *model.Category) string {
+	if c == nil {
+		return ""
+	}
+	return p.categoryService.Path(c)
+}
+
+// reprPayee represents payee by its name, missing payee is represented as empty string.
+func (*Transaction) reprPayee(e *model.Payee) string {
+	if e == nil {
+		return ""
+	}
+	return e.Name
+}
+
+// parsePayee parses payee by its name, empty name means there is no payee.
+func (p *Transaction) parsePayee(value string) *model.Payee {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	if e := p.payeeService.GetByName(value); e != nil {
+		return e
+	}
+
+	return &model.Payee{Name: value}
+}
+
+// reprSplits represents splits by their number, transaction without splits is represented as empty
+// string.
+func (*Transaction) reprSplits(ss []*model.Split) string {
+	if len(ss) == 0 {
+		return ""
+	}
+	return strconv.Itoa(len(ss))
+}
+
+// reprTags represents tags as names separated by comma.
+func (*Transaction) reprTags(tt []*model.Tag) string {
+	names := make([]string, len(tt))
+	for i, t := range tt {
+		names[i] = t.Name
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// parseTags parses names separated by comma to tags. Empty and duplicated names are skipped.
+func (p *Transaction) parseTags(value string) []*model.Tag {
+	var res []*model.Tag
+	seen := make(map[string]bool)
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		t := p.tagService.GetByName(name)
+		if t == nil {
+			t = &model.Tag{Name: name}
+		}
+		res = append(res, t)
+	}
+
+	return res
+}
+
+// applyKind negates amount of expense category if its value is typed without sign, so "12.00" in
+// expense category becomes an expense. Value with explicit sign is kept as is.
+func (*Transaction) applyKind(value string, amount money.Money, c *model.Category) (money.Money, error) {
+	if c == nil || c.Kind != model.Expense || strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		return amount, nil
+	}
+	return amount.Neg()
+}
+
+// reprAmount represents amount of transaction, positive amount is prefixed with "+".
+func (*Transaction) reprAmount(m money.Money) string {
 	sign := ""
-	if value > 0 {
+	if m.Amount() > 0 {
 		sign = "+"
 	}
-	return sign + reprMoney(value)
+	return sign + m.String()
 }
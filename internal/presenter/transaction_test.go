@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
 	"github.com/kotlw/gentlemoney/internal/model"
 	"github.com/kotlw/gentlemoney/internal/presenter"
 	"github.com/kotlw/gentlemoney/internal/service"
@@ -22,12 +23,15 @@ type TransactionPresenterTestSuite struct {
 	db           *sql.DB
 	presenter    *presenter.Transaction
 	initCategory *model.Category
+	initExpense  *model.Category
 	initCurrency *model.Currency
 	initAccount  *model.Account
+	initTag      *model.Tag
+	initPayee    *model.Payee
 }
 
 func (s *TransactionPresenterTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
@@ -36,22 +40,34 @@ func (s *TransactionPresenterTestSuite) SetupSuite() {
 
 	inmemoryStorage := inmemory.New()
 
-	service, err := service.New(persistentStorage, inmemoryStorage)
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemoryStorage), s.T().TempDir())
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
-	s.presenter = presenter.NewTransaction(service.Account(), service.Category())
+	s.presenter = presenter.NewTransaction(service.Account(), service.Category(), service.Tag(), service.Payee())
 
 	s.initCategory = &model.Category{Title: "Health"}
 	err = service.Category().Insert(s.initCategory)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
-	s.initCurrency = &model.Currency{Abbreviation: "USD"}
+	s.initExpense = &model.Category{Title: "Grocery", Kind: model.Expense}
+	err = service.Category().Insert(s.initExpense)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initCurrency = &model.Currency{Abbreviation: "USD", Precision: 2}
 	err = service.Currency().Insert(s.initCurrency)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
 	s.initAccount = &model.Account{Name: "Card1", Currency: s.initCurrency}
 	err = service.Account().Insert(s.initAccount)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initTag = &model.Tag{Name: "vacation"}
+	err = service.Tag().Insert(s.initTag)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initPayee = &model.Payee{Name: "Pharmacy"}
+	err = service.Payee().Insert(s.initPayee)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
 }
 
 func (s *TransactionPresenterTestSuite) TestToMap() {
@@ -69,6 +85,7 @@ func (s *TransactionPresenterTestSuite) TestToMap() {
 				Category: s.initCategory,
 				Amount:   0,
 				Note:     "Note1",
+				Status:   model.Cleared,
 			},
 			expected: map[string]string{
 				"ID":       "1",
@@ -78,6 +95,10 @@ func (s *TransactionPresenterTestSuite) TestToMap() {
 				"Currency": s.initCurrency.Abbreviation,
 				"Amount":   "0.00",
 				"Note":     "Note1",
+				"Status":   "cleared",
+				"Tags":     "",
+				"Splits":   "",
+				"Payee":    "",
 			},
 		},
 		{
@@ -98,6 +119,10 @@ func (s *TransactionPresenterTestSuite) TestToMap() {
 				"Currency": s.initCurrency.Abbreviation,
 				"Amount":   "+0.01",
 				"Note":     "Note1",
+				"Status":   "",
+				"Tags":     "",
+				"Splits":   "",
+				"Payee":    "",
 			},
 		},
 		{
@@ -118,6 +143,10 @@ func (s *TransactionPresenterTestSuite) TestToMap() {
 				"Currency": s.initCurrency.Abbreviation,
 				"Amount":   "+0.31",
 				"Note":     "Note1",
+				"Status":   "",
+				"Tags":     "",
+				"Splits":   "",
+				"Payee":    "",
 			},
 		},
 		{
@@ -138,6 +167,82 @@ func (s *TransactionPresenterTestSuite) TestToMap() {
 				"Currency": s.initCurrency.Abbreviation,
 				"Amount":   "-3423423212.31",
 				"Note":     "Note1",
+				"Status":   "",
+				"Tags":     "",
+				"Splits":   "",
+				"Payee":    "",
+			},
+		},
+		{
+			name: "WithTags",
+			give: &model.Transaction{
+				ID:       int64(1),
+				Date:     time.Date(2020, 5, 6, 11, 45, 04, 0, time.UTC),
+				Account:  s.initAccount,
+				Category: s.initCategory,
+				Amount:   -100,
+				Note:     "Note1",
+				Tags:     []*model.Tag{s.initTag, {Name: "reimbursable"}},
+			},
+			expected: map[string]string{
+				"ID":       "1",
+				"Date":     "2020-05-06",
+				"Account":  s.initAccount.Name,
+				"Category": s.initCategory.Title,
+				"Currency": s.initCurrency.Abbreviation,
+				"Amount":   "-1.00",
+				"Note":     "Note1",
+				"Status":   "",
+				"Tags":     "vacation, reimbursable",
+				"Splits":   "",
+				"Payee":    "",
+			},
+		},
+		{
+			name: "WithPayee",
+			give: &model.Transaction{
+				ID:       int64(1),
+				Date:     time.Date(2020, 5, 6, 11, 45, 04, 0, time.UTC),
+				Account:  s.initAccount,
+				Category: s.initCategory,
+				Payee:    s.initPayee,
+				Amount:   -100,
+				Note:     "Note1",
+			},
+			expected: map[string]string{
+				"ID":       "1",
+				"Date":     "2020-05-06",
+				"Account":  s.initAccount.Name,
+				"Category": s.initCategory.Title,
+				"Payee":    "Pharmacy",
+				"Currency": s.initCurrency.Abbreviation,
+				"Amount":   "-1.00",
+				"Note":     "Note1",
+				"Status":   "",
+				"Tags":     "",
+				"Splits":   "",
+			},
+		},
+		{
+			name: "MissingAccountAndCategory",
+			give: &model.Transaction{
+				ID:     int64(1),
+				Date:   time.Date(2020, 5, 6, 11, 45, 04, 0, time.UTC),
+				Amount: -100,
+				Note:   "Note1",
+			},
+			expected: map[string]string{
+				"ID":       "1",
+				"Date":     "2020-05-06",
+				"Account":  "",
+				"Category": "",
+				"Payee":    "",
+				"Currency": "",
+				"Amount":   "-1.00",
+				"Note":     "Note1",
+				"Status":   "",
+				"Tags":     "",
+				"Splits":   "",
 			},
 		},
 	} {
@@ -212,6 +317,62 @@ func (s *TransactionPresenterTestSuite) TestFromMapPositive() {
 				Note:     "Note1",
 			},
 		},
+		{
+			name: "WithTags",
+			give: map[string]string{
+				"Date":     "2020-05-06",
+				"Account":  s.initAccount.Name,
+				"Category": s.initCategory.Title,
+				"Amount":   "0.00",
+				"Note":     "Note1",
+				"Tags":     " reimbursable,vacation,, vacation",
+			},
+			expected: &model.Transaction{
+				ID:       int64(0),
+				Date:     time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC),
+				Account:  s.initAccount,
+				Category: s.initCategory,
+				Amount:   0,
+				Note:     "Note1",
+				Tags:     []*model.Tag{{Name: "reimbursable"}, s.initTag},
+			},
+		},
+		{
+			name: "WithExistingPayee",
+			give: map[string]string{
+				"Date":     "2020-05-06",
+				"Account":  s.initAccount.Name,
+				"Category": s.initCategory.Title,
+				"Payee":    "Pharmacy ",
+				"Amount":   "0.00",
+				"Note":     "Note1",
+			},
+			expected: &model.Transaction{
+				Date:     time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC),
+				Account:  s.initAccount,
+				Category: s.initCategory,
+				Payee:    s.initPayee,
+				Note:     "Note1",
+			},
+		},
+		{
+			name: "WithNewPayee",
+			give: map[string]string{
+				"Date":     "2020-05-06",
+				"Account":  s.initAccount.Name,
+				"Category": s.initCategory.Title,
+				"Payee":    "Supermarket",
+				"Amount":   "0.00",
+				"Note":     "Note1",
+			},
+			expected: &model.Transaction{
+				Date:     time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC),
+				Account:  s.initAccount,
+				Category: s.initCategory,
+				Payee:    &model.Payee{Name: "Supermarket"},
+				Note:     "Note1",
+			},
+		},
 	} {
 		s.Run(tc.name, func() {
 			actual, err := s.presenter.FromMap(tc.give)
@@ -247,7 +408,7 @@ func (s *TransactionPresenterTestSuite) TestFromMapNegative() {
 				"Amount":   "invalid",
 				"Note":     "Note1",
 			},
-			expected: `parseMoney: strconv.Atoi: parsing "invalid": invalid syntax`,
+			expected: `accountCurrency(account).ParseMoney: invalid amount "invalid"`,
 		},
 		{
 			name: "MissingDate",
@@ -312,6 +473,120 @@ func (s *TransactionPresenterTestSuite) TearDownSuite() {
 	require.NoError(s.T(), err, "occurred in TearDownSuite")
 }
 
+func (s *TransactionPresenterTestSuite) TestSplits() {
+	splits := []*model.Split{
+		{Category: s.initCategory, Amount: -1050, Note: "pills"},
+		{Category: s.initCategory, Amount: 20},
+	}
+	maps := []map[string]string{
+		{"Category": "Health", "Amount": "-10.50", "Note": "pills"},
+		{"Category": "Health", "Amount": "+0.20", "Note": ""},
+	}
+
+	assert.Equal(s.T(), maps, s.presenter.SplitsToMaps(splits, s.initAccount))
+	assert.Equal(s.T(), "2", s.presenter.ToMap(&model.Transaction{
+		Account: s.initAccount, Category: s.initCategory, Splits: splits})["Splits"])
+
+	actual, err := s.presenter.SplitsFromMaps(maps, s.initAccount)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), splits, actual)
+
+	_, err = s.presenter.SplitsFromMaps([]map[string]string{{"Category": "Health", "Amount": "x", "Note": ""}}, s.initAccount)
+	assert.EqualError(s.T(), err, `accountCurrency(a).ParseMoney: invalid amount "x"`)
+}
+
+func (s *TransactionPresenterTestSuite) TestPrecision() {
+	for _, tc := range []struct {
+		name      string
+		precision int64
+		amount    int64
+		expected  string
+	}{
+		{name: "Zero", precision: 0, amount: -1500, expected: "-1500"},
+		{name: "Three", precision: 3, amount: 1500, expected: "+1.500"},
+		{name: "Eight", precision: 8, amount: -15, expected: "-0.00000015"},
+	} {
+		s.Run(tc.name, func() {
+			account := &model.Account{Name: "Wallet", Currency: &model.Currency{Abbreviation: "XXX", Precision: tc.precision}}
+			m := s.presenter.ToMap(&model.Transaction{Account: account, Category: s.initCategory, Amount: tc.amount})
+			assert.Equal(s.T(), tc.expected, m["Amount"])
+		})
+	}
+
+	m := map[string]string{"Date": "2020-05-06", "Account": s.initAccount.Name, "Category": s.initCategory.Title,
+		"Amount": "1.234", "Note": ""}
+	_, err := s.presenter.FromMap(m)
+	assert.EqualError(s.T(), err, `accountCurrency(account).ParseMoney: "1.234" has more than 2 digits after point`)
+
+	m["Amount"] = "-1.5"
+	actual, err := s.presenter.FromMap(m)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(-150), actual.Amount)
+}
+
+func (s *TransactionPresenterTestSuite) TestKind() {
+	for _, tc := range []struct {
+		name     string
+		category string
+		amount   string
+		expected int64
+	}{
+		{name: "ExpenseWithoutSign", category: "Grocery", amount: "12.50", expected: -1250},
+		{name: "ExpenseWithPlus", category: "Grocery", amount: "+12.50", expected: 1250},
+		{name: "ExpenseWithMinus", category: "Grocery", amount: "-12.50", expected: -1250},
+		{name: "BothWithoutSign", category: "Health", amount: "12.50", expected: 1250},
+	} {
+		s.Run(tc.name, func() {
+			m := map[string]string{"Date": "2020-05-06", "Account": s.initAccount.Name, "Category": tc.category,
+				"Amount": tc.amount, "Note": ""}
+			actual, err := s.presenter.FromMap(m)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, actual.Amount)
+
+			splits, err := s.presenter.SplitsFromMaps([]map[string]string{
+				{"Category": tc.category, "Amount": tc.amount, "Note": ""}}, s.initAccount)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, splits[0].Amount)
+		})
+	}
+}
+
+func (s *TransactionPresenterTestSuite) TestStatementFromMap() {
+	a, date, balance, err := s.presenter.StatementFromMap(map[string]string{
+		"Account": s.initAccount.Name, "Statement Date": "2020-05-31", "Statement Balance": "-10.50"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.initAccount, a)
+	assert.Equal(s.T(), time.Date(2020, 5, 31, 0, 0, 0, 0, time.UTC), date)
+	assert.Equal(s.T(), int64(-1050), balance)
+
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name:     "MissingBalance",
+			give:     map[string]string{"Account": s.initAccount.Name, "Statement Date": "2020-05-31"},
+			expected: `checkKeys: key "Statement Balance" is missing`,
+		},
+		{
+			name:     "UnknownAccount",
+			give:     map[string]string{"Account": "Unknown", "Statement Date": "2020-05-31", "Statement Balance": "0"},
+			expected: `account "Unknown" not found`,
+		},
+		{
+			name:     "WrongBalance",
+			give:     map[string]string{"Account": s.initAccount.Name, "Statement Date": "2020-05-31", "Statement Balance": "x"},
+			expected: `a.Currency.ParseMoney: invalid amount "x"`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, _, _, err := s.presenter.StatementFromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
 func TestTransactionPresenterTestSuite(t *testing.T) {
 	suite.Run(t, new(TransactionPresenterTestSuite))
 }
@@ -0,0 +1,128 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// Transfer presenter contains logic related to UI.
+type Transfer struct {
+	accountService  *service.Account
+	categoryService *service.Category
+}
+
+// NewTransfer returns Transfer presenter.
+func NewTransfer(accountService *service.Account, categoryService *service.Category) *Transfer {
+	return &Transfer{accountService: accountService, categoryService: categoryService}
+}
+
+// ToMap converts model.Transfer to map[string]string. Amounts are represented without sign,
+// category is represented by its path.
+func (p *Transfer) ToMap(t *model.Transfer) map[string]string {
+	return map[string]string{
+		"ID":        strconv.Itoa(int(t.ID)),
+		"Date":      t.From.Date.Format("2006-01-02"),
+		"From":      p.reprAccount(t.From.Account),
+		"To":        p.reprAccount(t.To.Account),
+		"Category":  p.reprCategory(t.From.Category),
+		"Amount":    accountMoney(-t.From.Amount, t.From.Account).String(),
+		"To Amount": accountMoney(t.To.Amount, t.To.Account).String(),
+		"Note":      t.From.Note,
+	}
+}
+
+// FromMap parses map[string]string to model.Transfer. Outgoing leg gets negative amount and
+// incoming one positive, if "To Amount" is empty it is considered equal to "Amount", so it is
+// required for transfer between different currencies. Ids of legs aren't handled.
+func (p *Transfer) FromMap(m map[string]string) (*model.Transfer, error) {
+	if err := checkKeys(m, []string{"Date", "From", "To", "Category", "Amount", "To Amount", "Note"}); err != nil {
+		return nil, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	id, err := getID(m)
+	if err != nil {
+		return nil, fmt.Errorf("getID: %w", err)
+	}
+
+	date, err := time.Parse("2006-01-02", m["Date"])
+	if err != nil {
+		return nil, fmt.Errorf("time.Parse: %w", err)
+	}
+
+	from, to := p.accountService.GetByName(m["From"]), p.accountService.GetByName(m["To"])
+
+	amount, err := accountCurrency(from).ParseMoney(strings.TrimLeft(m["Amount"], "+-"))
+	if err != nil {
+		return nil, fmt.Errorf("accountCurrency(from).ParseMoney: %w", err)
+	}
+
+	toValue := m["To Amount"]
+	if toValue == "" {
+		if !sameCurrency(from, to) {
+			return nil, errors.New(`"To Amount" is required for transfer between different currencies`)
+		}
+		toValue = m["Amount"]
+	}
+
+	toAmount, err := accountCurrency(to).ParseMoney(strings.TrimLeft(toValue, "+-"))
+	if err != nil {
+		return nil, fmt.Errorf("accountCurrency(to).ParseMoney: %w", err)
+	}
+
+	outgoing, err := amount.Neg()
+	if err != nil {
+		return nil, fmt.Errorf("amount.Neg: %w", err)
+	}
+
+	category := p.categoryService.GetByPath(m["Category"])
+
+	return &model.Transfer{
+		ID: id,
+		From: &model.Transaction{
+			Date:     date,
+			Account:  from,
+			Category: category,
+			Amount:   outgoing.Amount(),
+			Note:     m["Note"],
+		},
+		To: &model.Transaction{
+			Date:     date,
+			Account:  to,
+			Category: category,
+			Amount:   toAmount.Amount(),
+			Note:     m["Note"],
+		},
+	}, nil
+}
+
+// reprAccount represents account by its name, missing account is represented as empty string.
+func (*Transfer) reprAccount(a *model.Account) string {
+	if a == nil {
+		return ""
+	}
+	return a.Name
+}
+
+// reprCategory represents category by its path, missing category is represented as empty string.
+func (p *Transfer) reprCategory(c *model.Category) string {
+	if c == nil {
+		return ""
+	}
+	return p.categoryService.Path(c)
+}
+
+// sameCurrency reports whether both accounts are in the same currency, missing account is
+// considered to be in the currency of default precision.
+func sameCurrency(a, b *model.Account) bool {
+	ca, cb := accountCurrency(a), accountCurrency(b)
+	if ca == nil || cb == nil {
+		return ca == cb
+	}
+	return ca.ID == cb.ID
+}
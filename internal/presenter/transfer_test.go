@@ -0,0 +1,206 @@
+package presenter_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransferPresenterTestSuite struct {
+	suite.Suite
+	db           *sql.DB
+	presenter    *presenter.Transfer
+	initCategory *model.Category
+	subcategory  *model.Category
+	initAccounts []*model.Account
+}
+
+func (s *TransferPresenterTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	service, err := service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.presenter = presenter.NewTransfer(service.Account(), service.Category())
+
+	s.initCategory = &model.Category{Title: "Transfer"}
+	err = service.Category().Insert(s.initCategory)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.subcategory = &model.Category{Title: "Savings", Parent: s.initCategory}
+	err = service.Category().Insert(s.subcategory)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	currency := &model.Currency{Abbreviation: "UAH", Precision: 2}
+	err = service.Currency().Insert(currency)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	yen := &model.Currency{Abbreviation: "KRW", Precision: 0}
+	err = service.Currency().Insert(yen)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initAccounts = []*model.Account{
+		{Name: "Cash", Currency: currency},
+		{Name: "Card", Currency: currency},
+		{Name: "Yen", Currency: yen},
+	}
+	for _, a := range s.initAccounts {
+		err = service.Account().Insert(a)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+}
+
+func (s *TransferPresenterTestSuite) TestToMap() {
+	transfer := s.newTransfer(7, 12345, 12000)
+	expected := map[string]string{
+		"ID":        "7",
+		"Date":      "2020-05-06",
+		"From":      "Cash",
+		"To":        "Card",
+		"Category":  "Transfer",
+		"Amount":    "123.45",
+		"To Amount": "120.00",
+		"Note":      "Note1",
+	}
+
+	actual := s.presenter.ToMap(transfer)
+	assert.Equal(s.T(), expected, actual)
+
+	transfer.From.Category = s.subcategory
+	assert.Equal(s.T(), "Transfer / Savings", s.presenter.ToMap(transfer)["Category"])
+
+	transfer.From.Account, transfer.From.Category = nil, nil
+	actual = s.presenter.ToMap(transfer)
+	assert.Equal(s.T(), "", actual["From"])
+	assert.Equal(s.T(), "", actual["Category"])
+}
+
+func (s *TransferPresenterTestSuite) TestFromMapPositive() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected *model.Transfer
+	}{
+		{
+			name: "DifferentAmounts",
+			give: map[string]string{
+				"ID": "7", "Date": "2020-05-06", "From": "Cash", "To": "Card", "Category": "Transfer",
+				"Amount": "123.45", "To Amount": "120", "Note": "Note1",
+			},
+			expected: s.newTransfer(7, 12345, 12000),
+		},
+		{
+			name: "EmptyToAmount",
+			give: map[string]string{
+				"ID": "7", "Date": "2020-05-06", "From": "Cash", "To": "Card", "Category": "Transfer",
+				"Amount": "-123.45", "To Amount": "", "Note": "Note1",
+			},
+			expected: s.newTransfer(7, 12345, 12345),
+		},
+		{
+			name: "Subcategory",
+			give: map[string]string{
+				"ID": "7", "Date": "2020-05-06", "From": "Cash", "To": "Card", "Category": "Transfer / Savings",
+				"Amount": "123.45", "To Amount": "120", "Note": "Note1",
+			},
+			expected: func() *model.Transfer {
+				t := s.newTransfer(7, 12345, 12000)
+				t.From.Category, t.To.Category = s.subcategory, s.subcategory
+				return t
+			}(),
+		},
+		{
+			name: "DifferentCurrencies",
+			give: map[string]string{
+				"ID": "7", "Date": "2020-05-06", "From": "Cash", "To": "Yen", "Category": "Transfer",
+				"Amount": "123.45", "To Amount": "4500", "Note": "Note1",
+			},
+			expected: func() *model.Transfer {
+				t := s.newTransfer(7, 12345, 4500)
+				t.To.Account = s.initAccounts[2]
+				return t
+			}(),
+		},
+	} {
+		s.Run(tc.name, func() {
+			actual, err := s.presenter.FromMap(tc.give)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, actual)
+		})
+	}
+}
+
+func (s *TransferPresenterTestSuite) TestFromMapNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     map[string]string
+		expected string
+	}{
+		{
+			name: "InvalidToAmount",
+			give: map[string]string{
+				"Date": "2020-05-06", "From": "Cash", "To": "Card", "Category": "Transfer",
+				"Amount": "1.00", "To Amount": "invalid", "Note": "",
+			},
+			expected: `accountCurrency(to).ParseMoney: invalid amount "invalid"`,
+		},
+		{
+			name: "MissingTo",
+			give: map[string]string{
+				"Date": "2020-05-06", "From": "Cash", "Category": "Transfer",
+				"Amount": "1.00", "To Amount": "", "Note": "",
+			},
+			expected: `checkKeys: key "To" is missing`,
+		},
+		{
+			name: "MissingToAmountBetweenCurrencies",
+			give: map[string]string{
+				"Date": "2020-05-06", "From": "Cash", "To": "Yen", "Category": "Transfer",
+				"Amount": "1.00", "To Amount": "", "Note": "",
+			},
+			expected: `"To Amount" is required for transfer between different currencies`,
+		},
+	} {
+		s.Run(tc.name, func() {
+			_, err := s.presenter.FromMap(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *TransferPresenterTestSuite) newTransfer(id, fromAmount, toAmount int64) *model.Transfer {
+	date := time.Date(2020, 5, 6, 0, 0, 0, 0, time.UTC)
+	return &model.Transfer{
+		ID: id,
+		From: &model.Transaction{Date: date, Account: s.initAccounts[0], Category: s.initCategory,
+			Amount: -fromAmount, Note: "Note1"},
+		To: &model.Transaction{Date: date, Account: s.initAccounts[1], Category: s.initCategory,
+			Amount: toAmount, Note: "Note1"},
+	}
+}
+
+func (s *TransferPresenterTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestTransferPresenterTestSuite(t *testing.T) {
+	suite.Run(t, new(TransferPresenterTestSuite))
+}
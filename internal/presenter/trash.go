@@ -0,0 +1,42 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Trash presenter contains logic related to UI.
+type Trash struct{}
+
+// NewTrash returns Trash presenter.
+func NewTrash() *Trash {
+	return &Trash{}
+}
+
+// ToMap converts model.TrashItem to map[string]string. Key "Deleted" is the local time when item
+// was moved to trash.
+func (p *Trash) ToMap(i *model.TrashItem) map[string]string {
+	return map[string]string{
+		"ID":      strconv.Itoa(int(i.ID)),
+		"Kind":    string(i.Kind),
+		"Title":   i.Title,
+		"Deleted": i.DeletedAt.Local().Format("2006-01-02 15:04"),
+	}
+}
+
+// FromMap parses map[string]string to model.TrashItem, only kind and id are parsed since they are
+// enough to find deleted item.
+func (p *Trash) FromMap(m map[string]string) (*model.TrashItem, error) {
+	if err := checkKeys(m, []string{"ID", "Kind"}); err != nil {
+		return nil, fmt.Errorf("checkKeys: %w", err)
+	}
+
+	id, err := getID(m)
+	if err != nil {
+		return nil, fmt.Errorf("getID: %w", err)
+	}
+
+	return &model.TrashItem{Kind: model.TrashKind(m["Kind"]), ID: id, Title: m["Title"]}, nil
+}
@@ -0,0 +1,42 @@
+package presenter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrashPresenterTestSuite struct {
+	suite.Suite
+	presenter *presenter.Trash
+}
+
+func (s *TrashPresenterTestSuite) SetupSuite() {
+	s.presenter = presenter.NewTrash()
+}
+
+func (s *TrashPresenterTestSuite) TestToMap() {
+	item := &model.TrashItem{
+		Kind: model.TrashAccount, ID: 3, Title: "Cash", DeletedAt: time.Date(2022, time.Month(2), 21, 9, 5, 0, 0, time.Local)}
+	expected := map[string]string{"ID": "3", "Kind": "account", "Title": "Cash", "Deleted": "2022-02-21 09:05"}
+	assert.Equal(s.T(), expected, s.presenter.ToMap(item))
+}
+
+func (s *TrashPresenterTestSuite) TestFromMap() {
+	actual, err := s.presenter.FromMap(map[string]string{"ID": "3", "Kind": "account", "Title": "Cash"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), &model.TrashItem{Kind: model.TrashAccount, ID: 3, Title: "Cash"}, actual)
+
+	_, err = s.presenter.FromMap(map[string]string{"ID": "3"})
+	assert.EqualError(s.T(), err, `checkKeys: key "Kind" is missing`)
+}
+
+func TestTrashPresenterTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashPresenterTestSuite))
+}
@@ -1,28 +1,33 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kotlw/gentlemoney/internal/model"
-	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
-	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
 )
 
 // Account service contains business logic related to model.Account.
 type Account struct {
-	persistentStorage *sqlite.Account
-	inmemoryStorage   *inmemory.Account
+	persistentStorage AccountPersistentStorage
+	inmemoryStorage   AccountInmemoryStorage
+	currencyService   *Currency
+	history           *History
+	unitOfWork        *UnitOfWork
+	references        *References
 }
 
 // NewAccount returns Account service.
 func NewAccount(
-	persistentStorage *sqlite.Account,
-	inmemoryStorage *inmemory.Account,
+	persistentStorage AccountPersistentStorage,
+	inmemoryStorage AccountInmemoryStorage,
 	currencyService *Currency) (*Account, error) {
 
 	a := &Account{
 		persistentStorage: persistentStorage,
 		inmemoryStorage:   inmemoryStorage,
+		currencyService:   currencyService,
 	}
 
 	if err := a.Init(currencyService); err != nil {
@@ -49,8 +54,26 @@ func (s *Account) Init(currencyService *Currency) error {
 	return nil
 }
 
-// Insert appends account to both persistent and inmemory storages.
-func (s *Account) Insert(a *model.Account) error {
+// Insert appends account to both persistent and inmemory storages. Account without type is
+// considered as checking one.
+func (s *Account) Insert(a *model.Account) (err error) {
+	defer s.history.record(&err, "insert account",
+		func() error { return s.Delete(a, DeletePolicy[model.Account]{}) },
+		func() error { return s.Restore(a) })()
+	defer s.history.refer(model.TrashAccount, &a.ID)
+
+	if a.Type == "" {
+		a.Type = model.Checking
+	}
+
+	if err := s.validate(a); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.checkTrash(a); err != nil {
+		return fmt.Errorf("s.checkTrash: %w", err)
+	}
+
 	id, err := s.persistentStorage.Insert(a)
 	if err != nil {
 		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
@@ -63,8 +86,27 @@ func (s *Account) Insert(a *model.Account) error {
 }
 
 // Update updates account in persistent storage. Since GetAll returns pointers to inmemory data
-// after update the category we need to update it in persistent storage as well.
-func (s *Account) Update(a *model.Account) error {
+// after update the category we need to update it in persistent storage as well. Account without
+// type is considered as checking one.
+func (s *Account) Update(a *model.Account) (err error) {
+	prev, next := clone(s.GetByID(a.ID)), clone(a)
+	defer s.history.record(&err, "update account",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+	defer s.history.refer(model.TrashAccount, &a.ID)
+
+	if a.Type == "" {
+		a.Type = model.Checking
+	}
+
+	if err := s.validate(a); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.checkTrash(a); err != nil {
+		return fmt.Errorf("s.checkTrash: %w", err)
+	}
+
 	if err := s.persistentStorage.Update(a); err != nil {
 		return fmt.Errorf("s.persistentStorage.Update: %w", err)
 	}
@@ -74,8 +116,61 @@ func (s *Account) Update(a *model.Account) error {
 	return nil
 }
 
-// Delete deletes account from inmemory and persistent storages.
-func (s *Account) Delete(a *model.Account) error {
+// Delete moves account to trash, so it is removed from inmemory storage only. Account which is in
+// use is deleted according to given policy: deletion is refused with InUseError, the data which
+// refers to account is deleted along with it, or it is moved to the target account of the same
+// currency. Account with reconciled transactions can't be deleted by policy, since they are
+// locked. Goals are unlinked from deleted account. Changes made by policy are undone along with
+// deletion.
+func (s *Account) Delete(a *model.Account, policy DeletePolicy[model.Account]) error {
+	switch policy.Action {
+	case Refuse:
+		if err := s.checkUsage(a); err != nil {
+			return err
+		}
+	case Cascade:
+	case Reassign:
+		if policy.Target == nil || s.GetByID(policy.Target.ID) == nil {
+			return ErrNoReassignTarget
+		}
+		if policy.Target.ID == a.ID {
+			return ErrReassignToItself
+		}
+		policy.Target = s.GetByID(policy.Target.ID)
+		if stored := s.GetByID(a.ID); stored != nil && stored.Currency.ID != policy.Target.Currency.ID {
+			return fmt.Errorf("account %q has another currency", policy.Target.Name)
+		}
+	default:
+		return fmt.Errorf("unknown delete action %d", policy.Action)
+	}
+
+	if policy.Action != Refuse {
+		if err := s.references.accountLocked(a).err(fmt.Sprintf("account %q", a.Name)); err != nil {
+			return err
+		}
+	}
+
+	return s.unitOfWork.Do(func() error {
+		if err := s.references.releaseAccount(a, policy); err != nil {
+			return fmt.Errorf("s.references.releaseAccount: %w", err)
+		}
+
+		return s.delete(a)
+	})
+}
+
+// delete moves account which isn't in use to trash.
+func (s *Account) delete(a *model.Account) (err error) {
+	stored := s.GetByID(a.ID)
+	defer s.history.record(&err, "delete account",
+		func() error { return s.Restore(stored) },
+		func() error { return s.delete(stored) })()
+	defer s.history.refer(model.TrashAccount, &a.ID)
+
+	if err := s.checkUsage(a); err != nil {
+		return err
+	}
+
 	if err := s.persistentStorage.Delete(a.ID); err != nil {
 		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
 	}
@@ -85,11 +180,92 @@ func (s *Account) Delete(a *model.Account) error {
 	return nil
 }
 
+// GetDeleted returns accounts from trash. Currency is nil if it is in trash as well.
+func (s *Account) GetDeleted() ([]*model.Account, error) {
+	aa, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	for _, a := range aa {
+		a.Currency = s.currencyService.GetByID(a.Currency.ID)
+	}
+
+	return aa, nil
+}
+
+// checkUsage returns InUseError if any data refers to account.
+func (s *Account) checkUsage(a *model.Account) error {
+	return s.references.accountUsage(a).err(fmt.Sprintf("account %q", a.Name))
+}
+
+// getDeletedRefs returns accounts from trash as they are kept in persistent storage, so currency
+// has only id set even if it is in trash.
+func (s *Account) getDeletedRefs() ([]*model.Account, error) {
+	aa, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	return aa, nil
+}
+
+// Restore brings account back from trash. Account can't be restored while its currency is in
+// trash.
+func (s *Account) Restore(a *model.Account) error {
+	if a.Currency == nil || s.currencyService.GetByID(a.Currency.ID) == nil {
+		return fmt.Errorf("currency of account %q: %w", a.Name, ErrDependsOnTrash)
+	}
+
+	if err := s.persistentStorage.Restore(a.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Restore: %w", err)
+	}
+
+	a.DeletedAt = time.Time{}
+	s.inmemoryStorage.Insert(a)
+
+	return nil
+}
+
+// Purge permanently deletes account from trash. Account can't be purged while transactions in
+// trash refer to it.
+func (s *Account) Purge(a *model.Account) error {
+	u, err := s.references.trashUsage(a.ID, 0)
+	if err != nil {
+		return fmt.Errorf("s.references.trashUsage: %w", err)
+	}
+
+	if err = u.err(fmt.Sprintf("account %q", a.Name)); err != nil {
+		return err
+	}
+
+	if err := s.persistentStorage.Purge(a.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Purge: %w", err)
+	}
+
+	return s.unitOfWork.AfterCommit(func() error {
+		s.history.forget(model.TrashAccount, a.ID)
+		return nil
+	})
+}
+
 // GetAll returns all accounts.
 func (s *Account) GetAll() []*model.Account {
 	return s.inmemoryStorage.GetAll()
 }
 
+// GetActive returns accounts which aren't archived.
+func (s *Account) GetActive() []*model.Account {
+	res := make([]*model.Account, 0)
+	for _, a := range s.GetAll() {
+		if !a.Archived {
+			res = append(res, a)
+		}
+	}
+
+	return res
+}
+
 // GetByID returns account by given model.Account.ID.
 func (s *Account) GetByID(id int64) *model.Account {
 	return s.inmemoryStorage.GetByID(id)
@@ -99,3 +275,38 @@ func (s *Account) GetByID(id int64) *model.Account {
 func (s *Account) GetByName(name string) *model.Account {
 	return s.inmemoryStorage.GetByName(name)
 }
+
+// validate checks if account type is known and credit limit isn't negative.
+func (*Account) validate(a *model.Account) error {
+	known := false
+	for _, t := range model.AccountTypes() {
+		known = known || a.Type == t
+	}
+
+	if !known {
+		return fmt.Errorf("unknown account type %q", a.Type)
+	}
+
+	if a.CreditLimit < 0 {
+		return errors.New("credit limit can't be negative")
+	}
+
+	return nil
+}
+
+// checkTrash returns error if account with the same name is in trash, since names are unique
+// among deleted accounts as well.
+func (s *Account) checkTrash(a *model.Account) error {
+	aa, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	for _, e := range aa {
+		if e.Name == a.Name {
+			return fmt.Errorf("account %q: %w", a.Name, ErrNameInTrash)
+		}
+	}
+
+	return nil
+}
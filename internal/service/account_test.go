@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
 	"github.com/kotlw/gentlemoney/internal/model"
 	"github.com/kotlw/gentlemoney/internal/service"
 	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
@@ -26,20 +27,20 @@ type AccountServiceTestSuite struct {
 }
 
 func (s *AccountServiceTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
 	s.persistentStorage, err = sqlite.New(db)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.inmemoryStorage = inmemory.New()
-	s.service, err = service.New(s.persistentStorage, s.inmemoryStorage)
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(s.inmemoryStorage), s.T().TempDir())
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
 	// id's settled by sqlite on insert incrementally starting from 1,
 	// so here they are initialized for match purpose
 	s.InitCurrencies = []*model.Currency{
-		{ID: 1, Abbreviation: "USD"},
+		{ID: 1, Abbreviation: "USD", IsMain: true},
 		{ID: 2, Abbreviation: "EUR"},
 	}
 	s.InitAccounts = []*model.Account{
@@ -117,11 +118,43 @@ func (s *AccountServiceTestSuite) TestUpdateNegative() {
 	aa[0].ID = 1 // return real id to proper teardown
 }
 
+func (s *AccountServiceTestSuite) TestValidateNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Account
+		expected string
+	}{
+		{
+			name:     "UnknownType",
+			give:     &model.Account{Name: "Card3", Currency: s.InitCurrencies[0], Type: "wallet"},
+			expected: `s.validate: unknown account type "wallet"`,
+		},
+		{
+			name:     "NegativeCreditLimit",
+			give:     &model.Account{Name: "Card3", Currency: s.InitCurrencies[0], Type: model.CreditCard, CreditLimit: -1},
+			expected: "s.validate: credit limit can't be negative",
+		},
+	} {
+		s.Run(tc.name, func() {
+			assert.EqualError(s.T(), s.service.Account().Insert(tc.give), tc.expected)
+			assert.EqualError(s.T(), s.service.Account().Update(tc.give), tc.expected)
+		})
+	}
+}
+
+func (s *AccountServiceTestSuite) TestGetActive() {
+	aa := s.service.Account().GetAll()
+	aa[1].Archived = true
+	defer func() { aa[1].Archived = false }()
+
+	assert.Equal(s.T(), []*model.Account{aa[0]}, s.service.Account().GetActive())
+}
+
 func (s *AccountServiceTestSuite) TestDeletePositive() {
 	aa := s.service.Account().GetAll()
 	expectedAccounts := []*model.Account{aa[0]}
 
-	err := s.service.Account().Delete(aa[1])
+	err := s.service.Account().Delete(aa[1], service.DeletePolicy[model.Account]{})
 	require.NoError(s.T(), err)
 
 	persistentAccounts := s.getLinkedPersistantAccounts()
@@ -134,7 +167,7 @@ func (s *AccountServiceTestSuite) TestDeleteNegative() {
 	aa := s.service.Account().GetAll()
 	aa[0].ID = 10
 
-	err := s.service.Account().Delete(aa[0])
+	err := s.service.Account().Delete(aa[0], service.DeletePolicy[model.Account]{})
 	assert.ErrorContains(s.T(), err, "s.persistentStorage.Delete: total affected rows 0 while expected 1")
 	aa[0].ID = 1 // return real id to proper teardown
 }
@@ -171,6 +204,10 @@ func (s *AccountServiceTestSuite) TearDownTest() {
 		require.NoError(s.T(), err, "occurred in TearDownTest")
 		s.inmemoryStorage.Account().Delete(aa[0])
 	}
+
+	// deleted items are kept in trash, so they are purged to reuse ids
+	_, err := s.db.Exec(`DELETE FROM account WHERE deletedAt IS NOT NULL;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
 }
 
 func (s *AccountServiceTestSuite) TearDownSuite() {
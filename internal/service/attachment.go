@@ -0,0 +1,208 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// tmpPattern is a name pattern of temporary copies of files in managed folder.
+const tmpPattern = "tmp-*"
+
+// Attachment service contains business logic related to model.Attachment. Attached files are copied
+// into managed folder under the name of their content hash, so equal files are stored only once.
+// Files are changed only along with stored attachments, so folder keeps no files of failed changes.
+type Attachment struct {
+	persistentStorage AttachmentPersistentStorage
+	inmemoryStorage   AttachmentInmemoryStorage
+	unitOfWork        *UnitOfWork
+	dir               string
+}
+
+// NewAttachment returns Attachment service which keeps attached files in given dir.
+func NewAttachment(
+	persistentStorage AttachmentPersistentStorage,
+	inmemoryStorage AttachmentInmemoryStorage,
+	dir string) (*Attachment, error) {
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	s := &Attachment{persistentStorage: persistentStorage, inmemoryStorage: inmemoryStorage, dir: dir}
+
+	if err := s.Init(); err != nil {
+		return nil, fmt.Errorf("s.Init: %w", err)
+	}
+
+	return s, nil
+}
+
+// Init initialize inmemory storage with data from persistent storage. Copies of files left by
+// failed units of work are removed, since Init is called to reload the data after them.
+func (s *Attachment) Init() error {
+	aa, err := s.persistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
+	}
+
+	s.inmemoryStorage.Init(aa)
+
+	tmps, err := filepath.Glob(filepath.Join(s.dir, tmpPattern))
+	if err != nil {
+		return fmt.Errorf("filepath.Glob: %w", err)
+	}
+
+	for _, tmp := range tmps {
+		if err = s.removeFile(tmp); err != nil {
+			return fmt.Errorf("s.removeFile: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Add copies file of given path into managed folder and attaches it to transaction. The copy is
+// kept under temporary name until attachment is stored, inside of unit of work it is moved into
+// place only after the unit of work is committed.
+func (s *Attachment) Add(t *model.Transaction, path string) (*model.Attachment, error) {
+	if t.ID == 0 {
+		return nil, errors.New("can't attach file to unsaved transaction")
+	}
+
+	a := &model.Attachment{TransactionID: t.ID, Name: filepath.Base(path)}
+
+	tmp, hash, err := s.copy(path)
+	if err != nil {
+		return nil, fmt.Errorf("s.copy: %w", err)
+	}
+
+	a.Hash = hash
+	if a.ID, err = s.persistentStorage.Insert(a); err != nil {
+		_ = os.Remove(tmp)
+		return nil, fmt.Errorf("s.persistentStorage.Insert: %w", err)
+	}
+
+	dst := s.Path(a)
+	if err = s.unitOfWork.AfterCommit(func() error { return s.place(tmp, dst) }); err != nil {
+		// attachment without file is useless, so it isn't kept outside of unit of work.
+		if delErr := s.persistentStorage.Delete(a.ID); delErr != nil {
+			return nil, fmt.Errorf("s.persistentStorage.Delete: %v: %w", delErr, err)
+		}
+		return nil, fmt.Errorf("s.unitOfWork.AfterCommit: %w", err)
+	}
+
+	s.inmemoryStorage.Insert(a)
+
+	return a, nil
+}
+
+// Remove detaches attachment from its transaction. The file is deleted from managed folder when
+// there is no more attachments referring to it, inside of unit of work it is deleted only after
+// the unit of work is committed.
+func (s *Attachment) Remove(a *model.Attachment) error {
+	if err := s.persistentStorage.Delete(a.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+	}
+	s.inmemoryStorage.Delete(a)
+
+	path := s.Path(a)
+	for _, e := range s.inmemoryStorage.GetAll() {
+		if s.Path(e) == path {
+			return nil
+		}
+	}
+
+	if err := s.unitOfWork.AfterCommit(func() error { return s.removeFile(path) }); err != nil {
+		return fmt.Errorf("s.unitOfWork.AfterCommit: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveAll removes all attachments of transaction.
+func (s *Attachment) RemoveAll(t *model.Transaction) error {
+	for _, a := range s.GetByTransaction(t) {
+		if err := s.Remove(a); err != nil {
+			return fmt.Errorf("s.Remove: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetByTransaction returns attachments of given transaction.
+func (s *Attachment) GetByTransaction(t *model.Transaction) []*model.Attachment {
+	return s.inmemoryStorage.GetByTransactionID(t.ID)
+}
+
+// Path returns path of the attachment file in managed folder.
+func (s *Attachment) Path(a *model.Attachment) string {
+	return filepath.Join(s.dir, a.Hash+s.ext(a.Name))
+}
+
+// ext returns extension of stored file, which is the extension of original one, so stored files can
+// be opened by the programs associated with it.
+func (*Attachment) ext(name string) string {
+	return strings.ToLower(filepath.Ext(name))
+}
+
+// copy copies file of given path into temporary file of managed folder and returns path of the
+// copy along with content hash of the file. The copy should be removed by caller.
+func (s *Attachment) copy(path string) (string, string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", "", fmt.Errorf("os.Open: %w", err)
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(s.dir, tmpPattern)
+	if err != nil {
+		return "", "", fmt.Errorf("os.CreateTemp: %w", err)
+	}
+
+	h := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(tmp, h), src); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return "", "", fmt.Errorf("io.Copy: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", "", fmt.Errorf("tmp.Close: %w", err)
+	}
+
+	return tmp.Name(), hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// place moves copy of file to given path of managed folder, the copy is removed if the file with
+// the same content is already there.
+func (s *Attachment) place(tmp, dst string) error {
+	if _, err := os.Stat(dst); err == nil {
+		return s.removeFile(tmp)
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("os.Rename: %w", err)
+	}
+
+	return nil
+}
+
+// removeFile deletes file of managed folder, missing file is considered deleted.
+func (*Attachment) removeFile(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("os.Remove: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package service_test
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentServiceTestSuite struct {
+	suite.Suite
+	db                *sql.DB
+	persistentStorage *sqlite.SqliteStorage
+	service           *service.Service
+	dir               string
+	receipt           string
+	warranty          string
+}
+
+func (s *AttachmentServiceTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	s.dir = filepath.Join(s.T().TempDir(), "attachments")
+	s.persistentStorage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	// attachments refer to transactions, since foreign keys are enforced
+	_, err = db.Exec(`INSERT INTO currency(abbreviation) VALUES ('USD');
+                      INSERT INTO category(title) VALUES ('Grocery');
+                      INSERT INTO account(name, currencyId) VALUES ('Cash', 1);
+                      INSERT INTO "transaction"(date, amount, note, accountId, categoryId)
+                      VALUES ('2022-02-21', -100, '', 1, 1), ('2022-02-22', -200, '', 1, 1);`)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(inmemory.New()), s.dir)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	src := s.T().TempDir()
+	s.receipt = filepath.Join(src, "Receipt.JPG")
+	s.warranty = filepath.Join(src, "warranty.pdf")
+	require.NoError(s.T(), os.WriteFile(s.receipt, []byte("receipt"), 0o600), "occurred in SetupSuite")
+	require.NoError(s.T(), os.WriteFile(s.warranty, []byte("warranty"), 0o600), "occurred in SetupSuite")
+}
+
+func (s *AttachmentServiceTestSuite) TestAddPositive() {
+	a, err := s.service.Attachment().Add(&model.Transaction{ID: 1}, s.receipt)
+	require.NoError(s.T(), err)
+
+	// sha256 of "receipt"
+	hash := "6f32860910ca0fb2a20c7fda143666b09dbf8db5238195c90a586fb542ff0cad"
+	assert.Equal(s.T(), &model.Attachment{ID: 1, TransactionID: 1, Name: "Receipt.JPG", Hash: hash}, a)
+	assert.Equal(s.T(), filepath.Join(s.dir, hash+".jpg"), s.service.Attachment().Path(a))
+
+	content, err := os.ReadFile(s.service.Attachment().Path(a))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "receipt", string(content))
+}
+
+func (s *AttachmentServiceTestSuite) TestAddDuplicate() {
+	a, err := s.service.Attachment().Add(&model.Transaction{ID: 1}, s.receipt)
+	require.NoError(s.T(), err)
+	b, err := s.service.Attachment().Add(&model.Transaction{ID: 2}, s.receipt)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), s.service.Attachment().Path(a), s.service.Attachment().Path(b))
+
+	files, err := os.ReadDir(s.dir)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), files, 1)
+}
+
+func (s *AttachmentServiceTestSuite) TestAddNegative() {
+	_, err := s.service.Attachment().Add(&model.Transaction{}, s.receipt)
+	assert.EqualError(s.T(), err, "can't attach file to unsaved transaction")
+
+	_, err = s.service.Attachment().Add(&model.Transaction{ID: 1}, filepath.Join(s.dir, "missing.pdf"))
+	assert.ErrorContains(s.T(), err, "s.copy: os.Open: open")
+
+	// attachment of missing transaction isn't stored, so its file isn't kept either
+	_, err = s.service.Attachment().Add(&model.Transaction{ID: 99}, s.receipt)
+	assert.ErrorContains(s.T(), err, "s.persistentStorage.Insert: ")
+
+	files, err := os.ReadDir(s.dir)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), files)
+	assert.Empty(s.T(), s.service.Attachment().GetByTransaction(&model.Transaction{ID: 99}))
+}
+
+func (s *AttachmentServiceTestSuite) TestAddInUnitOfWork() {
+	var a *model.Attachment
+	err := s.service.WithTx(func() (err error) {
+		a, err = s.service.Attachment().Add(&model.Transaction{ID: 1}, s.receipt)
+		require.NoError(s.T(), err)
+
+		// file is moved into place only after commit
+		assert.NoFileExists(s.T(), s.service.Attachment().Path(a))
+		return nil
+	})
+	require.NoError(s.T(), err)
+
+	content, err := os.ReadFile(s.service.Attachment().Path(a))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "receipt", string(content))
+
+	files, err := os.ReadDir(s.dir)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), files, 1)
+}
+
+func (s *AttachmentServiceTestSuite) TestAddRollback() {
+	failure := errors.New("failure")
+
+	err := s.service.WithTx(func() error {
+		_, err := s.service.Attachment().Add(&model.Transaction{ID: 1}, s.receipt)
+		require.NoError(s.T(), err)
+		return failure
+	})
+	assert.ErrorIs(s.T(), err, failure)
+	assert.Empty(s.T(), s.service.Attachment().GetByTransaction(&model.Transaction{ID: 1}))
+
+	// copy of file is removed along with the rest of changes
+	files, err := os.ReadDir(s.dir)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), files)
+}
+
+func (s *AttachmentServiceTestSuite) TestGetByTransaction() {
+	a, err := s.service.Attachment().Add(&model.Transaction{ID: 1}, s.receipt)
+	require.NoError(s.T(), err)
+	b, err := s.service.Attachment().Add(&model.Transaction{ID: 1}, s.warranty)
+	require.NoError(s.T(), err)
+	_, err = s.service.Attachment().Add(&model.Transaction{ID: 2}, s.warranty)
+	require.NoError(s.T(), err)
+
+	actualAttachments := s.service.Attachment().GetByTransaction(&model.Transaction{ID: 1})
+	assert.Equal(s.T(), []*model.Attachment{a, b}, actualAttachments)
+}
+
+func (s *AttachmentServiceTestSuite) TestRemove() {
+	a, err := s.service.Attachment().Add(&model.Transaction{ID: 1}, s.receipt)
+	require.NoError(s.T(), err)
+	b, err := s.service.Attachment().Add(&model.Transaction{ID: 2}, s.receipt)
+	require.NoError(s.T(), err)
+
+	// file is kept while other attachment refers to it
+	require.NoError(s.T(), s.service.Attachment().Remove(a))
+	assert.FileExists(s.T(), s.service.Attachment().Path(b))
+
+	require.NoError(s.T(), s.service.Attachment().Remove(b))
+	assert.NoFileExists(s.T(), s.service.Attachment().Path(b))
+
+	actualAttachments, err := s.persistentStorage.Attachment().GetAll()
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), actualAttachments)
+}
+
+func (s *AttachmentServiceTestSuite) TestRemoveAll() {
+	_, err := s.service.Attachment().Add(&model.Transaction{ID: 1}, s.receipt)
+	require.NoError(s.T(), err)
+	_, err = s.service.Attachment().Add(&model.Transaction{ID: 1}, s.warranty)
+	require.NoError(s.T(), err)
+	c, err := s.service.Attachment().Add(&model.Transaction{ID: 2}, s.warranty)
+	require.NoError(s.T(), err)
+
+	err = s.service.Attachment().RemoveAll(&model.Transaction{ID: 1})
+	require.NoError(s.T(), err)
+
+	actualAttachments, err := s.persistentStorage.Attachment().GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Attachment{c}, actualAttachments)
+
+	files, err := os.ReadDir(s.dir)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), files, 1)
+}
+
+func (s *AttachmentServiceTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM attachment;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+
+	files, err := os.ReadDir(s.dir)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+	for _, f := range files {
+		require.NoError(s.T(), os.Remove(filepath.Join(s.dir, f.Name())), "occurred in TearDownTest")
+	}
+
+	require.NoError(s.T(), s.service.Init(), "occurred in TearDownTest")
+}
+
+func (s *AttachmentServiceTestSuite) TearDownSuite() {
+	_, err := s.db.Exec(`DELETE FROM "transaction"; DELETE FROM account; DELETE FROM category; DELETE FROM currency;`)
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+
+	err = s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestAttachmentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentServiceTestSuite))
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Budget service contains business logic related to model.Budget. Limits of budgets are kept in
+// the main currency.
+type Budget struct {
+	persistentStorage   BudgetPersistentStorage
+	inmemoryStorage     BudgetInmemoryStorage
+	categoryService     *Category
+	transactionService  *Transaction
+	exchangeRateService *ExchangeRate
+	history             *History
+}
+
+// NewBudget returns Budget service.
+func NewBudget(
+	persistentStorage BudgetPersistentStorage,
+	inmemoryStorage BudgetInmemoryStorage,
+	categoryService *Category,
+	transactionService *Transaction,
+	exchangeRateService *ExchangeRate) (*Budget, error) {
+
+	b := &Budget{
+		persistentStorage:   persistentStorage,
+		inmemoryStorage:     inmemoryStorage,
+		categoryService:     categoryService,
+		transactionService:  transactionService,
+		exchangeRateService: exchangeRateService,
+	}
+
+	if err := b.Init(categoryService); err != nil {
+		return nil, fmt.Errorf("b.Init: %w", err)
+	}
+
+	return b, nil
+}
+
+// Init initialize inmemory storage with data from persistent storage. It is also links existing
+// categories to corresponding fields of model.Budget.
+func (s *Budget) Init(categoryService *Category) error {
+	bb, err := s.persistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
+	}
+
+	for _, b := range bb {
+		b.Category = categoryService.GetByID(b.Category.ID)
+	}
+
+	s.inmemoryStorage.Init(bb)
+
+	return nil
+}
+
+// Insert appends budget to both persistent and inmemory storages. Period of budget is truncated
+// to the first day of month.
+func (s *Budget) Insert(b *model.Budget) (err error) {
+	defer s.history.record(&err, "insert budget",
+		func() error { return s.Delete(b) },
+		func() error { return s.Insert(b) })()
+
+	if err := s.validate(b); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+	b.Period = PeriodOf(b.Period)
+
+	id, err := s.persistentStorage.Insert(b)
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
+	}
+
+	b.ID = id
+	s.inmemoryStorage.Insert(b)
+
+	return nil
+}
+
+// Update updates budget in persistent and inmemory storages.
+func (s *Budget) Update(b *model.Budget) (err error) {
+	prev, next := clone(s.GetByID(b.ID)), clone(b)
+	defer s.history.record(&err, "update budget",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+
+	if err := s.validate(b); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+	b.Period = PeriodOf(b.Period)
+
+	if err := s.persistentStorage.Update(b); err != nil {
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	s.inmemoryStorage.Update(b)
+
+	return nil
+}
+
+// Delete deletes budget from inmemory and persistent storages.
+func (s *Budget) Delete(b *model.Budget) (err error) {
+	stored := s.GetByID(b.ID)
+	defer s.history.record(&err, "delete budget",
+		func() error { return s.Insert(stored) },
+		func() error { return s.Delete(stored) })()
+
+	if err := s.persistentStorage.Delete(b.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+	}
+	s.inmemoryStorage.Delete(b)
+	return nil
+}
+
+// GetAll returns all budgets.
+func (s *Budget) GetAll() []*model.Budget {
+	return s.inmemoryStorage.GetAll()
+}
+
+// GetByID returns budget by given model.Budget.ID.
+func (s *Budget) GetByID(id int64) *model.Budget {
+	return s.inmemoryStorage.GetByID(id)
+}
+
+// GetByPeriod returns budgets of the period which given date belongs to.
+func (s *Budget) GetByPeriod(date time.Time) []*model.Budget {
+	return s.inmemoryStorage.GetByPeriod(PeriodOf(date))
+}
+
+// Spent returns amount spent in the category of budget within its period converted to the main
+// currency. Transactions of subcategories are included, split transactions are attributed per
+// split. Incomes of the category reduce the spent amount, transfers and transactions whose account
+// is missing are ignored.
+func (s *Budget) Spent(b *model.Budget) (int64, error) {
+	main := s.exchangeRateService.currencyService.GetMain()
+	spent := main.Money(0)
+
+	end := b.Period.AddDate(0, 1, 0)
+	for _, t := range s.transactionService.GetAll() {
+		if t.Account == nil || t.Date.Before(b.Period) || !t.Date.Before(end) {
+			continue
+		}
+
+		if s.transactionService.GetTransferByTransactionID(t.ID) != nil {
+			continue
+		}
+
+		for _, e := range s.transactionService.SplitsOf(t) {
+			if !s.categoryService.IsWithin(e.Category, b.Category) {
+				continue
+			}
+
+			amount, err := s.exchangeRateService.ConvertToMain(e.Amount, t.Account.Currency, t.Date)
+			if err != nil {
+				return 0, fmt.Errorf("s.exchangeRateService.ConvertToMain: %w", err)
+			}
+
+			if spent, err = spent.Sub(main.Money(amount)); err != nil {
+				return 0, fmt.Errorf("spent.Sub: %w", err)
+			}
+		}
+	}
+
+	return spent.Amount(), nil
+}
+
+// Remaining returns amount which is left to spend within budget, it is negative if budget is
+// overspent.
+func (s *Budget) Remaining(b *model.Budget) (int64, error) {
+	spent, err := s.Spent(b)
+	if err != nil {
+		return 0, fmt.Errorf("s.Spent: %w", err)
+	}
+
+	return b.Limit - spent, nil
+}
+
+// validate checks if budget is consistent.
+func (*Budget) validate(b *model.Budget) error {
+	if b.Limit <= 0 {
+		return errors.New("limit of budget should be positive")
+	}
+
+	return nil
+}
+
+// PeriodOf returns budget period which given date belongs to, that is the first day of its month.
+func PeriodOf(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
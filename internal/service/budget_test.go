@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetServiceTestSuite struct {
+	suite.Suite
+	db                *sql.DB
+	persistentStorage *sqlite.SqliteStorage
+	inmemoryStorage   *inmemory.InmemoryStorage
+	service           *service.Service
+	InitCategories    []*model.Category
+	InitAccounts      []*model.Account
+	InitBudgets       []*model.Budget
+}
+
+func (s *BudgetServiceTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	s.persistentStorage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.inmemoryStorage = inmemory.New()
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(s.inmemoryStorage), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.InitCategories = []*model.Category{{Title: "Grocery"}, {Title: "Health"}}
+	s.InitCategories = append(s.InitCategories, &model.Category{Title: "Pharmacy", Parent: s.InitCategories[1]})
+	for _, c := range s.InitCategories {
+		err = s.service.Category().Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	currencies := []*model.Currency{{Abbreviation: "USD"}, {Abbreviation: "EUR"}}
+	for _, c := range currencies {
+		err = s.service.Currency().Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	err = s.service.ExchangeRate().Insert(
+		&model.ExchangeRate{Date: date(2022, 1, 1), From: currencies[1], To: currencies[0], Rate: 1.1})
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.InitAccounts = []*model.Account{{Name: "Cash", Currency: currencies[0]}, {Name: "Euro", Currency: currencies[1]}}
+	for _, a := range s.InitAccounts {
+		err = s.service.Account().Insert(a)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	for _, t := range []*model.Transaction{
+		{Date: date(2022, 2, 3), Account: s.InitAccounts[0], Category: s.InitCategories[0], Amount: -1000},
+		{Date: date(2022, 2, 5), Account: s.InitAccounts[1], Category: s.InitCategories[0], Amount: -1000},
+		{Date: date(2022, 2, 10), Account: s.InitAccounts[0], Category: s.InitCategories[0], Amount: 200},
+		{Date: date(2022, 3, 1), Account: s.InitAccounts[0], Category: s.InitCategories[0], Amount: -5000},
+		{Date: date(2022, 2, 10), Account: s.InitAccounts[0], Category: s.InitCategories[1], Amount: -300},
+		{Date: date(2022, 2, 12), Account: s.InitAccounts[0], Category: s.InitCategories[2], Amount: -200},
+		{Date: date(2022, 2, 15), Account: s.InitAccounts[0], Category: s.InitCategories[0], Amount: -700,
+			Splits: []*model.Split{
+				{Category: s.InitCategories[0], Amount: -600},
+				{Category: s.InitCategories[2], Amount: -100},
+			}},
+	} {
+		err = s.service.Transaction().Insert(t)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	err = s.service.Transaction().InsertTransfer(&model.Transfer{
+		From: &model.Transaction{Date: date(2022, 2, 11), Account: s.InitAccounts[0], Category: s.InitCategories[0], Amount: -100},
+		To:   &model.Transaction{Date: date(2022, 2, 11), Account: s.InitAccounts[1], Category: s.InitCategories[0], Amount: 90},
+	})
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitBudgets = []*model.Budget{
+		{ID: 1, Category: s.InitCategories[0], Period: date(2022, 2, 1), Limit: 1500},
+		{ID: 2, Category: s.InitCategories[1], Period: date(2022, 2, 1), Limit: 1000},
+	}
+}
+
+func (s *BudgetServiceTestSuite) SetupTest() {
+	for _, b := range s.InitBudgets {
+		_, err := s.persistentStorage.Budget().Insert(b)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+
+	err := s.service.Budget().Init(s.service.Category())
+	require.NoError(s.T(), err, "occurred in SetupTest")
+}
+
+func (s *BudgetServiceTestSuite) TestLinkage() {
+	bb := s.service.Budget().GetAll()
+
+	assert.Equal(s.T(), s.InitCategories[0], bb[0].Category)
+	assert.Equal(s.T(), s.InitCategories[1], bb[1].Category)
+}
+
+func (s *BudgetServiceTestSuite) TestInsertPositive() {
+	budget := &model.Budget{Category: s.InitCategories[0], Period: date(2022, 3, 15), Limit: 2000}
+
+	err := s.service.Budget().Insert(budget)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), int64(3), budget.ID)
+	assert.Equal(s.T(), date(2022, 3, 1), budget.Period)
+	assert.Equal(s.T(), []*model.Budget{budget}, s.service.Budget().GetByPeriod(date(2022, 3, 31)))
+}
+
+func (s *BudgetServiceTestSuite) TestInsertNegative() {
+	err := s.service.Budget().Insert(&model.Budget{Category: s.InitCategories[0], Period: date(2022, 3, 1)})
+	assert.EqualError(s.T(), err, "s.validate: limit of budget should be positive")
+
+	err = s.service.Budget().Insert(&model.Budget{Category: s.InitCategories[0], Period: date(2022, 2, 7), Limit: 1})
+	assert.ErrorContains(s.T(), err, "UNIQUE constraint failed: budget.categoryId, budget.period")
+}
+
+func (s *BudgetServiceTestSuite) TestUpdatePositive() {
+	budget := s.service.Budget().GetByID(2)
+	budget.Limit = 200
+
+	err := s.service.Budget().Update(budget)
+	require.NoError(s.T(), err)
+
+	persistentBudgets, err := s.persistentStorage.Budget().GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(200), persistentBudgets[1].Limit)
+}
+
+func (s *BudgetServiceTestSuite) TestDeletePositive() {
+	err := s.service.Budget().Delete(s.service.Budget().GetByID(1))
+	require.NoError(s.T(), err)
+
+	persistentBudgets, err := s.persistentStorage.Budget().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentBudgets, 1)
+	assert.Len(s.T(), s.inmemoryStorage.Budget().GetAll(), 1)
+}
+
+func (s *BudgetServiceTestSuite) TestSpentAndRemaining() {
+	for _, tc := range []struct {
+		name              string
+		give              *model.Budget
+		expectedSpent     int64
+		expectedRemaining int64
+	}{
+		{name: "Overspent", give: s.InitBudgets[0], expectedSpent: 2500, expectedRemaining: -1000},
+		{name: "WithinLimit", give: s.InitBudgets[1], expectedSpent: 600, expectedRemaining: 400},
+	} {
+		s.Run(tc.name, func() {
+			spent, err := s.service.Budget().Spent(tc.give)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expectedSpent, spent)
+
+			remaining, err := s.service.Budget().Remaining(tc.give)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expectedRemaining, remaining)
+		})
+	}
+}
+
+func (s *BudgetServiceTestSuite) TearDownTest() {
+	for {
+		bb := s.service.Budget().GetAll()
+		if len(bb) == 0 {
+			break
+		}
+
+		err := s.persistentStorage.Budget().Delete(bb[0].ID)
+		require.NoError(s.T(), err, "occurred in TearDownTest")
+		s.inmemoryStorage.Budget().Delete(bb[0])
+	}
+}
+
+func (s *BudgetServiceTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestBudgetServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetServiceTestSuite))
+}
@@ -1,21 +1,31 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/kotlw/gentlemoney/internal/model"
-	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
-	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
 )
 
+// CategoryPathSeparator separates titles of parent and child categories in category path.
+const CategoryPathSeparator = " / "
+
+// ErrCategoryCycle is returned when category becomes a parent of itself or of its ancestor.
+var ErrCategoryCycle = errors.New("category can't be a parent of itself or of its ancestors")
+
 // Category service contains business logic related to model.Category.
 type Category struct {
-	persistentStorage *sqlite.Category
-	inmemoryStorage   *inmemory.Category
+	persistentStorage CategoryPersistentStorage
+	inmemoryStorage   CategoryInmemoryStorage
+	history           *History
+	unitOfWork        *UnitOfWork
+	references        *References
 }
 
 // NewCategory returns Category service.
-func NewCategory(persistentStorage *sqlite.Category, inmemoryStorage *inmemory.Category) (*Category, error) {
+func NewCategory(persistentStorage CategoryPersistentStorage, inmemoryStorage CategoryInmemoryStorage) (*Category, error) {
 	c := &Category{
 		persistentStorage: persistentStorage,
 		inmemoryStorage:   inmemoryStorage,
@@ -28,7 +38,8 @@ func NewCategory(persistentStorage *sqlite.Category, inmemoryStorage *inmemory.C
 	return c, nil
 }
 
-// Init initialize inmemory storage with data from persistent storage.
+// Init initialize inmemory storage with data from persistent storage. It is also links parent
+// categories to corresponding field of model.Category.
 func (s *Category) Init() error {
 	cc, err := s.persistentStorage.GetAll()
 	if err != nil {
@@ -37,11 +48,30 @@ func (s *Category) Init() error {
 
 	s.inmemoryStorage.Init(cc)
 
+	for _, c := range cc {
+		if c.Parent != nil {
+			c.Parent = s.GetByID(c.Parent.ID)
+		}
+	}
+
 	return nil
 }
 
 // Insert appends category to both persistent and inmemory storages.
-func (s *Category) Insert(c *model.Category) error {
+func (s *Category) Insert(c *model.Category) (err error) {
+	defer s.history.record(&err, "insert category",
+		func() error { return s.Delete(c, DeletePolicy[model.Category]{}) },
+		func() error { return s.Restore(c) })()
+	defer s.history.refer(model.TrashCategory, &c.ID)
+
+	if err := s.validate(c); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.checkTrash(c); err != nil {
+		return fmt.Errorf("s.checkTrash: %w", err)
+	}
+
 	id, err := s.persistentStorage.Insert(c)
 	if err != nil {
 		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
@@ -54,19 +84,103 @@ func (s *Category) Insert(c *model.Category) error {
 }
 
 // Update updates category in persistent storage. Since GetAll returns pointers to inmemory data
-// after update the category we need to update it in persistent storage as well.
-func (s *Category) Update(c *model.Category) error {
+// after update the category we need to update it in persistent storage as well. Subcategories are
+// relinked to the updated category.
+func (s *Category) Update(c *model.Category) (err error) {
+	prev, next := clone(s.GetByID(c.ID)), clone(c)
+	defer s.history.record(&err, "update category",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+	defer s.history.refer(model.TrashCategory, &c.ID)
+
+	if err := s.validate(c); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.checkTrash(c); err != nil {
+		return fmt.Errorf("s.checkTrash: %w", err)
+	}
+
 	if err := s.persistentStorage.Update(c); err != nil {
 		return fmt.Errorf("s.persistentStorage.Update: %w", err)
 	}
 
+	children := s.GetChildren(c)
 	s.inmemoryStorage.Update(c)
 
+	for _, child := range children {
+		child.Parent = c
+	}
+
 	return nil
 }
 
-// Delete deletes category from inmemory and persistent storages.
-func (s *Category) Delete(c *model.Category) error {
+// Delete moves category to trash, so it is removed from inmemory storage only. Category which is
+// in use is deleted according to given policy: deletion is refused with InUseError, subcategories
+// and the data which refers to category are deleted along with it, or they are moved to the target
+// category. Category with reconciled transactions can't be deleted by policy, since they are
+// locked. Goals and payees are unlinked from deleted category. Changes made by policy are undone
+// along with deletion.
+func (s *Category) Delete(c *model.Category, policy DeletePolicy[model.Category]) error {
+	switch policy.Action {
+	case Refuse:
+		if err := s.checkUsage(c); err != nil {
+			return err
+		}
+	case Cascade:
+	case Reassign:
+		if policy.Target == nil || s.GetByID(policy.Target.ID) == nil {
+			return ErrNoReassignTarget
+		}
+		if s.IsWithin(policy.Target, c) {
+			return ErrReassignToItself
+		}
+		policy.Target = s.GetByID(policy.Target.ID)
+	default:
+		return fmt.Errorf("unknown delete action %d", policy.Action)
+	}
+
+	if policy.Action != Refuse {
+		if err := s.references.categoryLocked(c, policy.Action).err(fmt.Sprintf("category %q", c.Title)); err != nil {
+			return err
+		}
+	}
+
+	return s.unitOfWork.Do(func() error {
+		for _, child := range s.GetChildren(c) {
+			var err error
+			if policy.Action == Cascade {
+				err = s.Delete(child, policy)
+			} else {
+				child = clone(child)
+				child.Parent = policy.Target
+				err = s.Update(child)
+			}
+			if err != nil {
+				return fmt.Errorf("subcategory %q: %w", child.Title, err)
+			}
+		}
+
+		if err := s.references.releaseCategory(c, policy); err != nil {
+			return fmt.Errorf("s.references.releaseCategory: %w", err)
+		}
+
+		return s.delete(c)
+	})
+}
+
+// delete moves category which isn't in use to trash.
+func (s *Category) delete(c *model.Category) (err error) {
+	stored := s.GetByID(c.ID)
+	defer s.history.record(&err, "delete category",
+		func() error { return s.Restore(stored) },
+		func() error { return s.delete(stored) })()
+	defer s.history.refer(model.TrashCategory, &c.ID)
+
+	if err := s.checkUsage(c); err != nil {
+		return err
+	}
+
 	if err := s.persistentStorage.Delete(c.ID); err != nil {
 		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
 	}
@@ -74,6 +188,71 @@ func (s *Category) Delete(c *model.Category) error {
 	return nil
 }
 
+// GetDeleted returns categories from trash. Parent which is in trash as well has only ID set.
+func (s *Category) GetDeleted() ([]*model.Category, error) {
+	cc, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	for _, c := range cc {
+		if c.Parent != nil && s.GetByID(c.Parent.ID) != nil {
+			c.Parent = s.GetByID(c.Parent.ID)
+		}
+	}
+
+	return cc, nil
+}
+
+// Restore brings category back from trash. Subcategory can't be restored while its parent is in
+// trash.
+func (s *Category) Restore(c *model.Category) error {
+	if c.Parent != nil && s.GetByID(c.Parent.ID) == nil {
+		return fmt.Errorf("parent of category %q: %w", c.Title, ErrDependsOnTrash)
+	}
+
+	if err := s.persistentStorage.Restore(c.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Restore: %w", err)
+	}
+
+	c.DeletedAt = time.Time{}
+	s.inmemoryStorage.Insert(c)
+
+	return nil
+}
+
+// Purge permanently deletes category from trash. Category can't be purged while subcategories or
+// transactions in trash refer to it.
+func (s *Category) Purge(c *model.Category) error {
+	cc, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	u := usage{}
+	u.add("subcategories in trash", len(filter(cc, func(e *model.Category) bool {
+		return e.Parent != nil && e.Parent.ID == c.ID
+	})))
+
+	tu, err := s.references.trashUsage(0, c.ID)
+	if err != nil {
+		return fmt.Errorf("s.references.trashUsage: %w", err)
+	}
+
+	if err = append(u, tu...).err(fmt.Sprintf("category %q", c.Title)); err != nil {
+		return err
+	}
+
+	if err := s.persistentStorage.Purge(c.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Purge: %w", err)
+	}
+
+	return s.unitOfWork.AfterCommit(func() error {
+		s.history.forget(model.TrashCategory, c.ID)
+		return nil
+	})
+}
+
 // GetAll returns all categories.
 func (s *Category) GetAll() []*model.Category {
 	return s.inmemoryStorage.GetAll()
@@ -84,7 +263,150 @@ func (s *Category) GetByID(id int64) *model.Category {
 	return s.inmemoryStorage.GetByID(id)
 }
 
-// GetByTitle returns category by given model.Category.Title.
+// GetByTitle returns the first category with given model.Category.Title, titles are unique only
+// within parent, so GetByPath is used to find subcategory.
 func (s *Category) GetByTitle(title string) *model.Category {
 	return s.inmemoryStorage.GetByTitle(title)
 }
+
+// GetChildren returns direct subcategories of given category.
+func (s *Category) GetChildren(c *model.Category) []*model.Category {
+	res := make([]*model.Category, 0)
+
+	for _, e := range s.GetAll() {
+		if e.Parent != nil && e.Parent.ID == c.ID {
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
+
+// GetByPath returns category by its path, e.g. "Food / Groceries". Titles are unique only within
+// parent, so each title of the path is looked up among subcategories of the previous one. It
+// returns nil if there is no such category.
+func (s *Category) GetByPath(path string) *model.Category {
+	var c *model.Category
+	for _, title := range strings.Split(path, CategoryPathSeparator) {
+		if c = s.getChild(c, title); c == nil {
+			return nil
+		}
+	}
+
+	return c
+}
+
+// getChild returns subcategory of parent with given title, top level category if parent is nil.
+func (s *Category) getChild(parent *model.Category, title string) *model.Category {
+	var id int64
+	if parent != nil {
+		id = parent.ID
+	}
+
+	for _, e := range s.GetAll() {
+		if e.Title == title && parentID(e) == id {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// parentID returns id of the parent category or 0 for top level category.
+func parentID(c *model.Category) int64 {
+	if c.Parent == nil {
+		return 0
+	}
+
+	return c.Parent.ID
+}
+
+// Path returns titles of category and all its ancestors starting from the top level category
+// joined by CategoryPathSeparator.
+func (s *Category) Path(c *model.Category) string {
+	titles := []string{c.Title}
+	for p := c.Parent; p != nil; p = p.Parent {
+		titles = append([]string{p.Title}, titles...)
+	}
+
+	return strings.Join(titles, CategoryPathSeparator)
+}
+
+// IsWithin returns true if category is the same as ancestor or it is one of its subcategories at
+// any depth. It is used to roll up amounts to parent categories, missing category isn't within
+// any.
+func (s *Category) IsWithin(c, ancestor *model.Category) bool {
+	if c == nil || ancestor == nil {
+		return false
+	}
+
+	for c = s.GetByID(c.ID); c != nil; c = c.Parent {
+		if c.ID == ancestor.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkUsage returns InUseError if subcategories or other data refer to category.
+func (s *Category) checkUsage(c *model.Category) error {
+	u := usage{}
+	u.add("subcategories", len(s.GetChildren(c)))
+
+	return append(u, s.references.categoryUsage(c)...).err(fmt.Sprintf("category %q", c.Title))
+}
+
+// validate checks if kind of category is known, parent of category exists and it doesn't make a
+// cycle. Category without kind could be both income and expense.
+func (s *Category) validate(c *model.Category) error {
+	if c.Kind == "" {
+		c.Kind = model.Both
+	}
+	if err := validateKind(c.Kind); err != nil {
+		return err
+	}
+
+	if c.Parent == nil {
+		return nil
+	}
+
+	parent := s.GetByID(c.Parent.ID)
+	if parent == nil {
+		return fmt.Errorf("parent category with id %d not found", c.Parent.ID)
+	}
+
+	if c.ID != 0 && s.IsWithin(parent, c) {
+		return ErrCategoryCycle
+	}
+
+	return nil
+}
+
+// validateKind checks if category kind is known.
+func validateKind(kind model.CategoryKind) error {
+	for _, e := range model.CategoryKinds() {
+		if kind == e {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown category kind %q", kind)
+}
+
+// checkTrash returns error if category with the same title and parent is in trash, since titles
+// are unique within parent among deleted categories as well.
+func (s *Category) checkTrash(c *model.Category) error {
+	cc, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	for _, e := range cc {
+		if e.Title == c.Title && parentID(e) == parentID(c) {
+			return fmt.Errorf("category %q: %w", c.Title, ErrNameInTrash)
+		}
+	}
+
+	return nil
+}
@@ -25,13 +25,15 @@ type CategoryServiceTestSuite struct {
 }
 
 func (s *CategoryServiceTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
-	s.persistentStorage, err = sqlite.NewCategory(db)
+	err = sqlite.Migrate(db)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
+	s.persistentStorage = sqlite.NewCategory(sqlite.NewDB(db))
+
 	s.inmemoryStorage = inmemory.NewCategory()
 
 	s.service, err = service.NewCategory(s.persistentStorage, s.inmemoryStorage)
@@ -71,7 +73,7 @@ func (s *CategoryServiceTestSuite) TestInsertPositive() {
 
 func (s *CategoryServiceTestSuite) TestInsertNegative() {
 	err := s.service.Insert(s.InitCategories[0])
-	assert.ErrorContains(s.T(), err, "s.persistentStorage.Insert: e.db.Exec: UNIQUE constraint failed: category.title")
+	assert.ErrorContains(s.T(), err, "s.persistentStorage.Insert: e.db.Exec: UNIQUE constraint failed: index 'category_title'")
 }
 
 func (s *CategoryServiceTestSuite) TestUpdatePositive() {
@@ -103,7 +105,7 @@ func (s *CategoryServiceTestSuite) TestDeletePositive() {
 	cc := s.service.GetAll()
 	expectedCategories := []*model.Category{cc[0]}
 
-	err := s.service.Delete(cc[1])
+	err := s.service.Delete(cc[1], service.DeletePolicy[model.Category]{})
 	require.NoError(s.T(), err)
 
 	persistentCategories, err := s.persistentStorage.GetAll()
@@ -117,7 +119,7 @@ func (s *CategoryServiceTestSuite) TestDeleteNegative() {
 	cc := s.service.GetAll()
 	cc[0].ID = 10
 
-	err := s.service.Delete(cc[0])
+	err := s.service.Delete(cc[0], service.DeletePolicy[model.Category]{})
 	assert.ErrorContains(s.T(), err, "s.persistentStorage.Delete: total affected rows 0 while expected 1")
 	cc[0].ID = 1 // return real id to proper teardown
 }
@@ -132,6 +134,89 @@ func (s *CategoryServiceTestSuite) TestGetByTitle() {
 	assert.Equal(s.T(), s.InitCategories[1].ID, c.ID)
 }
 
+func (s *CategoryServiceTestSuite) TestHierarchy() {
+	parent := s.service.GetByID(1)
+	child := &model.Category{Title: "Pharmacy", Parent: parent}
+	grandchild := &model.Category{Title: "Vitamins", Parent: child}
+
+	require.NoError(s.T(), s.service.Insert(child))
+	require.NoError(s.T(), s.service.Insert(grandchild))
+
+	assert.Equal(s.T(), "Health / Pharmacy / Vitamins", s.service.Path(grandchild))
+	assert.Equal(s.T(), grandchild, s.service.GetByPath("Health / Pharmacy / Vitamins"))
+	assert.Nil(s.T(), s.service.GetByPath("Grocery / Vitamins"))
+	assert.Equal(s.T(), []*model.Category{child}, s.service.GetChildren(parent))
+	assert.True(s.T(), s.service.IsWithin(grandchild, parent))
+	assert.False(s.T(), s.service.IsWithin(parent, grandchild))
+
+	// parents are linked on init
+	err := s.service.Init()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Health / Pharmacy / Vitamins", s.service.Path(s.service.GetByTitle("Vitamins")))
+}
+
+func (s *CategoryServiceTestSuite) TestSameTitleWithinDifferentParents() {
+	health := &model.Category{Title: "Other", Parent: s.service.GetByID(1)}
+	grocery := &model.Category{Title: "Other", Parent: s.service.GetByID(2)}
+	require.NoError(s.T(), s.service.Insert(health))
+	require.NoError(s.T(), s.service.Insert(grocery))
+
+	assert.Equal(s.T(), health, s.service.GetByPath("Health / Other"))
+	assert.Equal(s.T(), grocery, s.service.GetByPath("Grocery / Other"))
+	assert.Nil(s.T(), s.service.GetByPath("Other"))
+	assert.Nil(s.T(), s.service.GetByPath("Health / Other / Other"))
+
+	err := s.service.Insert(&model.Category{Title: "Other", Parent: s.service.GetByID(1)})
+	assert.ErrorContains(s.T(), err, "UNIQUE constraint failed: index 'category_title'")
+}
+
+func (s *CategoryServiceTestSuite) TestHierarchyNegative() {
+	parent := s.service.GetByID(1)
+	child := &model.Category{Title: "Pharmacy", Parent: parent}
+	require.NoError(s.T(), s.service.Insert(child))
+
+	err := s.service.Update(&model.Category{ID: parent.ID, Title: parent.Title, Parent: child})
+	assert.ErrorIs(s.T(), err, service.ErrCategoryCycle)
+
+	err = s.service.Update(&model.Category{ID: parent.ID, Title: parent.Title, Parent: parent})
+	assert.ErrorIs(s.T(), err, service.ErrCategoryCycle)
+
+	err = s.service.Insert(&model.Category{Title: "Dentist", Parent: &model.Category{ID: 10}})
+	assert.EqualError(s.T(), err, "s.validate: parent category with id 10 not found")
+
+	err = s.service.Delete(parent, service.DeletePolicy[model.Category]{})
+	assert.ErrorIs(s.T(), err, service.ErrInUse)
+	assert.EqualError(s.T(), err, `category "Health" is used by subcategories (1)`)
+}
+
+func (s *CategoryServiceTestSuite) TestKind() {
+	category := &model.Category{Title: "Refunds"}
+	require.NoError(s.T(), s.service.Insert(category))
+	assert.Equal(s.T(), model.Both, category.Kind)
+
+	category.Kind = model.Income
+	require.NoError(s.T(), s.service.Update(category))
+	assert.Equal(s.T(), model.Income, s.service.GetByTitle("Refunds").Kind)
+
+	err := s.service.Insert(&model.Category{Title: "Salary", Kind: "salary"})
+	assert.EqualError(s.T(), err, `s.validate: unknown category kind "salary"`)
+
+	assert.True(s.T(), model.Income.Allows(100))
+	assert.False(s.T(), model.Income.Allows(-100))
+	assert.False(s.T(), model.Expense.Allows(100))
+	assert.True(s.T(), model.Expense.Allows(0))
+	assert.True(s.T(), model.Both.Allows(-100))
+}
+
+func (s *CategoryServiceTestSuite) TestUpdateRelinksChildren() {
+	child := &model.Category{Title: "Pharmacy", Parent: s.service.GetByID(1)}
+	require.NoError(s.T(), s.service.Insert(child))
+
+	err := s.service.Update(&model.Category{ID: 1, Title: "Medicine"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Medicine / Pharmacy", s.service.Path(child))
+}
+
 func (s *CategoryServiceTestSuite) TearDownTest() {
 	for {
 		cc := s.service.GetAll()
@@ -143,6 +228,10 @@ func (s *CategoryServiceTestSuite) TearDownTest() {
 		require.NoError(s.T(), err, "occurred in TearDownTest")
 		s.inmemoryStorage.Delete(cc[0])
 	}
+
+	// deleted items are kept in trash, so they are purged to reuse ids
+	_, err := s.db.Exec(`DELETE FROM category WHERE deletedAt IS NOT NULL;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
 }
 
 func (s *CategoryServiceTestSuite) TearDownSuite() {
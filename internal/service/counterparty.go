@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Counterparty service contains business logic related to model.Counterparty.
+type Counterparty struct {
+	persistentStorage CounterpartyPersistentStorage
+	inmemoryStorage   CounterpartyInmemoryStorage
+	history           *History
+	unitOfWork        *UnitOfWork
+	references        *References
+}
+
+// NewCounterparty returns Counterparty service.
+func NewCounterparty(persistentStorage CounterpartyPersistentStorage, inmemoryStorage CounterpartyInmemoryStorage) (*Counterparty, error) {
+	c := &Counterparty{
+		persistentStorage: persistentStorage,
+		inmemoryStorage:   inmemoryStorage,
+	}
+
+	if err := c.Init(); err != nil {
+		return nil, fmt.Errorf("c.Init: %w", err)
+	}
+
+	return c, nil
+}
+
+// Init initialize inmemory storage with data from persistent storage.
+func (s *Counterparty) Init() error {
+	cc, err := s.persistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
+	}
+
+	s.inmemoryStorage.Init(cc)
+
+	return nil
+}
+
+// Insert appends counterparty to both persistent and inmemory storages.
+func (s *Counterparty) Insert(c *model.Counterparty) (err error) {
+	defer s.history.record(&err, "insert counterparty",
+		func() error { return s.Delete(c, DeletePolicy[model.Counterparty]{}) },
+		func() error { return s.Insert(c) })()
+
+	if err := s.validate(c); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	id, err := s.persistentStorage.Insert(c)
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
+	}
+
+	c.ID = id
+	s.inmemoryStorage.Insert(c)
+
+	return nil
+}
+
+// Update updates counterparty in both persistent and inmemory storages.
+func (s *Counterparty) Update(c *model.Counterparty) (err error) {
+	prev, next := clone(s.GetByID(c.ID)), clone(c)
+	defer s.history.record(&err, "update counterparty",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+
+	if err := s.validate(c); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.persistentStorage.Update(c); err != nil {
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	s.inmemoryStorage.Update(c)
+
+	return nil
+}
+
+// Delete deletes counterparty from inmemory and persistent storages. Counterparty which has debts
+// is deleted according to given policy: deletion is refused with InUseError, debts are deleted
+// along with it, or they are moved to the target counterparty. Changes made by policy are undone
+// along with deletion.
+func (s *Counterparty) Delete(c *model.Counterparty, policy DeletePolicy[model.Counterparty]) error {
+	switch policy.Action {
+	case Refuse:
+		if err := s.checkUsage(c); err != nil {
+			return err
+		}
+	case Cascade:
+	case Reassign:
+		if policy.Target == nil || s.GetByID(policy.Target.ID) == nil {
+			return ErrNoReassignTarget
+		}
+		if policy.Target.ID == c.ID {
+			return ErrReassignToItself
+		}
+		policy.Target = s.GetByID(policy.Target.ID)
+	default:
+		return fmt.Errorf("unknown delete action %d", policy.Action)
+	}
+
+	return s.unitOfWork.Do(func() error {
+		if err := s.references.releaseCounterparty(c, policy); err != nil {
+			return fmt.Errorf("s.references.releaseCounterparty: %w", err)
+		}
+
+		return s.delete(c)
+	})
+}
+
+// delete deletes counterparty which has no debts from inmemory and persistent storages.
+func (s *Counterparty) delete(c *model.Counterparty) (err error) {
+	stored := s.GetByID(c.ID)
+	defer s.history.record(&err, "delete counterparty",
+		func() error { return s.Insert(stored) },
+		func() error { return s.delete(stored) })()
+
+	if err := s.checkUsage(c); err != nil {
+		return err
+	}
+
+	if err := s.persistentStorage.Delete(c.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+	}
+
+	s.inmemoryStorage.Delete(c)
+
+	return nil
+}
+
+// GetAll returns all counterparties.
+func (s *Counterparty) GetAll() []*model.Counterparty {
+	return s.inmemoryStorage.GetAll()
+}
+
+// GetByID returns counterparty by given model.Counterparty.ID.
+func (s *Counterparty) GetByID(id int64) *model.Counterparty {
+	return s.inmemoryStorage.GetByID(id)
+}
+
+// GetByName returns counterparty by given model.Counterparty.Name.
+func (s *Counterparty) GetByName(name string) *model.Counterparty {
+	return s.inmemoryStorage.GetByName(name)
+}
+
+// LinkDebt replaces counterparty of debt with stored instance. Counterparty which doesn't exist
+// yet is created.
+func (s *Counterparty) LinkDebt(d *model.Debt) error {
+	if stored := s.GetByName(d.Counterparty.Name); stored != nil {
+		d.Counterparty = stored
+		return nil
+	}
+
+	if err := s.Insert(d.Counterparty); err != nil {
+		return fmt.Errorf("s.Insert: %w", err)
+	}
+
+	return nil
+}
+
+// checkUsage returns InUseError if any debts refer to counterparty.
+func (s *Counterparty) checkUsage(c *model.Counterparty) error {
+	return s.references.counterpartyUsage(c).err(fmt.Sprintf("counterparty %q", c.Name))
+}
+
+// validate checks if counterparty has name.
+func (*Counterparty) validate(c *model.Counterparty) error {
+	if c.Name == "" {
+		return errors.New("counterparty name is empty")
+	}
+
+	return nil
+}
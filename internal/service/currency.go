@@ -1,21 +1,27 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kotlw/gentlemoney/internal/model"
-	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
-	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
 )
 
+// ErrNoMainCurrency is returned when operation leaves currencies without the main one.
+var ErrNoMainCurrency = errors.New("there should be exactly one main currency, mark another currency as main instead")
+
 // Currency service contains business logic related to model.Currency.
 type Currency struct {
-	persistentStorage *sqlite.Currency
-	inmemoryStorage   *inmemory.Currency
+	persistentStorage CurrencyPersistentStorage
+	inmemoryStorage   CurrencyInmemoryStorage
+	history           *History
+	unitOfWork        *UnitOfWork
+	references        *References
 }
 
 // NewCurrency returns Currency service.
-func NewCurrency(persistentStorage *sqlite.Currency, inmemoryStorage *inmemory.Currency) (*Currency, error) {
+func NewCurrency(persistentStorage CurrencyPersistentStorage, inmemoryStorage CurrencyInmemoryStorage) (*Currency, error) {
 	c := &Currency{
 		persistentStorage: persistentStorage,
 		inmemoryStorage:   inmemoryStorage,
@@ -28,7 +34,8 @@ func NewCurrency(persistentStorage *sqlite.Currency, inmemoryStorage *inmemory.C
 	return c, nil
 }
 
-// Init initialize inmemory storage with data from persistent storage.
+// Init initialize inmemory storage with data from persistent storage. If there are currencies but
+// none of them is main, the first one becomes main.
 func (s *Currency) Init() error {
 	cc, err := s.persistentStorage.GetAll()
 	if err != nil {
@@ -37,11 +44,38 @@ func (s *Currency) Init() error {
 
 	s.inmemoryStorage.Init(cc)
 
+	if len(cc) > 0 && s.GetMain() == nil {
+		if err = s.setMain(cc[0]); err != nil {
+			return fmt.Errorf("s.setMain: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// Insert appends currency to both persistent and inmemory storages.
-func (s *Currency) Insert(c *model.Currency) error {
+// Insert appends currency to both persistent and inmemory storages. The first currency always
+// becomes main, if inserted currency is main, the previous main currency is unmarked.
+func (s *Currency) Insert(c *model.Currency) (err error) {
+	defer s.history.record(&err, "insert currency",
+		func() error { return s.Delete(c, DeletePolicy[model.Currency]{}) },
+		func() error { return s.Restore(c) })()
+	defer s.history.refer(model.TrashCurrency, &c.ID)
+
+	if err := s.validate(c); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.checkTrash(c); err != nil {
+		return fmt.Errorf("s.checkTrash: %w", err)
+	}
+
+	isMain := c.IsMain || s.GetMain() == nil
+
+	return s.unitOfWork.Do(func() error { return s.insert(c, isMain) })
+}
+
+// insert appends currency to both persistent and inmemory storages and marks it as main if needed.
+func (s *Currency) insert(c *model.Currency, isMain bool) error {
 	id, err := s.persistentStorage.Insert(c)
 	if err != nil {
 		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
@@ -50,23 +84,131 @@ func (s *Currency) Insert(c *model.Currency) error {
 	c.ID = id
 	s.inmemoryStorage.Insert(c)
 
+	if isMain {
+		if err = s.setMain(c); err != nil {
+			return fmt.Errorf("s.setMain: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // Update updates currency in persistent storage. Since GetAll returns pointers to inmemory data
-// after update the category we need to update it in persistent storage as well.
-func (s *Currency) Update(c *model.Currency) error {
+// after update the category we need to update it in persistent storage as well. Main currency
+// can't be unmarked, another currency should be marked as main instead. Precision can't be changed
+// while amounts are kept in minor units of the currency, since it would scale all of them.
+func (s *Currency) Update(c *model.Currency) (err error) {
+	prev, next := clone(s.GetByID(c.ID)), clone(c)
+	defer s.history.record(&err, "update currency",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+	defer s.history.refer(model.TrashCurrency, &c.ID)
+
+	if err := s.validate(c); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.checkTrash(c); err != nil {
+		return fmt.Errorf("s.checkTrash: %w", err)
+	}
+
+	if err := s.checkPrecision(c); err != nil {
+		return fmt.Errorf("s.checkPrecision: %w", err)
+	}
+
+	main := s.GetMain()
+	if main != nil && main.ID == c.ID && !c.IsMain {
+		return ErrNoMainCurrency
+	}
+
+	return s.unitOfWork.Do(func() error { return s.update(c) })
+}
+
+// update changes currency in both persistent and inmemory storages and marks it as main if needed.
+func (s *Currency) update(c *model.Currency) error {
 	if err := s.persistentStorage.Update(c); err != nil {
 		return fmt.Errorf("s.persistentStorage.Update: %w", err)
 	}
 
 	s.inmemoryStorage.Update(c)
 
+	if c.IsMain {
+		if err := s.setMain(c); err != nil {
+			return fmt.Errorf("s.setMain: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// Delete deletes currency from inmemory and persistent storages.
-func (s *Currency) Delete(c *model.Currency) error {
+// Delete moves currency to trash, so it is removed from inmemory storage only. Currency which is
+// in use is deleted according to given policy: deletion is refused with InUseError, accounts and
+// the rest of data which refers to currency are deleted along with it, or they are moved to the
+// target currency of the same precision. Exchange rates of currency are deleted in both cases.
+// Main currency can be deleted only if it is the last one, unless the target currency becomes main
+// instead. Changes made by policy are undone along with deletion.
+func (s *Currency) Delete(c *model.Currency, policy DeletePolicy[model.Currency]) error {
+	switch policy.Action {
+	case Refuse:
+		if err := s.checkMain(c); err != nil {
+			return err
+		}
+		if err := s.checkUsage(c); err != nil {
+			return err
+		}
+	case Cascade:
+		if err := s.checkMain(c); err != nil {
+			return err
+		}
+	case Reassign:
+		if policy.Target == nil || s.GetByID(policy.Target.ID) == nil {
+			return ErrNoReassignTarget
+		}
+		if policy.Target.ID == c.ID {
+			return ErrReassignToItself
+		}
+		policy.Target = s.GetByID(policy.Target.ID)
+		if stored := s.GetByID(c.ID); stored != nil && stored.Precision != policy.Target.Precision {
+			return fmt.Errorf("currency %q has another precision", policy.Target.Abbreviation)
+		}
+	default:
+		return fmt.Errorf("unknown delete action %d", policy.Action)
+	}
+
+	return s.unitOfWork.Do(func() error {
+		if main := s.GetMain(); policy.Action == Reassign && main != nil && main.ID == c.ID {
+			target := clone(policy.Target)
+			target.IsMain = true
+			if err := s.Update(target); err != nil {
+				return fmt.Errorf("s.Update: %w", err)
+			}
+			policy.Target = target
+		}
+
+		if err := s.references.releaseCurrency(c, policy); err != nil {
+			return fmt.Errorf("s.references.releaseCurrency: %w", err)
+		}
+
+		return s.delete(c)
+	})
+}
+
+// delete moves currency which isn't in use to trash.
+func (s *Currency) delete(c *model.Currency) (err error) {
+	stored := s.GetByID(c.ID)
+	defer s.history.record(&err, "delete currency",
+		func() error { return s.Restore(stored) },
+		func() error { return s.delete(stored) })()
+	defer s.history.refer(model.TrashCurrency, &c.ID)
+
+	if err := s.checkMain(c); err != nil {
+		return err
+	}
+
+	if err := s.checkUsage(c); err != nil {
+		return err
+	}
+
 	if err := s.persistentStorage.Delete(c.ID); err != nil {
 		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
 	}
@@ -74,6 +216,65 @@ func (s *Currency) Delete(c *model.Currency) error {
 	return nil
 }
 
+// GetDeleted returns currencies from trash.
+func (s *Currency) GetDeleted() ([]*model.Currency, error) {
+	cc, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	return cc, nil
+}
+
+// Restore brings currency back from trash. Restored currency becomes main only if there is no main
+// currency, otherwise it is unmarked.
+func (s *Currency) Restore(c *model.Currency) error {
+	main := s.GetMain()
+	if main != nil && c.IsMain {
+		c.IsMain = false
+		if err := s.persistentStorage.Update(c); err != nil {
+			return fmt.Errorf("s.persistentStorage.Update: %w", err)
+		}
+	}
+
+	if err := s.persistentStorage.Restore(c.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Restore: %w", err)
+	}
+
+	c.DeletedAt = time.Time{}
+	s.inmemoryStorage.Insert(c)
+
+	if main == nil {
+		if err := s.setMain(c); err != nil {
+			return fmt.Errorf("s.setMain: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Purge permanently deletes currency from trash. Currency can't be purged while accounts in trash
+// refer to it.
+func (s *Currency) Purge(c *model.Currency) error {
+	u, err := s.references.trashAccounts(c)
+	if err != nil {
+		return fmt.Errorf("s.references.trashAccounts: %w", err)
+	}
+
+	if err = u.err(fmt.Sprintf("currency %q", c.Abbreviation)); err != nil {
+		return err
+	}
+
+	if err := s.persistentStorage.Purge(c.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Purge: %w", err)
+	}
+
+	return s.unitOfWork.AfterCommit(func() error {
+		s.history.forget(model.TrashCurrency, c.ID)
+		return nil
+	})
+}
+
 // GetAll returns all currencies.
 func (s *Currency) GetAll() []*model.Currency {
 	return s.inmemoryStorage.GetAll()
@@ -88,3 +289,76 @@ func (s *Currency) GetByID(id int64) *model.Currency {
 func (s *Currency) GetByAbbreviation(abbreviation string) *model.Currency {
 	return s.inmemoryStorage.GetByAbbreviation(abbreviation)
 }
+
+// GetMain returns main currency, or nil if there are no currencies.
+func (s *Currency) GetMain() *model.Currency {
+	return s.inmemoryStorage.GetMain()
+}
+
+// setMain marks given currency as main and unmarks all others in both persistent and inmemory
+// storages.
+func (s *Currency) setMain(c *model.Currency) error {
+	if err := s.persistentStorage.SetMain(c.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.SetMain: %w", err)
+	}
+
+	s.inmemoryStorage.SetMain(c.ID)
+
+	return nil
+}
+
+// checkMain returns ErrNoMainCurrency if currency is main and it isn't the last one.
+func (s *Currency) checkMain(c *model.Currency) error {
+	if main := s.GetMain(); main != nil && main.ID == c.ID && len(s.GetAll()) > 1 {
+		return ErrNoMainCurrency
+	}
+
+	return nil
+}
+
+// checkUsage returns InUseError if any data refers to currency.
+func (s *Currency) checkUsage(c *model.Currency) error {
+	return s.references.currencyUsage(c).err(fmt.Sprintf("currency %q", c.Abbreviation))
+}
+
+// checkPrecision returns InUseError if precision of stored currency is changed while any amounts
+// are kept in minor units of it.
+func (s *Currency) checkPrecision(c *model.Currency) error {
+	stored := s.GetByID(c.ID)
+	if stored == nil || stored.Precision == c.Precision {
+		return nil
+	}
+
+	u, err := s.references.amountUsage(stored)
+	if err != nil {
+		return fmt.Errorf("s.references.amountUsage: %w", err)
+	}
+
+	return u.err(fmt.Sprintf("precision of currency %q", stored.Abbreviation))
+}
+
+// validate checks if currency is consistent.
+func (*Currency) validate(c *model.Currency) error {
+	if c.Precision < 0 || c.Precision > model.MaxPrecision {
+		return fmt.Errorf("currency precision should be between 0 and %d", model.MaxPrecision)
+	}
+
+	return nil
+}
+
+// checkTrash returns error if currency with the same abbreviation is in trash, since abbreviations
+// are unique among deleted currencies as well.
+func (s *Currency) checkTrash(c *model.Currency) error {
+	cc, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	for _, e := range cc {
+		if e.Abbreviation == c.Abbreviation {
+			return fmt.Errorf("currency %q: %w", c.Abbreviation, ErrNameInTrash)
+		}
+	}
+
+	return nil
+}
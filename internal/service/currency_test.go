@@ -25,13 +25,15 @@ type CurrencyServiceTestSuite struct {
 }
 
 func (s *CurrencyServiceTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
-	s.persistentStorage, err = sqlite.NewCurrency(db)
+	err = sqlite.Migrate(db)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
+	s.persistentStorage = sqlite.NewCurrency(sqlite.NewDB(db))
+
 	s.inmemoryStorage = inmemory.NewCurrency()
 
 	s.service, err = service.NewCurrency(s.persistentStorage, s.inmemoryStorage)
@@ -40,8 +42,8 @@ func (s *CurrencyServiceTestSuite) SetupSuite() {
 	// id's settled by sqlite on insert incrementally starting from 1,
 	// so here they are initialized for match purpose
 	s.InitCurrencies = []*model.Currency{
-		{ID: 1, Abbreviation: "USD"},
-		{ID: 2, Abbreviation: "EUR"},
+		{ID: 1, Abbreviation: "USD", IsMain: true, Precision: 2},
+		{ID: 2, Abbreviation: "EUR", Precision: 2},
 	}
 }
 
@@ -56,7 +58,7 @@ func (s *CurrencyServiceTestSuite) SetupTest() {
 }
 
 func (s *CurrencyServiceTestSuite) TestInsertPositive() {
-	currency := &model.Currency{ID: 3, Abbreviation: "PLN"}
+	currency := &model.Currency{ID: 3, Abbreviation: "JPY", Precision: 0}
 	expectedCurrencies := append(s.InitCurrencies, currency)
 
 	err := s.service.Insert(currency)
@@ -72,6 +74,12 @@ func (s *CurrencyServiceTestSuite) TestInsertPositive() {
 func (s *CurrencyServiceTestSuite) TestInsertNegative() {
 	err := s.service.Insert(s.InitCurrencies[0])
 	assert.ErrorContains(s.T(), err, "s.persistentStorage.Insert: e.db.Exec: UNIQUE constraint failed: currency.abbreviation")
+
+	err = s.service.Insert(&model.Currency{Abbreviation: "BTC", Precision: 9})
+	assert.EqualError(s.T(), err, "s.validate: currency precision should be between 0 and 8")
+
+	err = s.service.Insert(&model.Currency{Abbreviation: "BTC", Precision: -1})
+	assert.EqualError(s.T(), err, "s.validate: currency precision should be between 0 and 8")
 }
 
 func (s *CurrencyServiceTestSuite) TestUpdatePositive() {
@@ -103,7 +111,7 @@ func (s *CurrencyServiceTestSuite) TestDeletePositive() {
 	cc := s.service.GetAll()
 	expectedCurrencies := []*model.Currency{cc[0]}
 
-	err := s.service.Delete(cc[1])
+	err := s.service.Delete(cc[1], service.DeletePolicy[model.Currency]{})
 	require.NoError(s.T(), err)
 
 	persistentCurrencies, err := s.persistentStorage.GetAll()
@@ -115,11 +123,56 @@ func (s *CurrencyServiceTestSuite) TestDeletePositive() {
 
 func (s *CurrencyServiceTestSuite) TestDeleteNegative() {
 	cc := s.service.GetAll()
-	cc[0].ID = 10
+	cc[1].ID = 10
 
-	err := s.service.Delete(cc[0])
+	err := s.service.Delete(cc[1], service.DeletePolicy[model.Currency]{})
 	assert.ErrorContains(s.T(), err, "s.persistentStorage.Delete: total affected rows 0 while expected 1")
-	cc[0].ID = 1 // return real id to proper teardown
+	cc[1].ID = 2 // return real id to proper teardown
+}
+
+func (s *CurrencyServiceTestSuite) TestInsertMain() {
+	currency := &model.Currency{Abbreviation: "PLN", IsMain: true}
+
+	err := s.service.Insert(currency)
+	require.NoError(s.T(), err)
+
+	persistentCurrencies, err := s.persistentStorage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), persistentCurrencies, s.inmemoryStorage.GetAll())
+	assert.Equal(s.T(), currency, s.service.GetMain())
+	assert.False(s.T(), s.service.GetByID(1).IsMain)
+}
+
+func (s *CurrencyServiceTestSuite) TestUpdateMain() {
+	currency := &model.Currency{ID: 2, Abbreviation: "EUR", IsMain: true}
+
+	err := s.service.Update(currency)
+	require.NoError(s.T(), err)
+
+	persistentCurrencies, err := s.persistentStorage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), persistentCurrencies, s.inmemoryStorage.GetAll())
+	assert.Equal(s.T(), int64(2), s.service.GetMain().ID)
+}
+
+func (s *CurrencyServiceTestSuite) TestMainNegative() {
+	err := s.service.Update(&model.Currency{ID: 1, Abbreviation: "USD"})
+	assert.ErrorIs(s.T(), err, service.ErrNoMainCurrency)
+
+	err = s.service.Delete(s.service.GetByID(1), service.DeletePolicy[model.Currency]{})
+	assert.ErrorIs(s.T(), err, service.ErrNoMainCurrency)
+}
+
+func (s *CurrencyServiceTestSuite) TestInitPromotesMain() {
+	_, err := s.db.Exec(`UPDATE currency SET isMain = 0;`)
+	require.NoError(s.T(), err)
+
+	err = s.service.Init()
+	require.NoError(s.T(), err)
+
+	persistentCurrencies, err := s.persistentStorage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), persistentCurrencies, s.InitCurrencies)
 }
 
 func (s *CurrencyServiceTestSuite) TestGetByID() {
@@ -143,6 +196,10 @@ func (s *CurrencyServiceTestSuite) TearDownTest() {
 		require.NoError(s.T(), err, "occurred in TearDownTest")
 		s.inmemoryStorage.Delete(cc[0])
 	}
+
+	// deleted items are kept in trash, so they are purged to reuse ids
+	_, err := s.db.Exec(`DELETE FROM currency WHERE deletedAt IS NOT NULL;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
 }
 
 func (s *CurrencyServiceTestSuite) TearDownSuite() {
@@ -0,0 +1,424 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Debt service contains business logic related to model.Debt and its repayments. Amounts of debt
+// are kept in the currency of debt, repayments are converted into it by exchange rates.
+type Debt struct {
+	persistentStorage   DebtPersistentStorage
+	inmemoryStorage     DebtInmemoryStorage
+	counterpartyService *Counterparty
+	transactionService  *Transaction
+	exchangeRateService *ExchangeRate
+	history             *History
+}
+
+// Installment is a single payment of debt repayment schedule. Amount includes Interest.
+type Installment struct {
+	Date     time.Time
+	Amount   int64
+	Interest int64
+}
+
+// CounterpartyBalance is a total of outstanding debts with counterparty in given currency. Positive
+// Amount means counterparty owes the user, negative one means the user owes counterparty.
+type CounterpartyBalance struct {
+	Counterparty *model.Counterparty
+	Currency     *model.Currency
+	Amount       int64
+}
+
+// NewDebt returns Debt service.
+func NewDebt(
+	persistentStorage DebtPersistentStorage,
+	inmemoryStorage DebtInmemoryStorage,
+	currencyService *Currency,
+	counterpartyService *Counterparty,
+	transactionService *Transaction,
+	exchangeRateService *ExchangeRate) (*Debt, error) {
+
+	d := &Debt{
+		persistentStorage:   persistentStorage,
+		inmemoryStorage:     inmemoryStorage,
+		counterpartyService: counterpartyService,
+		transactionService:  transactionService,
+		exchangeRateService: exchangeRateService,
+	}
+
+	if err := d.Init(currencyService); err != nil {
+		return nil, fmt.Errorf("d.Init: %w", err)
+	}
+
+	return d, nil
+}
+
+// Init initialize inmemory storage with data from persistent storage. It is also links existing
+// counterparties and currencies to corresponding fields of model.Debt and loads repayments.
+func (s *Debt) Init(currencyService *Currency) error {
+	dd, err := s.persistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
+	}
+
+	for _, d := range dd {
+		d.Counterparty = s.counterpartyService.GetByID(d.Counterparty.ID)
+		d.Currency = currencyService.GetByID(d.Currency.ID)
+	}
+
+	s.inmemoryStorage.Init(dd)
+
+	rr, err := s.persistentStorage.GetAllRepayments()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAllRepayments: %w", err)
+	}
+
+	for _, r := range rr {
+		s.inmemoryStorage.InsertRepayment(r)
+	}
+
+	return nil
+}
+
+// Insert appends debt to both persistent and inmemory storages. Counterparty which doesn't exist
+// yet is created.
+func (s *Debt) Insert(d *model.Debt) (err error) {
+	defer s.history.record(&err, "insert debt",
+		func() error { return s.Delete(d) },
+		func() error { return s.Insert(d) })()
+
+	if err := s.validate(d); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.counterpartyService.LinkDebt(d); err != nil {
+		return fmt.Errorf("s.counterpartyService.LinkDebt: %w", err)
+	}
+
+	id, err := s.persistentStorage.Insert(d)
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
+	}
+
+	d.ID = id
+	s.inmemoryStorage.Insert(d)
+
+	return nil
+}
+
+// Update updates debt in persistent and inmemory storages. Counterparty which doesn't exist yet is
+// created.
+func (s *Debt) Update(d *model.Debt) (err error) {
+	prev, next := clone(s.GetByID(d.ID)), clone(d)
+	defer s.history.record(&err, "update debt",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+
+	if err := s.validate(d); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.counterpartyService.LinkDebt(d); err != nil {
+		return fmt.Errorf("s.counterpartyService.LinkDebt: %w", err)
+	}
+
+	if err := s.persistentStorage.Update(d); err != nil {
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	s.inmemoryStorage.Update(d)
+
+	return nil
+}
+
+// Delete deletes debt along with links to its repayments from inmemory and persistent storages.
+// Repayment transactions themselves are kept.
+func (s *Debt) Delete(d *model.Debt) (err error) {
+	stored, repayments := s.GetByID(d.ID), s.Repayments(d)
+	defer s.history.record(&err, "delete debt",
+		func() error { return s.reinsert(stored, repayments) },
+		func() error { return s.Delete(stored) })()
+
+	if err := s.persistentStorage.Delete(d.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+	}
+	s.inmemoryStorage.Delete(d)
+	return nil
+}
+
+// GetAll returns all debts.
+func (s *Debt) GetAll() []*model.Debt {
+	return s.inmemoryStorage.GetAll()
+}
+
+// GetByID returns debt by given model.Debt.ID.
+func (s *Debt) GetByID(id int64) *model.Debt {
+	return s.inmemoryStorage.GetByID(id)
+}
+
+// GetByTransaction returns debt repaid by given transaction or nil if there is no such.
+func (s *Debt) GetByTransaction(t *model.Transaction) *model.Debt {
+	return s.inmemoryStorage.GetByTransactionID(t.ID)
+}
+
+// AddRepayment links transaction to debt as its repayment. Transaction could repay only one debt.
+func (s *Debt) AddRepayment(d *model.Debt, t *model.Transaction) (err error) {
+	defer s.history.record(&err, "link repayment",
+		func() error { return s.RemoveRepayment(d, t) },
+		func() error { return s.AddRepayment(d, t) })()
+
+	if t.ID == 0 {
+		return errors.New("can't link unsaved transaction to debt")
+	}
+
+	if other := s.GetByTransaction(t); other != nil {
+		return fmt.Errorf("transaction already repays debt of %q", other.Counterparty.Name)
+	}
+
+	r := &model.DebtRepayment{DebtID: d.ID, TransactionID: t.ID}
+	if err := s.persistentStorage.InsertRepayment(r); err != nil {
+		return fmt.Errorf("s.persistentStorage.InsertRepayment: %w", err)
+	}
+
+	s.inmemoryStorage.InsertRepayment(r)
+
+	return nil
+}
+
+// RemoveRepayment unlinks transaction from debt, the transaction itself is kept.
+func (s *Debt) RemoveRepayment(d *model.Debt, t *model.Transaction) (err error) {
+	defer s.history.record(&err, "unlink repayment",
+		func() error { return s.AddRepayment(d, t) },
+		func() error { return s.RemoveRepayment(d, t) })()
+
+	r := &model.DebtRepayment{DebtID: d.ID, TransactionID: t.ID}
+	if err := s.persistentStorage.DeleteRepayment(r); err != nil {
+		return fmt.Errorf("s.persistentStorage.DeleteRepayment: %w", err)
+	}
+
+	s.inmemoryStorage.DeleteRepayment(r)
+
+	return nil
+}
+
+// reinsert inserts deleted debt again along with links to its repayments.
+func (s *Debt) reinsert(d *model.Debt, repayments []*model.Transaction) error {
+	if err := s.Insert(d); err != nil {
+		return fmt.Errorf("s.Insert: %w", err)
+	}
+
+	for _, t := range repayments {
+		if err := s.AddRepayment(d, t); err != nil {
+			return fmt.Errorf("s.AddRepayment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Repayments returns transactions which repay given debt ordered by date.
+func (s *Debt) Repayments(d *model.Debt) []*model.Transaction {
+	ids := s.inmemoryStorage.GetTransactionIDs(d.ID)
+
+	res := make([]*model.Transaction, 0, len(ids))
+	for _, id := range ids {
+		if t := s.transactionService.GetByID(id); t != nil {
+			res = append(res, t)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Date.Before(res[j].Date) })
+
+	return res
+}
+
+// Repaid returns amount repaid by the end of given date in the currency of debt. Given debt is
+// repaid by incomes and taken one by expenses, transactions in opposite direction increase the
+// debt back. Transactions whose account is missing are ignored.
+func (s *Debt) Repaid(d *model.Debt, date time.Time) (int64, error) {
+	repaid := d.Currency.Money(0)
+	for _, t := range s.Repayments(d) {
+		if t.Account == nil || t.Date.After(date) {
+			continue
+		}
+
+		amount, err := s.exchangeRateService.Convert(t.Amount, t.Account.Currency, d.Currency, t.Date)
+		if err != nil {
+			return 0, fmt.Errorf("s.exchangeRateService.Convert: %w", err)
+		}
+
+		if d.Direction == model.Taken {
+			repaid, err = repaid.Sub(d.Currency.Money(amount))
+		} else {
+			repaid, err = repaid.Add(d.Currency.Money(amount))
+		}
+		if err != nil {
+			return 0, fmt.Errorf("repaid.Add: %w", err)
+		}
+	}
+
+	return repaid.Amount(), nil
+}
+
+// Due returns total amount to be repaid for debt as of given date. For debt with repayment schedule
+// it is the sum of all installments, otherwise it is principal with simple interest accrued up to
+// given date.
+func (s *Debt) Due(d *model.Debt, date time.Time) int64 {
+	if d.Installments > 0 {
+		var due int64
+		for _, i := range s.Schedule(d) {
+			due += i.Amount
+		}
+		return due
+	}
+
+	days := math.Floor(date.Sub(d.Date).Hours() / 24)
+	if days <= 0 {
+		return d.Principal
+	}
+
+	return d.Principal + int64(math.Round(float64(d.Principal)*d.InterestRate/100*days/365))
+}
+
+// Outstanding returns amount which is left to repay at the end of given date in the currency of
+// debt.
+func (s *Debt) Outstanding(d *model.Debt, date time.Time) (int64, error) {
+	repaid, err := s.Repaid(d, date)
+	if err != nil {
+		return 0, fmt.Errorf("s.Repaid: %w", err)
+	}
+
+	return s.Due(d, date) - repaid, nil
+}
+
+// Schedule returns monthly repayment schedule of debt starting a month after the date of debt,
+// installments keep the day of debt date limited by the last day of shorter months. Debt with
+// interest is repaid by equal installments (annuity), the last one absorbs rounding difference.
+// Debt without installments has no schedule.
+func (*Debt) Schedule(d *model.Debt) []*Installment {
+	if d.Installments <= 0 {
+		return nil
+	}
+
+	n := d.Installments
+	rate := d.InterestRate / 100 / 12
+
+	payment := float64(d.Principal) / float64(n)
+	if rate > 0 {
+		payment = float64(d.Principal) * rate / (1 - math.Pow(1+rate, -float64(n)))
+	}
+
+	res := make([]*Installment, n)
+	balance := d.Principal
+	for i := range res {
+		interest := int64(math.Round(float64(balance) * rate))
+		principal := int64(math.Round(payment)) - interest
+		if i == len(res)-1 || principal > balance {
+			principal = balance
+		}
+		balance -= principal
+
+		res[i] = &Installment{
+			Date:     dateClamped(d.Date.Year(), d.Date.Month()+time.Month(i+1), d.Date.Day(), d.Date.Location()),
+			Amount:   principal + interest,
+			Interest: interest,
+		}
+	}
+
+	return res
+}
+
+// Summary returns outstanding amounts at the end of given date grouped by counterparty and currency
+// of debt, ordered by counterparty name and currency abbreviation. Settled balances are omitted.
+func (s *Debt) Summary(date time.Time) ([]*CounterpartyBalance, error) {
+	type key struct{ counterpartyID, currencyID int64 }
+
+	balances := make(map[key]*CounterpartyBalance)
+	for _, d := range s.GetAll() {
+		if d.Date.After(date) {
+			continue
+		}
+
+		outstanding, err := s.Outstanding(d, date)
+		if err != nil {
+			return nil, fmt.Errorf("s.Outstanding: %w", err)
+		}
+
+		k := key{d.Counterparty.ID, d.Currency.ID}
+		if balances[k] == nil {
+			balances[k] = &CounterpartyBalance{Counterparty: d.Counterparty, Currency: d.Currency}
+		}
+
+		balance := d.Currency.Money(balances[k].Amount)
+		if d.Direction == model.Taken {
+			balance, err = balance.Sub(d.Currency.Money(outstanding))
+		} else {
+			balance, err = balance.Add(d.Currency.Money(outstanding))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("balance.Add: %w", err)
+		}
+		balances[k].Amount = balance.Amount()
+	}
+
+	res := make([]*CounterpartyBalance, 0, len(balances))
+	for _, b := range balances {
+		if b.Amount != 0 {
+			res = append(res, b)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Counterparty.Name == res[j].Counterparty.Name {
+			return res[i].Currency.Abbreviation < res[j].Currency.Abbreviation
+		}
+		return res[i].Counterparty.Name < res[j].Counterparty.Name
+	})
+
+	return res, nil
+}
+
+// validate checks if debt is consistent.
+func (*Debt) validate(d *model.Debt) error {
+	if d.Counterparty == nil || d.Counterparty.Name == "" {
+		return errors.New("debt counterparty is empty")
+	}
+
+	known := false
+	for _, dir := range model.DebtDirections() {
+		known = known || d.Direction == dir
+	}
+
+	if !known {
+		return fmt.Errorf("unknown debt direction %q", d.Direction)
+	}
+
+	if d.Principal <= 0 {
+		return errors.New("principal of debt should be positive")
+	}
+
+	if d.Currency == nil {
+		return errors.New("debt currency not found")
+	}
+
+	if d.InterestRate < 0 {
+		return errors.New("interest rate can't be negative")
+	}
+
+	if d.Date.IsZero() {
+		return errors.New("debt date is empty")
+	}
+
+	if d.Installments < 0 {
+		return errors.New("number of installments can't be negative")
+	}
+
+	return nil
+}
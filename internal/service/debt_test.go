@@ -0,0 +1,373 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DebtServiceTestSuite struct {
+	suite.Suite
+	db                 *sql.DB
+	persistentStorage  *sqlite.SqliteStorage
+	inmemoryStorage    *inmemory.InmemoryStorage
+	service            *service.Service
+	InitCurrencies     []*model.Currency
+	InitAccounts       []*model.Account
+	InitTransactions   []*model.Transaction
+	InitCounterparties []*model.Counterparty
+	InitDebts          []*model.Debt
+}
+
+func (s *DebtServiceTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	s.persistentStorage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.inmemoryStorage = inmemory.New()
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(s.inmemoryStorage), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	category := &model.Category{Title: "Debts"}
+	err = s.service.Category().Insert(category)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.InitCurrencies = []*model.Currency{{Abbreviation: "USD"}, {Abbreviation: "EUR"}}
+	for _, c := range s.InitCurrencies {
+		err = s.service.Currency().Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	err = s.service.ExchangeRate().Insert(
+		&model.ExchangeRate{Date: date(2022, 1, 1), From: s.InitCurrencies[1], To: s.InitCurrencies[0], Rate: 1.1})
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.InitAccounts = []*model.Account{
+		{Name: "Cash", Currency: s.InitCurrencies[0]},
+		{Name: "Euro", Currency: s.InitCurrencies[1]},
+	}
+	for _, a := range s.InitAccounts {
+		err = s.service.Account().Insert(a)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	s.InitTransactions = []*model.Transaction{
+		{Date: date(2022, 2, 1), Account: s.InitAccounts[0], Category: category, Amount: 3000},
+		{Date: date(2022, 2, 15), Account: s.InitAccounts[0], Category: category, Amount: -10662},
+		{Date: date(2022, 3, 1), Account: s.InitAccounts[1], Category: category, Amount: 1000},
+	}
+	for _, t := range s.InitTransactions {
+		err = s.service.Transaction().Insert(t)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitCounterparties = []*model.Counterparty{{ID: 1, Name: "John"}, {ID: 2, Name: "Bank"}}
+	s.InitDebts = []*model.Debt{
+		{ID: 1, Counterparty: s.InitCounterparties[0], Direction: model.Given, Principal: 10000,
+			Currency: s.InitCurrencies[0], Date: date(2022, 1, 1)},
+		{ID: 2, Counterparty: s.InitCounterparties[1], Direction: model.Taken, Principal: 120000,
+			Currency: s.InitCurrencies[0], InterestRate: 12, Date: date(2022, 1, 15), Installments: 12},
+		{ID: 3, Counterparty: s.InitCounterparties[0], Direction: model.Taken, Principal: 5000,
+			Currency: s.InitCurrencies[1], InterestRate: 10, Date: date(2022, 2, 1)},
+	}
+}
+
+func (s *DebtServiceTestSuite) SetupTest() {
+	for _, c := range s.InitCounterparties {
+		_, err := s.persistentStorage.Counterparty().Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+
+	for _, d := range s.InitDebts {
+		_, err := s.persistentStorage.Debt().Insert(d)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+
+	for _, r := range []*model.DebtRepayment{
+		{DebtID: 1, TransactionID: s.InitTransactions[0].ID},
+		{DebtID: 2, TransactionID: s.InitTransactions[1].ID},
+		{DebtID: 1, TransactionID: s.InitTransactions[2].ID},
+	} {
+		err := s.persistentStorage.Debt().InsertRepayment(r)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+
+	err := s.service.Counterparty().Init()
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	err = s.service.Debt().Init(s.service.Currency())
+	require.NoError(s.T(), err, "occurred in SetupTest")
+}
+
+func (s *DebtServiceTestSuite) TestLinkage() {
+	assert.Equal(s.T(), s.InitDebts, s.service.Debt().GetAll())
+	assert.Equal(s.T(), s.InitDebts[1], s.service.Debt().GetByTransaction(s.InitTransactions[1]))
+	assert.Equal(s.T(), []*model.Transaction{s.InitTransactions[0], s.InitTransactions[2]},
+		s.service.Debt().Repayments(s.InitDebts[0]))
+}
+
+func (s *DebtServiceTestSuite) TestInsertPositive() {
+	debt := &model.Debt{Counterparty: &model.Counterparty{Name: "Alice"}, Direction: model.Given, Principal: 700,
+		Currency: s.InitCurrencies[0], Date: date(2022, 4, 1)}
+
+	err := s.service.Debt().Insert(debt)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), int64(4), debt.ID)
+	assert.Equal(s.T(), debt, s.service.Debt().GetByID(4))
+	assert.Equal(s.T(), debt.Counterparty, s.service.Counterparty().GetByName("Alice"))
+
+	persistentDebts, err := s.persistentStorage.Debt().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentDebts, 4)
+}
+
+func (s *DebtServiceTestSuite) TestInsertExistingCounterparty() {
+	debt := &model.Debt{Counterparty: &model.Counterparty{Name: "John"}, Direction: model.Given, Principal: 700,
+		Currency: s.InitCurrencies[0], Date: date(2022, 4, 1)}
+
+	err := s.service.Debt().Insert(debt)
+	require.NoError(s.T(), err)
+
+	assert.Same(s.T(), s.service.Counterparty().GetByID(1), debt.Counterparty)
+	assert.Len(s.T(), s.service.Counterparty().GetAll(), 2)
+}
+
+func (s *DebtServiceTestSuite) TestInsertNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Debt
+		expected string
+	}{
+		{
+			name:     "NoCounterparty",
+			give:     &model.Debt{Direction: model.Given, Principal: 1, Currency: s.InitCurrencies[0], Date: date(2022, 4, 1)},
+			expected: "s.validate: debt counterparty is empty",
+		},
+		{
+			name: "UnknownDirection",
+			give: &model.Debt{Counterparty: s.InitCounterparties[0], Direction: "lent", Principal: 1,
+				Currency: s.InitCurrencies[0], Date: date(2022, 4, 1)},
+			expected: `s.validate: unknown debt direction "lent"`,
+		},
+		{
+			name: "NonPositivePrincipal",
+			give: &model.Debt{Counterparty: s.InitCounterparties[0], Direction: model.Given,
+				Currency: s.InitCurrencies[0], Date: date(2022, 4, 1)},
+			expected: "s.validate: principal of debt should be positive",
+		},
+		{
+			name: "NoCurrency",
+			give: &model.Debt{Counterparty: s.InitCounterparties[0], Direction: model.Given, Principal: 1,
+				Date: date(2022, 4, 1)},
+			expected: "s.validate: debt currency not found",
+		},
+		{
+			name: "NegativeInterestRate",
+			give: &model.Debt{Counterparty: s.InitCounterparties[0], Direction: model.Given, Principal: 1,
+				Currency: s.InitCurrencies[0], InterestRate: -1, Date: date(2022, 4, 1)},
+			expected: "s.validate: interest rate can't be negative",
+		},
+		{
+			name: "NoDate",
+			give: &model.Debt{Counterparty: s.InitCounterparties[0], Direction: model.Given, Principal: 1,
+				Currency: s.InitCurrencies[0]},
+			expected: "s.validate: debt date is empty",
+		},
+		{
+			name: "NegativeInstallments",
+			give: &model.Debt{Counterparty: s.InitCounterparties[0], Direction: model.Given, Principal: 1,
+				Currency: s.InitCurrencies[0], Date: date(2022, 4, 1), Installments: -1},
+			expected: "s.validate: number of installments can't be negative",
+		},
+	} {
+		s.Run(tc.name, func() {
+			err := s.service.Debt().Insert(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *DebtServiceTestSuite) TestUpdatePositive() {
+	debt := *s.service.Debt().GetByID(1)
+	debt.Principal = 20000
+	debt.Note = "bike"
+
+	err := s.service.Debt().Update(&debt)
+	require.NoError(s.T(), err)
+
+	persistentDebts, err := s.persistentStorage.Debt().GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(20000), persistentDebts[0].Principal)
+	assert.Equal(s.T(), &debt, s.service.Debt().GetByID(1))
+}
+
+func (s *DebtServiceTestSuite) TestDeletePositive() {
+	err := s.service.Debt().Delete(s.service.Debt().GetByID(1))
+	require.NoError(s.T(), err)
+
+	persistentDebts, err := s.persistentStorage.Debt().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentDebts, 2)
+	assert.Nil(s.T(), s.service.Debt().GetByTransaction(s.InitTransactions[0]))
+
+	persistentRepayments, err := s.persistentStorage.Debt().GetAllRepayments()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentRepayments, 1)
+	assert.Len(s.T(), s.service.Transaction().GetAll(), 3)
+}
+
+func (s *DebtServiceTestSuite) TestRepayment() {
+	t := &model.Transaction{Date: date(2022, 4, 1), Account: s.InitAccounts[0],
+		Category: s.InitTransactions[0].Category, Amount: 500}
+	err := s.service.Transaction().Insert(t)
+	require.NoError(s.T(), err)
+
+	err = s.service.Debt().AddRepayment(s.InitDebts[0], t)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitDebts[0], s.service.Debt().GetByTransaction(t))
+
+	err = s.service.Debt().RemoveRepayment(s.InitDebts[0], t)
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), s.service.Debt().GetByTransaction(t))
+
+	err = s.service.Debt().AddRepayment(s.InitDebts[0], t)
+	require.NoError(s.T(), err)
+
+	// deleted transaction is unlinked from debt
+	err = s.service.Transaction().Delete(t)
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), s.service.Debt().GetByTransaction(t))
+
+	persistentRepayments, err := s.persistentStorage.Debt().GetAllRepayments()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentRepayments, 3)
+}
+
+func (s *DebtServiceTestSuite) TestDeleteRepaymentNegative() {
+	t := s.InitTransactions[1]
+
+	// transaction moved to trash behind the service can't be deleted again, so its link to debt is
+	// kept as well
+	require.NoError(s.T(), s.persistentStorage.Transaction().Delete(t.ID))
+	err := s.service.Transaction().Delete(t)
+	assert.ErrorContains(s.T(), err, "s.persistentStorage.Delete: ")
+
+	persistentRepayments, err := s.persistentStorage.Debt().GetAllRepayments()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentRepayments, 3)
+
+	require.NoError(s.T(), s.persistentStorage.Transaction().Restore(t.ID))
+	require.NoError(s.T(), s.service.Init())
+	assert.Equal(s.T(), s.InitDebts[1], s.service.Debt().GetByTransaction(t))
+}
+
+func (s *DebtServiceTestSuite) TestAddRepaymentNegative() {
+	err := s.service.Debt().AddRepayment(s.InitDebts[0], &model.Transaction{})
+	assert.EqualError(s.T(), err, "can't link unsaved transaction to debt")
+
+	err = s.service.Debt().AddRepayment(s.InitDebts[1], s.InitTransactions[0])
+	assert.EqualError(s.T(), err, `transaction already repays debt of "John"`)
+}
+
+func (s *DebtServiceTestSuite) TestRepaid() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Debt
+		date     time.Time
+		expected int64
+	}{
+		{name: "Given", give: s.InitDebts[0], date: date(2022, 2, 15), expected: 3000},
+		{name: "ConvertedToDebtCurrency", give: s.InitDebts[0], date: date(2022, 3, 15), expected: 4100},
+		{name: "Taken", give: s.InitDebts[1], date: date(2022, 3, 15), expected: 10662},
+		{name: "NoRepayments", give: s.InitDebts[2], date: date(2022, 3, 15), expected: 0},
+	} {
+		s.Run(tc.name, func() {
+			repaid, err := s.service.Debt().Repaid(tc.give, tc.date)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, repaid)
+		})
+	}
+}
+
+func (s *DebtServiceTestSuite) TestOutstanding() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Debt
+		date     time.Time
+		expected int64
+	}{
+		{name: "WithoutInterest", give: s.InitDebts[0], date: date(2022, 3, 15), expected: 5900},
+		{name: "Scheduled", give: s.InitDebts[1], date: date(2022, 3, 15), expected: 117280},
+		{name: "BeforeDate", give: s.InitDebts[2], date: date(2022, 1, 1), expected: 5000},
+		{name: "AccruedInterest", give: s.InitDebts[2], date: date(2023, 2, 1), expected: 5500},
+	} {
+		s.Run(tc.name, func() {
+			outstanding, err := s.service.Debt().Outstanding(tc.give, tc.date)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, outstanding)
+		})
+	}
+}
+
+func (s *DebtServiceTestSuite) TestSchedule() {
+	schedule := s.service.Debt().Schedule(s.InitDebts[1])
+	require.Len(s.T(), schedule, 12)
+
+	assert.Equal(s.T(), &service.Installment{Date: date(2022, 2, 15), Amount: 10662, Interest: 1200}, schedule[0])
+	assert.Equal(s.T(), date(2023, 1, 15), schedule[11].Date)
+
+	var principal int64
+	for _, i := range schedule {
+		principal += i.Amount - i.Interest
+	}
+	assert.Equal(s.T(), int64(120000), principal)
+
+	schedule = s.service.Debt().Schedule(&model.Debt{Principal: 1000, Date: date(2022, 1, 31), Installments: 3})
+	assert.Equal(s.T(), []*service.Installment{
+		{Date: date(2022, 2, 28), Amount: 333},
+		{Date: date(2022, 3, 31), Amount: 333},
+		{Date: date(2022, 4, 30), Amount: 334},
+	}, schedule)
+
+	assert.Nil(s.T(), s.service.Debt().Schedule(s.InitDebts[0]))
+}
+
+func (s *DebtServiceTestSuite) TestSummary() {
+	summary, err := s.service.Debt().Summary(date(2022, 3, 15))
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), []*service.CounterpartyBalance{
+		{Counterparty: s.InitCounterparties[1], Currency: s.InitCurrencies[0], Amount: -117280},
+		{Counterparty: s.InitCounterparties[0], Currency: s.InitCurrencies[1], Amount: -5058},
+		{Counterparty: s.InitCounterparties[0], Currency: s.InitCurrencies[0], Amount: 5900},
+	}, summary)
+}
+
+func (s *DebtServiceTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM debt_repayment; DELETE FROM debt; DELETE FROM counterparty;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *DebtServiceTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestDebtServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(DebtServiceTestSuite))
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/money"
+)
+
+// ErrExchangeRateNotFound is returned when there is no exchange rate to convert currencies.
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+// ExchangeRate service contains business logic related to model.ExchangeRate and conversion
+// of amounts between currencies.
+type ExchangeRate struct {
+	persistentStorage  ExchangeRatePersistentStorage
+	inmemoryStorage    ExchangeRateInmemoryStorage
+	currencyService    *Currency
+	transactionService *Transaction
+	history            *History
+}
+
+// NewExchangeRate returns ExchangeRate service.
+func NewExchangeRate(
+	persistentStorage ExchangeRatePersistentStorage,
+	inmemoryStorage ExchangeRateInmemoryStorage,
+	currencyService *Currency) (*ExchangeRate, error) {
+
+	r := &ExchangeRate{
+		persistentStorage: persistentStorage,
+		inmemoryStorage:   inmemoryStorage,
+		currencyService:   currencyService,
+	}
+
+	if err := r.Init(); err != nil {
+		return nil, fmt.Errorf("r.Init: %w", err)
+	}
+
+	return r, nil
+}
+
+// Init initialize inmemory storage with data from persistent storage. It is also links existing
+// currencies to corresponding fields of model.ExchangeRate.
+func (s *ExchangeRate) Init() error {
+	rr, err := s.persistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
+	}
+
+	for _, r := range rr {
+		r.From = s.currencyService.GetByID(r.From.ID)
+		r.To = s.currencyService.GetByID(r.To.ID)
+	}
+
+	s.inmemoryStorage.Init(rr)
+
+	return nil
+}
+
+// Insert appends exchange rate to both persistent and inmemory storages.
+func (s *ExchangeRate) Insert(r *model.ExchangeRate) (err error) {
+	defer s.history.record(&err, "insert exchange rate",
+		func() error { return s.Delete(r) },
+		func() error { return s.Insert(r) })()
+
+	if err := s.validate(r); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	id, err := s.persistentStorage.Insert(r)
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
+	}
+
+	r.ID = id
+	s.inmemoryStorage.Insert(r)
+
+	return nil
+}
+
+// Update updates exchange rate in persistent and inmemory storages.
+func (s *ExchangeRate) Update(r *model.ExchangeRate) (err error) {
+	prev, next := clone(s.GetByID(r.ID)), clone(r)
+	defer s.history.record(&err, "update exchange rate",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+
+	if err := s.validate(r); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.persistentStorage.Update(r); err != nil {
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	s.inmemoryStorage.Update(r)
+
+	return nil
+}
+
+// Delete deletes exchange rate from inmemory and persistent storages.
+func (s *ExchangeRate) Delete(r *model.ExchangeRate) (err error) {
+	stored := s.GetByID(r.ID)
+	defer s.history.record(&err, "delete exchange rate",
+		func() error { return s.Insert(stored) },
+		func() error { return s.Delete(stored) })()
+
+	if err := s.persistentStorage.Delete(r.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+	}
+	s.inmemoryStorage.Delete(r)
+	return nil
+}
+
+// GetAll returns all exchange rates.
+func (s *ExchangeRate) GetAll() []*model.ExchangeRate {
+	return s.inmemoryStorage.GetAll()
+}
+
+// GetByID returns exchange rate by given model.ExchangeRate.ID.
+func (s *ExchangeRate) GetByID(id int64) *model.ExchangeRate {
+	return s.inmemoryStorage.GetByID(id)
+}
+
+// GetRate returns the latest known rate of from currency to currency on given date. If there is no
+// direct rate, the reversed one is used.
+func (s *ExchangeRate) GetRate(from, to *model.Currency, date time.Time) (float64, error) {
+	if from.ID == to.ID {
+		return 1, nil
+	}
+
+	if r := latestBefore(s.inmemoryStorage.GetByPair(from.ID, to.ID), date); r != nil {
+		return r.Rate, nil
+	}
+
+	if r := latestBefore(s.inmemoryStorage.GetByPair(to.ID, from.ID), date); r != nil {
+		return 1 / r.Rate, nil
+	}
+
+	return 0, fmt.Errorf("%s/%s on %s: %w", from.Abbreviation, to.Abbreviation, date.Format("2006-01-02"),
+		ErrExchangeRateNotFound)
+}
+
+// Convert converts amount in from currency to currency by exchange rate on given date. Since
+// amounts are kept in minor units, the result is rescaled to precision of to currency and rounded
+// half away from zero.
+func (s *ExchangeRate) Convert(amount int64, from, to *model.Currency, date time.Time) (int64, error) {
+	rate, err := s.GetRate(from, to, date)
+	if err != nil {
+		return 0, fmt.Errorf("s.GetRate: %w", err)
+	}
+
+	res, err := from.Money(amount).Convert(rate, to.Abbreviation, to.Precision, money.HalfUp)
+	if err != nil {
+		return 0, fmt.Errorf("money.Convert: %w", err)
+	}
+
+	return res.Amount(), nil
+}
+
+// ConvertToMain converts amount in given currency to the main currency by exchange rate on given
+// date. Result is rounded to the nearest integer.
+func (s *ExchangeRate) ConvertToMain(amount int64, c *model.Currency, date time.Time) (int64, error) {
+	main := s.currencyService.GetMain()
+	if main == nil {
+		return 0, ErrNoMainCurrency
+	}
+
+	res, err := s.Convert(amount, c, main, date)
+	if err != nil {
+		return 0, fmt.Errorf("s.Convert: %w", err)
+	}
+
+	return res, nil
+}
+
+// TotalInMain returns sum of transaction amounts converted to the main currency by exchange rates
+// on dates of transactions. Transfers are skipped, since they only move money between accounts, as
+// well as transactions whose account is missing, since their currency is unknown. Sum which
+// overflows is an error.
+func (s *ExchangeRate) TotalInMain(tt []*model.Transaction) (int64, error) {
+	main := s.currencyService.GetMain()
+	total := main.Money(0)
+
+	for _, t := range tt {
+		if t.Account == nil || s.transactionService != nil && s.transactionService.isTransfer(t) {
+			continue
+		}
+
+		amount, err := s.ConvertToMain(t.Amount, t.Account.Currency, t.Date)
+		if err != nil {
+			return 0, fmt.Errorf("s.ConvertToMain: %w", err)
+		}
+
+		if total, err = total.Add(main.Money(amount)); err != nil {
+			return 0, fmt.Errorf("total.Add: %w", err)
+		}
+	}
+
+	return total.Amount(), nil
+}
+
+// validate checks if exchange rate is consistent.
+func (*ExchangeRate) validate(r *model.ExchangeRate) error {
+	if r.From.ID == r.To.ID {
+		return errors.New("exchange rate should be between different currencies")
+	}
+
+	if r.Rate <= 0 {
+		return errors.New("exchange rate should be positive")
+	}
+
+	return nil
+}
+
+// latestBefore returns the latest rate on or before given date from slice sorted by date.
+func latestBefore(rr []*model.ExchangeRate, date time.Time) *model.ExchangeRate {
+	var res *model.ExchangeRate
+	for _, r := range rr {
+		if r.Date.After(date) {
+			break
+		}
+		res = r
+	}
+	return res
+}
@@ -0,0 +1,213 @@
+package service_test
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/money"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExchangeRateServiceTestSuite struct {
+	suite.Suite
+	db                *sql.DB
+	persistentStorage *sqlite.SqliteStorage
+	inmemoryStorage   *inmemory.InmemoryStorage
+	service           *service.Service
+	InitCurrencies    []*model.Currency
+	InitExchangeRates []*model.ExchangeRate
+}
+
+func (s *ExchangeRateServiceTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	s.persistentStorage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.inmemoryStorage = inmemory.New()
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(s.inmemoryStorage), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitCurrencies = []*model.Currency{
+		{ID: 1, Abbreviation: "USD", IsMain: true, Precision: 2},
+		{ID: 2, Abbreviation: "EUR", Precision: 2},
+		{ID: 3, Abbreviation: "UAH", Precision: 2},
+		{ID: 4, Abbreviation: "JPY", Precision: 0},
+	}
+	s.InitExchangeRates = []*model.ExchangeRate{
+		{ID: 1, Date: date(2022, 1, 1), From: s.InitCurrencies[1], To: s.InitCurrencies[0], Rate: 1.2},
+		{ID: 2, Date: date(2022, 2, 1), From: s.InitCurrencies[1], To: s.InitCurrencies[0], Rate: 1.1},
+		{ID: 3, Date: date(2022, 1, 1), From: s.InitCurrencies[0], To: s.InitCurrencies[2], Rate: 25},
+	}
+
+	for _, c := range s.InitCurrencies {
+		_, err = s.persistentStorage.Currency().Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	err = s.service.Currency().Init()
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+}
+
+func (s *ExchangeRateServiceTestSuite) SetupTest() {
+	for _, r := range s.InitExchangeRates {
+		_, err := s.persistentStorage.ExchangeRate().Insert(r)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+
+	err := s.service.ExchangeRate().Init()
+	require.NoError(s.T(), err, "occurred in SetupTest")
+}
+
+func (s *ExchangeRateServiceTestSuite) TestInsertPositive() {
+	rate := &model.ExchangeRate{Date: date(2022, 3, 1), From: s.InitCurrencies[1], To: s.InitCurrencies[0], Rate: 1.05}
+
+	err := s.service.ExchangeRate().Insert(rate)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), int64(4), rate.ID)
+	assert.Len(s.T(), s.inmemoryStorage.ExchangeRate().GetAll(), 4)
+}
+
+func (s *ExchangeRateServiceTestSuite) TestInsertNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.ExchangeRate
+		expected string
+	}{
+		{
+			name:     "SameCurrency",
+			give:     &model.ExchangeRate{From: s.InitCurrencies[0], To: s.InitCurrencies[0], Rate: 1},
+			expected: "s.validate: exchange rate should be between different currencies",
+		},
+		{
+			name:     "NotPositiveRate",
+			give:     &model.ExchangeRate{From: s.InitCurrencies[1], To: s.InitCurrencies[0], Rate: 0},
+			expected: "s.validate: exchange rate should be positive",
+		},
+	} {
+		s.Run(tc.name, func() {
+			err := s.service.ExchangeRate().Insert(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *ExchangeRateServiceTestSuite) TestUpdatePositive() {
+	rate := s.service.ExchangeRate().GetByID(2)
+	rate.Rate = 1.15
+
+	err := s.service.ExchangeRate().Update(rate)
+	require.NoError(s.T(), err)
+
+	persistentRates, err := s.persistentStorage.ExchangeRate().GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1.15, persistentRates[1].Rate)
+}
+
+func (s *ExchangeRateServiceTestSuite) TestDeletePositive() {
+	err := s.service.ExchangeRate().Delete(s.service.ExchangeRate().GetByID(3))
+	require.NoError(s.T(), err)
+
+	persistentRates, err := s.persistentStorage.ExchangeRate().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentRates, 2)
+	assert.Len(s.T(), s.inmemoryStorage.ExchangeRate().GetAll(), 2)
+}
+
+func (s *ExchangeRateServiceTestSuite) TestConvertToMainPositive() {
+	for _, tc := range []struct {
+		name     string
+		amount   int64
+		currency *model.Currency
+		date     time.Time
+		expected int64
+	}{
+		{name: "MainCurrency", amount: 1000, currency: s.InitCurrencies[0], date: date(2021, 1, 1), expected: 1000},
+		{name: "ExactDate", amount: 1000, currency: s.InitCurrencies[1], date: date(2022, 1, 1), expected: 1200},
+		{name: "LatestBefore", amount: 1000, currency: s.InitCurrencies[1], date: date(2022, 5, 1), expected: 1100},
+		{name: "Reversed", amount: 2500, currency: s.InitCurrencies[2], date: date(2022, 1, 15), expected: 100},
+		{name: "Rounded", amount: -333, currency: s.InitCurrencies[1], date: date(2022, 1, 15), expected: -400},
+	} {
+		s.Run(tc.name, func() {
+			actual, err := s.service.ExchangeRate().ConvertToMain(tc.amount, tc.currency, tc.date)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, actual)
+		})
+	}
+}
+
+func (s *ExchangeRateServiceTestSuite) TestConvertDifferentPrecision() {
+	r := &model.ExchangeRate{Date: date(2022, 1, 1), From: s.InitCurrencies[3], To: s.InitCurrencies[0], Rate: 0.0067}
+	require.NoError(s.T(), s.service.ExchangeRate().Insert(r))
+
+	// 1000 JPY is 6.70 USD, while 6.70 USD is 1000 JPY.
+	actual, err := s.service.ExchangeRate().Convert(1000, s.InitCurrencies[3], s.InitCurrencies[0], date(2022, 1, 15))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(670), actual)
+
+	actual, err = s.service.ExchangeRate().Convert(670, s.InitCurrencies[0], s.InitCurrencies[3], date(2022, 1, 15))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1000), actual)
+}
+
+func (s *ExchangeRateServiceTestSuite) TestConvertToMainNegative() {
+	_, err := s.service.ExchangeRate().ConvertToMain(1000, s.InitCurrencies[1], date(2021, 12, 31))
+	assert.ErrorIs(s.T(), err, service.ErrExchangeRateNotFound)
+}
+
+func (s *ExchangeRateServiceTestSuite) TestTotalInMain() {
+	tt := []*model.Transaction{
+		{Date: date(2022, 1, 2), Account: &model.Account{Currency: s.InitCurrencies[0]}, Amount: -500},
+		{Date: date(2022, 2, 2), Account: &model.Account{Currency: s.InitCurrencies[1]}, Amount: 1000},
+		{Date: date(2022, 2, 2), Account: &model.Account{Currency: s.InitCurrencies[2]}, Amount: -5000},
+	}
+
+	total, err := s.service.ExchangeRate().TotalInMain(tt)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(400), total)
+
+	tt[0].Amount, tt[2].Amount = math.MaxInt64, 5000
+	_, err = s.service.ExchangeRate().TotalInMain(tt)
+	assert.ErrorIs(s.T(), err, money.ErrOverflow)
+}
+
+func (s *ExchangeRateServiceTestSuite) TearDownTest() {
+	for {
+		rr := s.service.ExchangeRate().GetAll()
+		if len(rr) == 0 {
+			break
+		}
+
+		err := s.persistentStorage.ExchangeRate().Delete(rr[0].ID)
+		require.NoError(s.T(), err, "occurred in TearDownTest")
+		s.inmemoryStorage.ExchangeRate().Delete(rr[0])
+	}
+}
+
+func (s *ExchangeRateServiceTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestExchangeRateServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRateServiceTestSuite))
+}
+
+func date(year, month, day int) time.Time {
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
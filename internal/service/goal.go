@@ -0,0 +1,235 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Goal service contains business logic related to model.Goal. Target of goal is kept in the currency
+// of goal, progress is converted into it by exchange rates.
+type Goal struct {
+	persistentStorage   GoalPersistentStorage
+	inmemoryStorage     GoalInmemoryStorage
+	categoryService     *Category
+	transactionService  *Transaction
+	exchangeRateService *ExchangeRate
+	history             *History
+}
+
+// NewGoal returns Goal service.
+func NewGoal(
+	persistentStorage GoalPersistentStorage,
+	inmemoryStorage GoalInmemoryStorage,
+	currencyService *Currency,
+	accountService *Account,
+	categoryService *Category,
+	transactionService *Transaction,
+	exchangeRateService *ExchangeRate) (*Goal, error) {
+
+	g := &Goal{
+		persistentStorage:   persistentStorage,
+		inmemoryStorage:     inmemoryStorage,
+		categoryService:     categoryService,
+		transactionService:  transactionService,
+		exchangeRateService: exchangeRateService,
+	}
+
+	if err := g.Init(currencyService, accountService); err != nil {
+		return nil, fmt.Errorf("g.Init: %w", err)
+	}
+
+	return g, nil
+}
+
+// Init initialize inmemory storage with data from persistent storage. It is also links existing
+// currencies, accounts and categories to corresponding fields of model.Goal.
+func (s *Goal) Init(currencyService *Currency, accountService *Account) error {
+	gg, err := s.persistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
+	}
+
+	for _, g := range gg {
+		g.Currency = currencyService.GetByID(g.Currency.ID)
+		if g.Account != nil {
+			g.Account = accountService.GetByID(g.Account.ID)
+		}
+		if g.Category != nil {
+			g.Category = s.categoryService.GetByID(g.Category.ID)
+		}
+	}
+
+	s.inmemoryStorage.Init(gg)
+
+	return nil
+}
+
+// Insert appends goal to both persistent and inmemory storages.
+func (s *Goal) Insert(g *model.Goal) (err error) {
+	defer s.history.record(&err, "insert goal",
+		func() error { return s.Delete(g) },
+		func() error { return s.Insert(g) })()
+
+	if err := s.validate(g); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	id, err := s.persistentStorage.Insert(g)
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
+	}
+
+	g.ID = id
+	s.inmemoryStorage.Insert(g)
+
+	return nil
+}
+
+// Update updates goal in persistent and inmemory storages.
+func (s *Goal) Update(g *model.Goal) (err error) {
+	prev, next := clone(s.GetByID(g.ID)), clone(g)
+	defer s.history.record(&err, "update goal",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+
+	if err := s.validate(g); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.persistentStorage.Update(g); err != nil {
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	s.inmemoryStorage.Update(g)
+
+	return nil
+}
+
+// Delete deletes goal from inmemory and persistent storages.
+func (s *Goal) Delete(g *model.Goal) (err error) {
+	stored := s.GetByID(g.ID)
+	defer s.history.record(&err, "delete goal",
+		func() error { return s.Insert(stored) },
+		func() error { return s.Delete(stored) })()
+
+	if err := s.persistentStorage.Delete(g.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+	}
+	s.inmemoryStorage.Delete(g)
+	return nil
+}
+
+// GetAll returns all goals.
+func (s *Goal) GetAll() []*model.Goal {
+	return s.inmemoryStorage.GetAll()
+}
+
+// GetByID returns goal by given model.Goal.ID.
+func (s *Goal) GetByID(id int64) *model.Goal {
+	return s.inmemoryStorage.GetByID(id)
+}
+
+// GetByName returns goal by given model.Goal.Name.
+func (s *Goal) GetByName(name string) *model.Goal {
+	return s.inmemoryStorage.GetByName(name)
+}
+
+// Saved returns amount saved toward goal at the end of given date in the currency of goal. For goal
+// linked to account it is the balance of account. For goal linked to category it is the sum of
+// amounts put into the category and its subcategories, that is expenses of the category increase
+// saved amount while incomes decrease it, transactions whose account is missing are ignored.
+func (s *Goal) Saved(g *model.Goal, date time.Time) (int64, error) {
+	if g.Account != nil {
+		balance, err := s.transactionService.BalanceAt(g.Account, date)
+		if err != nil {
+			return 0, fmt.Errorf("s.transactionService.BalanceAt: %w", err)
+		}
+
+		saved, err := s.exchangeRateService.Convert(balance, g.Account.Currency, g.Currency, date)
+		if err != nil {
+			return 0, fmt.Errorf("s.exchangeRateService.Convert: %w", err)
+		}
+		return saved, nil
+	}
+
+	saved := g.Currency.Money(0)
+	for _, t := range s.transactionService.GetAll() {
+		if t.Account == nil || t.Date.After(date) {
+			continue
+		}
+
+		for _, e := range s.transactionService.SplitsOf(t) {
+			if !s.categoryService.IsWithin(e.Category, g.Category) {
+				continue
+			}
+
+			amount, err := s.exchangeRateService.Convert(e.Amount, t.Account.Currency, g.Currency, t.Date)
+			if err != nil {
+				return 0, fmt.Errorf("s.exchangeRateService.Convert: %w", err)
+			}
+
+			if saved, err = saved.Sub(g.Currency.Money(amount)); err != nil {
+				return 0, fmt.Errorf("saved.Sub: %w", err)
+			}
+		}
+	}
+
+	return saved.Amount(), nil
+}
+
+// MonthlyContribution returns amount which should be saved every month starting from the month of
+// given date to reach the target of goal by its deadline. The month of deadline is included, after
+// the deadline the whole remaining amount is due at once. Reached goal requires no contribution.
+func (s *Goal) MonthlyContribution(g *model.Goal, date time.Time) (int64, error) {
+	saved, err := s.Saved(g, date)
+	if err != nil {
+		return 0, fmt.Errorf("s.Saved: %w", err)
+	}
+
+	remaining := g.Target - saved
+	if remaining <= 0 {
+		return 0, nil
+	}
+
+	months := int64(monthsBetween(date, g.Deadline)) + 1
+	if months < 1 {
+		months = 1
+	}
+
+	// rounded up, so the target is reached by the deadline.
+	return (remaining + months - 1) / months, nil
+}
+
+// validate checks if goal is consistent.
+func (*Goal) validate(g *model.Goal) error {
+	if g.Name == "" {
+		return errors.New("goal name is empty")
+	}
+
+	if g.Target <= 0 {
+		return errors.New("target of goal should be positive")
+	}
+
+	if g.Currency == nil {
+		return errors.New("goal currency not found")
+	}
+
+	if g.Deadline.IsZero() {
+		return errors.New("goal deadline is empty")
+	}
+
+	if (g.Account == nil) == (g.Category == nil) {
+		return errors.New("goal should be linked to either account or category")
+	}
+
+	return nil
+}
+
+// monthsBetween returns number of calendar months from the month of from date to the month of to
+// date, it is negative if to date is in earlier month.
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
@@ -0,0 +1,258 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type GoalServiceTestSuite struct {
+	suite.Suite
+	db                *sql.DB
+	persistentStorage *sqlite.SqliteStorage
+	inmemoryStorage   *inmemory.InmemoryStorage
+	service           *service.Service
+	InitCategories    []*model.Category
+	InitCurrencies    []*model.Currency
+	InitAccounts      []*model.Account
+	InitGoals         []*model.Goal
+}
+
+func (s *GoalServiceTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	s.persistentStorage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.inmemoryStorage = inmemory.New()
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(s.inmemoryStorage), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.InitCategories = []*model.Category{{Title: "Salary"}, {Title: "Savings"}}
+	s.InitCategories = append(s.InitCategories, &model.Category{Title: "Car fund", Parent: s.InitCategories[1]})
+	for _, c := range s.InitCategories {
+		err = s.service.Category().Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	s.InitCurrencies = []*model.Currency{{Abbreviation: "USD"}, {Abbreviation: "EUR"}}
+	for _, c := range s.InitCurrencies {
+		err = s.service.Currency().Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	err = s.service.ExchangeRate().Insert(
+		&model.ExchangeRate{Date: date(2022, 1, 1), From: s.InitCurrencies[1], To: s.InitCurrencies[0], Rate: 1.1})
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.InitAccounts = []*model.Account{
+		{Name: "Deposit", Currency: s.InitCurrencies[0], OpeningBalance: 10000, OpeningDate: date(2022, 1, 1)},
+		{Name: "Cash", Currency: s.InitCurrencies[1]},
+	}
+	for _, a := range s.InitAccounts {
+		err = s.service.Account().Insert(a)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	for _, t := range []*model.Transaction{
+		{Date: date(2022, 2, 1), Account: s.InitAccounts[0], Category: s.InitCategories[0], Amount: 5000},
+		{Date: date(2022, 2, 10), Account: s.InitAccounts[1], Category: s.InitCategories[2], Amount: -1000},
+		{Date: date(2022, 3, 1), Account: s.InitAccounts[0], Category: s.InitCategories[1], Amount: -2000},
+	} {
+		err = s.service.Transaction().Insert(t)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitGoals = []*model.Goal{
+		{ID: 1, Name: "Emergency fund", Target: 30000, Currency: s.InitCurrencies[0], Deadline: date(2022, 6, 30),
+			Account: s.InitAccounts[0]},
+		{ID: 2, Name: "Car", Target: 100000, Currency: s.InitCurrencies[0], Deadline: date(2023, 12, 31),
+			Category: s.InitCategories[1]},
+	}
+}
+
+func (s *GoalServiceTestSuite) SetupTest() {
+	for _, g := range s.InitGoals {
+		_, err := s.persistentStorage.Goal().Insert(g)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+
+	err := s.service.Goal().Init(s.service.Currency(), s.service.Account())
+	require.NoError(s.T(), err, "occurred in SetupTest")
+}
+
+func (s *GoalServiceTestSuite) TestLinkage() {
+	assert.Equal(s.T(), s.InitGoals, s.service.Goal().GetAll())
+	assert.Equal(s.T(), s.InitGoals[1], s.service.Goal().GetByName("Car"))
+}
+
+func (s *GoalServiceTestSuite) TestInsertPositive() {
+	goal := &model.Goal{Name: "Vacation", Target: 5000, Currency: s.InitCurrencies[1], Deadline: date(2022, 8, 1),
+		Category: s.InitCategories[2]}
+
+	err := s.service.Goal().Insert(goal)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), int64(3), goal.ID)
+	assert.Equal(s.T(), goal, s.service.Goal().GetByID(3))
+
+	persistentGoals, err := s.persistentStorage.Goal().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentGoals, 3)
+}
+
+func (s *GoalServiceTestSuite) TestInsertNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Goal
+		expected string
+	}{
+		{
+			name:     "EmptyName",
+			give:     &model.Goal{Target: 1, Currency: s.InitCurrencies[0], Deadline: date(2022, 8, 1), Account: s.InitAccounts[0]},
+			expected: "s.validate: goal name is empty",
+		},
+		{
+			name:     "NonPositiveTarget",
+			give:     &model.Goal{Name: "Vacation", Currency: s.InitCurrencies[0], Deadline: date(2022, 8, 1), Account: s.InitAccounts[0]},
+			expected: "s.validate: target of goal should be positive",
+		},
+		{
+			name:     "NoCurrency",
+			give:     &model.Goal{Name: "Vacation", Target: 1, Deadline: date(2022, 8, 1), Account: s.InitAccounts[0]},
+			expected: "s.validate: goal currency not found",
+		},
+		{
+			name:     "NoDeadline",
+			give:     &model.Goal{Name: "Vacation", Target: 1, Currency: s.InitCurrencies[0], Account: s.InitAccounts[0]},
+			expected: "s.validate: goal deadline is empty",
+		},
+		{
+			name:     "NotLinked",
+			give:     &model.Goal{Name: "Vacation", Target: 1, Currency: s.InitCurrencies[0], Deadline: date(2022, 8, 1)},
+			expected: "s.validate: goal should be linked to either account or category",
+		},
+		{
+			name: "LinkedToBoth",
+			give: &model.Goal{Name: "Vacation", Target: 1, Currency: s.InitCurrencies[0], Deadline: date(2022, 8, 1),
+				Account: s.InitAccounts[0], Category: s.InitCategories[1]},
+			expected: "s.validate: goal should be linked to either account or category",
+		},
+		{
+			name: "DuplicateName",
+			give: &model.Goal{Name: "Car", Target: 1, Currency: s.InitCurrencies[0], Deadline: date(2022, 8, 1),
+				Account: s.InitAccounts[0]},
+			expected: "s.persistentStorage.Insert: e.db.Exec: UNIQUE constraint failed: goal.name",
+		},
+	} {
+		s.Run(tc.name, func() {
+			err := s.service.Goal().Insert(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *GoalServiceTestSuite) TestUpdatePositive() {
+	goal := *s.service.Goal().GetByID(2)
+	goal.Name = "New car"
+	goal.Target = 200000
+
+	err := s.service.Goal().Update(&goal)
+	require.NoError(s.T(), err)
+
+	persistentGoals, err := s.persistentStorage.Goal().GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "New car", persistentGoals[1].Name)
+	assert.Equal(s.T(), &goal, s.service.Goal().GetByName("New car"))
+	assert.Nil(s.T(), s.service.Goal().GetByName("Car"))
+}
+
+func (s *GoalServiceTestSuite) TestDeletePositive() {
+	err := s.service.Goal().Delete(s.service.Goal().GetByID(1))
+	require.NoError(s.T(), err)
+
+	persistentGoals, err := s.persistentStorage.Goal().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentGoals, 1)
+	assert.Len(s.T(), s.inmemoryStorage.Goal().GetAll(), 1)
+}
+
+func (s *GoalServiceTestSuite) TestSaved() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Goal
+		expected int64
+	}{
+		{name: "Account", give: s.InitGoals[0], expected: 13000},
+		{name: "CategoryWithSubcategories", give: s.InitGoals[1], expected: 3100},
+		{
+			name:     "ConvertedToGoalCurrency",
+			give:     &model.Goal{Currency: s.InitCurrencies[1], Category: s.InitCategories[2]},
+			expected: 1000,
+		},
+	} {
+		s.Run(tc.name, func() {
+			saved, err := s.service.Goal().Saved(tc.give, date(2022, 3, 15))
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, saved)
+		})
+	}
+
+	saved, err := s.service.Goal().Saved(s.InitGoals[1], date(2022, 2, 28))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1100), saved)
+}
+
+func (s *GoalServiceTestSuite) TestMonthlyContribution() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Goal
+		date     time.Time
+		expected int64
+	}{
+		{name: "Account", give: s.InitGoals[0], date: date(2022, 3, 15), expected: 4250},
+		{name: "RoundedUp", give: s.InitGoals[1], date: date(2022, 3, 15), expected: 4405},
+		{name: "DeadlineMonth", give: s.InitGoals[0], date: date(2022, 6, 30), expected: 17000},
+		{name: "DeadlinePassed", give: s.InitGoals[1], date: date(2024, 1, 1), expected: 96900},
+		{
+			name:     "Reached",
+			give:     &model.Goal{Target: 5000, Currency: s.InitCurrencies[0], Deadline: date(2022, 6, 30), Account: s.InitAccounts[0]},
+			date:     date(2022, 3, 15),
+			expected: 0,
+		},
+	} {
+		s.Run(tc.name, func() {
+			monthly, err := s.service.Goal().MonthlyContribution(tc.give, tc.date)
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), tc.expected, monthly)
+		})
+	}
+}
+
+func (s *GoalServiceTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM goal;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *GoalServiceTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestGoalServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(GoalServiceTestSuite))
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// historyLimit is a number of the latest operations which could be undone.
+const historyLimit = 100
+
+var (
+	// ErrNothingToUndo is returned on undo when there are no recorded operations.
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNothingToRedo is returned on redo when there are no undone operations.
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// operation is a recorded change along with functions which revert and apply it again, and items
+// of trash kinds it refers to.
+type operation struct {
+	description string
+	undo        func() error
+	redo        func() error
+	refs        []ref
+}
+
+// ref is an id of transaction, account, category or currency which operation refers to.
+type ref struct {
+	kind model.TrashKind
+	id   int64
+}
+
+// History keeps operations made through services, so they could be undone and redone. Operations
+// made as a part of another operation, e.g. payee created along with transaction, aren't recorded
+// separately. Deleted transactions, accounts, categories and currencies are restored from trash,
+// other models are inserted again under new ids. Operations referring to items purged from trash
+// can't be undone or redone anymore, so they are dropped.
+type History struct {
+	undo       []operation
+	redo       []operation
+	refs       []ref
+	depth      int
+	groups     int
+	unitOfWork *UnitOfWork
+}
+
+// NewHistory returns empty History.
+func NewHistory() *History {
+	return &History{}
+}
+
+// Undo reverts the latest operation and returns its description. Operation which fails to revert
+// is dropped, so the older ones are still reachable.
+func (h *History) Undo() (string, error) {
+	if len(h.undo) == 0 {
+		return "", ErrNothingToUndo
+	}
+
+	op := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+
+	if err := h.replay(op.undo); err != nil {
+		return op.description, fmt.Errorf("undo %s: %w", op.description, err)
+	}
+
+	h.redo = append(h.redo, op)
+
+	return op.description, nil
+}
+
+// Redo applies the latest undone operation again and returns its description. Operation which
+// fails to apply is dropped.
+func (h *History) Redo() (string, error) {
+	if len(h.redo) == 0 {
+		return "", ErrNothingToRedo
+	}
+
+	op := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+
+	if err := h.replay(op.redo); err != nil {
+		return op.description, fmt.Errorf("redo %s: %w", op.description, err)
+	}
+
+	h.undo = append(h.undo, op)
+
+	return op.description, nil
+}
+
+// record starts operation with given inverse and returns func which completes it, so it is used
+// as defer s.history.record(&err, ...)(). Operation is recorded only if it succeeds and isn't a
+// part of another one, new operation discards undone ones. Nil History records nothing, so
+// services work without it.
+func (h *History) record(err *error, description string, undo, redo func() error) func() {
+	if h == nil {
+		return func() {}
+	}
+
+	if h.depth == 0 {
+		h.refs = nil
+	}
+	h.depth++
+
+	return func() {
+		h.depth--
+		if h.depth > 0 || *err != nil {
+			return
+		}
+
+		h.push(operation{description: description, undo: undo, redo: redo, refs: h.refs})
+		h.refs = nil
+	}
+}
+
+// refer adds items with given ids to the operation being recorded, so it is used as
+// defer s.history.refer(kind, &e.ID) right after record, since ids of inserted items are known
+// only once they are stored. Operations nested into the recorded one add their items to it.
+func (h *History) refer(kind model.TrashKind, ids ...*int64) {
+	if h == nil || h.depth == 0 {
+		return
+	}
+
+	for _, id := range ids {
+		h.refs = append(h.refs, ref{kind: kind, id: *id})
+	}
+}
+
+// forget drops operations referring to items with given ids, it is called once items are purged.
+func (h *History) forget(kind model.TrashKind, ids ...int64) {
+	if h == nil {
+		return
+	}
+
+	purged := make(map[ref]bool, len(ids))
+	for _, id := range ids {
+		purged[ref{kind: kind, id: id}] = true
+	}
+
+	keep := func(ops []operation) []operation {
+		res := ops[:0]
+		for _, op := range ops {
+			if !op.refersTo(purged) {
+				res = append(res, op)
+			}
+		}
+		return res
+	}
+
+	h.undo, h.redo = keep(h.undo), keep(h.redo)
+}
+
+// group starts a group of operations made inside of unit of work and returns func which completes
+// it. Operations of succeeded group are merged into a single one, so they are undone together,
+// operations of failed group are dropped, since their changes are rolled back.
+func (h *History) group(err *error) func() {
+	if h == nil || h.depth > 0 {
+		return func() {}
+	}
+
+	start, redo := len(h.undo), h.redo
+	h.groups++
+
+	return func() {
+		h.groups--
+
+		ops := append([]operation(nil), h.undo[start:]...)
+		h.undo = h.undo[:start]
+
+		switch {
+		case *err != nil:
+			h.redo = redo
+		case len(ops) == 1:
+			h.push(ops[0])
+		case len(ops) > 1:
+			h.push(merge(ops))
+		}
+	}
+}
+
+// push appends operation to the undo stack and discards undone ones. The oldest operation is
+// dropped when limit is exceeded, but not inside of group, since it is merged afterwards.
+func (h *History) push(op operation) {
+	h.undo = append(h.undo, op)
+	if h.groups == 0 && len(h.undo) > historyLimit {
+		h.undo = h.undo[1:]
+	}
+	h.redo = nil
+}
+
+// pause stops recording until returned func is called, it is used for operations which aren't
+// made by user.
+func (h *History) pause() func() {
+	if h == nil {
+		return func() {}
+	}
+
+	h.depth++
+
+	return func() { h.depth-- }
+}
+
+// replay calls fn as a single unit of work without recording operations it makes.
+func (h *History) replay(fn func() error) error {
+	defer h.pause()()
+	return h.unitOfWork.Do(fn)
+}
+
+// refersTo reports whether operation refers to any of given items.
+func (op operation) refersTo(refs map[ref]bool) bool {
+	for _, r := range op.refs {
+		if refs[r] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// merge returns operation which consists of given ones, they are undone in reverse order.
+func merge(ops []operation) operation {
+	descriptions := make([]string, 0, len(ops))
+	refs := make([]ref, 0)
+	for _, op := range ops {
+		descriptions = append(descriptions, op.description)
+		refs = append(refs, op.refs...)
+	}
+
+	return operation{
+		description: strings.Join(descriptions, ", "),
+		refs:        refs,
+		undo: func() error {
+			for i := len(ops) - 1; i >= 0; i-- {
+				if err := ops[i].undo(); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		redo: func() error {
+			for _, op := range ops {
+				if err := op.redo(); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// clone returns a shallow copy of given model, so the state of model could be kept regardless of
+// further changes.
+func clone[T any](e *T) *T {
+	if e == nil {
+		return nil
+	}
+
+	c := *e
+
+	return &c
+}
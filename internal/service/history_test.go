@@ -0,0 +1,198 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type HistoryServiceTestSuite struct {
+	suite.Suite
+	db       *sql.DB
+	service  *service.Service
+	account  *model.Account
+	category *model.Category
+}
+
+func (s *HistoryServiceTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	db.SetMaxOpenConns(1)
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	s.service, err = service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupTest")
+
+	currency := &model.Currency{Abbreviation: "USD", Precision: 2}
+	s.account = &model.Account{Name: "Cash", Currency: currency}
+	s.category = &model.Category{Title: "Grocery"}
+
+	require.NoError(s.T(), s.service.Currency().Insert(currency), "occurred in SetupTest")
+	require.NoError(s.T(), s.service.Account().Insert(s.account), "occurred in SetupTest")
+	require.NoError(s.T(), s.service.Category().Insert(s.category), "occurred in SetupTest")
+}
+
+func (s *HistoryServiceTestSuite) newTransaction() *model.Transaction {
+	return &model.Transaction{
+		Date:     time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC),
+		Account:  s.account,
+		Category: s.category,
+		Amount:   -1250,
+		Note:     "bread",
+		Payee:    &model.Payee{Name: "Bakery"},
+	}
+}
+
+func (s *HistoryServiceTestSuite) TestInsert() {
+	t := s.newTransaction()
+	require.NoError(s.T(), s.service.Transaction().Insert(t))
+
+	description, err := s.service.History().Undo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert transaction", description)
+	assert.Nil(s.T(), s.service.Transaction().GetByID(t.ID))
+
+	// payee created along with transaction isn't recorded separately
+	description, err = s.service.History().Undo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert category", description)
+
+	description, err = s.service.History().Redo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert category", description)
+
+	description, err = s.service.History().Redo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert transaction", description)
+	assert.Same(s.T(), t, s.service.Transaction().GetByID(t.ID))
+
+	_, err = s.service.History().Redo()
+	assert.ErrorIs(s.T(), err, service.ErrNothingToRedo)
+}
+
+func (s *HistoryServiceTestSuite) TestUpdate() {
+	t := s.newTransaction()
+	require.NoError(s.T(), s.service.Transaction().Insert(t))
+
+	upd := *t
+	upd.Note, upd.Amount = "milk", -300
+	require.NoError(s.T(), s.service.Transaction().Update(&upd))
+
+	_, err := s.service.History().Undo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "bread", s.service.Transaction().GetByID(t.ID).Note)
+	assert.Equal(s.T(), int64(-1250), s.service.Transaction().GetByID(t.ID).Amount)
+
+	_, err = s.service.History().Redo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "milk", s.service.Transaction().GetByID(t.ID).Note)
+}
+
+func (s *HistoryServiceTestSuite) TestDelete() {
+	t := s.newTransaction()
+	require.NoError(s.T(), s.service.Transaction().Insert(t))
+	require.NoError(s.T(), s.service.Transaction().Delete(t))
+
+	description, err := s.service.History().Undo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "delete transaction", description)
+	assert.Same(s.T(), t, s.service.Transaction().GetByID(t.ID))
+
+	// model without trash is inserted again
+	b := &model.Budget{Category: s.category, Period: time.Date(2022, time.Month(2), 1, 0, 0, 0, 0, time.UTC), Limit: 100}
+	require.NoError(s.T(), s.service.Budget().Insert(b))
+	require.NoError(s.T(), s.service.Budget().Delete(b))
+
+	_, err = s.service.History().Undo()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), s.service.Budget().GetAll(), 1)
+	assert.Equal(s.T(), int64(100), s.service.Budget().GetAll()[0].Limit)
+
+	_, err = s.service.History().Redo()
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), s.service.Budget().GetAll())
+}
+
+func (s *HistoryServiceTestSuite) TestNewOperationDiscardsRedo() {
+	require.NoError(s.T(), s.service.Transaction().Insert(s.newTransaction()))
+
+	_, err := s.service.History().Undo()
+	require.NoError(s.T(), err)
+
+	require.NoError(s.T(), s.service.Category().Insert(&model.Category{Title: "Health"}))
+
+	_, err = s.service.History().Redo()
+	assert.ErrorIs(s.T(), err, service.ErrNothingToRedo)
+}
+
+func (s *HistoryServiceTestSuite) TestPurgeForgetsOperations() {
+	t := s.newTransaction()
+	require.NoError(s.T(), s.service.Transaction().Insert(t))
+	t.Note = "rye bread"
+	require.NoError(s.T(), s.service.Transaction().Update(t))
+	require.NoError(s.T(), s.service.Category().Insert(&model.Category{Title: "Health"}))
+	require.NoError(s.T(), s.service.Transaction().Delete(t))
+
+	err := s.service.Trash().Purge(&model.TrashItem{Kind: model.TrashTransaction, ID: t.ID})
+	require.NoError(s.T(), err)
+
+	// operations on purged transaction are dropped, the others are still reachable
+	description, err := s.service.History().Undo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert category", description)
+	assert.Nil(s.T(), s.service.Category().GetByTitle("Health"))
+
+	description, err = s.service.History().Undo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert category", description)
+	assert.Nil(s.T(), s.service.Category().GetByTitle("Grocery"))
+
+	// undone operation on purged category can't be redone
+	err = s.service.Trash().Purge(&model.TrashItem{Kind: model.TrashCategory, ID: s.category.ID})
+	require.NoError(s.T(), err)
+
+	description, err = s.service.History().Redo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert category", description)
+	assert.NotNil(s.T(), s.service.Category().GetByTitle("Health"))
+
+	_, err = s.service.History().Redo()
+	assert.ErrorIs(s.T(), err, service.ErrNothingToRedo)
+}
+
+func (s *HistoryServiceTestSuite) TestGenerateNotRecorded() {
+	template := s.newTransaction()
+	template.Payee = nil
+	r := &model.Recurrence{Rule: model.Daily, Start: time.Now().AddDate(0, 0, -1), Template: template}
+	require.NoError(s.T(), s.service.Recurrence().Insert(r))
+
+	n, err := s.service.Recurrence().Generate(time.Now())
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, n)
+
+	description, err := s.service.History().Undo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert recurrence", description)
+}
+
+func (s *HistoryServiceTestSuite) TearDownTest() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func TestHistoryServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HistoryServiceTestSuite))
+}
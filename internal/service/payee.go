@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Payee service contains business logic related to model.Payee.
+type Payee struct {
+	persistentStorage  PayeePersistentStorage
+	inmemoryStorage    PayeeInmemoryStorage
+	categoryService    *Category
+	transactionService *Transaction
+	history            *History
+	unitOfWork         *UnitOfWork
+}
+
+// NewPayee returns Payee service.
+func NewPayee(
+	persistentStorage PayeePersistentStorage,
+	inmemoryStorage PayeeInmemoryStorage,
+	categoryService *Category) (*Payee, error) {
+
+	p := &Payee{
+		persistentStorage: persistentStorage,
+		inmemoryStorage:   inmemoryStorage,
+		categoryService:   categoryService,
+	}
+
+	if err := p.Init(); err != nil {
+		return nil, fmt.Errorf("p.Init: %w", err)
+	}
+
+	return p, nil
+}
+
+// Init initialize inmemory storage with data from persistent storage. It is also links existing
+// categories to default category of model.Payee.
+func (s *Payee) Init() error {
+	pp, err := s.persistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
+	}
+
+	for _, p := range pp {
+		if p.Category != nil {
+			p.Category = s.categoryService.GetByID(p.Category.ID)
+		}
+	}
+
+	s.inmemoryStorage.Init(pp)
+
+	return nil
+}
+
+// Insert appends payee to both persistent and inmemory storages.
+func (s *Payee) Insert(p *model.Payee) (err error) {
+	defer s.history.record(&err, "insert payee",
+		func() error { return s.Delete(p) },
+		func() error { return s.Insert(p) })()
+
+	if err := s.validate(p); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	id, err := s.persistentStorage.Insert(p)
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
+	}
+
+	p.ID = id
+	s.inmemoryStorage.Insert(p)
+
+	return nil
+}
+
+// Update updates payee in both persistent and inmemory storages.
+func (s *Payee) Update(p *model.Payee) (err error) {
+	prev, next := clone(s.GetByID(p.ID)), clone(p)
+	defer s.history.record(&err, "update payee",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+
+	if err := s.validate(p); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if err := s.persistentStorage.Update(p); err != nil {
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	s.inmemoryStorage.Update(p)
+
+	return nil
+}
+
+// Delete deletes payee from inmemory and persistent storages, transactions of payee are unlinked
+// from it. Undo links them back.
+func (s *Payee) Delete(p *model.Payee) (err error) {
+	stored, linked := s.GetByID(p.ID), s.transactions(p)
+	defer s.history.record(&err, "delete payee",
+		func() error { return s.restore(stored, linked) },
+		func() error { return s.Delete(stored) })()
+
+	return s.unitOfWork.Do(func() error {
+		if err := s.transactionService.setPayee(linked, nil); err != nil {
+			return fmt.Errorf("s.transactionService.setPayee: %w", err)
+		}
+
+		if err := s.persistentStorage.Delete(p.ID); err != nil {
+			return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+		}
+
+		s.inmemoryStorage.Delete(p)
+
+		return nil
+	})
+}
+
+// restore inserts deleted payee back and links given transactions to it again, transactions which
+// are gone since then are skipped.
+func (s *Payee) restore(p *model.Payee, tt []*model.Transaction) error {
+	return s.unitOfWork.Do(func() error {
+		if err := s.Insert(p); err != nil {
+			return fmt.Errorf("s.Insert: %w", err)
+		}
+
+		linked := make([]*model.Transaction, 0, len(tt))
+		for _, t := range tt {
+			if stored := s.transactionService.GetByID(t.ID); stored != nil {
+				linked = append(linked, stored)
+			}
+		}
+
+		if err := s.transactionService.setPayee(linked, p); err != nil {
+			return fmt.Errorf("s.transactionService.setPayee: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetAll returns all payees.
+func (s *Payee) GetAll() []*model.Payee {
+	return s.inmemoryStorage.GetAll()
+}
+
+// GetByID returns payee by given model.Payee.ID.
+func (s *Payee) GetByID(id int64) *model.Payee {
+	return s.inmemoryStorage.GetByID(id)
+}
+
+// GetByName returns payee by given model.Payee.Name.
+func (s *Payee) GetByName(name string) *model.Payee {
+	return s.inmemoryStorage.GetByName(name)
+}
+
+// LinkTransaction replaces payee of transaction with stored instance. Payee which doesn't exist
+// yet is created with category of the transaction as default one.
+func (s *Payee) LinkTransaction(t *model.Transaction) error {
+	if t.Payee == nil {
+		return nil
+	}
+
+	if stored := s.GetByName(t.Payee.Name); stored != nil {
+		t.Payee = stored
+		return nil
+	}
+
+	if t.Payee.Category == nil {
+		t.Payee.Category = t.Category
+	}
+
+	if err := s.Insert(t.Payee); err != nil {
+		return fmt.Errorf("s.Insert: %w", err)
+	}
+
+	return nil
+}
+
+// transactions returns transactions linked to given payee.
+func (s *Payee) transactions(p *model.Payee) []*model.Transaction {
+	if s.transactionService == nil {
+		return nil
+	}
+
+	return filter(s.transactionService.GetAll(), func(t *model.Transaction) bool {
+		return t.Payee != nil && t.Payee.ID == p.ID
+	})
+}
+
+// validate checks if payee has name and its default category exists.
+func (s *Payee) validate(p *model.Payee) error {
+	if p.Name == "" {
+		return errors.New("payee name is empty")
+	}
+
+	if p.Category != nil && s.categoryService.GetByID(p.Category.ID) == nil {
+		return fmt.Errorf("default category with id %d not found", p.Category.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PayeeServiceTestSuite struct {
+	suite.Suite
+	db                *sql.DB
+	persistentStorage *sqlite.SqliteStorage
+	inmemoryStorage   *inmemory.InmemoryStorage
+	service           *service.Service
+	InitCategories    []*model.Category
+	InitPayees        []*model.Payee
+}
+
+func (s *PayeeServiceTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	s.persistentStorage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.inmemoryStorage = inmemory.New()
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(s.inmemoryStorage), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitCategories = []*model.Category{
+		{ID: 1, Title: "Grocery"},
+	}
+	s.InitPayees = []*model.Payee{
+		{ID: 1, Name: "Supermarket", Category: s.InitCategories[0]},
+		{ID: 2, Name: "Landlord"},
+	}
+
+	for _, c := range s.InitCategories {
+		_, err = s.persistentStorage.Category().Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupSuite")
+	}
+
+	err = s.service.Category().Init()
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+}
+
+func (s *PayeeServiceTestSuite) SetupTest() {
+	for _, p := range s.InitPayees {
+		_, err := s.persistentStorage.Payee().Insert(p)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+
+	err := s.service.Payee().Init()
+	require.NoError(s.T(), err, "occurred in SetupTest")
+}
+
+func (s *PayeeServiceTestSuite) TestLinkage() {
+	assert.Equal(s.T(), s.service.Category().GetByID(1), s.service.Payee().GetByName("Supermarket").Category)
+	assert.Nil(s.T(), s.service.Payee().GetByName("Landlord").Category)
+}
+
+func (s *PayeeServiceTestSuite) TestInsertPositive() {
+	payee := &model.Payee{Name: "Pharmacy", Category: s.InitCategories[0]}
+
+	err := s.service.Payee().Insert(payee)
+	require.NoError(s.T(), err)
+
+	persistentPayees, err := s.persistentStorage.Payee().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentPayees, 3)
+	assert.Equal(s.T(), payee, s.service.Payee().GetByID(payee.ID))
+}
+
+func (s *PayeeServiceTestSuite) TestUpdatePositive() {
+	payee := &model.Payee{ID: 2, Name: "Landlady", Category: s.InitCategories[0]}
+
+	err := s.service.Payee().Update(payee)
+	require.NoError(s.T(), err)
+
+	err = s.service.Payee().Init()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), payee, s.service.Payee().GetByName("Landlady"))
+}
+
+func (s *PayeeServiceTestSuite) TestValidateNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Payee
+		expected string
+	}{
+		{
+			name:     "EmptyName",
+			give:     &model.Payee{},
+			expected: "s.validate: payee name is empty",
+		},
+		{
+			name:     "CategoryNotFound",
+			give:     &model.Payee{Name: "Pharmacy", Category: &model.Category{ID: 10}},
+			expected: "s.validate: default category with id 10 not found",
+		},
+	} {
+		s.Run(tc.name, func() {
+			assert.EqualError(s.T(), s.service.Payee().Insert(tc.give), tc.expected)
+			assert.EqualError(s.T(), s.service.Payee().Update(tc.give), tc.expected)
+		})
+	}
+}
+
+func (s *PayeeServiceTestSuite) TestDeletePositive() {
+	err := s.service.Payee().Delete(s.service.Payee().GetByID(2))
+	require.NoError(s.T(), err)
+
+	persistentPayees, err := s.persistentStorage.Payee().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentPayees, 1)
+	assert.Nil(s.T(), s.service.Payee().GetByID(2))
+}
+
+func (s *PayeeServiceTestSuite) TestDeleteNegative() {
+	err := s.service.Payee().Delete(&model.Payee{ID: 10})
+	assert.EqualError(s.T(), err, "s.persistentStorage.Delete: total affected rows 0 while expected 1")
+}
+
+func (s *PayeeServiceTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM payee;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *PayeeServiceTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestPayeeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PayeeServiceTestSuite))
+}
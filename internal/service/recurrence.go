@@ -0,0 +1,262 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Recurrence service contains business logic related to model.Recurrence and generation of
+// transactions by schedule.
+type Recurrence struct {
+	persistentStorage  RecurrencePersistentStorage
+	inmemoryStorage    RecurrenceInmemoryStorage
+	transactionService *Transaction
+	history            *History
+	unitOfWork         *UnitOfWork
+}
+
+// NewRecurrence returns Recurrence service.
+func NewRecurrence(
+	persistentStorage RecurrencePersistentStorage,
+	inmemoryStorage RecurrenceInmemoryStorage,
+	categoryService *Category,
+	accountService *Account,
+	transactionService *Transaction) (*Recurrence, error) {
+
+	r := &Recurrence{
+		persistentStorage:  persistentStorage,
+		inmemoryStorage:    inmemoryStorage,
+		transactionService: transactionService,
+	}
+
+	if err := r.Init(categoryService, accountService); err != nil {
+		return nil, fmt.Errorf("r.Init: %w", err)
+	}
+
+	return r, nil
+}
+
+// Init initialize inmemory storage with data from persistent storage. It is also links existing
+// categories and accounts to corresponding fields of template transactions.
+func (s *Recurrence) Init(categoryService *Category, accountService *Account) error {
+	rr, err := s.persistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
+	}
+
+	for _, r := range rr {
+		r.Template.Category = categoryService.GetByID(r.Template.Category.ID)
+		r.Template.Account = accountService.GetByID(r.Template.Account.ID)
+	}
+
+	s.inmemoryStorage.Init(rr)
+
+	return nil
+}
+
+// Insert appends recurrence to both persistent and inmemory storages. The first occurrence is
+// scheduled on the start date.
+func (s *Recurrence) Insert(r *model.Recurrence) (err error) {
+	defer s.history.record(&err, "insert recurrence",
+		func() error { return s.Delete(r) },
+		func() error { return s.Insert(r) })()
+
+	if err := s.validate(r); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+	r.Next = r.Start
+
+	id, err := s.persistentStorage.Insert(r)
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
+	}
+
+	r.ID = id
+	s.inmemoryStorage.Insert(r)
+
+	return nil
+}
+
+// Update updates recurrence in persistent and inmemory storages. Already created occurrences are
+// kept, if next date isn't set it is taken from the stored recurrence, if the start date is moved
+// forward, the next occurrence is moved along with it.
+func (s *Recurrence) Update(r *model.Recurrence) (err error) {
+	prev, next := clone(s.GetByID(r.ID)), clone(r)
+	defer s.history.record(&err, "update recurrence",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+
+	if err := s.validate(r); err != nil {
+		return fmt.Errorf("s.validate: %w", err)
+	}
+
+	if stored := s.GetByID(r.ID); r.Next.IsZero() && stored != nil {
+		r.Next = stored.Next
+	}
+	if r.Next.Before(r.Start) {
+		r.Next = r.Start
+	}
+
+	if err := s.persistentStorage.Update(r); err != nil {
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	s.inmemoryStorage.Update(r)
+
+	return nil
+}
+
+// Delete deletes recurrence from inmemory and persistent storages. Created transactions are kept.
+func (s *Recurrence) Delete(r *model.Recurrence) (err error) {
+	stored := s.GetByID(r.ID)
+	defer s.history.record(&err, "delete recurrence",
+		func() error { return s.Insert(stored) },
+		func() error { return s.Delete(stored) })()
+
+	if err := s.persistentStorage.Delete(r.ID); err != nil {
+		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+	}
+	s.inmemoryStorage.Delete(r)
+	return nil
+}
+
+// GetAll returns all recurrences.
+func (s *Recurrence) GetAll() []*model.Recurrence {
+	return s.inmemoryStorage.GetAll()
+}
+
+// GetByID returns recurrence by given model.Recurrence.ID.
+func (s *Recurrence) GetByID(id int64) *model.Recurrence {
+	return s.inmemoryStorage.GetByID(id)
+}
+
+// SetPaused pauses or resumes recurrence. Occurrences missed while recurrence was paused are
+// skipped on resume, so the next one is the first occurrence on or after now.
+func (s *Recurrence) SetPaused(r *model.Recurrence, paused bool, now time.Time) (err error) {
+	description := "resume recurrence"
+	if paused {
+		description = "pause recurrence"
+	}
+
+	prev := clone(r)
+	defer s.history.record(&err, description,
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.SetPaused(r, paused, now) })()
+
+	upd := *r
+	upd.Paused = paused
+
+	if !paused {
+		for upd.Next.Before(truncateDay(now)) {
+			upd.Next = nextOccurrence(&upd, upd.Next)
+		}
+	}
+
+	if err := s.persistentStorage.Update(&upd); err != nil {
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	s.inmemoryStorage.Update(&upd)
+
+	return nil
+}
+
+// Generate creates transactions for all occurrences of active recurrences due on or before now,
+// including ones missed since the last run. It returns the number of created transactions.
+func (s *Recurrence) Generate(now time.Time) (int, error) {
+	// created transactions aren't made by user, so they aren't recorded to history
+	defer s.history.pause()()
+
+	count := 0
+
+	for _, r := range s.GetAll() {
+		if r.Paused {
+			continue
+		}
+
+		for !r.Next.After(now) && (r.End.IsZero() || !r.Next.After(r.End)) {
+			// occurrence is inserted along with the next date, so it isn't duplicated if generation
+			// fails
+			if err := s.unitOfWork.Do(func() error { return s.generate(r) }); err != nil {
+				return count, err
+			}
+
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// validate checks if recurrence is consistent.
+func (*Recurrence) validate(r *model.Recurrence) error {
+	valid := false
+	for _, rule := range model.Rules() {
+		valid = valid || r.Rule == rule
+	}
+	if !valid {
+		return fmt.Errorf("unknown recurrence rule %q", r.Rule)
+	}
+
+	if !r.End.IsZero() && r.End.Before(r.Start) {
+		return errors.New("end of recurrence should be after its start")
+	}
+
+	if r.Template.Amount == 0 {
+		return errors.New("amount of recurring transaction should not be zero")
+	}
+
+	return nil
+}
+
+// generate inserts transaction of the next occurrence of recurrence and moves it to the following
+// one.
+func (s *Recurrence) generate(r *model.Recurrence) error {
+	t := *r.Template
+	t.ID, t.Date = 0, r.Next
+
+	if err := s.transactionService.Insert(&t); err != nil {
+		return fmt.Errorf("s.transactionService.Insert: %w", err)
+	}
+
+	r.Next = nextOccurrence(r, r.Next)
+	if err := s.persistentStorage.Update(r); err != nil {
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	return nil
+}
+
+// nextOccurrence returns the occurrence of recurrence following the given date. Monthly and yearly
+// occurrences keep the day of start date, it is limited by the last day of shorter months.
+func nextOccurrence(r *model.Recurrence, date time.Time) time.Time {
+	switch r.Rule {
+	case model.Daily:
+		return date.AddDate(0, 0, 1)
+	case model.Weekly:
+		return date.AddDate(0, 0, 7)
+	case model.Monthly:
+		return dateClamped(date.Year(), date.Month()+1, r.Start.Day(), date.Location())
+	case model.Yearly:
+		return dateClamped(date.Year()+1, r.Start.Month(), r.Start.Day(), date.Location())
+	}
+	return date
+}
+
+// dateClamped returns date with day limited by the last day of the month.
+func dateClamped(year int, month time.Month, day int, loc *time.Location) time.Time {
+	// zero day of the next month is the last day of the current one
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// truncateDay returns the beginning of the day of given time.
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,202 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecurrenceServiceTestSuite struct {
+	suite.Suite
+	db                *sql.DB
+	persistentStorage *sqlite.SqliteStorage
+	inmemoryStorage   *inmemory.InmemoryStorage
+	service           *service.Service
+	initCategory      *model.Category
+	initAccount       *model.Account
+}
+
+func (s *RecurrenceServiceTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	s.persistentStorage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.inmemoryStorage = inmemory.New()
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(s.inmemoryStorage), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initCategory = &model.Category{Title: "Rent"}
+	err = s.service.Category().Insert(s.initCategory)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	currency := &model.Currency{Abbreviation: "USD"}
+	err = s.service.Currency().Insert(currency)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.initAccount = &model.Account{Name: "Card", Currency: currency}
+	err = s.service.Account().Insert(s.initAccount)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+}
+
+func (s *RecurrenceServiceTestSuite) TestInsertPositive() {
+	recurrence := s.newRecurrence(model.Monthly, 2022, 1, 31)
+
+	err := s.service.Recurrence().Insert(recurrence)
+	require.NoError(s.T(), err)
+
+	persistentRecurrences, err := s.persistentStorage.Recurrence().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), persistentRecurrences, 1)
+	assert.Equal(s.T(), date(2022, 1, 31), persistentRecurrences[0].Next)
+	assert.Equal(s.T(), []*model.Recurrence{recurrence}, s.service.Recurrence().GetAll())
+}
+
+func (s *RecurrenceServiceTestSuite) TestInsertNegative() {
+	unknownRule := s.newRecurrence("hourly", 2022, 1, 1)
+
+	endBeforeStart := s.newRecurrence(model.Daily, 2022, 1, 1)
+	endBeforeStart.End = date(2021, 1, 1)
+
+	zeroAmount := s.newRecurrence(model.Daily, 2022, 1, 1)
+	zeroAmount.Template.Amount = 0
+
+	for _, tc := range []struct {
+		name     string
+		give     *model.Recurrence
+		expected string
+	}{
+		{name: "UnknownRule", give: unknownRule, expected: `s.validate: unknown recurrence rule "hourly"`},
+		{name: "EndBeforeStart", give: endBeforeStart, expected: "s.validate: end of recurrence should be after its start"},
+		{name: "ZeroAmount", give: zeroAmount, expected: "s.validate: amount of recurring transaction should not be zero"},
+	} {
+		s.Run(tc.name, func() {
+			err := s.service.Recurrence().Insert(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *RecurrenceServiceTestSuite) TestUpdateKeepsNext() {
+	recurrence := s.newRecurrence(model.Monthly, 2022, 1, 31)
+	err := s.service.Recurrence().Insert(recurrence)
+	require.NoError(s.T(), err)
+
+	_, err = s.service.Recurrence().Generate(date(2022, 2, 28))
+	require.NoError(s.T(), err)
+
+	upd := s.newRecurrence(model.Monthly, 2022, 1, 31)
+	upd.ID = recurrence.ID
+	upd.Template.Amount = -60000
+
+	err = s.service.Recurrence().Update(upd)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), date(2022, 3, 31), s.service.Recurrence().GetByID(recurrence.ID).Next)
+}
+
+func (s *RecurrenceServiceTestSuite) TestGenerate() {
+	monthly := s.newRecurrence(model.Monthly, 2022, 1, 31)
+
+	weekly := s.newRecurrence(model.Weekly, 2022, 2, 1)
+	weekly.End = date(2022, 2, 15)
+
+	paused := s.newRecurrence(model.Daily, 2022, 1, 1)
+	paused.Paused = true
+
+	for _, r := range []*model.Recurrence{monthly, weekly, paused} {
+		err := s.service.Recurrence().Insert(r)
+		require.NoError(s.T(), err)
+	}
+
+	count, err := s.service.Recurrence().Generate(date(2022, 4, 15))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 6, count)
+
+	dates := make([]string, 0, count)
+	for _, t := range s.service.Transaction().GetAll() {
+		dates = append(dates, t.Date.Format("2006-01-02"))
+	}
+	assert.ElementsMatch(s.T(), dates,
+		[]string{"2022-01-31", "2022-02-28", "2022-03-31", "2022-02-01", "2022-02-08", "2022-02-15"})
+	assert.Equal(s.T(), date(2022, 4, 30), monthly.Next)
+
+	// repeated generation doesn't duplicate transactions
+	count, err = s.service.Recurrence().Generate(date(2022, 4, 15))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 0, count)
+}
+
+func (s *RecurrenceServiceTestSuite) TestSetPaused() {
+	recurrence := s.newRecurrence(model.Daily, 2022, 1, 1)
+	err := s.service.Recurrence().Insert(recurrence)
+	require.NoError(s.T(), err)
+
+	err = s.service.Recurrence().SetPaused(recurrence, true, date(2022, 1, 1))
+	require.NoError(s.T(), err)
+	assert.True(s.T(), s.service.Recurrence().GetByID(recurrence.ID).Paused)
+
+	err = s.service.Recurrence().SetPaused(s.service.Recurrence().GetByID(recurrence.ID), false, date(2022, 1, 10))
+	require.NoError(s.T(), err)
+
+	persistentRecurrences, err := s.persistentStorage.Recurrence().GetAll()
+	require.NoError(s.T(), err)
+	assert.False(s.T(), persistentRecurrences[0].Paused)
+	assert.Equal(s.T(), date(2022, 1, 10), persistentRecurrences[0].Next)
+}
+
+func (s *RecurrenceServiceTestSuite) newRecurrence(rule model.Rule, year, month, day int) *model.Recurrence {
+	return &model.Recurrence{
+		Rule:  rule,
+		Start: date(year, month, day),
+		Template: &model.Transaction{
+			Account: s.initAccount, Category: s.initCategory, Amount: -50000, Note: "rent"},
+	}
+}
+
+func (s *RecurrenceServiceTestSuite) TearDownTest() {
+	for {
+		rr := s.service.Recurrence().GetAll()
+		if len(rr) == 0 {
+			break
+		}
+
+		err := s.persistentStorage.Recurrence().Delete(rr[0].ID)
+		require.NoError(s.T(), err, "occurred in TearDownTest")
+		s.inmemoryStorage.Recurrence().Delete(rr[0])
+	}
+
+	for {
+		tt := s.service.Transaction().GetAll()
+		if len(tt) == 0 {
+			break
+		}
+
+		err := s.persistentStorage.Transaction().Delete(tt[0].ID)
+		require.NoError(s.T(), err, "occurred in TearDownTest")
+		s.inmemoryStorage.Transaction().Delete(tt[0])
+	}
+
+	// deleted items are kept in trash, so they are purged to reuse ids
+	_, err := s.db.Exec(`DELETE FROM "transaction" WHERE deletedAt IS NOT NULL;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *RecurrenceServiceTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestRecurrenceServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RecurrenceServiceTestSuite))
+}
@@ -0,0 +1,638 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// DeleteAction defines what happens to the data which refers to deleted item.
+type DeleteAction int
+
+const (
+	// Refuse keeps item if any data refers to it.
+	Refuse DeleteAction = iota
+	// Cascade deletes the data which refers to item along with it.
+	Cascade
+	// Reassign moves the data which refers to item to another one.
+	Reassign
+)
+
+// DeletePolicy defines how the data which refers to deleted item is treated, Target is the item
+// the data is moved to on Reassign. Zero DeletePolicy refuses to delete item which is in use.
+type DeletePolicy[T model.Any] struct {
+	Action DeleteAction
+	Target *T
+}
+
+var (
+	// ErrInUse is wrapped by InUseError, it is used to check if item is in use with errors.Is.
+	ErrInUse = errors.New("item is in use")
+	// ErrNoReassignTarget is returned when item to reassign the data to isn't found.
+	ErrNoReassignTarget = errors.New("item to reassign to isn't found")
+	// ErrReassignToItself is returned on attempt to reassign the data to deleted item itself or to
+	// its subcategory.
+	ErrReassignToItself = errors.New("can't reassign to deleted item itself or to its subcategory")
+)
+
+// Usage is a number of items of some kind which refer to another item.
+type Usage struct {
+	Kind  string
+	Count int
+}
+
+// InUseError is returned on attempt to delete or purge item which other items refer to.
+type InUseError struct {
+	Item  string
+	Usage []Usage
+}
+
+// Error returns description of item along with counts of items which refer to it.
+func (e *InUseError) Error() string {
+	uu := make([]string, len(e.Usage))
+	for i, u := range e.Usage {
+		uu[i] = fmt.Sprintf("%s (%d)", u.Kind, u.Count)
+	}
+
+	return fmt.Sprintf("%s is used by %s", e.Item, strings.Join(uu, ", "))
+}
+
+// Unwrap returns ErrInUse.
+func (e *InUseError) Unwrap() error {
+	return ErrInUse
+}
+
+// usage is a list of non zero counts of items which refer to another item.
+type usage []Usage
+
+// add appends count of items of given kind if there are any.
+func (u *usage) add(kind string, count int) {
+	if count > 0 {
+		*u = append(*u, Usage{Kind: kind, Count: count})
+	}
+}
+
+// err returns InUseError of given item if there is any usage, otherwise nil.
+func (u usage) err(item string) error {
+	if len(u) == 0 {
+		return nil
+	}
+
+	return &InUseError{Item: item, Usage: u}
+}
+
+// References service keeps track of the data which refers to categories, accounts, currencies and
+// counterparties, so they are deleted without leaving references to missing items. Nil References knows of no
+// data, so services work without it.
+type References struct {
+	categoryService     *Category
+	currencyService     *Currency
+	accountService      *Account
+	transactionService  *Transaction
+	budgetService       *Budget
+	recurrenceService   *Recurrence
+	goalService         *Goal
+	payeeService        *Payee
+	exchangeRateService *ExchangeRate
+	debtService         *Debt
+}
+
+// NewReferences returns References service.
+func NewReferences(
+	categoryService *Category,
+	currencyService *Currency,
+	accountService *Account,
+	transactionService *Transaction,
+	budgetService *Budget,
+	recurrenceService *Recurrence,
+	goalService *Goal,
+	payeeService *Payee,
+	exchangeRateService *ExchangeRate,
+	debtService *Debt) *References {
+
+	return &References{
+		categoryService:     categoryService,
+		currencyService:     currencyService,
+		accountService:      accountService,
+		transactionService:  transactionService,
+		budgetService:       budgetService,
+		recurrenceService:   recurrenceService,
+		goalService:         goalService,
+		payeeService:        payeeService,
+		exchangeRateService: exchangeRateService,
+		debtService:         debtService,
+	}
+}
+
+// categoryUsage returns counts of transactions, budgets and recurrences which refer to category.
+// Transaction is counted once even if several of its splits are in category. Goals and payees
+// refer to category optionally, so they don't prevent it from deletion.
+func (r *References) categoryUsage(c *model.Category) usage {
+	res := usage{}
+	if r == nil {
+		return res
+	}
+
+	res.add("transactions", len(r.categoryTransactions(c)))
+	res.add("budgets", len(r.categoryBudgets(c)))
+	res.add("recurrences", len(r.categoryRecurrences(c)))
+
+	return res
+}
+
+// categoryLocked returns count of reconciled transactions in category or with a split in it. They
+// are locked, so policy can't delete or reassign them. Transactions of subcategories are counted on
+// cascade, since subcategories are deleted along with category.
+func (r *References) categoryLocked(c *model.Category, action DeleteAction) usage {
+	res := usage{}
+	if r == nil {
+		return res
+	}
+
+	within := func(e *model.Category) bool {
+		if action == Cascade {
+			return r.categoryService.IsWithin(e, c)
+		}
+		return e != nil && e.ID == c.ID
+	}
+
+	res.add("reconciled transactions", len(filter(r.transactionService.GetAll(), func(t *model.Transaction) bool {
+		if t.Status != model.Reconciled {
+			return false
+		}
+		if within(t.Category) {
+			return true
+		}
+		for _, split := range t.Splits {
+			if within(split.Category) {
+				return true
+			}
+		}
+		return false
+	})))
+
+	return res
+}
+
+// releaseCategory deletes or reassigns the data which refers to category according to policy.
+// Deleted transaction is moved to trash along with all its splits. Goals and payees are unlinked
+// from category unless they are reassigned.
+func (r *References) releaseCategory(c *model.Category, policy DeletePolicy[model.Category]) error {
+	if r == nil {
+		return nil
+	}
+
+	target := policy.Target
+	if policy.Action != Reassign {
+		target = nil
+	}
+
+	for _, t := range r.categoryTransactions(c) {
+		if err := r.releaseTransaction(t, policy.Action, func(t *model.Transaction) {
+			if t.Category != nil && t.Category.ID == c.ID {
+				t.Category = target
+			}
+			for i, split := range t.Splits {
+				if split.Category != nil && split.Category.ID == c.ID {
+					t.Splits[i] = clone(split)
+					t.Splits[i].Category = target
+				}
+			}
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, b := range r.categoryBudgets(c) {
+		var err error
+		if policy.Action == Cascade {
+			err = r.budgetService.Delete(b)
+		} else {
+			b = clone(b)
+			b.Category = target
+			err = r.budgetService.Update(b)
+		}
+		if err != nil {
+			return fmt.Errorf("budget: %w", err)
+		}
+	}
+
+	for _, rec := range r.categoryRecurrences(c) {
+		if err := r.releaseRecurrence(rec, policy.Action, func(t *model.Transaction) { t.Category = target }); err != nil {
+			return err
+		}
+	}
+
+	for _, g := range r.categoryGoals(c) {
+		g = clone(g)
+		g.Category = target
+		if err := r.goalService.Update(g); err != nil {
+			return fmt.Errorf("r.goalService.Update: %w", err)
+		}
+	}
+
+	for _, p := range r.categoryPayees(c) {
+		p = clone(p)
+		p.Category = target
+		if err := r.payeeService.Update(p); err != nil {
+			return fmt.Errorf("r.payeeService.Update: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// accountUsage returns counts of transactions and recurrences which refer to account. Goals refer
+// to account optionally, so they don't prevent it from deletion.
+func (r *References) accountUsage(a *model.Account) usage {
+	res := usage{}
+	if r == nil {
+		return res
+	}
+
+	res.add("transactions", len(r.accountTransactions(a)))
+	res.add("recurrences", len(r.accountRecurrences(a)))
+
+	return res
+}
+
+// accountLocked returns count of reconciled transactions of account. They are locked, so policy
+// can't delete or reassign them.
+func (r *References) accountLocked(a *model.Account) usage {
+	res := usage{}
+	if r == nil {
+		return res
+	}
+
+	res.add("reconciled transactions", len(filter(r.accountTransactions(a), isReconciled)))
+
+	return res
+}
+
+// releaseAccount deletes or reassigns the data which refers to account according to policy. Goals
+// are unlinked from account unless they are reassigned.
+func (r *References) releaseAccount(a *model.Account, policy DeletePolicy[model.Account]) error {
+	if r == nil {
+		return nil
+	}
+
+	target := policy.Target
+	if policy.Action != Reassign {
+		target = nil
+	}
+
+	for _, t := range r.accountTransactions(a) {
+		if err := r.releaseTransaction(t, policy.Action, func(t *model.Transaction) { t.Account = target }); err != nil {
+			return err
+		}
+	}
+
+	for _, rec := range r.accountRecurrences(a) {
+		if err := r.releaseRecurrence(rec, policy.Action, func(t *model.Transaction) { t.Account = target }); err != nil {
+			return err
+		}
+	}
+
+	for _, g := range r.accountGoals(a) {
+		g = clone(g)
+		g.Account = target
+		if err := r.goalService.Update(g); err != nil {
+			return fmt.Errorf("r.goalService.Update: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// currencyUsage returns counts of accounts, exchange rates, goals and debts which refer to
+// currency.
+func (r *References) currencyUsage(c *model.Currency) usage {
+	res := usage{}
+	if r == nil {
+		return res
+	}
+
+	res.add("accounts", len(r.currencyAccounts(c)))
+	res.add("exchange rates", len(r.currencyExchangeRates(c)))
+	res.add("goals", len(r.currencyGoals(c)))
+	res.add("debts", len(r.currencyDebts(c)))
+
+	return res
+}
+
+// releaseCurrency deletes or reassigns the data which refers to currency according to policy.
+// Accounts are deleted along with the data which refers to them. Exchange rates of currency are
+// deleted in both cases, since they make no sense for another currency.
+func (r *References) releaseCurrency(c *model.Currency, policy DeletePolicy[model.Currency]) error {
+	if r == nil {
+		return nil
+	}
+
+	for _, a := range r.currencyAccounts(c) {
+		var err error
+		if policy.Action == Cascade {
+			err = r.accountService.Delete(a, DeletePolicy[model.Account]{Action: Cascade})
+		} else {
+			a = clone(a)
+			a.Currency = policy.Target
+			err = r.accountService.Update(a)
+		}
+		if err != nil {
+			return fmt.Errorf("account %q: %w", a.Name, err)
+		}
+	}
+
+	for _, e := range r.currencyExchangeRates(c) {
+		if err := r.exchangeRateService.Delete(e); err != nil {
+			return fmt.Errorf("r.exchangeRateService.Delete: %w", err)
+		}
+	}
+
+	for _, g := range r.currencyGoals(c) {
+		var err error
+		if policy.Action == Cascade {
+			err = r.goalService.Delete(g)
+		} else {
+			g = clone(g)
+			g.Currency = policy.Target
+			err = r.goalService.Update(g)
+		}
+		if err != nil {
+			return fmt.Errorf("goal %q: %w", g.Name, err)
+		}
+	}
+
+	for _, d := range r.currencyDebts(c) {
+		var err error
+		if policy.Action == Cascade {
+			err = r.debtService.Delete(d)
+		} else {
+			d = clone(d)
+			d.Currency = policy.Target
+			err = r.debtService.Update(d)
+		}
+		if err != nil {
+			return fmt.Errorf("debt: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// counterpartyUsage returns count of debts which refer to counterparty.
+func (r *References) counterpartyUsage(c *model.Counterparty) usage {
+	res := usage{}
+	if r == nil {
+		return res
+	}
+
+	res.add("debts", len(r.counterpartyDebts(c)))
+
+	return res
+}
+
+// releaseCounterparty deletes debts of counterparty or moves them to the target one according to
+// policy.
+func (r *References) releaseCounterparty(c *model.Counterparty, policy DeletePolicy[model.Counterparty]) error {
+	if r == nil || policy.Action == Refuse {
+		return nil
+	}
+
+	for _, d := range r.counterpartyDebts(c) {
+		var err error
+		if policy.Action == Cascade {
+			err = r.debtService.Delete(d)
+		} else {
+			d = clone(d)
+			d.Counterparty = policy.Target
+			err = r.debtService.Update(d)
+		}
+		if err != nil {
+			return fmt.Errorf("debt: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// amountUsage returns counts of accounts, including ones in trash, goals and debts which keep
+// amounts in minor units of currency. Budgets are counted for the main currency, since their
+// limits are kept in it.
+func (r *References) amountUsage(c *model.Currency) (usage, error) {
+	res := usage{}
+	if r == nil {
+		return res, nil
+	}
+
+	res.add("accounts", len(r.currencyAccounts(c)))
+	res.add("goals", len(r.currencyGoals(c)))
+	res.add("debts", len(r.currencyDebts(c)))
+	if c.IsMain {
+		res.add("budgets", len(r.budgetService.GetAll()))
+	}
+
+	tu, err := r.trashAccounts(c)
+	if err != nil {
+		return nil, fmt.Errorf("r.trashAccounts: %w", err)
+	}
+
+	return append(res, tu...), nil
+}
+
+// trashUsage returns count of transactions in trash which refer to account or category with given
+// id, zero id stands for none. Transaction with split in category is counted as well.
+func (r *References) trashUsage(accountID, categoryID int64) (usage, error) {
+	res := usage{}
+	if r == nil {
+		return res, nil
+	}
+
+	tt, err := r.transactionService.getDeletedRefs()
+	if err != nil {
+		return nil, fmt.Errorf("r.transactionService.getDeletedRefs: %w", err)
+	}
+
+	n := 0
+	for _, t := range tt {
+		if t.Account != nil && t.Account.ID == accountID || t.Category != nil && t.Category.ID == categoryID {
+			n++
+			continue
+		}
+		for _, split := range t.Splits {
+			if split.Category != nil && split.Category.ID == categoryID {
+				n++
+				break
+			}
+		}
+	}
+	res.add("transactions in trash", n)
+
+	return res, nil
+}
+
+// trashAccounts returns count of accounts in trash which refer to currency.
+func (r *References) trashAccounts(c *model.Currency) (usage, error) {
+	res := usage{}
+	if r == nil {
+		return res, nil
+	}
+
+	aa, err := r.accountService.getDeletedRefs()
+	if err != nil {
+		return nil, fmt.Errorf("r.accountService.getDeletedRefs: %w", err)
+	}
+
+	res.add("accounts in trash", len(filter(aa, func(a *model.Account) bool {
+		return a.Currency != nil && a.Currency.ID == c.ID
+	})))
+
+	return res, nil
+}
+
+// releaseTransaction deletes transaction or updates its copy changed by reassign. Transaction
+// which is already deleted along with the other leg of transfer is skipped.
+func (r *References) releaseTransaction(t *model.Transaction, action DeleteAction, reassign func(*model.Transaction)) error {
+	if r.transactionService.GetByID(t.ID) == nil {
+		return nil
+	}
+
+	if action == Cascade {
+		if err := r.transactionService.Delete(t); err != nil {
+			return fmt.Errorf("r.transactionService.Delete: %w", err)
+		}
+		return nil
+	}
+
+	t = clone(t)
+	t.Splits = append([]*model.Split(nil), t.Splits...)
+	reassign(t)
+
+	if err := r.transactionService.Update(t); err != nil {
+		return fmt.Errorf("r.transactionService.Update: %w", err)
+	}
+
+	return nil
+}
+
+// releaseRecurrence deletes recurrence or updates its copy which template is changed by reassign.
+func (r *References) releaseRecurrence(rec *model.Recurrence, action DeleteAction, reassign func(*model.Transaction)) error {
+	if action == Cascade {
+		if err := r.recurrenceService.Delete(rec); err != nil {
+			return fmt.Errorf("r.recurrenceService.Delete: %w", err)
+		}
+		return nil
+	}
+
+	rec = clone(rec)
+	rec.Template = clone(rec.Template)
+	reassign(rec.Template)
+
+	if err := r.recurrenceService.Update(rec); err != nil {
+		return fmt.Errorf("r.recurrenceService.Update: %w", err)
+	}
+
+	return nil
+}
+
+// categoryTransactions returns transactions in category or with a split in it.
+func (r *References) categoryTransactions(c *model.Category) []*model.Transaction {
+	return filter(r.transactionService.GetAll(), func(t *model.Transaction) bool {
+		if t.Category != nil && t.Category.ID == c.ID {
+			return true
+		}
+		for _, split := range t.Splits {
+			if split.Category != nil && split.Category.ID == c.ID {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// categoryBudgets returns budgets of category.
+func (r *References) categoryBudgets(c *model.Category) []*model.Budget {
+	return filter(r.budgetService.GetAll(), func(b *model.Budget) bool { return b.Category != nil && b.Category.ID == c.ID })
+}
+
+// categoryRecurrences returns recurrences which create transactions in category.
+func (r *References) categoryRecurrences(c *model.Category) []*model.Recurrence {
+	return filter(r.recurrenceService.GetAll(), func(rec *model.Recurrence) bool {
+		return rec.Template != nil && rec.Template.Category != nil && rec.Template.Category.ID == c.ID
+	})
+}
+
+// categoryGoals returns goals tracked by category.
+func (r *References) categoryGoals(c *model.Category) []*model.Goal {
+	return filter(r.goalService.GetAll(), func(g *model.Goal) bool { return g.Category != nil && g.Category.ID == c.ID })
+}
+
+// categoryPayees returns payees which default category is category.
+func (r *References) categoryPayees(c *model.Category) []*model.Payee {
+	return filter(r.payeeService.GetAll(), func(p *model.Payee) bool { return p.Category != nil && p.Category.ID == c.ID })
+}
+
+// accountTransactions returns transactions of account.
+func (r *References) accountTransactions(a *model.Account) []*model.Transaction {
+	return filter(r.transactionService.GetAll(), func(t *model.Transaction) bool { return t.Account != nil && t.Account.ID == a.ID })
+}
+
+// accountRecurrences returns recurrences which create transactions of account.
+func (r *References) accountRecurrences(a *model.Account) []*model.Recurrence {
+	return filter(r.recurrenceService.GetAll(), func(rec *model.Recurrence) bool {
+		return rec.Template != nil && rec.Template.Account != nil && rec.Template.Account.ID == a.ID
+	})
+}
+
+// accountGoals returns goals tracked by account.
+func (r *References) accountGoals(a *model.Account) []*model.Goal {
+	return filter(r.goalService.GetAll(), func(g *model.Goal) bool { return g.Account != nil && g.Account.ID == a.ID })
+}
+
+// currencyAccounts returns accounts in currency.
+func (r *References) currencyAccounts(c *model.Currency) []*model.Account {
+	return filter(r.accountService.GetAll(), func(a *model.Account) bool { return a.Currency != nil && a.Currency.ID == c.ID })
+}
+
+// currencyExchangeRates returns exchange rates from or to currency.
+func (r *References) currencyExchangeRates(c *model.Currency) []*model.ExchangeRate {
+	return filter(r.exchangeRateService.GetAll(), func(e *model.ExchangeRate) bool {
+		return e.From != nil && e.From.ID == c.ID || e.To != nil && e.To.ID == c.ID
+	})
+}
+
+// currencyGoals returns goals in currency.
+func (r *References) currencyGoals(c *model.Currency) []*model.Goal {
+	return filter(r.goalService.GetAll(), func(g *model.Goal) bool { return g.Currency != nil && g.Currency.ID == c.ID })
+}
+
+// currencyDebts returns debts in currency.
+func (r *References) currencyDebts(c *model.Currency) []*model.Debt {
+	return filter(r.debtService.GetAll(), func(d *model.Debt) bool { return d.Currency != nil && d.Currency.ID == c.ID })
+}
+
+// counterpartyDebts returns debts of counterparty.
+func (r *References) counterpartyDebts(c *model.Counterparty) []*model.Debt {
+	return filter(r.debtService.GetAll(), func(d *model.Debt) bool {
+		return d.Counterparty != nil && d.Counterparty.ID == c.ID
+	})
+}
+
+// isReconciled returns true if transaction is reconciled.
+func isReconciled(t *model.Transaction) bool {
+	return t.Status == model.Reconciled
+}
+
+// filter returns elements of ee which match fn.
+func filter[T any](ee []*T, fn func(*T) bool) []*T {
+	res := make([]*T, 0)
+
+	for _, e := range ee {
+		if fn(e) {
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,381 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReferencesServiceTestSuite struct {
+	suite.Suite
+	db                *sql.DB
+	persistentStorage *sqlite.SqliteStorage
+	service           *service.Service
+	usd               *model.Currency
+	eur               *model.Currency
+	cash              *model.Account
+	card              *model.Account
+	euro              *model.Account
+	grocery           *model.Category
+	bakery            *model.Category
+	health            *model.Category
+	bread             *model.Transaction
+	milk              *model.Transaction
+	payee             *model.Payee
+}
+
+func (s *ReferencesServiceTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	db.SetMaxOpenConns(1)
+	s.db = db
+
+	s.persistentStorage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupTest")
+
+	s.usd = &model.Currency{Abbreviation: "USD", Precision: 2}
+	s.eur = &model.Currency{Abbreviation: "EUR", Precision: 2}
+	for _, c := range []*model.Currency{s.usd, s.eur} {
+		require.NoError(s.T(), s.service.Currency().Insert(c), "occurred in SetupTest")
+	}
+
+	s.cash = &model.Account{Name: "Cash", Currency: s.usd}
+	s.card = &model.Account{Name: "Card", Currency: s.usd}
+	s.euro = &model.Account{Name: "Euro", Currency: s.eur}
+	for _, a := range []*model.Account{s.cash, s.card, s.euro} {
+		require.NoError(s.T(), s.service.Account().Insert(a), "occurred in SetupTest")
+	}
+
+	s.grocery = &model.Category{Title: "Grocery", Kind: model.Expense}
+	s.bakery = &model.Category{Title: "Bakery", Parent: s.grocery, Kind: model.Expense}
+	s.health = &model.Category{Title: "Health", Kind: model.Expense}
+	for _, c := range []*model.Category{s.grocery, s.bakery, s.health} {
+		require.NoError(s.T(), s.service.Category().Insert(c), "occurred in SetupTest")
+	}
+
+	s.payee = &model.Payee{Name: "Bakery", Category: s.bakery}
+	require.NoError(s.T(), s.service.Payee().Insert(s.payee), "occurred in SetupTest")
+
+	s.bread = &model.Transaction{Date: date(2022, 2, 21), Account: s.cash, Category: s.bakery, Payee: s.payee,
+		Amount: -1250, Note: "bread"}
+	s.milk = &model.Transaction{Date: date(2022, 2, 22), Account: s.cash, Category: s.grocery, Amount: -300,
+		Note: "milk"}
+	for _, t := range []*model.Transaction{s.bread, s.milk} {
+		require.NoError(s.T(), s.service.Transaction().Insert(t), "occurred in SetupTest")
+	}
+
+	require.NoError(s.T(), s.service.Budget().Insert(
+		&model.Budget{Category: s.grocery, Period: date(2022, 2, 1), Limit: 10000}), "occurred in SetupTest")
+	require.NoError(s.T(), s.service.Recurrence().Insert(&model.Recurrence{Rule: model.Weekly, Start: date(2022, 2, 1),
+		Template: &model.Transaction{Account: s.cash, Category: s.grocery, Amount: -5000, Note: "groceries"}}),
+		"occurred in SetupTest")
+}
+
+func (s *ReferencesServiceTestSuite) TestRefuse() {
+	err := s.service.Category().Delete(s.grocery, service.DeletePolicy[model.Category]{})
+	assert.ErrorIs(s.T(), err, service.ErrInUse)
+	assert.EqualError(s.T(), err,
+		`category "Grocery" is used by subcategories (1), transactions (1), budgets (1), recurrences (1)`)
+
+	var inUse *service.InUseError
+	require.ErrorAs(s.T(), s.service.Account().Delete(s.cash, service.DeletePolicy[model.Account]{}), &inUse)
+	assert.Equal(s.T(), []service.Usage{{Kind: "transactions", Count: 2}, {Kind: "recurrences", Count: 1}}, inUse.Usage)
+
+	err = s.service.Currency().Delete(s.eur, service.DeletePolicy[model.Currency]{})
+	assert.EqualError(s.T(), err, `currency "EUR" is used by accounts (1)`)
+
+	// nothing is changed by refused deletion
+	assert.Len(s.T(), s.service.Category().GetAll(), 3)
+	assert.Len(s.T(), s.service.Account().GetAll(), 3)
+	assert.Len(s.T(), s.service.Currency().GetAll(), 2)
+	assert.Len(s.T(), s.service.Transaction().GetAll(), 2)
+
+	// payee refers to category optionally, so it is unlinked
+	require.NoError(s.T(), s.service.Transaction().Delete(s.bread))
+	require.NoError(s.T(), s.service.Category().Delete(s.bakery, service.DeletePolicy[model.Category]{}))
+	assert.Nil(s.T(), s.service.Payee().GetByName("Bakery").Category)
+}
+
+func (s *ReferencesServiceTestSuite) TestCascade() {
+	err := s.service.Category().Delete(s.grocery, service.DeletePolicy[model.Category]{Action: service.Cascade})
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), []*model.Category{s.health}, s.service.Category().GetAll())
+	assert.Empty(s.T(), s.service.Transaction().GetAll())
+	assert.Empty(s.T(), s.service.Budget().GetAll())
+	assert.Empty(s.T(), s.service.Recurrence().GetAll())
+	assert.Nil(s.T(), s.service.Payee().GetByName("Bakery").Category)
+
+	// deletion is undone at once along with the data deleted with it
+	_, err = s.service.History().Undo()
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), s.service.Init())
+
+	assert.Len(s.T(), s.service.Category().GetAll(), 3)
+	assert.Len(s.T(), s.service.Transaction().GetAll(), 2)
+	assert.Len(s.T(), s.service.Budget().GetAll(), 1)
+	assert.Len(s.T(), s.service.Recurrence().GetAll(), 1)
+	assert.Equal(s.T(), "Bakery", s.service.Payee().GetByName("Bakery").Category.Title)
+
+	err = s.service.Currency().Delete(s.eur, service.DeletePolicy[model.Currency]{Action: service.Cascade})
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), s.service.Account().GetByName("Euro"))
+
+	err = s.service.Currency().Delete(s.usd, service.DeletePolicy[model.Currency]{Action: service.Cascade})
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), s.service.Account().GetAll())
+	assert.Empty(s.T(), s.service.Transaction().GetAll())
+}
+
+func (s *ReferencesServiceTestSuite) TestReassign() {
+	err := s.service.Category().Delete(s.grocery,
+		service.DeletePolicy[model.Category]{Action: service.Reassign, Target: s.health})
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), "Health", s.service.Transaction().GetByID(s.milk.ID).Category.Title)
+	assert.Equal(s.T(), "Health / Bakery", s.service.Category().Path(s.service.Category().GetByTitle("Bakery")))
+	assert.Equal(s.T(), "Health", s.service.Budget().GetAll()[0].Category.Title)
+	assert.Equal(s.T(), "Health", s.service.Recurrence().GetAll()[0].Template.Category.Title)
+
+	err = s.service.Account().Delete(s.cash,
+		service.DeletePolicy[model.Account]{Action: service.Reassign, Target: s.card})
+	require.NoError(s.T(), err)
+
+	for _, t := range s.service.Transaction().GetAll() {
+		assert.Equal(s.T(), "Card", t.Account.Name)
+	}
+	assert.Equal(s.T(), "Card", s.service.Recurrence().GetAll()[0].Template.Account.Name)
+
+	// main currency is passed to the target one
+	err = s.service.Currency().Delete(s.usd,
+		service.DeletePolicy[model.Currency]{Action: service.Reassign, Target: s.eur})
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), "EUR", s.service.Currency().GetMain().Abbreviation)
+	assert.Equal(s.T(), "EUR", s.service.Account().GetByName("Card").Currency.Abbreviation)
+}
+
+func (s *ReferencesServiceTestSuite) TestReassignNegative() {
+	reassign := service.DeletePolicy[model.Category]{Action: service.Reassign}
+	assert.ErrorIs(s.T(), s.service.Category().Delete(s.grocery, reassign), service.ErrNoReassignTarget)
+
+	reassign.Target = s.bakery
+	assert.ErrorIs(s.T(), s.service.Category().Delete(s.grocery, reassign), service.ErrReassignToItself)
+
+	err := s.service.Account().Delete(s.cash,
+		service.DeletePolicy[model.Account]{Action: service.Reassign, Target: s.euro})
+	assert.EqualError(s.T(), err, `account "Euro" has another currency`)
+
+	yen := &model.Currency{Abbreviation: "JPY", Precision: 0}
+	require.NoError(s.T(), s.service.Currency().Insert(yen))
+	err = s.service.Currency().Delete(s.eur,
+		service.DeletePolicy[model.Currency]{Action: service.Reassign, Target: yen})
+	assert.EqualError(s.T(), err, `currency "JPY" has another precision`)
+
+	// reconciled transaction can't be moved, so nothing is changed
+	reconciled := *s.milk
+	reconciled.Status = model.Reconciled
+	require.NoError(s.T(), s.service.Transaction().Update(&reconciled))
+
+	err = s.service.Category().Delete(s.grocery, service.DeletePolicy[model.Category]{Action: service.Cascade})
+	assert.ErrorIs(s.T(), err, service.ErrInUse)
+	assert.Len(s.T(), s.service.Category().GetAll(), 3)
+	assert.Len(s.T(), s.service.Transaction().GetAll(), 2)
+}
+
+func (s *ReferencesServiceTestSuite) TestReconciledLocked() {
+	require.NoError(s.T(), s.service.Transaction().SetStatus(s.bread, model.Reconciled))
+
+	err := s.service.Account().Delete(s.cash, service.DeletePolicy[model.Account]{Action: service.Cascade})
+	assert.EqualError(s.T(), err, `account "Cash" is used by reconciled transactions (1)`)
+
+	err = s.service.Account().Delete(s.cash,
+		service.DeletePolicy[model.Account]{Action: service.Reassign, Target: s.card})
+	assert.EqualError(s.T(), err, `account "Cash" is used by reconciled transactions (1)`)
+
+	// transactions of subcategory are deleted on cascade, while on reassign they are kept
+	err = s.service.Category().Delete(s.grocery, service.DeletePolicy[model.Category]{Action: service.Cascade})
+	assert.EqualError(s.T(), err, `category "Grocery" is used by reconciled transactions (1)`)
+
+	err = s.service.Category().Delete(s.bakery,
+		service.DeletePolicy[model.Category]{Action: service.Reassign, Target: s.health})
+	assert.EqualError(s.T(), err, `category "Bakery" is used by reconciled transactions (1)`)
+
+	assert.Len(s.T(), s.service.Account().GetAll(), 3)
+	assert.Len(s.T(), s.service.Category().GetAll(), 3)
+	assert.Len(s.T(), s.service.Transaction().GetAll(), 2)
+	assert.Equal(s.T(), "Bakery", s.service.Transaction().GetByID(s.bread.ID).Category.Title)
+
+	err = s.service.Category().Delete(s.grocery,
+		service.DeletePolicy[model.Category]{Action: service.Reassign, Target: s.health})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Health / Bakery", s.service.Category().Path(s.service.Category().GetByTitle("Bakery")))
+}
+
+func (s *ReferencesServiceTestSuite) TestPurge() {
+	err := s.service.Category().Delete(s.grocery, service.DeletePolicy[model.Category]{Action: service.Cascade})
+	require.NoError(s.T(), err)
+
+	err = s.service.Trash().Purge(&model.TrashItem{Kind: model.TrashCategory, ID: s.grocery.ID})
+	assert.ErrorIs(s.T(), err, service.ErrInUse)
+	assert.EqualError(s.T(), err,
+		`category "Grocery" is used by subcategories in trash (1), transactions in trash (1)`)
+
+	// items are purged after the ones which refer to them
+	n, err := s.service.Trash().PurgeBefore(time.Now().Add(time.Hour))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 4, n)
+
+	items, err := s.service.Trash().GetAll()
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), items)
+}
+
+func (s *ReferencesServiceTestSuite) TestChangePrecision() {
+	usd := *s.usd
+	usd.Precision = 3
+	err := s.service.Currency().Update(&usd)
+	assert.ErrorIs(s.T(), err, service.ErrInUse)
+	assert.ErrorContains(s.T(), err, `precision of currency "USD" is used by accounts (2), budgets (1)`)
+	assert.Equal(s.T(), int64(2), s.service.Currency().GetByID(s.usd.ID).Precision)
+
+	// accounts in trash keep amounts in the currency as well
+	err = s.service.Account().Delete(s.euro, service.DeletePolicy[model.Account]{})
+	require.NoError(s.T(), err)
+
+	eur := *s.eur
+	eur.Precision = 0
+	err = s.service.Currency().Update(&eur)
+	assert.ErrorContains(s.T(), err, `precision of currency "EUR" is used by accounts in trash (1)`)
+
+	// precision of unused currency is changed freely
+	yen := &model.Currency{Abbreviation: "JPY", Precision: 2}
+	require.NoError(s.T(), s.service.Currency().Insert(yen))
+	require.NoError(s.T(), s.service.Currency().Update(&model.Currency{ID: yen.ID, Abbreviation: "JPY"}))
+	assert.Equal(s.T(), int64(0), s.service.Currency().GetByAbbreviation("JPY").Precision)
+}
+
+func (s *ReferencesServiceTestSuite) TestDeleteCounterparty() {
+	debt := &model.Debt{Counterparty: &model.Counterparty{Name: "John"}, Direction: model.Given, Principal: 700,
+		Currency: s.usd, Date: date(2022, 2, 1)}
+	require.NoError(s.T(), s.service.Debt().Insert(debt))
+	bank := &model.Counterparty{Name: "Bank"}
+	require.NoError(s.T(), s.service.Counterparty().Insert(bank))
+	john := s.service.Counterparty().GetByName("John")
+
+	err := s.service.Counterparty().Delete(john, service.DeletePolicy[model.Counterparty]{})
+	assert.EqualError(s.T(), err, `counterparty "John" is used by debts (1)`)
+
+	err = s.service.Counterparty().Delete(john,
+		service.DeletePolicy[model.Counterparty]{Action: service.Reassign, Target: john})
+	assert.ErrorIs(s.T(), err, service.ErrReassignToItself)
+
+	err = s.service.Counterparty().Delete(john,
+		service.DeletePolicy[model.Counterparty]{Action: service.Reassign, Target: bank})
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), s.service.Counterparty().GetByName("John"))
+	assert.Equal(s.T(), "Bank", s.service.Debt().GetByID(debt.ID).Counterparty.Name)
+
+	err = s.service.Counterparty().Delete(bank, service.DeletePolicy[model.Counterparty]{Action: service.Cascade})
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), s.service.Counterparty().GetAll())
+	assert.Empty(s.T(), s.service.Debt().GetAll())
+
+	// debt is brought back along with its counterparty
+	_, err = s.service.History().Undo()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), s.service.Debt().GetAll(), 1)
+	assert.Equal(s.T(), "Bank", s.service.Debt().GetAll()[0].Counterparty.Name)
+}
+
+func (s *ReferencesServiceTestSuite) TestDeletePayee() {
+	require.NoError(s.T(), s.service.Payee().Delete(s.payee))
+
+	// transaction is unlinked in both storages, so update doesn't bring payee back
+	bread := s.service.Transaction().GetByID(s.bread.ID)
+	assert.Nil(s.T(), bread.Payee)
+	require.NoError(s.T(), s.service.Transaction().Update(bread))
+	assert.Empty(s.T(), s.service.Payee().GetAll())
+
+	tt, err := s.persistentStorage.Transaction().GetAll()
+	require.NoError(s.T(), err)
+	for _, t := range tt {
+		assert.Nil(s.T(), t.Payee)
+	}
+
+	// undo of update and then of deletion links transaction back
+	for i := 0; i < 2; i++ {
+		_, err = s.service.History().Undo()
+		require.NoError(s.T(), err)
+	}
+
+	payee := s.service.Payee().GetByName("Bakery")
+	require.NotNil(s.T(), payee)
+	assert.Same(s.T(), payee, s.service.Transaction().GetByID(s.bread.ID).Payee)
+
+	require.NoError(s.T(), s.service.Init())
+	assert.Equal(s.T(), "Bakery", s.service.Transaction().GetByID(s.bread.ID).Payee.Name)
+}
+
+func (s *ReferencesServiceTestSuite) TestDanglingReferences() {
+	// account and category moved to trash behind the service, like ones deleted before references
+	// were kept, leave transactions pointing to nothing
+	require.NoError(s.T(), s.persistentStorage.Account().Delete(s.cash.ID))
+	require.NoError(s.T(), s.persistentStorage.Category().Delete(s.bakery.ID))
+	require.NoError(s.T(), s.service.Init())
+
+	tt := s.service.Transaction().GetAll()
+	require.Len(s.T(), tt, 2)
+	for _, t := range tt {
+		assert.Nil(s.T(), t.Account)
+	}
+
+	total, err := s.service.ExchangeRate().TotalInMain(tt)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), total)
+
+	totals, err := s.service.Transaction().TotalsByKind(tt)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), totals)
+
+	card := s.service.Account().GetByName("Card")
+	register, err := s.service.Transaction().Register(card)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), register)
+
+	balance, err := s.service.Transaction().BalanceAt(card, date(2022, 3, 1))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), balance)
+
+	cleared, err := s.service.Transaction().ClearedBalanceAt(card, date(2022, 3, 1))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), cleared)
+
+	spent, err := s.service.Budget().Spent(s.service.Budget().GetAll()[0])
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), spent)
+
+	assert.False(s.T(), s.service.Category().IsWithin(nil, s.health))
+}
+
+func (s *ReferencesServiceTestSuite) TearDownTest() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func TestReferencesServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReferencesServiceTestSuite))
+}
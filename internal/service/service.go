@@ -2,21 +2,31 @@ package service
 
 import (
 	"fmt"
-
-	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
-	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
 )
 
 // Service is a facade structure which aggregates all Services. It is used for convenience.
 type Service struct {
-	category    *Category
-	currency    *Currency
-	account     *Account
-	transaction *Transaction
+	category     *Category
+	currency     *Currency
+	account      *Account
+	transaction  *Transaction
+	exchangeRate *ExchangeRate
+	budget       *Budget
+	recurrence   *Recurrence
+	tag          *Tag
+	payee        *Payee
+	attachment   *Attachment
+	goal         *Goal
+	counterparty *Counterparty
+	debt         *Debt
+	trash        *Trash
+	references   *References
+	history      *History
+	unitOfWork   *UnitOfWork
 }
 
-// New returns new Service.
-func New(ps *sqlite.SqliteStorage, is *inmemory.InmemoryStorage) (s *Service, err error) {
+// New returns new Service, attached files are kept in given attachmentDir.
+func New(ps PersistentStorage, is InmemoryStorage, attachmentDir string) (s *Service, err error) {
 	s = &Service{}
 
 	if s.category, err = NewCategory(ps.Category(), is.Category()); err != nil {
@@ -28,13 +38,129 @@ func New(ps *sqlite.SqliteStorage, is *inmemory.InmemoryStorage) (s *Service, er
 	if s.account, err = NewAccount(ps.Account(), is.Account(), s.currency); err != nil {
 		return nil, fmt.Errorf("NewAccount: %w", err)
 	}
-	if s.transaction, err = NewTransaction(ps.Transaction(), is.Transaction(), s.category, s.account); err != nil {
+	if s.tag, err = NewTag(ps.Tag(), is.Tag()); err != nil {
+		return nil, fmt.Errorf("NewTag: %w", err)
+	}
+	if s.payee, err = NewPayee(ps.Payee(), is.Payee(), s.category); err != nil {
+		return nil, fmt.Errorf("NewPayee: %w", err)
+	}
+	if s.attachment, err = NewAttachment(ps.Attachment(), is.Attachment(), attachmentDir); err != nil {
+		return nil, fmt.Errorf("NewAttachment: %w", err)
+	}
+	if s.transaction, err = NewTransaction(
+		ps.Transaction(), is.Transaction(), ps.Transfer(), is.Transfer(), ps.Split(), ps.Debt(), is.Debt(),
+		s.category, s.account, s.tag, s.payee, s.attachment); err != nil {
 		return nil, fmt.Errorf("NewTransaction: %w", err)
 	}
+	if s.exchangeRate, err = NewExchangeRate(ps.ExchangeRate(), is.ExchangeRate(), s.currency); err != nil {
+		return nil, fmt.Errorf("NewExchangeRate: %w", err)
+	}
+	if s.budget, err = NewBudget(
+		ps.Budget(), is.Budget(), s.category, s.transaction, s.exchangeRate); err != nil {
+		return nil, fmt.Errorf("NewBudget: %w", err)
+	}
+	if s.recurrence, err = NewRecurrence(
+		ps.Recurrence(), is.Recurrence(), s.category, s.account, s.transaction); err != nil {
+		return nil, fmt.Errorf("NewRecurrence: %w", err)
+	}
+	if s.goal, err = NewGoal(
+		ps.Goal(), is.Goal(), s.currency, s.account, s.category, s.transaction, s.exchangeRate); err != nil {
+		return nil, fmt.Errorf("NewGoal: %w", err)
+	}
+	if s.counterparty, err = NewCounterparty(ps.Counterparty(), is.Counterparty()); err != nil {
+		return nil, fmt.Errorf("NewCounterparty: %w", err)
+	}
+	if s.debt, err = NewDebt(
+		ps.Debt(), is.Debt(), s.currency, s.counterparty, s.transaction, s.exchangeRate); err != nil {
+		return nil, fmt.Errorf("NewDebt: %w", err)
+	}
+	s.trash = NewTrash(s.category, s.currency, s.account, s.transaction, s.Init)
+	s.references = NewReferences(s.category, s.currency, s.account, s.transaction, s.budget,
+		s.recurrence, s.goal, s.payee, s.exchangeRate, s.debt)
+	s.category.references = s.references
+	s.currency.references = s.references
+	s.account.references = s.references
+	s.counterparty.references = s.references
+	s.payee.transactionService = s.transaction
+	s.transaction.exchangeRateService = s.exchangeRate
+	s.exchangeRate.transactionService = s.transaction
+
+	// services share the history, so operation nested into another one isn't recorded twice.
+	s.history = NewHistory()
+	for _, h := range []**History{
+		&s.category.history, &s.currency.history, &s.account.history, &s.transaction.history,
+		&s.exchangeRate.history, &s.budget.history, &s.recurrence.history, &s.payee.history,
+		&s.goal.history, &s.counterparty.history, &s.debt.history,
+	} {
+		*h = s.history
+	}
+
+	s.unitOfWork = NewUnitOfWork(ps, s.history, s.Init)
+	s.history.unitOfWork = s.unitOfWork
+	s.category.unitOfWork = s.unitOfWork
+	s.currency.unitOfWork = s.unitOfWork
+	s.account.unitOfWork = s.unitOfWork
+	s.transaction.unitOfWork = s.unitOfWork
+	s.recurrence.unitOfWork = s.unitOfWork
+	s.payee.unitOfWork = s.unitOfWork
+	s.counterparty.unitOfWork = s.unitOfWork
+	s.attachment.unitOfWork = s.unitOfWork
 
 	return s, nil
 }
 
+// Init reinitializes inmemory storages of all services with data from persistent storage, so all
+// the links between models are rebuilt.
+func (s *Service) Init() error {
+	if err := s.category.Init(); err != nil {
+		return fmt.Errorf("s.category.Init: %w", err)
+	}
+	if err := s.currency.Init(); err != nil {
+		return fmt.Errorf("s.currency.Init: %w", err)
+	}
+	if err := s.account.Init(s.currency); err != nil {
+		return fmt.Errorf("s.account.Init: %w", err)
+	}
+	if err := s.tag.Init(); err != nil {
+		return fmt.Errorf("s.tag.Init: %w", err)
+	}
+	if err := s.payee.Init(); err != nil {
+		return fmt.Errorf("s.payee.Init: %w", err)
+	}
+	if err := s.attachment.Init(); err != nil {
+		return fmt.Errorf("s.attachment.Init: %w", err)
+	}
+	if err := s.transaction.Init(s.category, s.account); err != nil {
+		return fmt.Errorf("s.transaction.Init: %w", err)
+	}
+	if err := s.exchangeRate.Init(); err != nil {
+		return fmt.Errorf("s.exchangeRate.Init: %w", err)
+	}
+	if err := s.budget.Init(s.category); err != nil {
+		return fmt.Errorf("s.budget.Init: %w", err)
+	}
+	if err := s.recurrence.Init(s.category, s.account); err != nil {
+		return fmt.Errorf("s.recurrence.Init: %w", err)
+	}
+	if err := s.goal.Init(s.currency, s.account); err != nil {
+		return fmt.Errorf("s.goal.Init: %w", err)
+	}
+	if err := s.counterparty.Init(); err != nil {
+		return fmt.Errorf("s.counterparty.Init: %w", err)
+	}
+	if err := s.debt.Init(s.currency); err != nil {
+		return fmt.Errorf("s.debt.Init: %w", err)
+	}
+
+	return nil
+}
+
+// WithTx runs fn as a single unit of work, so changes made through services inside of fn are
+// either all applied or none of them. See UnitOfWork for details.
+func (s *Service) WithTx(fn func() error) error {
+	return s.unitOfWork.Do(fn)
+}
+
 // Category returns category service.
 func (s *Service) Category() *Category {
 	return s.category
@@ -54,3 +180,58 @@ func (s *Service) Account() *Account {
 func (s *Service) Transaction() *Transaction {
 	return s.transaction
 }
+
+// ExchangeRate returns exchange rate service.
+func (s *Service) ExchangeRate() *ExchangeRate {
+	return s.exchangeRate
+}
+
+// Budget returns budget service.
+func (s *Service) Budget() *Budget {
+	return s.budget
+}
+
+// Recurrence returns recurrence service.
+func (s *Service) Recurrence() *Recurrence {
+	return s.recurrence
+}
+
+// Tag returns tag service.
+func (s *Service) Tag() *Tag {
+	return s.tag
+}
+
+// Payee returns payee service.
+func (s *Service) Payee() *Payee {
+	return s.payee
+}
+
+// Attachment returns attachment service.
+func (s *Service) Attachment() *Attachment {
+	return s.attachment
+}
+
+// Goal returns goal service.
+func (s *Service) Goal() *Goal {
+	return s.goal
+}
+
+// Counterparty returns counterparty service.
+func (s *Service) Counterparty() *Counterparty {
+	return s.counterparty
+}
+
+// Debt returns debt service.
+func (s *Service) Debt() *Debt {
+	return s.debt
+}
+
+// Trash returns trash service.
+func (s *Service) Trash() *Trash {
+	return s.trash
+}
+
+// History returns history of operations which could be undone.
+func (s *Service) History() *History {
+	return s.history
+}
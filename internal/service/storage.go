@@ -0,0 +1,348 @@
+package service
+
+import (
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// PersistentStorage provides persistent storages of all the entities, it is implemented by each
+// persistent backend. Changes made through the storages inside of InTx are committed only if fn
+// succeeds.
+type PersistentStorage interface {
+	Category() CategoryPersistentStorage
+	Currency() CurrencyPersistentStorage
+	Account() AccountPersistentStorage
+	Transaction() TransactionPersistentStorage
+	Transfer() TransferPersistentStorage
+	Split() SplitPersistentStorage
+	ExchangeRate() ExchangeRatePersistentStorage
+	Budget() BudgetPersistentStorage
+	Recurrence() RecurrencePersistentStorage
+	Tag() TagPersistentStorage
+	Payee() PayeePersistentStorage
+	Attachment() AttachmentPersistentStorage
+	Goal() GoalPersistentStorage
+	Counterparty() CounterpartyPersistentStorage
+	Debt() DebtPersistentStorage
+	InTx(fn func() error) error
+}
+
+// InmemoryStorage provides inmemory storages of all the entities.
+type InmemoryStorage interface {
+	Category() CategoryInmemoryStorage
+	Currency() CurrencyInmemoryStorage
+	Account() AccountInmemoryStorage
+	Transaction() TransactionInmemoryStorage
+	Transfer() TransferInmemoryStorage
+	ExchangeRate() ExchangeRateInmemoryStorage
+	Budget() BudgetInmemoryStorage
+	Recurrence() RecurrenceInmemoryStorage
+	Tag() TagInmemoryStorage
+	Payee() PayeeInmemoryStorage
+	Attachment() AttachmentInmemoryStorage
+	Goal() GoalInmemoryStorage
+	Counterparty() CounterpartyInmemoryStorage
+	Debt() DebtInmemoryStorage
+}
+
+// CategoryPersistentStorage stores categories persistently. Deleted categories are kept in trash
+// until purged.
+type CategoryPersistentStorage interface {
+	Insert(c *model.Category) (int64, error)
+	Update(c *model.Category) error
+	Delete(id int64) error
+	Restore(id int64) error
+	Purge(id int64) error
+	GetAll() ([]*model.Category, error)
+	GetDeleted() ([]*model.Category, error)
+}
+
+// CategoryInmemoryStorage keeps categories in memory for fast access.
+type CategoryInmemoryStorage interface {
+	Init(cc []*model.Category)
+	Insert(c *model.Category)
+	Update(c *model.Category)
+	Delete(c *model.Category)
+	GetAll() []*model.Category
+	GetByID(id int64) *model.Category
+	GetByTitle(title string) *model.Category
+}
+
+// CurrencyPersistentStorage stores currencies persistently. Deleted currencies are kept in trash
+// until purged.
+type CurrencyPersistentStorage interface {
+	Insert(c *model.Currency) (int64, error)
+	Update(c *model.Currency) error
+	Delete(id int64) error
+	Restore(id int64) error
+	Purge(id int64) error
+	GetAll() ([]*model.Currency, error)
+	GetDeleted() ([]*model.Currency, error)
+	SetMain(id int64) error
+}
+
+// CurrencyInmemoryStorage keeps currencies in memory for fast access.
+type CurrencyInmemoryStorage interface {
+	Init(cc []*model.Currency)
+	Insert(c *model.Currency)
+	Update(c *model.Currency)
+	Delete(c *model.Currency)
+	GetAll() []*model.Currency
+	GetByID(id int64) *model.Currency
+	GetByAbbreviation(abbreviation string) *model.Currency
+	GetMain() *model.Currency
+	SetMain(id int64)
+}
+
+// AccountPersistentStorage stores accounts persistently. Deleted accounts are kept in trash until
+// purged.
+type AccountPersistentStorage interface {
+	Insert(a *model.Account) (int64, error)
+	Update(a *model.Account) error
+	Delete(id int64) error
+	Restore(id int64) error
+	Purge(id int64) error
+	GetAll() ([]*model.Account, error)
+	GetDeleted() ([]*model.Account, error)
+}
+
+// AccountInmemoryStorage keeps accounts in memory for fast access.
+type AccountInmemoryStorage interface {
+	Init(aa []*model.Account)
+	Insert(a *model.Account)
+	Update(a *model.Account)
+	Delete(a *model.Account)
+	GetAll() []*model.Account
+	GetByID(id int64) *model.Account
+	GetByName(name string) *model.Account
+}
+
+// TransactionPersistentStorage stores transactions persistently. Deleted transactions are kept in
+// trash until purged.
+type TransactionPersistentStorage interface {
+	Insert(t *model.Transaction) (int64, error)
+	Update(t *model.Transaction) error
+	Delete(id int64) error
+	Restore(id int64) error
+	Purge(id int64) error
+	GetAll() ([]*model.Transaction, error)
+	GetDeleted() ([]*model.Transaction, error)
+}
+
+// TransactionInmemoryStorage keeps transactions in memory for fast access.
+type TransactionInmemoryStorage interface {
+	Init(tt []*model.Transaction)
+	Insert(t *model.Transaction)
+	Update(t *model.Transaction)
+	Delete(t *model.Transaction)
+	GetAll() []*model.Transaction
+	GetByID(id int64) *model.Transaction
+}
+
+// TransferPersistentStorage stores transfers persistently. Deleted transfers are kept in trash
+// until purged.
+type TransferPersistentStorage interface {
+	Insert(t *model.Transfer) (int64, error)
+	Update(t *model.Transfer) error
+	Delete(id int64) error
+	Restore(id int64) error
+	Purge(id int64) error
+	GetAll() ([]*model.Transfer, error)
+}
+
+// TransferInmemoryStorage keeps transfers in memory for fast access.
+type TransferInmemoryStorage interface {
+	Init(tt []*model.Transfer)
+	Insert(t *model.Transfer)
+	Update(t *model.Transfer)
+	Delete(t *model.Transfer)
+	GetAll() []*model.Transfer
+	GetByID(id int64) *model.Transfer
+	GetByTransactionID(id int64) *model.Transfer
+}
+
+// SplitPersistentStorage stores splits of transactions persistently.
+type SplitPersistentStorage interface {
+	GetAll() ([]*model.Split, error)
+	SetTransactionSplits(transactionID int64, ss []*model.Split) error
+}
+
+// ExchangeRatePersistentStorage stores exchange rates persistently.
+type ExchangeRatePersistentStorage interface {
+	Insert(r *model.ExchangeRate) (int64, error)
+	Update(r *model.ExchangeRate) error
+	Delete(id int64) error
+	GetAll() ([]*model.ExchangeRate, error)
+}
+
+// ExchangeRateInmemoryStorage keeps exchange rates in memory for fast access.
+type ExchangeRateInmemoryStorage interface {
+	Init(rr []*model.ExchangeRate)
+	Insert(r *model.ExchangeRate)
+	Update(r *model.ExchangeRate)
+	Delete(r *model.ExchangeRate)
+	GetAll() []*model.ExchangeRate
+	GetByID(id int64) *model.ExchangeRate
+	GetByPair(fromID, toID int64) []*model.ExchangeRate
+}
+
+// BudgetPersistentStorage stores budgets persistently.
+type BudgetPersistentStorage interface {
+	Insert(b *model.Budget) (int64, error)
+	Update(b *model.Budget) error
+	Delete(id int64) error
+	GetAll() ([]*model.Budget, error)
+}
+
+// BudgetInmemoryStorage keeps budgets in memory for fast access.
+type BudgetInmemoryStorage interface {
+	Init(bb []*model.Budget)
+	Insert(b *model.Budget)
+	Update(b *model.Budget)
+	Delete(b *model.Budget)
+	GetAll() []*model.Budget
+	GetByID(id int64) *model.Budget
+	GetByPeriod(period time.Time) []*model.Budget
+}
+
+// RecurrencePersistentStorage stores recurrences persistently.
+type RecurrencePersistentStorage interface {
+	Insert(r *model.Recurrence) (int64, error)
+	Update(r *model.Recurrence) error
+	Delete(id int64) error
+	GetAll() ([]*model.Recurrence, error)
+}
+
+// RecurrenceInmemoryStorage keeps recurrences in memory for fast access.
+type RecurrenceInmemoryStorage interface {
+	Init(rr []*model.Recurrence)
+	Insert(r *model.Recurrence)
+	Update(r *model.Recurrence)
+	Delete(r *model.Recurrence)
+	GetAll() []*model.Recurrence
+	GetByID(id int64) *model.Recurrence
+}
+
+// TagPersistentStorage stores tags persistently.
+type TagPersistentStorage interface {
+	Insert(t *model.Tag) (int64, error)
+	Update(t *model.Tag) error
+	Delete(id int64) error
+	GetAll() ([]*model.Tag, error)
+	SetTransactionTags(transactionID int64, tagIDs []int64) error
+	GetAllTransactionTags() ([]*model.TransactionTag, error)
+}
+
+// TagInmemoryStorage keeps tags in memory for fast access.
+type TagInmemoryStorage interface {
+	Init(tt []*model.Tag)
+	Insert(t *model.Tag)
+	Update(t *model.Tag)
+	Delete(t *model.Tag)
+	GetAll() []*model.Tag
+	GetByID(id int64) *model.Tag
+	GetByName(name string) *model.Tag
+	SetTransactionTags(transactionID int64, tt []*model.Tag)
+	GetTransactionIDs(tagID int64) []int64
+}
+
+// PayeePersistentStorage stores payees persistently.
+type PayeePersistentStorage interface {
+	Insert(p *model.Payee) (int64, error)
+	Update(p *model.Payee) error
+	Delete(id int64) error
+	GetAll() ([]*model.Payee, error)
+}
+
+// PayeeInmemoryStorage keeps payees in memory for fast access.
+type PayeeInmemoryStorage interface {
+	Init(pp []*model.Payee)
+	Insert(p *model.Payee)
+	Update(p *model.Payee)
+	Delete(p *model.Payee)
+	GetAll() []*model.Payee
+	GetByID(id int64) *model.Payee
+	GetByName(name string) *model.Payee
+}
+
+// AttachmentPersistentStorage stores attachments persistently.
+type AttachmentPersistentStorage interface {
+	Insert(a *model.Attachment) (int64, error)
+	Delete(id int64) error
+	GetAll() ([]*model.Attachment, error)
+	GetByTransactionID(transactionID int64) ([]*model.Attachment, error)
+}
+
+// AttachmentInmemoryStorage keeps attachments in memory for fast access.
+type AttachmentInmemoryStorage interface {
+	Init(aa []*model.Attachment)
+	Insert(a *model.Attachment)
+	Delete(a *model.Attachment)
+	GetAll() []*model.Attachment
+	GetByID(id int64) *model.Attachment
+	GetByTransactionID(transactionID int64) []*model.Attachment
+}
+
+// GoalPersistentStorage stores goals persistently.
+type GoalPersistentStorage interface {
+	Insert(g *model.Goal) (int64, error)
+	Update(g *model.Goal) error
+	Delete(id int64) error
+	GetAll() ([]*model.Goal, error)
+}
+
+// GoalInmemoryStorage keeps goals in memory for fast access.
+type GoalInmemoryStorage interface {
+	Init(gg []*model.Goal)
+	Insert(g *model.Goal)
+	Update(g *model.Goal)
+	Delete(g *model.Goal)
+	GetAll() []*model.Goal
+	GetByID(id int64) *model.Goal
+	GetByName(name string) *model.Goal
+}
+
+// CounterpartyPersistentStorage stores counterparties persistently.
+type CounterpartyPersistentStorage interface {
+	Insert(c *model.Counterparty) (int64, error)
+	Update(c *model.Counterparty) error
+	Delete(id int64) error
+	GetAll() ([]*model.Counterparty, error)
+}
+
+// CounterpartyInmemoryStorage keeps counterparties in memory for fast access.
+type CounterpartyInmemoryStorage interface {
+	Init(cc []*model.Counterparty)
+	Insert(c *model.Counterparty)
+	Update(c *model.Counterparty)
+	Delete(c *model.Counterparty)
+	GetAll() []*model.Counterparty
+	GetByID(id int64) *model.Counterparty
+	GetByName(name string) *model.Counterparty
+}
+
+// DebtPersistentStorage stores debts persistently.
+type DebtPersistentStorage interface {
+	Insert(d *model.Debt) (int64, error)
+	Update(d *model.Debt) error
+	Delete(id int64) error
+	GetAll() ([]*model.Debt, error)
+	InsertRepayment(r *model.DebtRepayment) error
+	DeleteRepayment(r *model.DebtRepayment) error
+	GetAllRepayments() ([]*model.DebtRepayment, error)
+}
+
+// DebtInmemoryStorage keeps debts in memory for fast access.
+type DebtInmemoryStorage interface {
+	Init(dd []*model.Debt)
+	Insert(d *model.Debt)
+	Update(d *model.Debt)
+	Delete(d *model.Debt)
+	GetAll() []*model.Debt
+	GetByID(id int64) *model.Debt
+	InsertRepayment(r *model.DebtRepayment)
+	DeleteRepayment(r *model.DebtRepayment)
+	GetTransactionIDs(debtID int64) []int64
+	GetByTransactionID(transactionID int64) *model.Debt
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Tag service contains business logic related to model.Tag and links between tags and
+// transactions.
+type Tag struct {
+	persistentStorage TagPersistentStorage
+	inmemoryStorage   TagInmemoryStorage
+}
+
+// NewTag returns Tag service.
+func NewTag(persistentStorage TagPersistentStorage, inmemoryStorage TagInmemoryStorage) (*Tag, error) {
+	t := &Tag{
+		persistentStorage: persistentStorage,
+		inmemoryStorage:   inmemoryStorage,
+	}
+
+	if err := t.Init(); err != nil {
+		return nil, fmt.Errorf("t.Init: %w", err)
+	}
+
+	return t, nil
+}
+
+// Init initialize inmemory storage with data from persistent storage. Links to transactions are
+// loaded by LinkTransactions.
+func (s *Tag) Init() error {
+	tt, err := s.persistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
+	}
+
+	s.inmemoryStorage.Init(tt)
+
+	return nil
+}
+
+// Insert appends tag to both persistent and inmemory storages.
+func (s *Tag) Insert(t *model.Tag) error {
+	id, err := s.persistentStorage.Insert(t)
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
+	}
+
+	t.ID = id
+	s.inmemoryStorage.Insert(t)
+
+	return nil
+}
+
+// GetAll returns all tags.
+func (s *Tag) GetAll() []*model.Tag {
+	return s.inmemoryStorage.GetAll()
+}
+
+// GetByID returns tag by given model.Tag.ID.
+func (s *Tag) GetByID(id int64) *model.Tag {
+	return s.inmemoryStorage.GetByID(id)
+}
+
+// GetByName returns tag by given model.Tag.Name.
+func (s *Tag) GetByName(name string) *model.Tag {
+	return s.inmemoryStorage.GetByName(name)
+}
+
+// GetTransactionIDs returns ids of transactions marked with given tag.
+func (s *Tag) GetTransactionIDs(t *model.Tag) []int64 {
+	return s.inmemoryStorage.GetTransactionIDs(t.ID)
+}
+
+// LinkTransactions loads links between given transactions and tags from persistent storage and
+// sets tags to corresponding field of model.Transaction.
+func (s *Tag) LinkTransactions(tt []*model.Transaction) error {
+	links, err := s.persistentStorage.GetAllTransactionTags()
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.GetAllTransactionTags: %w", err)
+	}
+
+	tagsByTransaction := make(map[int64][]*model.Tag)
+	for _, l := range links {
+		if tag := s.GetByID(l.TagID); tag != nil {
+			tagsByTransaction[l.TransactionID] = append(tagsByTransaction[l.TransactionID], tag)
+		}
+	}
+
+	for _, t := range tt {
+		t.Tags = tagsByTransaction[t.ID]
+		s.inmemoryStorage.SetTransactionTags(t.ID, t.Tags)
+	}
+
+	return nil
+}
+
+// SetTransactionTags replaces tags of transaction in both persistent and inmemory storages. Tags
+// which don't exist yet are created, existing ones are replaced by stored instances.
+func (s *Tag) SetTransactionTags(t *model.Transaction) error {
+	ids := make([]int64, len(t.Tags))
+
+	for i, tag := range t.Tags {
+		if stored := s.GetByName(tag.Name); stored != nil {
+			t.Tags[i] = stored
+		} else if err := s.Insert(tag); err != nil {
+			return fmt.Errorf("s.Insert: %w", err)
+		}
+		ids[i] = t.Tags[i].ID
+	}
+
+	if err := s.persistentStorage.SetTransactionTags(t.ID, ids); err != nil {
+		return fmt.Errorf("s.persistentStorage.SetTransactionTags: %w", err)
+	}
+
+	s.inmemoryStorage.SetTransactionTags(t.ID, t.Tags)
+
+	return nil
+}
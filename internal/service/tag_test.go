@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeTagStorage is a persistent storage of tags kept in memory, it fails on demand.
+type fakeTagStorage struct {
+	service.TagPersistentStorage
+	tags  []*model.Tag
+	links map[int64][]int64
+	err   error
+}
+
+func (f *fakeTagStorage) Insert(t *model.Tag) (int64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+
+	f.tags = append(f.tags, &model.Tag{ID: int64(len(f.tags) + 1), Name: t.Name})
+
+	return int64(len(f.tags)), nil
+}
+
+func (f *fakeTagStorage) GetAll() ([]*model.Tag, error) {
+	return f.tags, f.err
+}
+
+func (f *fakeTagStorage) SetTransactionTags(transactionID int64, tagIDs []int64) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.links[transactionID] = tagIDs
+
+	return nil
+}
+
+func (f *fakeTagStorage) GetAllTransactionTags() ([]*model.TransactionTag, error) {
+	var res []*model.TransactionTag
+	for transactionID, tagIDs := range f.links {
+		for _, tagID := range tagIDs {
+			res = append(res, &model.TransactionTag{TransactionID: transactionID, TagID: tagID})
+		}
+	}
+
+	return res, f.err
+}
+
+type TagServiceTestSuite struct {
+	suite.Suite
+	persistentStorage *fakeTagStorage
+	service           *service.Tag
+}
+
+func (s *TagServiceTestSuite) SetupTest() {
+	s.persistentStorage = &fakeTagStorage{
+		tags:  []*model.Tag{{ID: 1, Name: "daily"}},
+		links: map[int64][]int64{1: {1}},
+	}
+
+	var err error
+	s.service, err = service.NewTag(s.persistentStorage, inmemory.NewTag())
+	require.NoError(s.T(), err, "occurred in SetupTest")
+}
+
+func (s *TagServiceTestSuite) TestNewTagNegative() {
+	failure := errors.New("failure")
+
+	_, err := service.NewTag(&fakeTagStorage{err: failure}, inmemory.NewTag())
+	assert.ErrorIs(s.T(), err, failure)
+}
+
+func (s *TagServiceTestSuite) TestSetTransactionTagsPositive() {
+	transaction := &model.Transaction{ID: 2, Tags: []*model.Tag{{Name: "daily"}, {Name: "trip"}}}
+
+	err := s.service.SetTransactionTags(transaction)
+	require.NoError(s.T(), err)
+
+	// existing tag is replaced by stored instance, new one is created
+	assert.Same(s.T(), s.service.GetByName("daily"), transaction.Tags[0])
+	assert.Equal(s.T(), int64(2), transaction.Tags[1].ID)
+	assert.Equal(s.T(), []int64{1, 2}, s.persistentStorage.links[2])
+	assert.Equal(s.T(), []int64{2}, s.service.GetTransactionIDs(transaction.Tags[0]))
+}
+
+func (s *TagServiceTestSuite) TestSetTransactionTagsNegative() {
+	failure := errors.New("failure")
+	s.persistentStorage.err = failure
+
+	err := s.service.SetTransactionTags(&model.Transaction{ID: 2, Tags: []*model.Tag{{Name: "trip"}}})
+	assert.ErrorIs(s.T(), err, failure)
+	assert.Nil(s.T(), s.service.GetByName("trip"))
+}
+
+func (s *TagServiceTestSuite) TestLinkTransactions() {
+	transaction := &model.Transaction{ID: 1}
+
+	err := s.service.LinkTransactions([]*model.Transaction{transaction})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Tag{s.service.GetByID(1)}, transaction.Tags)
+}
+
+func TestTagServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TagServiceTestSuite))
+}
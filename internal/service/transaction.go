@@ -1,29 +1,65 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/kotlw/gentlemoney/internal/model"
-	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
-	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+	"github.com/kotlw/gentlemoney/internal/money"
 )
 
+// ErrKindMismatch is returned when sign of amount contradicts kind of its category.
+var ErrKindMismatch = errors.New("sign of amount contradicts kind of category")
+
 // Transaction service contains business logic related to model.Transaction.
 type Transaction struct {
-	persistentStorage *sqlite.Transaction
-	inmemoryStorage   *inmemory.Transaction
+	persistentStorage         TransactionPersistentStorage
+	inmemoryStorage           TransactionInmemoryStorage
+	transferPersistentStorage TransferPersistentStorage
+	transferInmemoryStorage   TransferInmemoryStorage
+	splitPersistentStorage    SplitPersistentStorage
+	debtPersistentStorage     DebtPersistentStorage
+	debtInmemoryStorage       DebtInmemoryStorage
+	categoryService           *Category
+	accountService            *Account
+	tagService                *Tag
+	payeeService              *Payee
+	attachmentService         *Attachment
+	exchangeRateService       *ExchangeRate
+	history                   *History
+	unitOfWork                *UnitOfWork
 }
 
 // NewCurrency returns Transaction service.
 func NewTransaction(
-	persistentStorage *sqlite.Transaction,
-	inmemoryStorage *inmemory.Transaction,
+	persistentStorage TransactionPersistentStorage,
+	inmemoryStorage TransactionInmemoryStorage,
+	transferPersistentStorage TransferPersistentStorage,
+	transferInmemoryStorage TransferInmemoryStorage,
+	splitPersistentStorage SplitPersistentStorage,
+	debtPersistentStorage DebtPersistentStorage,
+	debtInmemoryStorage DebtInmemoryStorage,
 	categoryService *Category,
-	accountService *Account) (*Transaction, error) {
+	accountService *Account,
+	tagService *Tag,
+	payeeService *Payee,
+	attachmentService *Attachment) (*Transaction, error) {
 
 	a := &Transaction{
-		persistentStorage: persistentStorage,
-		inmemoryStorage:   inmemoryStorage,
+		persistentStorage:         persistentStorage,
+		inmemoryStorage:           inmemoryStorage,
+		transferPersistentStorage: transferPersistentStorage,
+		transferInmemoryStorage:   transferInmemoryStorage,
+		splitPersistentStorage:    splitPersistentStorage,
+		debtPersistentStorage:     debtPersistentStorage,
+		debtInmemoryStorage:       debtInmemoryStorage,
+		categoryService:           categoryService,
+		accountService:            accountService,
+		tagService:                tagService,
+		payeeService:              payeeService,
+		attachmentService:         attachmentService,
 	}
 
 	if err := a.Init(categoryService, accountService); err != nil {
@@ -34,25 +70,67 @@ func NewTransaction(
 }
 
 // Init initialize inmemory storage with data from persistent storage. It is also links existing
-// categories and accounts to corresponding fields of model.Transaction.
+// categories, accounts, payees, tags and splits to corresponding fields of model.Transaction and
+// transactions to legs of model.Transfer. Transfers with legs in trash are omitted.
 func (s *Transaction) Init(categoryService *Category, accountService *Account) error {
+	s.categoryService, s.accountService = categoryService, accountService
+
 	tt, err := s.persistentStorage.GetAll()
 	if err != nil {
 		return fmt.Errorf("s.persistentStorage.GetAll: %w", err)
 	}
 
-	for _, t := range tt {
-		t.Category = categoryService.GetByID(t.Category.ID)
-		t.Account = accountService.GetByID(t.Account.ID)
+	if err = s.link(tt); err != nil {
+		return fmt.Errorf("s.link: %w", err)
 	}
 
 	s.inmemoryStorage.Init(tt)
 
+	transfers, err := s.transferPersistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.transferPersistentStorage.GetAll: %w", err)
+	}
+
+	active := make([]*model.Transfer, 0, len(transfers))
+	for _, t := range transfers {
+		t.From = s.inmemoryStorage.GetByID(t.From.ID)
+		t.To = s.inmemoryStorage.GetByID(t.To.ID)
+		if t.From != nil && t.To != nil {
+			active = append(active, t)
+		}
+	}
+
+	s.transferInmemoryStorage.Init(active)
+
 	return nil
 }
 
-// Insert appends transaction to both persistent and inmemory storages.
-func (s *Transaction) Insert(t *model.Transaction) error {
+// Insert appends transaction to both persistent and inmemory storages along with its tags and
+// splits. Payee which doesn't exist yet is created, transaction without status is pending.
+func (s *Transaction) Insert(t *model.Transaction) (err error) {
+	defer s.history.record(&err, "insert transaction",
+		func() error { return s.Delete(t) },
+		func() error { return s.Restore(t) })()
+	defer s.history.refer(model.TrashTransaction, &t.ID)
+
+	if err := s.validateSplits(t); err != nil {
+		return fmt.Errorf("s.validateSplits: %w", err)
+	}
+
+	if err := s.setStatus(t); err != nil {
+		return fmt.Errorf("s.setStatus: %w", err)
+	}
+
+	return s.unitOfWork.Do(func() error { return s.insert(t) })
+}
+
+// insert appends transaction along with its payee, tags and splits to both persistent and inmemory
+// storages.
+func (s *Transaction) insert(t *model.Transaction) error {
+	if err := s.payeeService.LinkTransaction(t); err != nil {
+		return fmt.Errorf("s.payeeService.LinkTransaction: %w", err)
+	}
+
 	id, err := s.persistentStorage.Insert(t)
 	if err != nil {
 		return fmt.Errorf("s.persistentStorage.Insert: %w", err)
@@ -61,29 +139,242 @@ func (s *Transaction) Insert(t *model.Transaction) error {
 	t.ID = id
 	s.inmemoryStorage.Insert(t)
 
+	if err = s.tagService.SetTransactionTags(t); err != nil {
+		return fmt.Errorf("s.tagService.SetTransactionTags: %w", err)
+	}
+
+	if err = s.splitPersistentStorage.SetTransactionSplits(t.ID, t.Splits); err != nil {
+		return fmt.Errorf("s.splitPersistentStorage.SetTransactionSplits: %w", err)
+	}
+
 	return nil
 }
 
-// Update updates transaction in persistent and inmemory storages.
-func (s *Transaction) Update(t *model.Transaction) error {
-	if err := s.persistentStorage.Update(t); err != nil {
-		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+// Update updates transaction in persistent and inmemory storages. If transaction is a leg of
+// transfer, date and note of the other leg are synchronized with it, transfer legs can't be split.
+// Payee which doesn't exist yet is created. Transaction without status keeps the stored one,
+// reconciled transaction can't be updated.
+func (s *Transaction) Update(t *model.Transaction) (err error) {
+	prev, next := clone(s.GetByID(t.ID)), clone(t)
+	defer s.history.record(&err, "update transaction",
+		func() error { return s.Update(clone(prev)) },
+		func() error { return s.Update(clone(next)) })()
+	defer s.history.refer(model.TrashTransaction, &t.ID)
+
+	if err := s.checkUnlocked(t); err != nil {
+		return fmt.Errorf("s.checkUnlocked: %w", err)
 	}
 
-	s.inmemoryStorage.Update(t)
+	if err := s.validateSplits(t); err != nil {
+		return fmt.Errorf("s.validateSplits: %w", err)
+	}
+
+	if err := s.setStatus(t); err != nil {
+		return fmt.Errorf("s.setStatus: %w", err)
+	}
+
+	return s.unitOfWork.Do(func() error { return s.update(t) })
+}
+
+// update changes transaction along with its payee, tags and splits in both persistent and inmemory
+// storages, leg of transfer is updated along with the other one.
+func (s *Transaction) update(t *model.Transaction) error {
+	if err := s.payeeService.LinkTransaction(t); err != nil {
+		return fmt.Errorf("s.payeeService.LinkTransaction: %w", err)
+	}
+
+	if tr := s.transferInmemoryStorage.GetByTransactionID(t.ID); tr != nil {
+		if len(t.Splits) > 0 {
+			return errors.New("transfer can't be split")
+		}
+
+		from, to := *tr.From, *tr.To
+		if t.ID == from.ID {
+			from = *t
+		} else {
+			to = *t
+		}
+		from.Date, from.Note = t.Date, t.Note
+		to.Date, to.Note = t.Date, t.Note
+
+		if err := s.UpdateTransfer(&model.Transfer{ID: tr.ID, From: &from, To: &to}); err != nil {
+			return err
+		}
+	} else {
+		if err := s.persistentStorage.Update(t); err != nil {
+			return fmt.Errorf("s.persistentStorage.Update: %w", err)
+		}
+
+		s.inmemoryStorage.Update(t)
+	}
+
+	if err := s.tagService.SetTransactionTags(t); err != nil {
+		return fmt.Errorf("s.tagService.SetTransactionTags: %w", err)
+	}
+
+	if err := s.splitPersistentStorage.SetTransactionSplits(t.ID, t.Splits); err != nil {
+		return fmt.Errorf("s.splitPersistentStorage.SetTransactionSplits: %w", err)
+	}
 
 	return nil
 }
 
-// Delete deletes transaction from inmemory and persistent storages.
-func (s *Transaction) Delete(t *model.Transaction) error {
-	if err := s.persistentStorage.Delete(t.ID); err != nil {
-		return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+// Delete moves transaction to trash, so it is removed from inmemory storage only. Tags, splits and
+// attachments are kept until transaction is purged, while link to repaid debt is removed along with
+// it. If transaction is a leg of transfer, the whole transfer is deleted. Reconciled transaction
+// can't be deleted.
+func (s *Transaction) Delete(t *model.Transaction) (err error) {
+	stored := s.GetByID(t.ID)
+	defer s.history.record(&err, "delete transaction",
+		func() error { return s.Restore(stored) },
+		func() error { return s.Delete(stored) })()
+	defer s.history.refer(model.TrashTransaction, &t.ID)
+
+	if err := s.checkUnlocked(t); err != nil {
+		return fmt.Errorf("s.checkUnlocked: %w", err)
+	}
+
+	if tr := s.transferInmemoryStorage.GetByTransactionID(t.ID); tr != nil {
+		return s.DeleteTransfer(tr)
+	}
+
+	return s.unitOfWork.Do(func() error {
+		if err := s.unlinkDebt(t); err != nil {
+			return fmt.Errorf("s.unlinkDebt: %w", err)
+		}
+
+		if err := s.persistentStorage.Delete(t.ID); err != nil {
+			return fmt.Errorf("s.persistentStorage.Delete: %w", err)
+		}
+		s.inmemoryStorage.Delete(t)
+		return nil
+	})
+}
+
+// GetDeleted returns transactions from trash. Account or category is nil if it is in trash as
+// well.
+func (s *Transaction) GetDeleted() ([]*model.Transaction, error) {
+	tt, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	if err = s.link(tt); err != nil {
+		return nil, fmt.Errorf("s.link: %w", err)
+	}
+
+	return tt, nil
+}
+
+// getDeletedRefs returns transactions from trash along with their splits as they are kept in
+// persistent storage, so accounts and categories have only ids set even if they are in trash.
+func (s *Transaction) getDeletedRefs() ([]*model.Transaction, error) {
+	tt, err := s.persistentStorage.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.persistentStorage.GetDeleted: %w", err)
+	}
+
+	splits, err := s.splitPersistentStorage.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("s.splitPersistentStorage.GetAll: %w", err)
+	}
+
+	splitsByTransaction := make(map[int64][]*model.Split)
+	for _, e := range splits {
+		splitsByTransaction[e.TransactionID] = append(splitsByTransaction[e.TransactionID], e)
+	}
+
+	for _, t := range tt {
+		t.Splits = splitsByTransaction[t.ID]
+	}
+
+	return tt, nil
+}
+
+// Restore brings transaction back from trash. If transaction is a leg of transfer, the whole
+// transfer is restored. Transaction can't be restored while its account or category is in trash.
+func (s *Transaction) Restore(t *model.Transaction) error {
+	legs, tr, err := s.deletedLegs(t)
+	if err != nil {
+		return fmt.Errorf("s.deletedLegs: %w", err)
+	}
+
+	for _, leg := range legs {
+		if leg.Account == nil || leg.Category == nil {
+			return fmt.Errorf("account or category of transaction: %w", ErrDependsOnTrash)
+		}
+	}
+
+	if tr != nil {
+		err = s.transferPersistentStorage.Restore(tr.ID)
+	} else {
+		err = s.persistentStorage.Restore(t.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("s.persistentStorage.Restore: %w", err)
+	}
+
+	for _, leg := range legs {
+		leg.DeletedAt = time.Time{}
+		s.inmemoryStorage.Insert(leg)
 	}
-	s.inmemoryStorage.Delete(t)
+
+	if tr != nil {
+		tr.From, tr.To = legs[0], legs[1]
+		s.transferInmemoryStorage.Insert(tr)
+	}
+
 	return nil
 }
 
+// Purge permanently deletes transaction from trash along with its tags, splits and attachments. If
+// transaction is a leg of transfer, the whole transfer is purged. All of them are purged in a single
+// unit of work, so attached files are deleted and operations referring to the purged transactions
+// are dropped from history only once it is committed.
+func (s *Transaction) Purge(t *model.Transaction) error {
+	legs, tr, err := s.deletedLegs(t)
+	if err != nil {
+		return fmt.Errorf("s.deletedLegs: %w", err)
+	}
+
+	return s.unitOfWork.Do(func() error {
+		for _, leg := range legs {
+			// transaction without tags is used to unlink all its tags
+			if err := s.tagService.SetTransactionTags(&model.Transaction{ID: leg.ID}); err != nil {
+				return fmt.Errorf("s.tagService.SetTransactionTags: %w", err)
+			}
+
+			if err := s.splitPersistentStorage.SetTransactionSplits(leg.ID, nil); err != nil {
+				return fmt.Errorf("s.splitPersistentStorage.SetTransactionSplits: %w", err)
+			}
+
+			if err := s.attachmentService.RemoveAll(leg); err != nil {
+				return fmt.Errorf("s.attachmentService.RemoveAll: %w", err)
+			}
+		}
+
+		var err error
+		if tr != nil {
+			err = s.transferPersistentStorage.Purge(tr.ID)
+		} else {
+			err = s.persistentStorage.Purge(t.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("s.persistentStorage.Purge: %w", err)
+		}
+
+		ids := make([]int64, 0, len(legs))
+		for _, leg := range legs {
+			ids = append(ids, leg.ID)
+		}
+
+		return s.unitOfWork.AfterCommit(func() error {
+			s.history.forget(model.TrashTransaction, ids...)
+			return nil
+		})
+	})
+}
+
 // GetAll returns all transactions.
 func (s *Transaction) GetAll() []*model.Transaction {
 	return s.inmemoryStorage.GetAll()
@@ -93,3 +384,615 @@ func (s *Transaction) GetAll() []*model.Transaction {
 func (s *Transaction) GetByID(id int64) *model.Transaction {
 	return s.inmemoryStorage.GetByID(id)
 }
+
+// SplitsOf returns splits of transaction. Transaction without splits is represented as a single
+// split of the whole amount, so reports can attribute amounts per split regardless.
+func (s *Transaction) SplitsOf(t *model.Transaction) []*model.Split {
+	if len(t.Splits) > 0 {
+		return t.Splits
+	}
+
+	return []*model.Split{{TransactionID: t.ID, Category: t.Category, Amount: t.Amount, Note: t.Note}}
+}
+
+// TotalsByKind returns sums of transaction amounts converted to the main currency, split into
+// model.Income and model.Expense, split transactions are attributed per split. Amounts in categories
+// which could be both, in categories without kind or without category are classified by sign.
+// Transfers are skipped, since they are neither income nor expense, as well as transactions whose
+// account is missing, since their currency is unknown. Sum which overflows is an error.
+func (s *Transaction) TotalsByKind(tt []*model.Transaction) (map[model.CategoryKind]int64, error) {
+	main := s.exchangeRateService.currencyService.GetMain()
+	totals := make(map[model.CategoryKind]money.Money)
+
+	for _, t := range tt {
+		if t.Account == nil || s.isTransfer(t) {
+			continue
+		}
+
+		for _, e := range s.SplitsOf(t) {
+			amount, err := s.exchangeRateService.ConvertToMain(e.Amount, t.Account.Currency, t.Date)
+			if err != nil {
+				return nil, fmt.Errorf("s.exchangeRateService.ConvertToMain: %w", err)
+			}
+
+			kind := model.Expense
+			if e.Category != nil && (e.Category.Kind == model.Income || e.Category.Kind == model.Expense) {
+				kind = e.Category.Kind
+			} else if e.Amount > 0 {
+				kind = model.Income
+			}
+
+			total, ok := totals[kind]
+			if !ok {
+				total = main.Money(0)
+			}
+			if totals[kind], err = total.Add(main.Money(amount)); err != nil {
+				return nil, fmt.Errorf("total.Add: %w", err)
+			}
+		}
+	}
+
+	res := make(map[model.CategoryKind]int64, len(totals))
+	for kind, total := range totals {
+		res[kind] = total.Amount()
+	}
+
+	return res, nil
+}
+
+// CheckKind checks if signs of transaction amounts match kinds of their categories, split
+// transaction is checked per split and transfers aren't checked at all. Mismatch doesn't prevent
+// saving transaction, e.g. refund in expense category, so it is meant to be shown as a warning.
+func (s *Transaction) CheckKind(t *model.Transaction) error {
+	if s.isTransfer(t) {
+		return nil
+	}
+
+	for _, e := range s.SplitsOf(t) {
+		if e.Category == nil || e.Category.Kind.Allows(e.Amount) {
+			continue
+		}
+
+		sign := "positive"
+		if e.Amount < 0 {
+			sign = "negative"
+		}
+		return fmt.Errorf("%s amount in %s category %q: %w", sign, e.Category.Kind, e.Category.Title, ErrKindMismatch)
+	}
+
+	return nil
+}
+
+// isTransfer returns true if transaction is a leg of transfer.
+func (s *Transaction) isTransfer(t *model.Transaction) bool {
+	return t.ID != 0 && s.GetTransferByTransactionID(t.ID) != nil
+}
+
+// RegisterEntry is a transaction of account register along with the account balance after it.
+type RegisterEntry struct {
+	Transaction *model.Transaction
+	Balance     int64
+}
+
+// Register returns transactions of given account starting from its opening date ordered by date,
+// each of them is accompanied with running balance of the account. Balance which overflows is an
+// error.
+func (s *Transaction) Register(a *model.Account) ([]*RegisterEntry, error) {
+	res := make([]*RegisterEntry, 0)
+	for _, t := range s.GetAll() {
+		if t.Account != nil && t.Account.ID == a.ID && !t.Date.Before(a.OpeningDate) {
+			res = append(res, &RegisterEntry{Transaction: t})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		ti, tj := res[i].Transaction, res[j].Transaction
+		if ti.Date.Equal(tj.Date) {
+			return ti.ID < tj.ID
+		}
+		return ti.Date.Before(tj.Date)
+	})
+
+	balance := a.Currency.Money(a.OpeningBalance)
+	for _, e := range res {
+		var err error
+		if balance, err = balance.Add(a.Currency.Money(e.Transaction.Amount)); err != nil {
+			return nil, fmt.Errorf("balance.Add: %w", err)
+		}
+		e.Balance = balance.Amount()
+	}
+
+	return res, nil
+}
+
+// BalanceAt returns balance of given account at the end of given date. It is the opening balance
+// plus all transactions of the account from the opening date up to the given date, the balance
+// before the opening date is zero. Balance which overflows is an error.
+func (s *Transaction) BalanceAt(a *model.Account, date time.Time) (int64, error) {
+	return s.sumAt(a, date, func(*model.Transaction) bool { return true })
+}
+
+// Balance returns current balance of given account.
+func (s *Transaction) Balance(a *model.Account) (int64, error) {
+	return s.BalanceAt(a, time.Now())
+}
+
+// SetStatus changes status of transaction in persistent and inmemory storages, unlike Update it
+// is allowed for reconciled transaction, so it is the way to unlock it.
+func (s *Transaction) SetStatus(t *model.Transaction, status model.TransactionStatus) (err error) {
+	prev := t.Status
+	defer s.history.record(&err, "change status of transaction",
+		func() error { return s.SetStatus(t, prev) },
+		func() error { return s.SetStatus(t, status) })()
+	defer s.history.refer(model.TrashTransaction, &t.ID)
+
+	if err := validateStatus(status); err != nil {
+		return fmt.Errorf("validateStatus: %w", err)
+	}
+
+	t.Status = status
+
+	if err := s.persistentStorage.Update(t); err != nil {
+		t.Status = prev
+		return fmt.Errorf("s.persistentStorage.Update: %w", err)
+	}
+
+	s.inmemoryStorage.Update(t)
+
+	return nil
+}
+
+// setPayee links given transactions to payee in persistent and inmemory storages, nil payee unlinks
+// them. Like SetStatus it is allowed for reconciled transactions, since payee doesn't affect
+// balances.
+func (s *Transaction) setPayee(tt []*model.Transaction, p *model.Payee) error {
+	for _, t := range tt {
+		prev := t.Payee
+		t.Payee = p
+
+		if err := s.persistentStorage.Update(t); err != nil {
+			t.Payee = prev
+			return fmt.Errorf("s.persistentStorage.Update: %w", err)
+		}
+
+		s.inmemoryStorage.Update(t)
+	}
+
+	return nil
+}
+
+// ClearedBalanceAt returns balance of given account at the end of given date counting only cleared
+// and reconciled transactions, it is the balance which should match bank statement. Opening
+// balance is considered cleared. Balance which overflows is an error.
+func (s *Transaction) ClearedBalanceAt(a *model.Account, date time.Time) (int64, error) {
+	return s.sumAt(a, date, func(t *model.Transaction) bool { return t.Status.IsCleared() })
+}
+
+// sumAt returns opening balance of given account plus transactions of the account which match fn
+// from the opening date up to the end of given date, the sum before the opening date is zero.
+func (s *Transaction) sumAt(a *model.Account, date time.Time, fn func(*model.Transaction) bool) (int64, error) {
+	if date.Before(a.OpeningDate) {
+		return 0, nil
+	}
+
+	res := a.Currency.Money(a.OpeningBalance)
+	for _, t := range s.GetAll() {
+		if t.Account == nil || t.Account.ID != a.ID || t.Date.Before(a.OpeningDate) || t.Date.After(date) || !fn(t) {
+			continue
+		}
+
+		var err error
+		if res, err = res.Add(a.Currency.Money(t.Amount)); err != nil {
+			return 0, fmt.Errorf("res.Add: %w", err)
+		}
+	}
+
+	return res.Amount(), nil
+}
+
+// Unreconciled returns transactions of given account up to the end of given date which aren't
+// reconciled yet ordered by date, these are candidates for matching with bank statement.
+func (s *Transaction) Unreconciled(a *model.Account, date time.Time) ([]*model.Transaction, error) {
+	register, err := s.Register(a)
+	if err != nil {
+		return nil, fmt.Errorf("s.Register: %w", err)
+	}
+
+	res := make([]*model.Transaction, 0)
+	for _, e := range register {
+		if e.Transaction.Status != model.Reconciled && !e.Transaction.Date.After(date) {
+			res = append(res, e.Transaction)
+		}
+	}
+
+	return res, nil
+}
+
+// Reconcile locks cleared transactions of given account up to the end of given date as reconciled.
+// It succeeds only if cleared balance matches balance of bank statement.
+func (s *Transaction) Reconcile(a *model.Account, date time.Time, balance int64) (err error) {
+	locked := make([]*model.Transaction, 0)
+	defer s.history.record(&err, "reconcile account",
+		func() error { return s.setStatuses(locked, model.Cleared) },
+		func() error { return s.setStatuses(locked, model.Reconciled) })()
+
+	cleared, err := s.ClearedBalanceAt(a, date)
+	if err != nil {
+		return fmt.Errorf("s.ClearedBalanceAt: %w", err)
+	}
+
+	if cleared != balance {
+		return errors.New("cleared balance doesn't match statement balance")
+	}
+
+	unreconciled, err := s.Unreconciled(a, date)
+	if err != nil {
+		return fmt.Errorf("s.Unreconciled: %w", err)
+	}
+
+	// transactions are locked all at once, so reconciliation isn't left half done
+	return s.unitOfWork.Do(func() error {
+		for _, t := range unreconciled {
+			if t.Status != model.Cleared {
+				continue
+			}
+
+			if err := s.SetStatus(t, model.Reconciled); err != nil {
+				return fmt.Errorf("s.SetStatus: %w", err)
+			}
+			locked = append(locked, t)
+		}
+
+		return nil
+	})
+}
+
+// GetByTag returns transactions marked with given tag.
+func (s *Transaction) GetByTag(tag *model.Tag) []*model.Transaction {
+	ids := s.tagService.GetTransactionIDs(tag)
+
+	res := make([]*model.Transaction, 0, len(ids))
+	for _, id := range ids {
+		if t := s.GetByID(id); t != nil {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// InsertTransfer appends both legs of transfer to persistent and inmemory storages as a single unit
+// of work.
+func (s *Transaction) InsertTransfer(t *model.Transfer) (err error) {
+	defer s.history.record(&err, "insert transfer",
+		func() error { return s.DeleteTransfer(t) },
+		func() error { return s.Restore(t.From) })()
+	defer s.history.refer(model.TrashTransaction, &t.From.ID, &t.To.ID)
+
+	if err := s.validateTransfer(t); err != nil {
+		return fmt.Errorf("s.validateTransfer: %w", err)
+	}
+
+	for _, leg := range []*model.Transaction{t.From, t.To} {
+		if err := s.setStatus(leg); err != nil {
+			return fmt.Errorf("s.setStatus: %w", err)
+		}
+	}
+
+	return s.unitOfWork.Do(func() error {
+		id, err := s.transferPersistentStorage.Insert(t)
+		if err != nil {
+			return fmt.Errorf("s.transferPersistentStorage.Insert: %w", err)
+		}
+
+		t.ID = id
+		s.inmemoryStorage.Insert(t.From)
+		s.inmemoryStorage.Insert(t.To)
+		s.transferInmemoryStorage.Insert(t)
+
+		return nil
+	})
+}
+
+// UpdateTransfer updates both legs of transfer in persistent and inmemory storages as a single unit
+// of work. Transfer with reconciled leg can't be updated.
+func (s *Transaction) UpdateTransfer(t *model.Transfer) (err error) {
+	prev, next := cloneTransfer(s.transferInmemoryStorage.GetByID(t.ID)), cloneTransfer(t)
+	defer s.history.record(&err, "update transfer",
+		func() error { return s.UpdateTransfer(cloneTransfer(prev)) },
+		func() error { return s.UpdateTransfer(cloneTransfer(next)) })()
+	defer s.history.refer(model.TrashTransaction, &t.From.ID, &t.To.ID)
+
+	if err := s.checkUnlocked(t.From, t.To); err != nil {
+		return fmt.Errorf("s.checkUnlocked: %w", err)
+	}
+
+	if err := s.validateTransfer(t); err != nil {
+		return fmt.Errorf("s.validateTransfer: %w", err)
+	}
+
+	for _, leg := range []*model.Transaction{t.From, t.To} {
+		if err := s.setStatus(leg); err != nil {
+			return fmt.Errorf("s.setStatus: %w", err)
+		}
+	}
+
+	return s.unitOfWork.Do(func() error {
+		if err := s.transferPersistentStorage.Update(t); err != nil {
+			return fmt.Errorf("s.transferPersistentStorage.Update: %w", err)
+		}
+
+		s.inmemoryStorage.Update(t.From)
+		s.inmemoryStorage.Update(t.To)
+		s.transferInmemoryStorage.Update(t)
+
+		return nil
+	})
+}
+
+// PurgeBefore permanently deletes transactions moved to trash before given date and returns their
+// count.
+func (s *Transaction) PurgeBefore(date time.Time) (int, error) {
+	tt, err := s.GetDeleted()
+	if err != nil {
+		return 0, fmt.Errorf("s.GetDeleted: %w", err)
+	}
+
+	n := 0
+	purged := make(map[int64]bool)
+	for _, t := range tt {
+		// the second leg of transfer is purged along with the first one
+		if purged[t.ID] || !t.DeletedAt.Before(date) {
+			continue
+		}
+
+		legs, _, err := s.deletedLegs(t)
+		if err != nil {
+			return n, fmt.Errorf("s.deletedLegs: %w", err)
+		}
+
+		if err = s.Purge(t); err != nil {
+			return n, fmt.Errorf("s.Purge: %w", err)
+		}
+
+		for _, leg := range legs {
+			purged[leg.ID] = true
+		}
+		n += len(legs)
+	}
+
+	return n, nil
+}
+
+// DeleteTransfer moves both legs of transfer to trash, so they are removed from inmemory storage
+// only. Transfer with reconciled leg can't be deleted.
+func (s *Transaction) DeleteTransfer(t *model.Transfer) (err error) {
+	defer s.history.record(&err, "delete transfer",
+		func() error { return s.Restore(t.From) },
+		func() error { return s.DeleteTransfer(t) })()
+	defer s.history.refer(model.TrashTransaction, &t.From.ID, &t.To.ID)
+
+	if err := s.checkUnlocked(t.From, t.To); err != nil {
+		return fmt.Errorf("s.checkUnlocked: %w", err)
+	}
+
+	return s.unitOfWork.Do(func() error {
+		for _, leg := range []*model.Transaction{t.From, t.To} {
+			if err := s.unlinkDebt(leg); err != nil {
+				return fmt.Errorf("s.unlinkDebt: %w", err)
+			}
+		}
+
+		if err := s.transferPersistentStorage.Delete(t.ID); err != nil {
+			return fmt.Errorf("s.transferPersistentStorage.Delete: %w", err)
+		}
+
+		s.inmemoryStorage.Delete(t.From)
+		s.inmemoryStorage.Delete(t.To)
+		s.transferInmemoryStorage.Delete(t)
+
+		return nil
+	})
+}
+
+// GetTransferByTransactionID returns transfer by id of any of its legs, or nil if transaction
+// isn't a part of transfer.
+func (s *Transaction) GetTransferByTransactionID(id int64) *model.Transfer {
+	return s.transferInmemoryStorage.GetByTransactionID(id)
+}
+
+// setStatuses changes status of given transactions.
+func (s *Transaction) setStatuses(tt []*model.Transaction, status model.TransactionStatus) error {
+	for _, t := range tt {
+		if err := s.SetStatus(t, status); err != nil {
+			return fmt.Errorf("s.SetStatus: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// link links existing categories, accounts, payees, tags and splits to corresponding fields of
+// given transactions.
+func (s *Transaction) link(tt []*model.Transaction) error {
+	for _, t := range tt {
+		t.Category = s.categoryService.GetByID(t.Category.ID)
+		t.Account = s.accountService.GetByID(t.Account.ID)
+		if t.Payee != nil {
+			t.Payee = s.payeeService.GetByID(t.Payee.ID)
+		}
+	}
+
+	if err := s.tagService.LinkTransactions(tt); err != nil {
+		return fmt.Errorf("s.tagService.LinkTransactions: %w", err)
+	}
+
+	splits, err := s.splitPersistentStorage.GetAll()
+	if err != nil {
+		return fmt.Errorf("s.splitPersistentStorage.GetAll: %w", err)
+	}
+
+	splitsByTransaction := make(map[int64][]*model.Split)
+	for _, e := range splits {
+		e.Category = s.categoryService.GetByID(e.Category.ID)
+		splitsByTransaction[e.TransactionID] = append(splitsByTransaction[e.TransactionID], e)
+	}
+
+	for _, t := range tt {
+		t.Splits = splitsByTransaction[t.ID]
+	}
+
+	return nil
+}
+
+// deletedLegs returns given transaction from trash, or both legs of transfer and the transfer
+// itself if transaction is a leg of transfer.
+func (s *Transaction) deletedLegs(t *model.Transaction) ([]*model.Transaction, *model.Transfer, error) {
+	transfers, err := s.transferPersistentStorage.GetAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("s.transferPersistentStorage.GetAll: %w", err)
+	}
+
+	for _, tr := range transfers {
+		if tr.From.ID != t.ID && tr.To.ID != t.ID {
+			continue
+		}
+
+		tt, err := s.GetDeleted()
+		if err != nil {
+			return nil, nil, fmt.Errorf("s.GetDeleted: %w", err)
+		}
+
+		byID := make(map[int64]*model.Transaction, len(tt))
+		for _, e := range tt {
+			byID[e.ID] = e
+		}
+
+		from, to := byID[tr.From.ID], byID[tr.To.ID]
+		if from == nil || to == nil {
+			return nil, nil, errors.New("transfer isn't in trash")
+		}
+
+		return []*model.Transaction{from, to}, tr, nil
+	}
+
+	return []*model.Transaction{t}, nil, nil
+}
+
+// unlinkDebt removes link between transaction and debt it repays, if any. Ids of deleted
+// transactions are reused, so link left behind would attach debt to unrelated transaction.
+func (s *Transaction) unlinkDebt(t *model.Transaction) error {
+	d := s.debtInmemoryStorage.GetByTransactionID(t.ID)
+	if d == nil {
+		return nil
+	}
+
+	r := &model.DebtRepayment{DebtID: d.ID, TransactionID: t.ID}
+	if err := s.debtPersistentStorage.DeleteRepayment(r); err != nil {
+		return fmt.Errorf("s.debtPersistentStorage.DeleteRepayment: %w", err)
+	}
+
+	s.debtInmemoryStorage.DeleteRepayment(r)
+
+	return nil
+}
+
+// setStatus fills empty status of transaction with the stored one, or with pending if transaction
+// isn't stored yet, and checks if status is known.
+func (s *Transaction) setStatus(t *model.Transaction) error {
+	if stored := s.GetByID(t.ID); t.Status == "" && stored != nil {
+		t.Status = stored.Status
+	}
+
+	if t.Status == "" {
+		t.Status = model.Pending
+	}
+
+	return validateStatus(t.Status)
+}
+
+// checkUnlocked returns error if any of given transactions is reconciled in the storage.
+func (s *Transaction) checkUnlocked(tt ...*model.Transaction) error {
+	for _, t := range tt {
+		if stored := s.GetByID(t.ID); stored != nil && stored.Status == model.Reconciled {
+			return errors.New("reconciled transaction is locked")
+		}
+	}
+
+	return nil
+}
+
+// validateStatus checks if transaction status is known.
+func validateStatus(status model.TransactionStatus) error {
+	for _, e := range model.TransactionStatuses() {
+		if status == e {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown transaction status %q", status)
+}
+
+// validateSplits checks if splits of transaction are consistent. Transaction without splits is
+// always valid.
+func (*Transaction) validateSplits(t *model.Transaction) error {
+	if len(t.Splits) == 0 {
+		return nil
+	}
+
+	amount := t.Money()
+	sum := money.New(0, amount.Currency(), amount.Precision())
+	for _, e := range t.Splits {
+		if e.Category == nil {
+			return errors.New("split category not found")
+		}
+
+		var err error
+		if sum, err = sum.Add(money.New(e.Amount, amount.Currency(), amount.Precision())); err != nil {
+			return fmt.Errorf("sum.Add: %w", err)
+		}
+	}
+
+	if sum != amount {
+		return fmt.Errorf("sum of splits %s doesn't match transaction amount %s", sum, amount)
+	}
+
+	return nil
+}
+
+// validateTransfer checks if transfer legs are consistent. Both legs should have account and
+// category, amounts of legs in the same currency should be equal, otherwise both amounts are
+// recorded as is.
+func (*Transaction) validateTransfer(t *model.Transfer) error {
+	for _, leg := range []*model.Transaction{t.From, t.To} {
+		if leg.Account == nil {
+			return errors.New("account of transfer leg is missing")
+		}
+		if leg.Category == nil {
+			return errors.New("category of transfer leg is missing")
+		}
+	}
+
+	if t.From.Account.ID == t.To.Account.ID {
+		return errors.New("can't transfer to the same account")
+	}
+
+	if t.From.Amount >= 0 || t.To.Amount <= 0 {
+		return errors.New("outgoing amount should be negative and incoming positive")
+	}
+
+	if t.From.Account.Currency.ID == t.To.Account.Currency.ID && t.From.Amount != -t.To.Amount {
+		return errors.New("amounts of transfer in the same currency should be equal")
+	}
+
+	return nil
+}
+
+// cloneTransfer returns a copy of transfer along with copies of its legs.
+func cloneTransfer(t *model.Transfer) *model.Transfer {
+	if t == nil {
+		return nil
+	}
+
+	return &model.Transfer{ID: t.ID, From: clone(t.From), To: clone(t.To)}
+}
@@ -2,10 +2,16 @@ package service_test
 
 import (
 	"database/sql"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
 	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/money"
 	"github.com/kotlw/gentlemoney/internal/service"
 	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
 	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
@@ -29,14 +35,14 @@ type TransactionServiceTestSuite struct {
 }
 
 func (s *TransactionServiceTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
 	s.persistentStorage, err = sqlite.New(db)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.inmemoryStorage = inmemory.New()
-	s.service, err = service.New(s.persistentStorage, s.inmemoryStorage)
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(s.inmemoryStorage), s.T().TempDir())
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
 	// id's settled by sqlite on insert incrementally starting from 1,
@@ -46,8 +52,8 @@ func (s *TransactionServiceTestSuite) SetupSuite() {
 		{ID: 2, Title: "Grocery"},
 	}
 	s.InitCurrencies = []*model.Currency{
-		{ID: 1, Abbreviation: "USD"},
-		{ID: 2, Abbreviation: "EUR"},
+		{ID: 1, Abbreviation: "USD", IsMain: true, Precision: 2},
+		{ID: 2, Abbreviation: "EUR", Precision: 2},
 	}
 	s.InitAccounts = []*model.Account{
 		{ID: 1, Name: "BCard1", Currency: s.InitCurrencies[0]},
@@ -194,6 +200,524 @@ func (s *TransactionServiceTestSuite) TestGetByID() {
 	assert.EqualValues(s.T(), s.InitTransactions[1], t)
 }
 
+func (s *TransactionServiceTestSuite) TestTags() {
+	transaction := &model.Transaction{
+		Date:     time.Date(2022, time.Month(2), 23, 1, 10, 30, 0, time.UTC),
+		Account:  s.InitAccounts[0],
+		Category: s.InitCategories[0],
+		Amount:   4321,
+		Tags:     []*model.Tag{{Name: "vacation"}, {Name: "reimbursable"}},
+	}
+
+	err := s.service.Transaction().Insert(transaction)
+	require.NoError(s.T(), err)
+
+	vacation := s.service.Tag().GetByName("vacation")
+	require.NotNil(s.T(), vacation)
+	assert.Equal(s.T(), []*model.Transaction{transaction}, s.service.Transaction().GetByTag(vacation))
+
+	// existing tag is reused
+	updated := *transaction
+	updated.Tags = []*model.Tag{{Name: "reimbursable"}}
+	err = s.service.Transaction().Update(&updated)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), s.service.Tag().GetAll(), 2)
+	assert.Empty(s.T(), s.service.Transaction().GetByTag(vacation))
+
+	// tags are linked on init
+	err = s.service.Tag().Init()
+	require.NoError(s.T(), err)
+	err = s.service.Transaction().Init(s.service.Category(), s.service.Account())
+	require.NoError(s.T(), err)
+	reimbursable := s.service.Tag().GetByName("reimbursable")
+	assert.Equal(s.T(), []*model.Tag{reimbursable}, s.service.Transaction().GetByID(updated.ID).Tags)
+
+	err = s.service.Transaction().Delete(&updated)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), s.service.Transaction().GetByTag(reimbursable))
+}
+
+func (s *TransactionServiceTestSuite) TestPayees() {
+	transaction := &model.Transaction{
+		Date:     time.Date(2022, time.Month(2), 23, 1, 10, 30, 0, time.UTC),
+		Account:  s.InitAccounts[0],
+		Category: s.InitCategories[1],
+		Payee:    &model.Payee{Name: "Supermarket"},
+		Amount:   -4321,
+	}
+
+	err := s.service.Transaction().Insert(transaction)
+	require.NoError(s.T(), err)
+
+	// new payee is created with transaction category as default one
+	supermarket := s.service.Payee().GetByName("Supermarket")
+	require.NotNil(s.T(), supermarket)
+	assert.Equal(s.T(), s.InitCategories[1], supermarket.Category)
+	assert.Same(s.T(), supermarket, transaction.Payee)
+
+	// existing payee is reused
+	updated := *transaction
+	updated.Payee = &model.Payee{Name: "Supermarket"}
+	err = s.service.Transaction().Update(&updated)
+	require.NoError(s.T(), err)
+	assert.Same(s.T(), supermarket, updated.Payee)
+	assert.Len(s.T(), s.service.Payee().GetAll(), 1)
+
+	// payees are linked on init
+	err = s.service.Transaction().Init(s.service.Category(), s.service.Account())
+	require.NoError(s.T(), err)
+	assert.Same(s.T(), supermarket, s.service.Transaction().GetByID(updated.ID).Payee)
+
+	_, err = s.db.Exec(`UPDATE "transaction" SET payeeId = NULL; DELETE FROM payee;`)
+	require.NoError(s.T(), err)
+	err = s.service.Payee().Init()
+	require.NoError(s.T(), err)
+}
+
+func (s *TransactionServiceTestSuite) TestSplits() {
+	transaction := &model.Transaction{
+		Date:     time.Date(2022, time.Month(2), 23, 1, 10, 30, 0, time.UTC),
+		Account:  s.InitAccounts[0],
+		Category: s.InitCategories[1],
+		Amount:   -1500,
+		Splits: []*model.Split{
+			{Category: s.InitCategories[1], Amount: -1000, Note: "bread"},
+			{Category: s.InitCategories[0], Amount: -400, Note: "pills"},
+		},
+	}
+
+	err := s.service.Transaction().Insert(transaction)
+	assert.EqualError(s.T(), err,
+		"s.validateSplits: sum of splits -14.00 doesn't match transaction amount -15.00")
+
+	transaction.Splits[1].Amount = -500
+	err = s.service.Transaction().Insert(transaction)
+	require.NoError(s.T(), err)
+
+	// splits are linked on init
+	err = s.service.Transaction().Init(s.service.Category(), s.service.Account())
+	require.NoError(s.T(), err)
+	actual := s.service.Transaction().GetByID(transaction.ID)
+	assert.Equal(s.T(), transaction.Splits, actual.Splits)
+	assert.Equal(s.T(), transaction.Splits, s.service.Transaction().SplitsOf(actual))
+
+	actual.Splits = nil
+	err = s.service.Transaction().Update(actual)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Split{{TransactionID: actual.ID, Category: s.InitCategories[1], Amount: -1500}},
+		s.service.Transaction().SplitsOf(actual))
+
+	err = s.service.Transaction().Delete(actual)
+	require.NoError(s.T(), err)
+}
+
+func (s *TransactionServiceTestSuite) TestCheckKind() {
+	salary := &model.Category{Title: "Salary", Kind: model.Income}
+	grocery := &model.Category{Title: "Grocery", Kind: model.Expense}
+
+	transaction := &model.Transaction{Account: s.InitAccounts[0], Category: grocery, Amount: -1500}
+	assert.NoError(s.T(), s.service.Transaction().CheckKind(transaction))
+
+	transaction.Amount = 1500
+	err := s.service.Transaction().CheckKind(transaction)
+	assert.ErrorIs(s.T(), err, service.ErrKindMismatch)
+	assert.EqualError(s.T(), err,
+		`positive amount in expense category "Grocery": sign of amount contradicts kind of category`)
+
+	// category without kind could be both.
+	transaction.Category = s.InitCategories[0]
+	assert.NoError(s.T(), s.service.Transaction().CheckKind(transaction))
+
+	transaction.Splits = []*model.Split{{Category: grocery, Amount: 2000}, {Category: salary, Amount: -500}}
+	assert.EqualError(s.T(), s.service.Transaction().CheckKind(transaction),
+		`positive amount in expense category "Grocery": sign of amount contradicts kind of category`)
+}
+
+func (s *TransactionServiceTestSuite) TestTotalsByKind() {
+	salary := &model.Category{Title: "Salary", Kind: model.Income}
+	grocery := &model.Category{Title: "Grocery", Kind: model.Expense}
+	gifts := &model.Category{Title: "Gifts", Kind: model.Both}
+	refunds := &model.Category{Title: "Refunds"}
+	card := s.InitAccounts[0]
+
+	tt := []*model.Transaction{
+		{Date: date(2022, 1, 2), Account: card, Category: salary, Amount: 5000},
+		{Date: date(2022, 2, 2), Account: card, Category: grocery, Amount: -1000},
+		{Date: date(2022, 2, 2), Account: card, Category: grocery, Amount: -300,
+			Splits: []*model.Split{{Category: grocery, Amount: -200}, {Category: refunds, Amount: -100}}},
+		{Date: date(2022, 2, 3), Account: card, Category: gifts, Amount: 700},
+		{Date: date(2022, 2, 4), Account: card, Category: gifts, Amount: -400},
+		{Date: date(2022, 2, 5), Category: gifts, Amount: 900},
+	}
+
+	// transfer between own accounts is neither income nor expense
+	transfer := s.newTransfer(1000, 900)
+	require.NoError(s.T(), s.service.Transaction().InsertTransfer(transfer))
+	tt = append(tt, transfer.From, transfer.To)
+
+	totals, err := s.service.Transaction().TotalsByKind(tt)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), map[model.CategoryKind]int64{model.Income: 5700, model.Expense: -1700}, totals)
+
+	total, err := s.service.ExchangeRate().TotalInMain([]*model.Transaction{transfer.From, transfer.To})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), total)
+
+	tt[3].Amount = math.MaxInt64
+	_, err = s.service.Transaction().TotalsByKind(tt)
+	assert.ErrorIs(s.T(), err, money.ErrOverflow)
+}
+
+func (s *TransactionServiceTestSuite) TestPurgeWithAttachments() {
+	file := filepath.Join(s.T().TempDir(), "receipt.pdf")
+	require.NoError(s.T(), os.WriteFile(file, []byte("receipt"), 0o600))
+
+	tr := s.service.Transaction().GetByID(2)
+	a, err := s.service.Attachment().Add(tr, file)
+	require.NoError(s.T(), err)
+
+	err = s.service.Transaction().Delete(tr)
+	require.NoError(s.T(), err)
+
+	// attachments are kept while transaction is in trash
+	actualAttachments, err := s.persistentStorage.Attachment().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), actualAttachments, 1)
+	assert.FileExists(s.T(), s.service.Attachment().Path(a))
+
+	// file isn't deleted unless purge is committed
+	err = s.service.WithTx(func() error {
+		if err := s.service.Transaction().Purge(tr); err != nil {
+			return err
+		}
+		assert.FileExists(s.T(), s.service.Attachment().Path(a))
+		return errors.New("abort")
+	})
+	require.EqualError(s.T(), err, "abort")
+	assert.FileExists(s.T(), s.service.Attachment().Path(a))
+	assert.Equal(s.T(), []*model.Attachment{a}, s.service.Attachment().GetByTransaction(tr))
+
+	err = s.service.Transaction().Purge(tr)
+	require.NoError(s.T(), err)
+
+	actualAttachments, err = s.persistentStorage.Attachment().GetAll()
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), actualAttachments)
+	assert.NoFileExists(s.T(), s.service.Attachment().Path(a))
+}
+
+func (s *TransactionServiceTestSuite) TestSplitTransferLegNegative() {
+	transfer := s.newTransfer(1000, 1000)
+	err := s.service.Transaction().InsertTransfer(transfer)
+	require.NoError(s.T(), err)
+
+	leg := *transfer.From
+	leg.Splits = []*model.Split{{Category: s.InitCategories[0], Amount: -1000}}
+	err = s.service.Transaction().Update(&leg)
+	assert.EqualError(s.T(), err, "transfer can't be split")
+}
+
+func (s *TransactionServiceTestSuite) TestBalance() {
+	account := s.service.Account().GetByID(1)
+	account.OpeningBalance, account.OpeningDate = 1000, time.Date(2022, time.Month(2), 1, 0, 0, 0, 0, time.UTC)
+	defer func() { account.OpeningBalance, account.OpeningDate = 0, time.Time{} }()
+
+	before := &model.Transaction{Date: time.Date(2022, time.Month(1), 10, 0, 0, 0, 0, time.UTC),
+		Account: account, Category: s.InitCategories[0], Amount: -100}
+	after := &model.Transaction{Date: time.Date(2022, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+		Account: account, Category: s.InitCategories[0], Amount: -345}
+	for _, t := range []*model.Transaction{after, before} {
+		require.NoError(s.T(), s.service.Transaction().Insert(t))
+	}
+
+	balance, err := s.service.Transaction().Balance(account)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(13000), balance)
+	assert.Equal(s.T(), int64(13345), s.balanceAt(account, time.Date(2022, time.Month(2), 25, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(s.T(), int64(1000), s.balanceAt(account, time.Date(2022, time.Month(2), 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(s.T(), int64(0), s.balanceAt(account, time.Date(2022, time.Month(1), 15, 0, 0, 0, 0, time.UTC)))
+
+	expected := []*service.RegisterEntry{
+		{Transaction: s.service.Transaction().GetByID(1), Balance: 13345},
+		{Transaction: after, Balance: 13000},
+	}
+	register, err := s.service.Transaction().Register(account)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, register)
+
+	// balance which doesn't fit into amount isn't wrapped around
+	account.OpeningBalance = math.MaxInt64
+	_, err = s.service.Transaction().Balance(account)
+	assert.ErrorIs(s.T(), err, money.ErrOverflow)
+	_, err = s.service.Transaction().Register(account)
+	assert.ErrorIs(s.T(), err, money.ErrOverflow)
+}
+
+func (s *TransactionServiceTestSuite) balanceAt(a *model.Account, date time.Time) int64 {
+	balance, err := s.service.Transaction().BalanceAt(a, date)
+	require.NoError(s.T(), err)
+	return balance
+}
+
+func (s *TransactionServiceTestSuite) TestStatus() {
+	transaction := &model.Transaction{Date: time.Date(2022, time.Month(2), 23, 0, 0, 0, 0, time.UTC),
+		Account: s.InitAccounts[0], Category: s.InitCategories[0], Amount: -100}
+	require.NoError(s.T(), s.service.Transaction().Insert(transaction))
+	assert.Equal(s.T(), model.Pending, transaction.Status)
+
+	changed := *transaction
+	changed.Status = ""
+	changed.Note = "CHANGED"
+	require.NoError(s.T(), s.service.Transaction().Update(&changed))
+	assert.Equal(s.T(), model.Pending, changed.Status)
+
+	changed.Status = "unknown"
+	err := s.service.Transaction().Update(&changed)
+	assert.EqualError(s.T(), err, `s.setStatus: unknown transaction status "unknown"`)
+
+	err = s.service.Transaction().SetStatus(&changed, "unknown")
+	assert.EqualError(s.T(), err, `validateStatus: unknown transaction status "unknown"`)
+}
+
+func (s *TransactionServiceTestSuite) TestReconcile() {
+	account := s.service.Account().GetByID(1)
+	date := time.Date(2022, time.Month(2), 28, 0, 0, 0, 0, time.UTC)
+
+	cleared := &model.Transaction{Date: time.Date(2022, time.Month(2), 23, 0, 0, 0, 0, time.UTC),
+		Account: account, Category: s.InitCategories[0], Amount: -100, Status: model.Cleared}
+	pending := &model.Transaction{Date: time.Date(2022, time.Month(2), 24, 0, 0, 0, 0, time.UTC),
+		Account: account, Category: s.InitCategories[0], Amount: -200}
+	later := &model.Transaction{Date: time.Date(2022, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+		Account: account, Category: s.InitCategories[0], Amount: -300, Status: model.Cleared}
+	for _, t := range []*model.Transaction{cleared, pending, later} {
+		require.NoError(s.T(), s.service.Transaction().Insert(t))
+	}
+	require.NoError(s.T(), s.service.Transaction().SetStatus(s.service.Transaction().GetByID(1), model.Cleared))
+
+	balance, err := s.service.Transaction().ClearedBalanceAt(account, date)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(12245), balance)
+
+	unreconciled, err := s.service.Transaction().Unreconciled(account, date)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Transaction{s.service.Transaction().GetByID(1), cleared, pending}, unreconciled)
+
+	err = s.service.Transaction().Reconcile(account, date, 12000)
+	assert.EqualError(s.T(), err, "cleared balance doesn't match statement balance")
+
+	err = s.service.Transaction().Reconcile(account, date, 12245)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), model.Reconciled, cleared.Status)
+	assert.Equal(s.T(), model.Pending, pending.Status)
+	assert.Equal(s.T(), model.Cleared, later.Status)
+	unreconciled, err = s.service.Transaction().Unreconciled(account, date)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Transaction{pending}, unreconciled)
+	assert.ElementsMatch(s.T(), s.getLinkedPersistantTransactions(), s.inmemoryStorage.Transaction().GetAll())
+
+	locked := *cleared
+	locked.Note = "CHANGED"
+	err = s.service.Transaction().Update(&locked)
+	assert.EqualError(s.T(), err, "s.checkUnlocked: reconciled transaction is locked")
+	err = s.service.Transaction().Delete(cleared)
+	assert.EqualError(s.T(), err, "s.checkUnlocked: reconciled transaction is locked")
+
+	require.NoError(s.T(), s.service.Transaction().SetStatus(cleared, model.Cleared))
+	require.NoError(s.T(), s.service.Transaction().SetStatus(s.service.Transaction().GetByID(1), model.Pending))
+	require.NoError(s.T(), s.service.Transaction().Delete(cleared))
+}
+
+func (s *TransactionServiceTestSuite) TestReconciledTransferLocked() {
+	transfer := s.newTransfer(1000, 900)
+	require.NoError(s.T(), s.service.Transaction().InsertTransfer(transfer))
+	require.NoError(s.T(), s.service.Transaction().SetStatus(transfer.To, model.Reconciled))
+
+	leg := *transfer.From
+	leg.Note = "CHANGED"
+	err := s.service.Transaction().Update(&leg)
+	assert.EqualError(s.T(), err, "s.checkUnlocked: reconciled transaction is locked")
+
+	err = s.service.Transaction().Delete(transfer.From)
+	assert.EqualError(s.T(), err, "s.checkUnlocked: reconciled transaction is locked")
+
+	require.NoError(s.T(), s.service.Transaction().SetStatus(transfer.To, model.Pending))
+}
+
+func (s *TransactionServiceTestSuite) TestInsertTransferPositive() {
+	transfer := s.newTransfer(1000, 900)
+
+	err := s.service.Transaction().InsertTransfer(transfer)
+	require.NoError(s.T(), err)
+
+	expectedTransactions := append(s.InitTransactions, transfer.From, transfer.To)
+	assert.ElementsMatch(s.T(), s.getLinkedPersistantTransactions(), expectedTransactions)
+	assert.ElementsMatch(s.T(), s.inmemoryStorage.Transaction().GetAll(), expectedTransactions)
+	assert.Equal(s.T(), transfer, s.service.Transaction().GetTransferByTransactionID(transfer.From.ID))
+	assert.Equal(s.T(), transfer, s.service.Transaction().GetTransferByTransactionID(transfer.To.ID))
+}
+
+func (s *TransactionServiceTestSuite) TestInsertTransferNegative() {
+	for _, tc := range []struct {
+		name     string
+		give     *model.Transfer
+		expected string
+	}{
+		{
+			name: "SameAccount",
+			give: func() *model.Transfer {
+				t := s.newTransfer(1000, 1000)
+				t.To.Account = t.From.Account
+				return t
+			}(),
+			expected: "s.validateTransfer: can't transfer to the same account",
+		},
+		{
+			name:     "WrongSign",
+			give:     s.newTransfer(-1000, 1000),
+			expected: "s.validateTransfer: outgoing amount should be negative and incoming positive",
+		},
+		{
+			name: "SameCurrencyDifferentAmounts",
+			give: func() *model.Transfer {
+				t := s.newTransfer(1000, 900)
+				t.To.Account = &model.Account{ID: 3, Currency: t.From.Account.Currency}
+				return t
+			}(),
+			expected: "s.validateTransfer: amounts of transfer in the same currency should be equal",
+		},
+		{
+			name: "MissingCategory",
+			give: func() *model.Transfer {
+				t := s.newTransfer(1000, 1000)
+				t.To.Category = nil
+				return t
+			}(),
+			expected: "s.validateTransfer: category of transfer leg is missing",
+		},
+		{
+			name: "MissingAccount",
+			give: func() *model.Transfer {
+				t := s.newTransfer(1000, 1000)
+				t.From.Account = nil
+				return t
+			}(),
+			expected: "s.validateTransfer: account of transfer leg is missing",
+		},
+	} {
+		s.Run(tc.name, func() {
+			err := s.service.Transaction().InsertTransfer(tc.give)
+			assert.EqualError(s.T(), err, tc.expected)
+		})
+	}
+}
+
+func (s *TransactionServiceTestSuite) TestInsertTransferFailure() {
+	transfer := s.newTransfer(1000, 900)
+	transfer.To.Category = &model.Category{ID: 999, Title: "Missing"}
+
+	err := s.service.Transaction().InsertTransfer(transfer)
+	require.Error(s.T(), err)
+
+	// neither leg is left behind
+	assert.ElementsMatch(s.T(), s.getLinkedPersistantTransactions(), s.InitTransactions)
+	assert.ElementsMatch(s.T(), s.inmemoryStorage.Transaction().GetAll(), s.InitTransactions)
+	assert.Nil(s.T(), s.service.Transaction().GetTransferByTransactionID(transfer.From.ID))
+}
+
+func (s *TransactionServiceTestSuite) TestUpdateTransferLegPositive() {
+	transfer := s.newTransfer(1000, 900)
+	err := s.service.Transaction().InsertTransfer(transfer)
+	require.NoError(s.T(), err)
+
+	leg := *transfer.To
+	leg.Amount = 950
+	leg.Note = "CHANGED"
+	leg.Date = time.Date(2022, time.Month(3), 1, 0, 0, 0, 0, time.UTC)
+
+	err = s.service.Transaction().Update(&leg)
+	require.NoError(s.T(), err)
+
+	actual := s.service.Transaction().GetTransferByTransactionID(leg.ID)
+	assert.Equal(s.T(), int64(-1000), actual.From.Amount)
+	assert.Equal(s.T(), int64(950), actual.To.Amount)
+	assert.Equal(s.T(), "CHANGED", actual.From.Note)
+	assert.Equal(s.T(), leg.Date, actual.From.Date)
+	assert.ElementsMatch(s.T(), s.getLinkedPersistantTransactions(), s.inmemoryStorage.Transaction().GetAll())
+}
+
+func (s *TransactionServiceTestSuite) TestDeleteTransferLegPositive() {
+	transfer := s.newTransfer(1000, 900)
+	err := s.service.Transaction().InsertTransfer(transfer)
+	require.NoError(s.T(), err)
+
+	err = s.service.Transaction().Delete(transfer.From)
+	require.NoError(s.T(), err)
+
+	assert.ElementsMatch(s.T(), s.getLinkedPersistantTransactions(), s.InitTransactions)
+	assert.ElementsMatch(s.T(), s.inmemoryStorage.Transaction().GetAll(), s.InitTransactions)
+	assert.Nil(s.T(), s.service.Transaction().GetTransferByTransactionID(transfer.To.ID))
+}
+
+func (s *TransactionServiceTestSuite) TestRestoreAndPurge() {
+	tr := s.service.Transaction().GetByID(2)
+	tr.Tags = []*model.Tag{{Name: "vacation"}}
+	require.NoError(s.T(), s.service.Transaction().Update(tr))
+	require.NoError(s.T(), s.service.Transaction().Delete(tr))
+
+	deleted, err := s.service.Transaction().GetDeleted()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), deleted, 1)
+	assert.False(s.T(), deleted[0].DeletedAt.IsZero())
+	assert.Equal(s.T(), s.InitAccounts[1], deleted[0].Account)
+	assert.Equal(s.T(), "vacation", deleted[0].Tags[0].Name)
+
+	require.NoError(s.T(), s.service.Transaction().Restore(deleted[0]))
+	restored := s.service.Transaction().GetByID(2)
+	require.NotNil(s.T(), restored)
+	assert.True(s.T(), restored.DeletedAt.IsZero())
+	assert.Equal(s.T(), "vacation", restored.Tags[0].Name)
+
+	require.NoError(s.T(), s.service.Transaction().Delete(restored))
+	require.NoError(s.T(), s.service.Transaction().Purge(restored))
+
+	deleted, err = s.service.Transaction().GetDeleted()
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), deleted)
+	assert.Empty(s.T(), s.service.Tag().GetTransactionIDs(s.service.Tag().GetByName("vacation")))
+}
+
+func (s *TransactionServiceTestSuite) TestRestoreTransfer() {
+	transfer := s.newTransfer(1000, 900)
+	err := s.service.Transaction().InsertTransfer(transfer)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), s.service.Transaction().Delete(transfer.From))
+
+	// restoring any leg brings back the whole transfer
+	err = s.service.Transaction().Restore(&model.Transaction{ID: transfer.To.ID})
+	require.NoError(s.T(), err)
+
+	actual := s.service.Transaction().GetTransferByTransactionID(transfer.From.ID)
+	require.NotNil(s.T(), actual)
+	assert.Equal(s.T(), int64(-1000), actual.From.Amount)
+	assert.Equal(s.T(), int64(900), actual.To.Amount)
+	assert.ElementsMatch(s.T(), s.getLinkedPersistantTransactions(), s.inmemoryStorage.Transaction().GetAll())
+
+	require.NoError(s.T(), s.service.Transaction().Delete(actual.To))
+	n, err := s.service.Transaction().PurgeBefore(time.Now().Add(time.Minute))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, n)
+}
+
+func (s *TransactionServiceTestSuite) newTransfer(fromAmount, toAmount int64) *model.Transfer {
+	date := time.Date(2022, time.Month(2), 25, 0, 0, 0, 0, time.UTC)
+	return &model.Transfer{
+		From: &model.Transaction{Date: date, Account: s.InitAccounts[0], Category: s.InitCategories[0],
+			Amount: -fromAmount, Note: "transfer"},
+		To: &model.Transaction{Date: date, Account: s.InitAccounts[1], Category: s.InitCategories[0],
+			Amount: toAmount, Note: "transfer"},
+	}
+}
+
 func (s *TransactionServiceTestSuite) getLinkedPersistantTransactions() []*model.Transaction {
 	persistentTransactions, err := s.persistentStorage.Transaction().GetAll()
 	require.NoError(s.T(), err)
@@ -207,6 +731,16 @@ func (s *TransactionServiceTestSuite) getLinkedPersistantTransactions() []*model
 }
 
 func (s *TransactionServiceTestSuite) TearDownTest() {
+	for {
+		tt := s.inmemoryStorage.Transfer().GetAll()
+		if len(tt) == 0 {
+			break
+		}
+
+		err := s.service.Transaction().DeleteTransfer(tt[0])
+		require.NoError(s.T(), err, "occurred in TearDownTest")
+	}
+
 	for {
 		tt := s.service.Transaction().GetAll()
 		if len(tt) == 0 {
@@ -217,6 +751,12 @@ func (s *TransactionServiceTestSuite) TearDownTest() {
 		require.NoError(s.T(), err, "occurred in TearDownTest")
 		s.inmemoryStorage.Transaction().Delete(tt[0])
 	}
+
+	// deleted items are kept in trash, so they are purged along with the rows which refer to them
+	// to reuse ids
+	_, err := s.db.Exec(`DELETE FROM transaction_tag; DELETE FROM split; DELETE FROM attachment;
+                         DELETE FROM transfer; DELETE FROM "transaction" WHERE deletedAt IS NOT NULL;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
 }
 
 func (s *TransactionServiceTestSuite) TearDownSuite() {
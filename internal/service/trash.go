@@ -0,0 +1,194 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+var (
+	// ErrNameInTrash is returned when item with the same name is in trash, names are unique among
+	// deleted items as well.
+	ErrNameInTrash = errors.New("item with the same name is in trash, restore or purge it first")
+	// ErrDependsOnTrash is returned on attempt to restore item which refers to another item in trash.
+	ErrDependsOnTrash = errors.New("item refers to another one in trash, restore it first")
+)
+
+// Trash service contains business logic related to deleted transactions, accounts, categories and
+// currencies.
+type Trash struct {
+	categoryService    *Category
+	currencyService    *Currency
+	accountService     *Account
+	transactionService *Transaction
+	reload             func() error
+}
+
+// NewTrash returns Trash service. Since restored items are new instances, reload is called after
+// restore to relink all the data referring to them.
+func NewTrash(
+	categoryService *Category,
+	currencyService *Currency,
+	accountService *Account,
+	transactionService *Transaction,
+	reload func() error) *Trash {
+
+	return &Trash{
+		categoryService:    categoryService,
+		currencyService:    currencyService,
+		accountService:     accountService,
+		transactionService: transactionService,
+		reload:             reload,
+	}
+}
+
+// GetAll returns all items in trash, recently deleted first.
+func (s *Trash) GetAll() ([]*model.TrashItem, error) {
+	res := make([]*model.TrashItem, 0)
+
+	tt, err := s.transactionService.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.transactionService.GetDeleted: %w", err)
+	}
+	for _, t := range tt {
+		title := fmt.Sprintf("%s %s %s", t.Date.Format("2006-01-02"), t.Money(), t.Note)
+		res = append(res, &model.TrashItem{Kind: model.TrashTransaction, ID: t.ID, Title: title, DeletedAt: t.DeletedAt})
+	}
+
+	aa, err := s.accountService.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.accountService.GetDeleted: %w", err)
+	}
+	for _, a := range aa {
+		res = append(res, &model.TrashItem{Kind: model.TrashAccount, ID: a.ID, Title: a.Name, DeletedAt: a.DeletedAt})
+	}
+
+	cc, err := s.categoryService.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.categoryService.GetDeleted: %w", err)
+	}
+	for _, c := range cc {
+		res = append(res, &model.TrashItem{Kind: model.TrashCategory, ID: c.ID, Title: c.Title, DeletedAt: c.DeletedAt})
+	}
+
+	currencies, err := s.currencyService.GetDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("s.currencyService.GetDeleted: %w", err)
+	}
+	for _, c := range currencies {
+		res = append(res, &model.TrashItem{Kind: model.TrashCurrency, ID: c.ID, Title: c.Abbreviation, DeletedAt: c.DeletedAt})
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].DeletedAt.After(res[j].DeletedAt) })
+
+	return res, nil
+}
+
+// Restore brings item back from trash.
+func (s *Trash) Restore(item *model.TrashItem) error {
+	if err := s.apply(item, s.transactionService.Restore, s.accountService.Restore,
+		s.categoryService.Restore, s.currencyService.Restore); err != nil {
+		return err
+	}
+
+	if err := s.reload(); err != nil {
+		return fmt.Errorf("s.reload: %w", err)
+	}
+
+	return nil
+}
+
+// Purge permanently deletes item from trash.
+func (s *Trash) Purge(item *model.TrashItem) error {
+	return s.apply(item, s.transactionService.Purge, s.accountService.Purge,
+		s.categoryService.Purge, s.currencyService.Purge)
+}
+
+// PurgeBefore permanently deletes items moved to trash before given date and returns their count.
+// Transactions are purged first, since they refer to accounts and categories. Items are purged in
+// passes, item used by other items in trash is purged after them, or kept if they are deleted
+// after the date.
+func (s *Trash) PurgeBefore(date time.Time) (int, error) {
+	n, err := s.transactionService.PurgeBefore(date)
+	if err != nil {
+		return n, fmt.Errorf("s.transactionService.PurgeBefore: %w", err)
+	}
+
+	for purged := true; purged; {
+		purged = false
+
+		items, err := s.GetAll()
+		if err != nil {
+			return n, fmt.Errorf("s.GetAll: %w", err)
+		}
+
+		for _, item := range items {
+			if !item.DeletedAt.Before(date) {
+				continue
+			}
+
+			err = s.Purge(item)
+			if errors.Is(err, ErrInUse) {
+				continue
+			}
+			if err != nil {
+				return n, fmt.Errorf("s.Purge: %w", err)
+			}
+			n, purged = n+1, true
+		}
+	}
+
+	return n, nil
+}
+
+// apply calls the function which corresponds to kind of item with the deleted instance of item.
+func (s *Trash) apply(
+	item *model.TrashItem,
+	transactionFn func(*model.Transaction) error,
+	accountFn func(*model.Account) error,
+	categoryFn func(*model.Category) error,
+	currencyFn func(*model.Currency) error) error {
+
+	switch item.Kind {
+	case model.TrashTransaction:
+		tt, err := s.transactionService.GetDeleted()
+		if err != nil {
+			return fmt.Errorf("s.transactionService.GetDeleted: %w", err)
+		}
+		return applyByID(tt, item.ID, func(t *model.Transaction) int64 { return t.ID }, transactionFn)
+	case model.TrashAccount:
+		aa, err := s.accountService.GetDeleted()
+		if err != nil {
+			return fmt.Errorf("s.accountService.GetDeleted: %w", err)
+		}
+		return applyByID(aa, item.ID, func(a *model.Account) int64 { return a.ID }, accountFn)
+	case model.TrashCategory:
+		cc, err := s.categoryService.GetDeleted()
+		if err != nil {
+			return fmt.Errorf("s.categoryService.GetDeleted: %w", err)
+		}
+		return applyByID(cc, item.ID, func(c *model.Category) int64 { return c.ID }, categoryFn)
+	case model.TrashCurrency:
+		cc, err := s.currencyService.GetDeleted()
+		if err != nil {
+			return fmt.Errorf("s.currencyService.GetDeleted: %w", err)
+		}
+		return applyByID(cc, item.ID, func(c *model.Currency) int64 { return c.ID }, currencyFn)
+	}
+
+	return fmt.Errorf("unknown trash kind %q", item.Kind)
+}
+
+// applyByID calls fn with element of ee which has given id.
+func applyByID[T any](ee []*T, id int64, idOf func(*T) int64, fn func(*T) error) error {
+	for _, e := range ee {
+		if idOf(e) == id {
+			return fn(e)
+		}
+	}
+
+	return fmt.Errorf("item with id %d isn't in trash", id)
+}
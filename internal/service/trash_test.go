@@ -0,0 +1,165 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrashServiceTestSuite struct {
+	suite.Suite
+	db       *sql.DB
+	service  *service.Service
+	usd      *model.Currency
+	eur      *model.Currency
+	account  *model.Account
+	category *model.Category
+}
+
+func (s *TrashServiceTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	db.SetMaxOpenConns(1)
+	s.db = db
+
+	persistentStorage, err := sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	s.service, err = service.New(adapter.Sqlite(persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupTest")
+
+	s.usd = &model.Currency{Abbreviation: "USD", Precision: 2}
+	s.eur = &model.Currency{Abbreviation: "EUR", Precision: 2}
+	s.account = &model.Account{Name: "Cash", Currency: s.eur}
+	s.category = &model.Category{Title: "Grocery"}
+
+	require.NoError(s.T(), s.service.Currency().Insert(s.usd), "occurred in SetupTest")
+	require.NoError(s.T(), s.service.Currency().Insert(s.eur), "occurred in SetupTest")
+	require.NoError(s.T(), s.service.Account().Insert(s.account), "occurred in SetupTest")
+	require.NoError(s.T(), s.service.Category().Insert(s.category), "occurred in SetupTest")
+}
+
+func (s *TrashServiceTestSuite) newTransaction(note string) *model.Transaction {
+	t := &model.Transaction{
+		Date:     time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC),
+		Account:  s.account,
+		Category: s.category,
+		Amount:   -1250,
+		Note:     note,
+	}
+	require.NoError(s.T(), s.service.Transaction().Insert(t))
+
+	return t
+}
+
+func (s *TrashServiceTestSuite) TestGetAll() {
+	t := s.newTransaction("bread")
+	require.NoError(s.T(), s.service.Transaction().Delete(t))
+	require.NoError(s.T(), s.service.Category().Delete(s.category, service.DeletePolicy[model.Category]{}))
+
+	items, err := s.service.Trash().GetAll()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), items, 2)
+
+	assert.Equal(s.T(), model.TrashCategory, items[0].Kind)
+	assert.Equal(s.T(), "Grocery", items[0].Title)
+	assert.Equal(s.T(), model.TrashTransaction, items[1].Kind)
+	assert.Equal(s.T(), "2022-02-21 -12.50 bread", items[1].Title)
+	assert.False(s.T(), items[0].DeletedAt.Before(items[1].DeletedAt))
+}
+
+func (s *TrashServiceTestSuite) TestRestore() {
+	t := s.newTransaction("bread")
+	require.NoError(s.T(), s.service.Transaction().Delete(t))
+	require.NoError(s.T(), s.service.Account().Delete(s.account, service.DeletePolicy[model.Account]{}))
+	require.NoError(s.T(), s.service.Currency().Delete(s.eur, service.DeletePolicy[model.Currency]{}))
+
+	// items are restored in reverse order, since they refer to each other
+	err := s.service.Trash().Restore(&model.TrashItem{Kind: model.TrashTransaction, ID: t.ID})
+	assert.ErrorIs(s.T(), err, service.ErrDependsOnTrash)
+	err = s.service.Trash().Restore(&model.TrashItem{Kind: model.TrashAccount, ID: s.account.ID})
+	assert.ErrorIs(s.T(), err, service.ErrDependsOnTrash)
+
+	for _, item := range []*model.TrashItem{
+		{Kind: model.TrashCurrency, ID: s.eur.ID},
+		{Kind: model.TrashAccount, ID: s.account.ID},
+		{Kind: model.TrashTransaction, ID: t.ID},
+	} {
+		require.NoError(s.T(), s.service.Trash().Restore(item))
+	}
+
+	restored := s.service.Transaction().GetByID(t.ID)
+	require.NotNil(s.T(), restored)
+	assert.Same(s.T(), s.service.Account().GetByName("Cash"), restored.Account)
+	assert.Same(s.T(), s.service.Currency().GetByAbbreviation("EUR"), restored.Account.Currency)
+	assert.False(s.T(), restored.Account.Currency.IsMain)
+
+	items, err := s.service.Trash().GetAll()
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), items)
+}
+
+func (s *TrashServiceTestSuite) TestRestoreMainCurrency() {
+	require.NoError(s.T(), s.service.Account().Delete(s.account, service.DeletePolicy[model.Account]{}))
+	require.NoError(s.T(), s.service.Currency().Delete(s.eur, service.DeletePolicy[model.Currency]{}))
+	require.NoError(s.T(), s.service.Currency().Delete(s.usd, service.DeletePolicy[model.Currency]{}))
+	assert.Nil(s.T(), s.service.Currency().GetMain())
+
+	err := s.service.Trash().Restore(&model.TrashItem{Kind: model.TrashCurrency, ID: s.eur.ID})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "EUR", s.service.Currency().GetMain().Abbreviation)
+
+	// currency which was main before deletion is unmarked, since another one is main already
+	err = s.service.Trash().Restore(&model.TrashItem{Kind: model.TrashCurrency, ID: s.usd.ID})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "EUR", s.service.Currency().GetMain().Abbreviation)
+	assert.False(s.T(), s.service.Currency().GetByAbbreviation("USD").IsMain)
+}
+
+func (s *TrashServiceTestSuite) TestNameInTrash() {
+	require.NoError(s.T(), s.service.Category().Delete(s.category, service.DeletePolicy[model.Category]{}))
+
+	err := s.service.Category().Insert(&model.Category{Title: "Grocery"})
+	assert.ErrorIs(s.T(), err, service.ErrNameInTrash)
+
+	err = s.service.Trash().Purge(&model.TrashItem{Kind: model.TrashCategory, ID: s.category.ID})
+	require.NoError(s.T(), err)
+	assert.NoError(s.T(), s.service.Category().Insert(&model.Category{Title: "Grocery"}))
+}
+
+func (s *TrashServiceTestSuite) TestPurgeBefore() {
+	t := s.newTransaction("bread")
+	require.NoError(s.T(), s.service.Transaction().Delete(t))
+	require.NoError(s.T(), s.service.Account().Delete(s.account, service.DeletePolicy[model.Account]{}))
+
+	n, err := s.service.Trash().PurgeBefore(time.Now().Add(-time.Hour))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 0, n)
+
+	n, err = s.service.Trash().PurgeBefore(time.Now().Add(time.Hour))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, n)
+
+	items, err := s.service.Trash().GetAll()
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), items)
+}
+
+func (s *TrashServiceTestSuite) TearDownTest() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func TestTrashServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashServiceTestSuite))
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+)
+
+// UnitOfWork runs several changes made through services atomically. Inmemory storages are changed
+// along with persistent storage, so if unit of work fails after some of the changes are made, they
+// are rolled back in persistent storage and inmemory storages are reloaded from it. Therefore
+// inmemory storages keep only the changes of succeeded units of work and never diverge from
+// persistent storage.
+type UnitOfWork struct {
+	persistentStorage PersistentStorage
+	history           *History
+	reload            func() error
+	depth             int
+	committed         []func() error
+}
+
+// NewUnitOfWork returns UnitOfWork which runs inside of transactions of given persistent storage.
+// Operations recorded to history inside of unit of work are undone together, reload is called to
+// rebuild all inmemory storages when unit of work fails.
+func NewUnitOfWork(persistentStorage PersistentStorage, history *History, reload func() error) *UnitOfWork {
+	return &UnitOfWork{
+		persistentStorage: persistentStorage,
+		history:           history,
+		reload:            reload,
+	}
+}
+
+// Do runs fn as a single unit of work, changes made inside of fn are committed only if it
+// succeeds. Unit of work started inside of another one joins it, so it is committed or rolled
+// back along with the outer one. Nil UnitOfWork just calls fn, so services work without it.
+func (u *UnitOfWork) Do(fn func() error) error {
+	if u == nil {
+		return fn()
+	}
+
+	if u.depth > 0 {
+		return fn()
+	}
+
+	committed, err := u.do(fn)
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range committed {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AfterCommit calls fn once changes of current unit of work are committed, it is used for changes
+// which can't be rolled back, like removal of files. Fn is dropped if unit of work fails, outside
+// of unit of work it is called at once. Error of fn is returned by Do, while the changes are kept.
+func (u *UnitOfWork) AfterCommit(fn func() error) error {
+	if u == nil || u.depth == 0 {
+		return fn()
+	}
+
+	u.committed = append(u.committed, fn)
+
+	return nil
+}
+
+// do runs fn inside of transaction of persistent storage and returns funcs to call after commit.
+func (u *UnitOfWork) do(fn func() error) (committed []func() error, err error) {
+	defer u.history.group(&err)()
+
+	u.depth++
+	err = u.persistentStorage.InTx(fn)
+	u.depth--
+
+	committed, u.committed = u.committed, nil
+
+	if err == nil {
+		return committed, nil
+	}
+
+	if rlErr := u.reload(); rlErr != nil {
+		return nil, fmt.Errorf("u.reload: %v: %w", rlErr, err)
+	}
+
+	return nil, err
+}
@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/app/adapter"
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitOfWorkServiceTestSuite struct {
+	suite.Suite
+	db                *sql.DB
+	persistentStorage *sqlite.SqliteStorage
+	service           *service.Service
+	account           *model.Account
+	category          *model.Category
+}
+
+func (s *UnitOfWorkServiceTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	db.SetMaxOpenConns(1)
+	s.db = db
+
+	s.persistentStorage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	s.service, err = service.New(adapter.Sqlite(s.persistentStorage), adapter.Inmemory(inmemory.New()), s.T().TempDir())
+	require.NoError(s.T(), err, "occurred in SetupTest")
+
+	currency := &model.Currency{Abbreviation: "USD", Precision: 2}
+	s.account = &model.Account{Name: "Cash", Currency: currency}
+	s.category = &model.Category{Title: "Grocery"}
+
+	require.NoError(s.T(), s.service.Currency().Insert(currency), "occurred in SetupTest")
+	require.NoError(s.T(), s.service.Account().Insert(s.account), "occurred in SetupTest")
+	require.NoError(s.T(), s.service.Category().Insert(s.category), "occurred in SetupTest")
+}
+
+func (s *UnitOfWorkServiceTestSuite) newTransaction() *model.Transaction {
+	return &model.Transaction{
+		Date:     time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC),
+		Account:  s.account,
+		Category: s.category,
+		Amount:   -1250,
+		Note:     "bread",
+		Payee:    &model.Payee{Name: "Bakery"},
+		Tags:     []*model.Tag{{Name: "daily"}},
+	}
+}
+
+func (s *UnitOfWorkServiceTestSuite) TestWithTxCommit() {
+	err := s.service.WithTx(func() error {
+		if err := s.service.Category().Insert(&model.Category{Title: "Health"}); err != nil {
+			return err
+		}
+		return s.service.Transaction().Insert(s.newTransaction())
+	})
+	require.NoError(s.T(), err)
+	s.assertInSync()
+	assert.NotNil(s.T(), s.service.Category().GetByTitle("Health"))
+	assert.Len(s.T(), s.service.Transaction().GetAll(), 1)
+
+	// changes made inside of unit of work are undone together
+	description, err := s.service.History().Undo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert category, insert transaction", description)
+	assert.Nil(s.T(), s.service.Category().GetByTitle("Health"))
+	assert.Empty(s.T(), s.service.Transaction().GetAll())
+}
+
+func (s *UnitOfWorkServiceTestSuite) TestWithTxRollback() {
+	failure := errors.New("failure")
+
+	err := s.service.WithTx(func() error {
+		if err := s.service.Currency().Insert(&model.Currency{Abbreviation: "EUR", Precision: 2}); err != nil {
+			return err
+		}
+		if err := s.service.Transaction().Insert(s.newTransaction()); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(s.T(), err, failure)
+	s.assertInSync()
+	assert.Nil(s.T(), s.service.Currency().GetByAbbreviation("EUR"))
+	assert.Nil(s.T(), s.service.Payee().GetByName("Bakery"))
+	assert.Empty(s.T(), s.service.Transaction().GetAll())
+
+	// operations of failed unit of work aren't recorded
+	description, err := s.service.History().Undo()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "insert category", description)
+}
+
+func (s *UnitOfWorkServiceTestSuite) TestInsertRollback() {
+	_, err := s.db.Exec(`CREATE TRIGGER fail BEFORE INSERT ON transaction_tag BEGIN SELECT RAISE(ABORT, 'failure'); END;`)
+	require.NoError(s.T(), err)
+
+	// payee and tag are created before tags of transaction fail to be saved
+	err = s.service.Transaction().Insert(s.newTransaction())
+	assert.ErrorContains(s.T(), err, "failure")
+	s.assertInSync()
+	assert.Empty(s.T(), s.service.Transaction().GetAll())
+	assert.Nil(s.T(), s.service.Payee().GetByName("Bakery"))
+	assert.Nil(s.T(), s.service.Tag().GetByName("daily"))
+
+	// inmemory storages are reloaded, so the existing data is still linked
+	require.Len(s.T(), s.service.Account().GetAll(), 1)
+	assert.Same(s.T(), s.service.Currency().GetByAbbreviation("USD"), s.service.Account().GetAll()[0].Currency)
+}
+
+func (s *UnitOfWorkServiceTestSuite) TestInsertMainCurrencyRollback() {
+	_, err := s.db.Exec(`CREATE TRIGGER fail BEFORE UPDATE OF isMain ON currency BEGIN SELECT RAISE(ABORT, 'failure'); END;`)
+	require.NoError(s.T(), err)
+
+	// currency is inserted before it fails to be marked as main
+	err = s.service.Currency().Insert(&model.Currency{Abbreviation: "EUR", Precision: 2, IsMain: true})
+	assert.ErrorContains(s.T(), err, "failure")
+	s.assertInSync()
+	assert.Nil(s.T(), s.service.Currency().GetByAbbreviation("EUR"))
+	assert.Equal(s.T(), "USD", s.service.Currency().GetMain().Abbreviation)
+}
+
+// assertInSync checks that inmemory storages contain exactly the data of persistent storage.
+func (s *UnitOfWorkServiceTestSuite) assertInSync() {
+	currencies, err := s.persistentStorage.Currency().GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), ids(currencies, func(c *model.Currency) int64 { return c.ID }),
+		ids(s.service.Currency().GetAll(), func(c *model.Currency) int64 { return c.ID }))
+
+	categories, err := s.persistentStorage.Category().GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), ids(categories, func(c *model.Category) int64 { return c.ID }),
+		ids(s.service.Category().GetAll(), func(c *model.Category) int64 { return c.ID }))
+
+	payees, err := s.persistentStorage.Payee().GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), ids(payees, func(p *model.Payee) int64 { return p.ID }),
+		ids(s.service.Payee().GetAll(), func(p *model.Payee) int64 { return p.ID }))
+
+	transactions, err := s.persistentStorage.Transaction().GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), ids(transactions, func(t *model.Transaction) int64 { return t.ID }),
+		ids(s.service.Transaction().GetAll(), func(t *model.Transaction) int64 { return t.ID }))
+}
+
+// ids returns ids of given models.
+func ids[T any](ee []*T, idOf func(*T) int64) []int64 {
+	res := make([]int64, 0, len(ee))
+	for _, e := range ee {
+		res = append(res, idOf(e))
+	}
+
+	return res
+}
+
+func (s *UnitOfWorkServiceTestSuite) TearDownTest() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func TestUnitOfWorkServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(UnitOfWorkServiceTestSuite))
+}
@@ -22,6 +22,9 @@ func NewAccount() *Account {
 
 // Init initialize inmemory storage with given slice of data.
 func (s *Account) Init(aa []*model.Account) {
+	s.accountByID = make(map[int64]*model.Account)
+	s.accountByName = make(map[string]*model.Account)
+
 	for _, a := range aa {
 		s.accountByID[a.ID] = a
 		s.accountByName[a.Name] = a
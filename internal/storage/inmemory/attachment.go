@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Attachment is used to acces inmemory storage. Attachments are kept in order they are added, so
+// attachments of transaction are listed in the same order.
+type Attachment struct {
+	attachments    []*model.Attachment
+	attachmentByID map[int64]*model.Attachment
+}
+
+// NewAttachment returns new attachment inmemory storage.
+func NewAttachment() *Attachment {
+	return &Attachment{
+		attachments:    make([]*model.Attachment, 0, 20),
+		attachmentByID: make(map[int64]*model.Attachment),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *Attachment) Init(aa []*model.Attachment) {
+	s.attachmentByID = make(map[int64]*model.Attachment)
+
+	for _, a := range aa {
+		s.attachmentByID[a.ID] = a
+	}
+	s.attachments = aa
+}
+
+// Insert appends attachment to inmemory storage.
+func (s *Attachment) Insert(a *model.Attachment) {
+	s.attachmentByID[a.ID] = a
+	s.attachments = append(s.attachments, a)
+}
+
+// Delete removes attachment from current inmemory storage.
+func (s *Attachment) Delete(a *model.Attachment) {
+	delete(s.attachmentByID, a.ID)
+
+	for i, aa := range s.attachments {
+		if aa.ID == a.ID {
+			s.attachments = append(s.attachments[:i], s.attachments[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetAll returns slice of attachments.
+func (s *Attachment) GetAll() []*model.Attachment {
+	return s.attachments
+}
+
+// GetByID returns attachment by its id.
+func (s *Attachment) GetByID(id int64) *model.Attachment {
+	return s.attachmentByID[id]
+}
+
+// GetByTransactionID returns attachments of transaction with given id.
+func (s *Attachment) GetByTransactionID(transactionID int64) []*model.Attachment {
+	res := make([]*model.Attachment, 0)
+
+	for _, a := range s.attachments {
+		if a.TransactionID == transactionID {
+			res = append(res, a)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,64 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage         *inmemory.Attachment
+	InitAttachments []*model.Attachment
+}
+
+func (s *AttachmentInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewAttachment()
+	s.InitAttachments = []*model.Attachment{
+		{ID: 1, TransactionID: 1, Name: "receipt.jpg", Hash: "a1"},
+		{ID: 2, TransactionID: 2, Name: "warranty.pdf", Hash: "b2"},
+		{ID: 3, TransactionID: 1, Name: "invoice.pdf", Hash: "c3"},
+	}
+}
+
+func (s *AttachmentInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(append([]*model.Attachment(nil), s.InitAttachments...))
+}
+
+func (s *AttachmentInmemoryStorageTestSuite) TestInsertPositive() {
+	attachment := &model.Attachment{ID: 4, TransactionID: 2, Name: "check.png", Hash: "d4"}
+	expectedAttachments := append(append([]*model.Attachment(nil), s.InitAttachments...), attachment)
+
+	s.storage.Insert(attachment)
+
+	assert.Equal(s.T(), expectedAttachments, s.storage.GetAll())
+	assert.Equal(s.T(), attachment, s.storage.GetByID(attachment.ID))
+}
+
+func (s *AttachmentInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.Delete(s.InitAttachments[0])
+
+	assert.Equal(s.T(), s.InitAttachments[1:], s.storage.GetAll())
+	assert.Nil(s.T(), s.storage.GetByID(1))
+}
+
+func (s *AttachmentInmemoryStorageTestSuite) TestGetByTransactionID() {
+	expectedAttachments := []*model.Attachment{s.InitAttachments[0], s.InitAttachments[2]}
+
+	assert.Equal(s.T(), expectedAttachments, s.storage.GetByTransactionID(1))
+	assert.Empty(s.T(), s.storage.GetByTransactionID(3))
+}
+
+func (s *AttachmentInmemoryStorageTestSuite) TearDownTest() {
+	for len(s.storage.GetAll()) > 0 {
+		s.storage.Delete(s.storage.GetAll()[0])
+	}
+}
+
+func TestAttachmentInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentInmemoryStorageTestSuite))
+}
@@ -0,0 +1,83 @@
+package inmemory
+
+import (
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Budget is used to acces inmemory storage.
+type Budget struct {
+	budgets    []*model.Budget
+	budgetByID map[int64]*model.Budget
+}
+
+// NewBudget returns new budget inmemory storage.
+func NewBudget() *Budget {
+	return &Budget{
+		budgets:    make([]*model.Budget, 0, 20),
+		budgetByID: make(map[int64]*model.Budget),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *Budget) Init(bb []*model.Budget) {
+	s.budgetByID = make(map[int64]*model.Budget)
+
+	for _, b := range bb {
+		s.budgetByID[b.ID] = b
+	}
+	s.budgets = bb
+}
+
+// Insert appends budget to inmemory storage.
+func (s *Budget) Insert(b *model.Budget) {
+	s.budgetByID[b.ID] = b
+	s.budgets = append(s.budgets, b)
+}
+
+// Update updates budget of inmemory storage.
+func (s *Budget) Update(b *model.Budget) {
+	s.budgetByID[b.ID] = b
+
+	for i, bb := range s.budgets {
+		if bb.ID == b.ID {
+			s.budgets[i] = b
+			return
+		}
+	}
+}
+
+// Delete removes budget from current inmemory storage.
+func (s *Budget) Delete(b *model.Budget) {
+	delete(s.budgetByID, b.ID)
+
+	for i, bb := range s.budgets {
+		if bb.ID == b.ID {
+			last := len(s.budgets) - 1
+			s.budgets[i] = s.budgets[last]
+			s.budgets = s.budgets[:last]
+		}
+	}
+}
+
+// GetAll returns slice of budgets.
+func (s *Budget) GetAll() []*model.Budget {
+	return s.budgets
+}
+
+// GetByID returns budget by its id.
+func (s *Budget) GetByID(id int64) *model.Budget {
+	return s.budgetByID[id]
+}
+
+// GetByPeriod returns budgets of given period.
+func (s *Budget) GetByPeriod(period time.Time) []*model.Budget {
+	res := make([]*model.Budget, 0, len(s.budgets))
+	for _, b := range s.budgets {
+		if b.Period.Equal(period) {
+			res = append(res, b)
+		}
+	}
+	return res
+}
@@ -0,0 +1,71 @@
+package inmemory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage     *inmemory.Budget
+	InitBudgets []*model.Budget
+}
+
+func (s *BudgetInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewBudget()
+	s.InitBudgets = []*model.Budget{
+		{ID: 1, Category: &model.Category{ID: 1}, Period: time.Date(2022, time.Month(2), 1, 0, 0, 0, 0, time.UTC), Limit: 1000},
+		{ID: 2, Category: &model.Category{ID: 2}, Period: time.Date(2022, time.Month(3), 1, 0, 0, 0, 0, time.UTC), Limit: 2000},
+	}
+}
+
+func (s *BudgetInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(append([]*model.Budget(nil), s.InitBudgets...))
+}
+
+func (s *BudgetInmemoryStorageTestSuite) TestInsertPositive() {
+	budget := &model.Budget{ID: 3, Category: &model.Category{ID: 3}, Period: s.InitBudgets[0].Period, Limit: 3000}
+	expectedBudgets := append(append([]*model.Budget(nil), s.InitBudgets...), budget)
+
+	s.storage.Insert(budget)
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), expectedBudgets)
+	assert.Equal(s.T(), budget, s.storage.GetByID(budget.ID))
+}
+
+func (s *BudgetInmemoryStorageTestSuite) TestUpdatePositive() {
+	budget := &model.Budget{ID: 2, Category: &model.Category{ID: 2}, Period: s.InitBudgets[1].Period, Limit: 2500}
+
+	s.storage.Update(budget)
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), []*model.Budget{s.InitBudgets[0], budget})
+	assert.Equal(s.T(), budget, s.storage.GetByID(budget.ID))
+}
+
+func (s *BudgetInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.Delete(s.InitBudgets[1])
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), []*model.Budget{s.InitBudgets[0]})
+	assert.Nil(s.T(), s.storage.GetByID(2))
+}
+
+func (s *BudgetInmemoryStorageTestSuite) TestGetByPeriod() {
+	budgets := s.storage.GetByPeriod(time.Date(2022, time.Month(3), 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(s.T(), []*model.Budget{s.InitBudgets[1]}, budgets)
+}
+
+func (s *BudgetInmemoryStorageTestSuite) TearDownTest() {
+	for len(s.storage.GetAll()) > 0 {
+		s.storage.Delete(s.storage.GetAll()[0])
+	}
+}
+
+func TestBudgetInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetInmemoryStorageTestSuite))
+}
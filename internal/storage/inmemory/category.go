@@ -6,25 +6,24 @@ import (
 
 // Category is used to acces inmemory storage.
 type Category struct {
-	categories      []*model.Category
-	categoryByID    map[int64]*model.Category
-	categoryByTitle map[string]*model.Category
+	categories   []*model.Category
+	categoryByID map[int64]*model.Category
 }
 
 // NewCategory returns new category inmemory storage.
 func NewCategory() *Category {
 	return &Category{
-		categories:      make([]*model.Category, 0, 20),
-		categoryByID:    make(map[int64]*model.Category),
-		categoryByTitle: make(map[string]*model.Category),
+		categories:   make([]*model.Category, 0, 20),
+		categoryByID: make(map[int64]*model.Category),
 	}
 }
 
 // Init initialize inmemory storage with given slice of data.
 func (s *Category) Init(cc []*model.Category) {
+	s.categoryByID = make(map[int64]*model.Category)
+
 	for _, c := range cc {
 		s.categoryByID[c.ID] = c
-		s.categoryByTitle[c.Title] = c
 	}
 	s.categories = cc
 }
@@ -32,16 +31,12 @@ func (s *Category) Init(cc []*model.Category) {
 // Insert appends category to inmemory storage.
 func (s *Category) Insert(c *model.Category) {
 	s.categoryByID[c.ID] = c
-	s.categoryByTitle[c.Title] = c
 	s.categories = append(s.categories, c)
 }
 
 // Update updates category of inmemory storage.
 func (s *Category) Update(c *model.Category) {
-	delete(s.categoryByTitle, s.categoryByID[c.ID].Title)
-
 	s.categoryByID[c.ID] = c
-	s.categoryByTitle[c.Title] = c
 
 	for i, cc := range s.categories {
 		if cc.ID == c.ID {
@@ -54,7 +49,6 @@ func (s *Category) Update(c *model.Category) {
 // Delete removes category from current inmemory storage.
 func (s *Category) Delete(c *model.Category) {
 	delete(s.categoryByID, c.ID)
-	delete(s.categoryByTitle, c.Title)
 
 	for i, cc := range s.categories {
 		if cc.ID == c.ID {
@@ -75,7 +69,13 @@ func (s *Category) GetByID(id int64) *model.Category {
 	return s.categoryByID[id]
 }
 
-// GetByID returns category by its title.
+// GetByTitle returns the first category with given title, titles are unique only within parent.
 func (s *Category) GetByTitle(title string) *model.Category {
-	return s.categoryByTitle[title]
+	for _, c := range s.categories {
+		if c.Title == title {
+			return c
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,84 @@
+package inmemory
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Counterparty is used to acces inmemory storage.
+type Counterparty struct {
+	counterparties     []*model.Counterparty
+	counterpartyByID   map[int64]*model.Counterparty
+	counterpartyByName map[string]*model.Counterparty
+}
+
+// NewCounterparty returns new counterparty inmemory storage.
+func NewCounterparty() *Counterparty {
+	return &Counterparty{
+		counterparties:     make([]*model.Counterparty, 0, 20),
+		counterpartyByID:   make(map[int64]*model.Counterparty),
+		counterpartyByName: make(map[string]*model.Counterparty),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *Counterparty) Init(cc []*model.Counterparty) {
+	s.counterpartyByID = make(map[int64]*model.Counterparty)
+	s.counterpartyByName = make(map[string]*model.Counterparty)
+
+	for _, c := range cc {
+		s.counterpartyByID[c.ID] = c
+		s.counterpartyByName[c.Name] = c
+	}
+	s.counterparties = cc
+}
+
+// Insert appends counterparty to inmemory storage.
+func (s *Counterparty) Insert(c *model.Counterparty) {
+	s.counterpartyByID[c.ID] = c
+	s.counterpartyByName[c.Name] = c
+	s.counterparties = append(s.counterparties, c)
+}
+
+// Update updates counterparty of inmemory storage.
+func (s *Counterparty) Update(c *model.Counterparty) {
+	delete(s.counterpartyByName, s.counterpartyByID[c.ID].Name)
+
+	s.counterpartyByID[c.ID] = c
+	s.counterpartyByName[c.Name] = c
+
+	for i, cc := range s.counterparties {
+		if cc.ID == c.ID {
+			s.counterparties[i] = c
+			return
+		}
+	}
+}
+
+// Delete removes counterparty from current inmemory storage.
+func (s *Counterparty) Delete(c *model.Counterparty) {
+	delete(s.counterpartyByID, c.ID)
+	delete(s.counterpartyByName, c.Name)
+
+	for i, cc := range s.counterparties {
+		if cc.ID == c.ID {
+			last := len(s.counterparties) - 1
+			s.counterparties[i] = s.counterparties[last]
+			s.counterparties = s.counterparties[:last]
+		}
+	}
+}
+
+// GetAll returns slice of counterparties.
+func (s *Counterparty) GetAll() []*model.Counterparty {
+	return s.counterparties
+}
+
+// GetByID returns counterparty by its id.
+func (s *Counterparty) GetByID(id int64) *model.Counterparty {
+	return s.counterpartyByID[id]
+}
+
+// GetByName returns counterparty by its name.
+func (s *Counterparty) GetByName(name string) *model.Counterparty {
+	return s.counterpartyByName[name]
+}
@@ -0,0 +1,67 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CounterpartyInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage            *inmemory.Counterparty
+	InitCounterparties []*model.Counterparty
+}
+
+func (s *CounterpartyInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewCounterparty()
+	s.InitCounterparties = []*model.Counterparty{
+		{ID: 1, Name: "John"},
+		{ID: 2, Name: "Bank"},
+	}
+}
+
+func (s *CounterpartyInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(append([]*model.Counterparty(nil), s.InitCounterparties...))
+}
+
+func (s *CounterpartyInmemoryStorageTestSuite) TestInsertPositive() {
+	counterparty := &model.Counterparty{ID: 3, Name: "Alice"}
+	expectedCounterparties := append(s.InitCounterparties, counterparty)
+
+	s.storage.Insert(counterparty)
+
+	assert.ElementsMatch(s.T(), expectedCounterparties, s.storage.GetAll())
+	assert.Equal(s.T(), counterparty, s.storage.GetByID(counterparty.ID))
+	assert.Equal(s.T(), counterparty, s.storage.GetByName(counterparty.Name))
+}
+
+func (s *CounterpartyInmemoryStorageTestSuite) TestUpdatePositive() {
+	counterparty := &model.Counterparty{ID: 2, Name: "Credit union"}
+
+	s.storage.Update(counterparty)
+
+	assert.ElementsMatch(s.T(), []*model.Counterparty{s.InitCounterparties[0], counterparty}, s.storage.GetAll())
+	assert.Equal(s.T(), counterparty, s.storage.GetByName("Credit union"))
+	assert.Nil(s.T(), s.storage.GetByName("Bank"))
+}
+
+func (s *CounterpartyInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.Delete(s.InitCounterparties[1])
+
+	assert.ElementsMatch(s.T(), s.InitCounterparties[:1], s.storage.GetAll())
+	assert.Nil(s.T(), s.storage.GetByID(2))
+}
+
+func (s *CounterpartyInmemoryStorageTestSuite) TearDownTest() {
+	for len(s.storage.GetAll()) > 0 {
+		s.storage.Delete(s.storage.GetAll()[0])
+	}
+}
+
+func TestCounterpartyInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(CounterpartyInmemoryStorageTestSuite))
+}
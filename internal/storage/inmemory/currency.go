@@ -22,6 +22,9 @@ func NewCurrency() *Currency {
 
 // Init initialize inmemory storage with given slice of data.
 func (s *Currency) Init(cc []*model.Currency) {
+	s.currencyByID = make(map[int64]*model.Currency)
+	s.currencyByAbbr = make(map[string]*model.Currency)
+
 	for _, c := range cc {
 		s.currencyByID[c.ID] = c
 		s.currencyByAbbr[c.Abbreviation] = c
@@ -79,3 +82,20 @@ func (s *Currency) GetByID(id int64) *model.Currency {
 func (s *Currency) GetByAbbreviation(abbreviation string) *model.Currency {
 	return s.currencyByAbbr[abbreviation]
 }
+
+// GetMain returns main currency, or nil if there is no main currency.
+func (s *Currency) GetMain() *model.Currency {
+	for _, c := range s.currencies {
+		if c.IsMain {
+			return c
+		}
+	}
+	return nil
+}
+
+// SetMain marks currency with given id as main and unmarks all others.
+func (s *Currency) SetMain(id int64) {
+	for _, c := range s.currencies {
+		c.IsMain = c.ID == id
+	}
+}
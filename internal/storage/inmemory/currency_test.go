@@ -26,7 +26,8 @@ func (s *CurrencyInmemoryStorageTestSuite) SetupSuite() {
 }
 
 func (s *CurrencyInmemoryStorageTestSuite) SetupTest() {
-	s.storage.Init(s.InitCurrencies)
+	// init with a copy, so teardown doesn't reorder initial data
+	s.storage.Init(append([]*model.Currency(nil), s.InitCurrencies...))
 }
 
 func (s *CurrencyInmemoryStorageTestSuite) TestInsertPositive() {
@@ -58,6 +59,21 @@ func (s *CurrencyInmemoryStorageTestSuite) TestDeletePositive() {
 	assert.ElementsMatch(s.T(), s.storage.GetAll(), s.InitCurrencies[:1])
 }
 
+func (s *CurrencyInmemoryStorageTestSuite) TestSetMainPositive() {
+	assert.Nil(s.T(), s.storage.GetMain())
+
+	s.storage.SetMain(2)
+
+	assert.Equal(s.T(), s.InitCurrencies[1], s.storage.GetMain())
+	assert.False(s.T(), s.InitCurrencies[0].IsMain)
+
+	s.storage.SetMain(1)
+
+	assert.Equal(s.T(), s.InitCurrencies[0], s.storage.GetMain())
+	assert.False(s.T(), s.InitCurrencies[1].IsMain)
+	s.InitCurrencies[0].IsMain = false // return initial state to proper teardown
+}
+
 func (s *CurrencyInmemoryStorageTestSuite) TearDownTest() {
 	for {
 		cc := s.storage.GetAll()
@@ -0,0 +1,114 @@
+package inmemory
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Debt is used to acces inmemory storage. Besides debts it keeps index of repayment transactions by
+// debt.
+type Debt struct {
+	debts                 []*model.Debt
+	debtByID              map[int64]*model.Debt
+	transactionIDsByDebt  map[int64][]int64
+	debtIDByTransactionID map[int64]int64
+}
+
+// NewDebt returns new debt inmemory storage.
+func NewDebt() *Debt {
+	return &Debt{
+		debts:                 make([]*model.Debt, 0, 20),
+		debtByID:              make(map[int64]*model.Debt),
+		transactionIDsByDebt:  make(map[int64][]int64),
+		debtIDByTransactionID: make(map[int64]int64),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *Debt) Init(dd []*model.Debt) {
+	s.debtByID = make(map[int64]*model.Debt)
+	s.transactionIDsByDebt = make(map[int64][]int64)
+	s.debtIDByTransactionID = make(map[int64]int64)
+
+	for _, d := range dd {
+		s.debtByID[d.ID] = d
+	}
+	s.debts = dd
+}
+
+// Insert appends debt to inmemory storage.
+func (s *Debt) Insert(d *model.Debt) {
+	s.debtByID[d.ID] = d
+	s.debts = append(s.debts, d)
+}
+
+// Update updates debt of inmemory storage.
+func (s *Debt) Update(d *model.Debt) {
+	s.debtByID[d.ID] = d
+
+	for i, dd := range s.debts {
+		if dd.ID == d.ID {
+			s.debts[i] = d
+			return
+		}
+	}
+}
+
+// Delete removes debt along with its repayment links from current inmemory storage.
+func (s *Debt) Delete(d *model.Debt) {
+	for _, id := range s.transactionIDsByDebt[d.ID] {
+		delete(s.debtIDByTransactionID, id)
+	}
+	delete(s.transactionIDsByDebt, d.ID)
+	delete(s.debtByID, d.ID)
+
+	for i, dd := range s.debts {
+		if dd.ID == d.ID {
+			last := len(s.debts) - 1
+			s.debts[i] = s.debts[last]
+			s.debts = s.debts[:last]
+		}
+	}
+}
+
+// GetAll returns slice of debts.
+func (s *Debt) GetAll() []*model.Debt {
+	return s.debts
+}
+
+// GetByID returns debt by its id.
+func (s *Debt) GetByID(id int64) *model.Debt {
+	return s.debtByID[id]
+}
+
+// InsertRepayment links transaction to debt as its repayment.
+func (s *Debt) InsertRepayment(r *model.DebtRepayment) {
+	s.transactionIDsByDebt[r.DebtID] = append(s.transactionIDsByDebt[r.DebtID], r.TransactionID)
+	s.debtIDByTransactionID[r.TransactionID] = r.DebtID
+}
+
+// DeleteRepayment unlinks transaction from debt.
+func (s *Debt) DeleteRepayment(r *model.DebtRepayment) {
+	delete(s.debtIDByTransactionID, r.TransactionID)
+
+	ids := s.transactionIDsByDebt[r.DebtID]
+	for i, id := range ids {
+		if id == r.TransactionID {
+			s.transactionIDsByDebt[r.DebtID] = append(ids[:i:i], ids[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetTransactionIDs returns ids of transactions which repay given debt in order of linking.
+func (s *Debt) GetTransactionIDs(debtID int64) []int64 {
+	return s.transactionIDsByDebt[debtID]
+}
+
+// GetByTransactionID returns debt repaid by transaction with given id or nil if there is no such.
+func (s *Debt) GetByTransactionID(transactionID int64) *model.Debt {
+	id, ok := s.debtIDByTransactionID[transactionID]
+	if !ok {
+		return nil
+	}
+	return s.debtByID[id]
+}
@@ -0,0 +1,99 @@
+package inmemory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DebtInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage   *inmemory.Debt
+	InitDebts []*model.Debt
+}
+
+func (s *DebtInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewDebt()
+	s.InitDebts = []*model.Debt{
+		{
+			ID:           1,
+			Counterparty: &model.Counterparty{ID: 1},
+			Direction:    model.Given,
+			Principal:    50000,
+			Currency:     &model.Currency{ID: 1},
+			Date:         time.Date(2023, time.Month(1), 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:           2,
+			Counterparty: &model.Counterparty{ID: 2},
+			Direction:    model.Taken,
+			Principal:    1200000,
+			Currency:     &model.Currency{ID: 1},
+			Date:         time.Date(2023, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+			Installments: 12,
+		},
+	}
+}
+
+func (s *DebtInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(append([]*model.Debt(nil), s.InitDebts...))
+}
+
+func (s *DebtInmemoryStorageTestSuite) TestInsertPositive() {
+	debt := &model.Debt{ID: 3, Counterparty: &model.Counterparty{ID: 1}, Currency: &model.Currency{ID: 1}}
+	expectedDebts := append(s.InitDebts, debt)
+
+	s.storage.Insert(debt)
+
+	assert.ElementsMatch(s.T(), expectedDebts, s.storage.GetAll())
+	assert.Equal(s.T(), debt, s.storage.GetByID(debt.ID))
+}
+
+func (s *DebtInmemoryStorageTestSuite) TestUpdatePositive() {
+	debt := &model.Debt{ID: 2, Counterparty: &model.Counterparty{ID: 2}, Currency: &model.Currency{ID: 1}}
+
+	s.storage.Update(debt)
+
+	assert.ElementsMatch(s.T(), []*model.Debt{s.InitDebts[0], debt}, s.storage.GetAll())
+	assert.Equal(s.T(), debt, s.storage.GetByID(2))
+}
+
+func (s *DebtInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 2, TransactionID: 5})
+
+	s.storage.Delete(s.InitDebts[1])
+
+	assert.ElementsMatch(s.T(), s.InitDebts[:1], s.storage.GetAll())
+	assert.Nil(s.T(), s.storage.GetByID(2))
+	assert.Nil(s.T(), s.storage.GetByTransactionID(5))
+	assert.Empty(s.T(), s.storage.GetTransactionIDs(2))
+}
+
+func (s *DebtInmemoryStorageTestSuite) TestRepayments() {
+	s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 1, TransactionID: 5})
+	s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 1, TransactionID: 7})
+	s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 2, TransactionID: 6})
+
+	assert.Equal(s.T(), []int64{5, 7}, s.storage.GetTransactionIDs(1))
+	assert.Equal(s.T(), s.InitDebts[1], s.storage.GetByTransactionID(6))
+
+	s.storage.DeleteRepayment(&model.DebtRepayment{DebtID: 1, TransactionID: 5})
+
+	assert.Equal(s.T(), []int64{7}, s.storage.GetTransactionIDs(1))
+	assert.Nil(s.T(), s.storage.GetByTransactionID(5))
+}
+
+func (s *DebtInmemoryStorageTestSuite) TearDownTest() {
+	for len(s.storage.GetAll()) > 0 {
+		s.storage.Delete(s.storage.GetAll()[0])
+	}
+}
+
+func TestDebtInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(DebtInmemoryStorageTestSuite))
+}
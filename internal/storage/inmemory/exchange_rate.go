@@ -0,0 +1,106 @@
+package inmemory
+
+import (
+	"sort"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// ExchangeRate is used to acces inmemory storage.
+type ExchangeRate struct {
+	exchangeRates      []*model.ExchangeRate
+	exchangeRateByID   map[int64]*model.ExchangeRate
+	exchangeRateByPair map[[2]int64][]*model.ExchangeRate
+}
+
+// NewExchangeRate returns new exchange rate inmemory storage.
+func NewExchangeRate() *ExchangeRate {
+	return &ExchangeRate{
+		exchangeRates:      make([]*model.ExchangeRate, 0, 20),
+		exchangeRateByID:   make(map[int64]*model.ExchangeRate),
+		exchangeRateByPair: make(map[[2]int64][]*model.ExchangeRate),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *ExchangeRate) Init(rr []*model.ExchangeRate) {
+	s.exchangeRateByID = make(map[int64]*model.ExchangeRate)
+	s.exchangeRateByPair = make(map[[2]int64][]*model.ExchangeRate)
+
+	for _, r := range rr {
+		s.exchangeRateByID[r.ID] = r
+		s.insertToPair(r)
+	}
+	s.exchangeRates = rr
+}
+
+// Insert appends exchange rate to inmemory storage.
+func (s *ExchangeRate) Insert(r *model.ExchangeRate) {
+	s.exchangeRateByID[r.ID] = r
+	s.insertToPair(r)
+	s.exchangeRates = append(s.exchangeRates, r)
+}
+
+// Update updates exchange rate of inmemory storage.
+func (s *ExchangeRate) Update(r *model.ExchangeRate) {
+	s.deleteFromPair(s.exchangeRateByID[r.ID])
+
+	s.exchangeRateByID[r.ID] = r
+	s.insertToPair(r)
+
+	for i, rr := range s.exchangeRates {
+		if rr.ID == r.ID {
+			s.exchangeRates[i] = r
+			return
+		}
+	}
+}
+
+// Delete removes exchange rate from current inmemory storage.
+func (s *ExchangeRate) Delete(r *model.ExchangeRate) {
+	delete(s.exchangeRateByID, r.ID)
+	s.deleteFromPair(r)
+
+	for i, rr := range s.exchangeRates {
+		if rr.ID == r.ID {
+			last := len(s.exchangeRates) - 1
+			s.exchangeRates[i] = s.exchangeRates[last]
+			s.exchangeRates = s.exchangeRates[:last]
+		}
+	}
+}
+
+// GetAll returns slice of exchange rates.
+func (s *ExchangeRate) GetAll() []*model.ExchangeRate {
+	return s.exchangeRates
+}
+
+// GetByID returns exchange rate by its id.
+func (s *ExchangeRate) GetByID(id int64) *model.ExchangeRate {
+	return s.exchangeRateByID[id]
+}
+
+// GetByPair returns exchange rates of given currency pair sorted by date.
+func (s *ExchangeRate) GetByPair(fromID, toID int64) []*model.ExchangeRate {
+	return s.exchangeRateByPair[[2]int64{fromID, toID}]
+}
+
+// insertToPair inserts exchange rate to pair index keeping it sorted by date.
+func (s *ExchangeRate) insertToPair(r *model.ExchangeRate) {
+	key := [2]int64{r.From.ID, r.To.ID}
+	rr := append(s.exchangeRateByPair[key], r)
+	sort.SliceStable(rr, func(i, j int) bool { return rr[i].Date.Before(rr[j].Date) })
+	s.exchangeRateByPair[key] = rr
+}
+
+// deleteFromPair removes exchange rate from pair index.
+func (s *ExchangeRate) deleteFromPair(r *model.ExchangeRate) {
+	key := [2]int64{r.From.ID, r.To.ID}
+	rr := s.exchangeRateByPair[key]
+	for i, e := range rr {
+		if e.ID == r.ID {
+			s.exchangeRateByPair[key] = append(rr[:i:i], rr[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package inmemory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExchangeRateInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage           *inmemory.ExchangeRate
+	InitExchangeRates []*model.ExchangeRate
+}
+
+func (s *ExchangeRateInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewExchangeRate()
+	s.InitExchangeRates = []*model.ExchangeRate{
+		{
+			ID:   1,
+			Date: time.Date(2022, time.Month(2), 22, 0, 0, 0, 0, time.UTC),
+			From: &model.Currency{ID: 2},
+			To:   &model.Currency{ID: 1},
+			Rate: 1.14,
+		},
+		{
+			ID:   2,
+			Date: time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC),
+			From: &model.Currency{ID: 2},
+			To:   &model.Currency{ID: 1},
+			Rate: 1.13,
+		},
+	}
+}
+
+func (s *ExchangeRateInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(s.InitExchangeRates)
+}
+
+func (s *ExchangeRateInmemoryStorageTestSuite) TestInsertPositive() {
+	rate := &model.ExchangeRate{
+		ID:   3,
+		Date: time.Date(2022, time.Month(2), 23, 0, 0, 0, 0, time.UTC),
+		From: &model.Currency{ID: 2},
+		To:   &model.Currency{ID: 1},
+		Rate: 1.12,
+	}
+	expectedExchangeRates := append(s.InitExchangeRates, rate)
+
+	s.storage.Insert(rate)
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), expectedExchangeRates)
+	assert.Equal(s.T(), rate, s.storage.GetByID(rate.ID))
+	assert.Equal(s.T(),
+		[]*model.ExchangeRate{s.InitExchangeRates[1], s.InitExchangeRates[0], rate}, s.storage.GetByPair(2, 1))
+}
+
+func (s *ExchangeRateInmemoryStorageTestSuite) TestUpdatePositive() {
+	rate := &model.ExchangeRate{
+		ID:   2,
+		Date: time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC),
+		From: &model.Currency{ID: 1},
+		To:   &model.Currency{ID: 2},
+		Rate: 0.88,
+	}
+
+	s.storage.Update(rate)
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), []*model.ExchangeRate{s.InitExchangeRates[0], rate})
+	assert.Equal(s.T(), rate, s.storage.GetByID(rate.ID))
+	assert.Equal(s.T(), []*model.ExchangeRate{s.InitExchangeRates[0]}, s.storage.GetByPair(2, 1))
+	assert.Equal(s.T(), []*model.ExchangeRate{rate}, s.storage.GetByPair(1, 2))
+}
+
+func (s *ExchangeRateInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.Delete(s.InitExchangeRates[1])
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), s.InitExchangeRates[:1])
+	assert.Equal(s.T(), s.InitExchangeRates[:1], s.storage.GetByPair(2, 1))
+}
+
+func (s *ExchangeRateInmemoryStorageTestSuite) TearDownTest() {
+	for {
+		rr := s.storage.GetAll()
+		if len(rr) == 0 {
+			break
+		}
+		s.storage.Delete(rr[0])
+	}
+}
+
+func TestExchangeRateInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRateInmemoryStorageTestSuite))
+}
@@ -0,0 +1,84 @@
+package inmemory
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Goal is used to acces inmemory storage.
+type Goal struct {
+	goals      []*model.Goal
+	goalByID   map[int64]*model.Goal
+	goalByName map[string]*model.Goal
+}
+
+// NewGoal returns new goal inmemory storage.
+func NewGoal() *Goal {
+	return &Goal{
+		goals:      make([]*model.Goal, 0, 20),
+		goalByID:   make(map[int64]*model.Goal),
+		goalByName: make(map[string]*model.Goal),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *Goal) Init(gg []*model.Goal) {
+	s.goalByID = make(map[int64]*model.Goal)
+	s.goalByName = make(map[string]*model.Goal)
+
+	for _, g := range gg {
+		s.goalByID[g.ID] = g
+		s.goalByName[g.Name] = g
+	}
+	s.goals = gg
+}
+
+// Insert appends goal to inmemory storage.
+func (s *Goal) Insert(g *model.Goal) {
+	s.goalByID[g.ID] = g
+	s.goalByName[g.Name] = g
+	s.goals = append(s.goals, g)
+}
+
+// Update updates goal of inmemory storage.
+func (s *Goal) Update(g *model.Goal) {
+	delete(s.goalByName, s.goalByID[g.ID].Name)
+
+	s.goalByID[g.ID] = g
+	s.goalByName[g.Name] = g
+
+	for i, gg := range s.goals {
+		if gg.ID == g.ID {
+			s.goals[i] = g
+			return
+		}
+	}
+}
+
+// Delete removes goal from current inmemory storage.
+func (s *Goal) Delete(g *model.Goal) {
+	delete(s.goalByID, g.ID)
+	delete(s.goalByName, g.Name)
+
+	for i, gg := range s.goals {
+		if gg.ID == g.ID {
+			last := len(s.goals) - 1
+			s.goals[i] = s.goals[last]
+			s.goals = s.goals[:last]
+		}
+	}
+}
+
+// GetAll returns slice of goals.
+func (s *Goal) GetAll() []*model.Goal {
+	return s.goals
+}
+
+// GetByID returns goal by its id.
+func (s *Goal) GetByID(id int64) *model.Goal {
+	return s.goalByID[id]
+}
+
+// GetByName returns goal by its name.
+func (s *Goal) GetByName(name string) *model.Goal {
+	return s.goalByName[name]
+}
@@ -0,0 +1,82 @@
+package inmemory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GoalInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage   *inmemory.Goal
+	InitGoals []*model.Goal
+}
+
+func (s *GoalInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewGoal()
+	s.InitGoals = []*model.Goal{
+		{
+			ID:       1,
+			Name:     "Car",
+			Target:   1000000,
+			Currency: &model.Currency{ID: 1},
+			Deadline: time.Date(2024, time.Month(6), 1, 0, 0, 0, 0, time.UTC),
+			Account:  &model.Account{ID: 1},
+		},
+		{
+			ID:       2,
+			Name:     "Emergency fund",
+			Target:   300000,
+			Currency: &model.Currency{ID: 1},
+			Deadline: time.Date(2023, time.Month(12), 31, 0, 0, 0, 0, time.UTC),
+			Category: &model.Category{ID: 1},
+		},
+	}
+}
+
+func (s *GoalInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(append([]*model.Goal(nil), s.InitGoals...))
+}
+
+func (s *GoalInmemoryStorageTestSuite) TestInsertPositive() {
+	goal := &model.Goal{ID: 3, Name: "Vacation", Currency: &model.Currency{ID: 1}}
+	expectedGoals := append(s.InitGoals, goal)
+
+	s.storage.Insert(goal)
+
+	assert.ElementsMatch(s.T(), expectedGoals, s.storage.GetAll())
+	assert.Equal(s.T(), goal, s.storage.GetByID(goal.ID))
+	assert.Equal(s.T(), goal, s.storage.GetByName(goal.Name))
+}
+
+func (s *GoalInmemoryStorageTestSuite) TestUpdatePositive() {
+	goal := &model.Goal{ID: 2, Name: "Rainy day", Currency: &model.Currency{ID: 1}}
+
+	s.storage.Update(goal)
+
+	assert.ElementsMatch(s.T(), []*model.Goal{s.InitGoals[0], goal}, s.storage.GetAll())
+	assert.Equal(s.T(), goal, s.storage.GetByName("Rainy day"))
+	assert.Nil(s.T(), s.storage.GetByName("Emergency fund"))
+}
+
+func (s *GoalInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.Delete(s.InitGoals[1])
+
+	assert.ElementsMatch(s.T(), s.InitGoals[:1], s.storage.GetAll())
+	assert.Nil(s.T(), s.storage.GetByID(2))
+}
+
+func (s *GoalInmemoryStorageTestSuite) TearDownTest() {
+	for len(s.storage.GetAll()) > 0 {
+		s.storage.Delete(s.storage.GetAll()[0])
+	}
+}
+
+func TestGoalInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(GoalInmemoryStorageTestSuite))
+}
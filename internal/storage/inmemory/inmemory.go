@@ -2,19 +2,39 @@ package inmemory
 
 // InmemoryStorage is a facade structure which aggregates all inmemory storages. It is used for convenience.
 type InmemoryStorage struct {
-	category    *Category
-	currency    *Currency
-	account     *Account
-	transaction *Transaction
+	category     *Category
+	currency     *Currency
+	account      *Account
+	transaction  *Transaction
+	transfer     *Transfer
+	exchangeRate *ExchangeRate
+	budget       *Budget
+	recurrence   *Recurrence
+	tag          *Tag
+	payee        *Payee
+	attachment   *Attachment
+	goal         *Goal
+	counterparty *Counterparty
+	debt         *Debt
 }
 
 // New returns new InmemoryStorage.
 func New() *InmemoryStorage {
 	return &InmemoryStorage{
-		category:    NewCategory(),
-		currency:    NewCurrency(),
-		account:     NewAccount(),
-		transaction: NewTransaction(),
+		category:     NewCategory(),
+		currency:     NewCurrency(),
+		account:      NewAccount(),
+		transaction:  NewTransaction(),
+		transfer:     NewTransfer(),
+		exchangeRate: NewExchangeRate(),
+		budget:       NewBudget(),
+		recurrence:   NewRecurrence(),
+		tag:          NewTag(),
+		payee:        NewPayee(),
+		attachment:   NewAttachment(),
+		goal:         NewGoal(),
+		counterparty: NewCounterparty(),
+		debt:         NewDebt(),
 	}
 }
 
@@ -37,3 +57,53 @@ func (s *InmemoryStorage) Account() *Account {
 func (s *InmemoryStorage) Transaction() *Transaction {
 	return s.transaction
 }
+
+// Transfer returns transfer inmemory storage.
+func (s *InmemoryStorage) Transfer() *Transfer {
+	return s.transfer
+}
+
+// ExchangeRate returns exchange rate inmemory storage.
+func (s *InmemoryStorage) ExchangeRate() *ExchangeRate {
+	return s.exchangeRate
+}
+
+// Budget returns budget inmemory storage.
+func (s *InmemoryStorage) Budget() *Budget {
+	return s.budget
+}
+
+// Recurrence returns recurrence inmemory storage.
+func (s *InmemoryStorage) Recurrence() *Recurrence {
+	return s.recurrence
+}
+
+// Tag returns tag inmemory storage.
+func (s *InmemoryStorage) Tag() *Tag {
+	return s.tag
+}
+
+// Payee returns payee inmemory storage.
+func (s *InmemoryStorage) Payee() *Payee {
+	return s.payee
+}
+
+// Attachment returns attachment inmemory storage.
+func (s *InmemoryStorage) Attachment() *Attachment {
+	return s.attachment
+}
+
+// Goal returns goal inmemory storage.
+func (s *InmemoryStorage) Goal() *Goal {
+	return s.goal
+}
+
+// Counterparty returns counterparty inmemory storage.
+func (s *InmemoryStorage) Counterparty() *Counterparty {
+	return s.counterparty
+}
+
+// Debt returns debt inmemory storage.
+func (s *InmemoryStorage) Debt() *Debt {
+	return s.debt
+}
@@ -18,6 +18,16 @@ func (s *InmemoryStorageTestSuite) TestStorageGet() {
 	storage.Currency()
 	storage.Account()
 	storage.Transaction()
+	storage.Transfer()
+	storage.ExchangeRate()
+	storage.Budget()
+	storage.Recurrence()
+	storage.Tag()
+	storage.Payee()
+	storage.Attachment()
+	storage.Goal()
+	storage.Counterparty()
+	storage.Debt()
 }
 
 func TestInmemoryStorageTestSuite(t *testing.T) {
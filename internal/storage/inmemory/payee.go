@@ -0,0 +1,84 @@
+package inmemory
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Payee is used to acces inmemory storage.
+type Payee struct {
+	payees      []*model.Payee
+	payeeByID   map[int64]*model.Payee
+	payeeByName map[string]*model.Payee
+}
+
+// NewPayee returns new payee inmemory storage.
+func NewPayee() *Payee {
+	return &Payee{
+		payees:      make([]*model.Payee, 0, 20),
+		payeeByID:   make(map[int64]*model.Payee),
+		payeeByName: make(map[string]*model.Payee),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *Payee) Init(pp []*model.Payee) {
+	s.payeeByID = make(map[int64]*model.Payee)
+	s.payeeByName = make(map[string]*model.Payee)
+
+	for _, p := range pp {
+		s.payeeByID[p.ID] = p
+		s.payeeByName[p.Name] = p
+	}
+	s.payees = pp
+}
+
+// Insert appends payee to inmemory storage.
+func (s *Payee) Insert(p *model.Payee) {
+	s.payeeByID[p.ID] = p
+	s.payeeByName[p.Name] = p
+	s.payees = append(s.payees, p)
+}
+
+// Update updates payee of inmemory storage.
+func (s *Payee) Update(p *model.Payee) {
+	delete(s.payeeByName, s.payeeByID[p.ID].Name)
+
+	s.payeeByID[p.ID] = p
+	s.payeeByName[p.Name] = p
+
+	for i, pp := range s.payees {
+		if pp.ID == p.ID {
+			s.payees[i] = p
+			return
+		}
+	}
+}
+
+// Delete removes payee from current inmemory storage.
+func (s *Payee) Delete(p *model.Payee) {
+	delete(s.payeeByID, p.ID)
+	delete(s.payeeByName, p.Name)
+
+	for i, pp := range s.payees {
+		if pp.ID == p.ID {
+			last := len(s.payees) - 1
+			s.payees[i] = s.payees[last]
+			s.payees = s.payees[:last]
+		}
+	}
+}
+
+// GetAll returns slice of payees.
+func (s *Payee) GetAll() []*model.Payee {
+	return s.payees
+}
+
+// GetByID returns payee by its id.
+func (s *Payee) GetByID(id int64) *model.Payee {
+	return s.payeeByID[id]
+}
+
+// GetByName returns payee by its name.
+func (s *Payee) GetByName(name string) *model.Payee {
+	return s.payeeByName[name]
+}
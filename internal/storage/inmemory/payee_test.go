@@ -0,0 +1,67 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PayeeInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage    *inmemory.Payee
+	InitPayees []*model.Payee
+}
+
+func (s *PayeeInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewPayee()
+	s.InitPayees = []*model.Payee{
+		{ID: 1, Name: "Supermarket", Category: &model.Category{ID: 1}},
+		{ID: 2, Name: "Landlord"},
+	}
+}
+
+func (s *PayeeInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(append([]*model.Payee(nil), s.InitPayees...))
+}
+
+func (s *PayeeInmemoryStorageTestSuite) TestInsertPositive() {
+	payee := &model.Payee{ID: 3, Name: "Pharmacy"}
+	expectedPayees := append(s.InitPayees, payee)
+
+	s.storage.Insert(payee)
+
+	assert.ElementsMatch(s.T(), expectedPayees, s.storage.GetAll())
+	assert.Equal(s.T(), payee, s.storage.GetByID(payee.ID))
+	assert.Equal(s.T(), payee, s.storage.GetByName(payee.Name))
+}
+
+func (s *PayeeInmemoryStorageTestSuite) TestUpdatePositive() {
+	payee := &model.Payee{ID: 2, Name: "Landlady"}
+
+	s.storage.Update(payee)
+
+	assert.ElementsMatch(s.T(), []*model.Payee{s.InitPayees[0], payee}, s.storage.GetAll())
+	assert.Equal(s.T(), payee, s.storage.GetByName("Landlady"))
+	assert.Nil(s.T(), s.storage.GetByName("Landlord"))
+}
+
+func (s *PayeeInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.Delete(s.InitPayees[1])
+
+	assert.ElementsMatch(s.T(), s.InitPayees[:1], s.storage.GetAll())
+	assert.Nil(s.T(), s.storage.GetByID(2))
+}
+
+func (s *PayeeInmemoryStorageTestSuite) TearDownTest() {
+	for len(s.storage.GetAll()) > 0 {
+		s.storage.Delete(s.storage.GetAll()[0])
+	}
+}
+
+func TestPayeeInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(PayeeInmemoryStorageTestSuite))
+}
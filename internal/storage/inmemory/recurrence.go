@@ -0,0 +1,70 @@
+package inmemory
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Recurrence is used to acces inmemory storage.
+type Recurrence struct {
+	recurrences    []*model.Recurrence
+	recurrenceByID map[int64]*model.Recurrence
+}
+
+// NewRecurrence returns new recurrence inmemory storage.
+func NewRecurrence() *Recurrence {
+	return &Recurrence{
+		recurrences:    make([]*model.Recurrence, 0, 20),
+		recurrenceByID: make(map[int64]*model.Recurrence),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *Recurrence) Init(rr []*model.Recurrence) {
+	s.recurrenceByID = make(map[int64]*model.Recurrence)
+
+	for _, r := range rr {
+		s.recurrenceByID[r.ID] = r
+	}
+	s.recurrences = rr
+}
+
+// Insert appends recurrence to inmemory storage.
+func (s *Recurrence) Insert(r *model.Recurrence) {
+	s.recurrenceByID[r.ID] = r
+	s.recurrences = append(s.recurrences, r)
+}
+
+// Update updates recurrence of inmemory storage.
+func (s *Recurrence) Update(r *model.Recurrence) {
+	s.recurrenceByID[r.ID] = r
+
+	for i, rr := range s.recurrences {
+		if rr.ID == r.ID {
+			s.recurrences[i] = r
+			return
+		}
+	}
+}
+
+// Delete removes recurrence from current inmemory storage.
+func (s *Recurrence) Delete(r *model.Recurrence) {
+	delete(s.recurrenceByID, r.ID)
+
+	for i, rr := range s.recurrences {
+		if rr.ID == r.ID {
+			last := len(s.recurrences) - 1
+			s.recurrences[i] = s.recurrences[last]
+			s.recurrences = s.recurrences[:last]
+		}
+	}
+}
+
+// GetAll returns slice of recurrences.
+func (s *Recurrence) GetAll() []*model.Recurrence {
+	return s.recurrences
+}
+
+// GetByID returns recurrence by its id.
+func (s *Recurrence) GetByID(id int64) *model.Recurrence {
+	return s.recurrenceByID[id]
+}
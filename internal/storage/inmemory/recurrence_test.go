@@ -0,0 +1,67 @@
+package inmemory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecurrenceInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage         *inmemory.Recurrence
+	InitRecurrences []*model.Recurrence
+}
+
+func (s *RecurrenceInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewRecurrence()
+	start := time.Date(2022, time.Month(2), 1, 0, 0, 0, 0, time.UTC)
+	s.InitRecurrences = []*model.Recurrence{
+		{ID: 1, Rule: model.Monthly, Start: start, Next: start, Template: model.NewEmptyTransaction()},
+		{ID: 2, Rule: model.Weekly, Start: start, Next: start, Template: model.NewEmptyTransaction()},
+	}
+}
+
+func (s *RecurrenceInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(append([]*model.Recurrence(nil), s.InitRecurrences...))
+}
+
+func (s *RecurrenceInmemoryStorageTestSuite) TestInsertPositive() {
+	recurrence := &model.Recurrence{ID: 3, Rule: model.Daily, Template: model.NewEmptyTransaction()}
+	expectedRecurrences := append(append([]*model.Recurrence(nil), s.InitRecurrences...), recurrence)
+
+	s.storage.Insert(recurrence)
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), expectedRecurrences)
+	assert.Equal(s.T(), recurrence, s.storage.GetByID(recurrence.ID))
+}
+
+func (s *RecurrenceInmemoryStorageTestSuite) TestUpdatePositive() {
+	recurrence := &model.Recurrence{ID: 2, Rule: model.Yearly, Paused: true, Template: model.NewEmptyTransaction()}
+
+	s.storage.Update(recurrence)
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), []*model.Recurrence{s.InitRecurrences[0], recurrence})
+	assert.Equal(s.T(), recurrence, s.storage.GetByID(recurrence.ID))
+}
+
+func (s *RecurrenceInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.Delete(s.InitRecurrences[1])
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), []*model.Recurrence{s.InitRecurrences[0]})
+	assert.Nil(s.T(), s.storage.GetByID(2))
+}
+
+func (s *RecurrenceInmemoryStorageTestSuite) TearDownTest() {
+	for len(s.storage.GetAll()) > 0 {
+		s.storage.Delete(s.storage.GetAll()[0])
+	}
+}
+
+func TestRecurrenceInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(RecurrenceInmemoryStorageTestSuite))
+}
@@ -0,0 +1,113 @@
+package inmemory
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Tag is used to acces inmemory storage. Besides tags it keeps index of transactions by tag.
+type Tag struct {
+	tags                []*model.Tag
+	tagByID             map[int64]*model.Tag
+	tagByName           map[string]*model.Tag
+	transactionIDsByTag map[int64]map[int64]struct{}
+}
+
+// NewTag returns new tag inmemory storage.
+func NewTag() *Tag {
+	return &Tag{
+		tags:                make([]*model.Tag, 0, 20),
+		tagByID:             make(map[int64]*model.Tag),
+		tagByName:           make(map[string]*model.Tag),
+		transactionIDsByTag: make(map[int64]map[int64]struct{}),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *Tag) Init(tt []*model.Tag) {
+	s.tagByID = make(map[int64]*model.Tag)
+	s.tagByName = make(map[string]*model.Tag)
+	s.transactionIDsByTag = make(map[int64]map[int64]struct{})
+
+	for _, t := range tt {
+		s.tagByID[t.ID] = t
+		s.tagByName[t.Name] = t
+	}
+	s.tags = tt
+}
+
+// Insert appends tag to inmemory storage.
+func (s *Tag) Insert(t *model.Tag) {
+	s.tagByID[t.ID] = t
+	s.tagByName[t.Name] = t
+	s.tags = append(s.tags, t)
+}
+
+// Update updates tag of inmemory storage.
+func (s *Tag) Update(t *model.Tag) {
+	delete(s.tagByName, s.tagByID[t.ID].Name)
+
+	s.tagByID[t.ID] = t
+	s.tagByName[t.Name] = t
+
+	for i, tt := range s.tags {
+		if tt.ID == t.ID {
+			s.tags[i] = t
+			return
+		}
+	}
+}
+
+// Delete removes tag along with its links to transactions from current inmemory storage.
+func (s *Tag) Delete(t *model.Tag) {
+	delete(s.tagByID, t.ID)
+	delete(s.tagByName, t.Name)
+	delete(s.transactionIDsByTag, t.ID)
+
+	for i, tt := range s.tags {
+		if tt.ID == t.ID {
+			last := len(s.tags) - 1
+			s.tags[i] = s.tags[last]
+			s.tags = s.tags[:last]
+		}
+	}
+}
+
+// GetAll returns slice of tags.
+func (s *Tag) GetAll() []*model.Tag {
+	return s.tags
+}
+
+// GetByID returns tag by its id.
+func (s *Tag) GetByID(id int64) *model.Tag {
+	return s.tagByID[id]
+}
+
+// GetByName returns tag by its name.
+func (s *Tag) GetByName(name string) *model.Tag {
+	return s.tagByName[name]
+}
+
+// SetTransactionTags replaces tags of transaction with given ones in the index.
+func (s *Tag) SetTransactionTags(transactionID int64, tt []*model.Tag) {
+	for _, ids := range s.transactionIDsByTag {
+		delete(ids, transactionID)
+	}
+
+	for _, t := range tt {
+		if s.transactionIDsByTag[t.ID] == nil {
+			s.transactionIDsByTag[t.ID] = make(map[int64]struct{})
+		}
+		s.transactionIDsByTag[t.ID][transactionID] = struct{}{}
+	}
+}
+
+// GetTransactionIDs returns ids of transactions marked with given tag.
+func (s *Tag) GetTransactionIDs(tagID int64) []int64 {
+	res := make([]int64, 0, len(s.transactionIDsByTag[tagID]))
+
+	for id := range s.transactionIDsByTag[tagID] {
+		res = append(res, id)
+	}
+
+	return res
+}
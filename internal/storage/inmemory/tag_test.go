@@ -0,0 +1,78 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TagInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage  *inmemory.Tag
+	InitTags []*model.Tag
+}
+
+func (s *TagInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewTag()
+	s.InitTags = []*model.Tag{
+		{ID: 1, Name: "vacation"},
+		{ID: 2, Name: "reimbursable"},
+	}
+}
+
+func (s *TagInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(append([]*model.Tag(nil), s.InitTags...))
+}
+
+func (s *TagInmemoryStorageTestSuite) TestInsertPositive() {
+	tag := &model.Tag{ID: 3, Name: "gift"}
+	expectedTags := append(append([]*model.Tag(nil), s.InitTags...), tag)
+
+	s.storage.Insert(tag)
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), expectedTags)
+	assert.Equal(s.T(), tag, s.storage.GetByID(tag.ID))
+	assert.Equal(s.T(), tag, s.storage.GetByName(tag.Name))
+}
+
+func (s *TagInmemoryStorageTestSuite) TestUpdatePositive() {
+	tag := &model.Tag{ID: 2, Name: "work"}
+
+	s.storage.Update(tag)
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), []*model.Tag{s.InitTags[0], tag})
+	assert.Equal(s.T(), tag, s.storage.GetByName("work"))
+	assert.Nil(s.T(), s.storage.GetByName("reimbursable"))
+}
+
+func (s *TagInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.SetTransactionTags(1, s.InitTags)
+	s.storage.Delete(s.InitTags[1])
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), s.InitTags[:1])
+	assert.Nil(s.T(), s.storage.GetByID(2))
+	assert.Empty(s.T(), s.storage.GetTransactionIDs(2))
+}
+
+func (s *TagInmemoryStorageTestSuite) TestSetTransactionTags() {
+	s.storage.SetTransactionTags(1, s.InitTags)
+	s.storage.SetTransactionTags(2, s.InitTags[1:])
+	s.storage.SetTransactionTags(1, s.InitTags[:1])
+
+	assert.ElementsMatch(s.T(), []int64{1}, s.storage.GetTransactionIDs(1))
+	assert.ElementsMatch(s.T(), []int64{2}, s.storage.GetTransactionIDs(2))
+}
+
+func (s *TagInmemoryStorageTestSuite) TearDownTest() {
+	for len(s.storage.GetAll()) > 0 {
+		s.storage.Delete(s.storage.GetAll()[0])
+	}
+}
+
+func TestTagInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(TagInmemoryStorageTestSuite))
+}
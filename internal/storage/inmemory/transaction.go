@@ -20,6 +20,8 @@ func NewTransaction() *Transaction {
 
 // Init initialize inmemory storage with given slice of data.
 func (s *Transaction) Init(tt []*model.Transaction) {
+	s.transactionByID = make(map[int64]*model.Transaction)
+
 	for _, t := range tt {
 		s.transactionByID[t.ID] = t
 	}
@@ -0,0 +1,86 @@
+package inmemory
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Transfer is used to acces inmemory storage.
+type Transfer struct {
+	transfers               []*model.Transfer
+	transferByID            map[int64]*model.Transfer
+	transferByTransactionID map[int64]*model.Transfer
+}
+
+// NewTransfer returns new transfer inmemory storage.
+func NewTransfer() *Transfer {
+	return &Transfer{
+		transfers:               make([]*model.Transfer, 0, 20),
+		transferByID:            make(map[int64]*model.Transfer),
+		transferByTransactionID: make(map[int64]*model.Transfer),
+	}
+}
+
+// Init initialize inmemory storage with given slice of data.
+func (s *Transfer) Init(tt []*model.Transfer) {
+	s.transferByID = make(map[int64]*model.Transfer)
+	s.transferByTransactionID = make(map[int64]*model.Transfer)
+
+	for _, t := range tt {
+		s.transferByID[t.ID] = t
+		s.transferByTransactionID[t.From.ID] = t
+		s.transferByTransactionID[t.To.ID] = t
+	}
+	s.transfers = tt
+}
+
+// Insert appends transfer to inmemory storage.
+func (s *Transfer) Insert(t *model.Transfer) {
+	s.transferByID[t.ID] = t
+	s.transferByTransactionID[t.From.ID] = t
+	s.transferByTransactionID[t.To.ID] = t
+	s.transfers = append(s.transfers, t)
+}
+
+// Update updates transfer of inmemory storage.
+func (s *Transfer) Update(t *model.Transfer) {
+	s.transferByID[t.ID] = t
+	s.transferByTransactionID[t.From.ID] = t
+	s.transferByTransactionID[t.To.ID] = t
+
+	for i, tt := range s.transfers {
+		if tt.ID == t.ID {
+			s.transfers[i] = t
+			return
+		}
+	}
+}
+
+// Delete removes transfer from current inmemory storage.
+func (s *Transfer) Delete(t *model.Transfer) {
+	delete(s.transferByID, t.ID)
+	delete(s.transferByTransactionID, t.From.ID)
+	delete(s.transferByTransactionID, t.To.ID)
+
+	for i, tt := range s.transfers {
+		if tt.ID == t.ID {
+			last := len(s.transfers) - 1
+			s.transfers[i] = s.transfers[last]
+			s.transfers = s.transfers[:last]
+		}
+	}
+}
+
+// GetAll returns slice of transfers.
+func (s *Transfer) GetAll() []*model.Transfer {
+	return s.transfers
+}
+
+// GetByID returns transfer by its id.
+func (s *Transfer) GetByID(id int64) *model.Transfer {
+	return s.transferByID[id]
+}
+
+// GetByTransactionID returns transfer by id of any of its legs.
+func (s *Transfer) GetByTransactionID(id int64) *model.Transfer {
+	return s.transferByTransactionID[id]
+}
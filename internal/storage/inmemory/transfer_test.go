@@ -0,0 +1,79 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransferInmemoryStorageTestSuite struct {
+	suite.Suite
+	storage       *inmemory.Transfer
+	InitTransfers []*model.Transfer
+}
+
+func (s *TransferInmemoryStorageTestSuite) SetupSuite() {
+	s.storage = inmemory.NewTransfer()
+	s.InitTransfers = []*model.Transfer{
+		{ID: 1, From: &model.Transaction{ID: 1, Amount: -100}, To: &model.Transaction{ID: 2, Amount: 100}},
+		{ID: 2, From: &model.Transaction{ID: 3, Amount: -200}, To: &model.Transaction{ID: 4, Amount: 200}},
+	}
+}
+
+func (s *TransferInmemoryStorageTestSuite) SetupTest() {
+	s.storage.Init(s.InitTransfers)
+}
+
+func (s *TransferInmemoryStorageTestSuite) TestInsertPositive() {
+	transfer := &model.Transfer{ID: 3, From: &model.Transaction{ID: 5}, To: &model.Transaction{ID: 6}}
+	expectedTransfers := append(s.InitTransfers, transfer)
+
+	s.storage.Insert(transfer)
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), expectedTransfers)
+	assert.Equal(s.T(), transfer, s.storage.GetByID(transfer.ID))
+	assert.Equal(s.T(), transfer, s.storage.GetByTransactionID(transfer.From.ID))
+	assert.Equal(s.T(), transfer, s.storage.GetByTransactionID(transfer.To.ID))
+}
+
+func (s *TransferInmemoryStorageTestSuite) TestUpdatePositive() {
+	expectedTransfers := make([]*model.Transfer, 2)
+	copy(expectedTransfers, s.InitTransfers)
+	expectedTransfers[0] = &model.Transfer{
+		ID:   1,
+		From: &model.Transaction{ID: 1, Amount: -300},
+		To:   &model.Transaction{ID: 2, Amount: 300},
+	}
+
+	s.storage.Update(expectedTransfers[0])
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), expectedTransfers)
+	assert.Equal(s.T(), expectedTransfers[0], s.storage.GetByID(1))
+	assert.Equal(s.T(), expectedTransfers[0], s.storage.GetByTransactionID(2))
+}
+
+func (s *TransferInmemoryStorageTestSuite) TestDeletePositive() {
+	s.storage.Delete(s.InitTransfers[1])
+
+	assert.ElementsMatch(s.T(), s.storage.GetAll(), s.InitTransfers[:1])
+	assert.Nil(s.T(), s.storage.GetByTransactionID(3))
+	assert.Nil(s.T(), s.storage.GetByTransactionID(4))
+}
+
+func (s *TransferInmemoryStorageTestSuite) TearDownTest() {
+	for {
+		tt := s.storage.GetAll()
+		if len(tt) == 0 {
+			break
+		}
+		s.storage.Delete(tt[0])
+	}
+}
+
+func TestTransferInmemoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(TransferInmemoryStorageTestSuite))
+}
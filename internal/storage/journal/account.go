@@ -0,0 +1,93 @@
+package journal
+
+import (
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Account is used to access accounts kept in journal.
+type Account struct {
+	journal *Journal
+}
+
+// Insert account into journal.
+func (s *Account) Insert(a *model.Account) (id int64, err error) {
+	err = s.journal.InTx(func() error {
+		id = s.journal.accounts.insert(a)
+		return nil
+	})
+
+	return id, err
+}
+
+// Update account in journal.
+func (s *Account) Update(a *model.Account) error {
+	return s.journal.InTx(func() error { return s.journal.accounts.update(a) })
+}
+
+// Delete moves account to trash.
+func (s *Account) Delete(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.accounts.softDelete(id) })
+}
+
+// Restore brings account back from trash.
+func (s *Account) Restore(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.accounts.restore(id) })
+}
+
+// Purge permanently deletes account from trash.
+func (s *Account) Purge(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.accounts.purge(id) })
+}
+
+// GetAll accounts from journal, accounts in trash are omitted.
+func (s *Account) GetAll() ([]*model.Account, error) {
+	return s.journal.accounts.getAll(false), nil
+}
+
+// GetDeleted returns accounts from trash.
+func (s *Account) GetDeleted() ([]*model.Account, error) {
+	return s.journal.accounts.getAll(true), nil
+}
+
+// newAccountTable returns empty table of accounts.
+func newAccountTable() *table[model.Account] {
+	return &table[model.Account]{
+		clone: func(a *model.Account) *model.Account {
+			res := *a
+			res.Currency = &model.Currency{ID: a.Currency.ID}
+			return &res
+		},
+		fields: func(a *model.Account) (*int64, *time.Time) { return &a.ID, &a.DeletedAt },
+	}
+}
+
+// encodeAccount returns journal line of account.
+func encodeAccount(a *model.Account) string {
+	return newLine("account", a.ID).
+		str("name", a.Name).
+		int("currency", a.Currency.ID).
+		str("type", string(a.Type)).
+		int("openingBalance", a.OpeningBalance).
+		time("openingDate", a.OpeningDate).
+		int("creditLimit", a.CreditLimit).
+		bool("archived", a.Archived).
+		time("deleted", a.DeletedAt).
+		String()
+}
+
+// decodeAccount returns account from journal record.
+func decodeAccount(r *record) *model.Account {
+	return &model.Account{
+		ID:             r.id,
+		Name:           r.str("name"),
+		Currency:       &model.Currency{ID: r.int("currency")},
+		Type:           model.AccountType(r.str("type")),
+		OpeningBalance: r.int("openingBalance"),
+		OpeningDate:    r.time("openingDate"),
+		CreditLimit:    r.int("creditLimit"),
+		Archived:       r.bool("archived"),
+		DeletedAt:      r.time("deleted"),
+	}
+}
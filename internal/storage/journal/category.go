@@ -0,0 +1,88 @@
+package journal
+
+import (
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Category is used to access categories kept in journal.
+type Category struct {
+	journal *Journal
+}
+
+// Insert category into journal.
+func (s *Category) Insert(c *model.Category) (id int64, err error) {
+	err = s.journal.InTx(func() error {
+		id = s.journal.categories.insert(c)
+		return nil
+	})
+
+	return id, err
+}
+
+// Update category in journal.
+func (s *Category) Update(c *model.Category) error {
+	return s.journal.InTx(func() error { return s.journal.categories.update(c) })
+}
+
+// Delete moves category to trash.
+func (s *Category) Delete(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.categories.softDelete(id) })
+}
+
+// Restore brings category back from trash.
+func (s *Category) Restore(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.categories.restore(id) })
+}
+
+// Purge permanently deletes category from trash.
+func (s *Category) Purge(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.categories.purge(id) })
+}
+
+// GetAll categories from journal, categories in trash are omitted.
+func (s *Category) GetAll() ([]*model.Category, error) {
+	return s.journal.categories.getAll(false), nil
+}
+
+// GetDeleted returns categories from trash.
+func (s *Category) GetDeleted() ([]*model.Category, error) {
+	return s.journal.categories.getAll(true), nil
+}
+
+// newCategoryTable returns empty table of categories.
+func newCategoryTable() *table[model.Category] {
+	return &table[model.Category]{
+		clone: func(c *model.Category) *model.Category {
+			res := *c
+			if c.Parent != nil {
+				res.Parent = &model.Category{ID: c.Parent.ID}
+			}
+			return &res
+		},
+		fields: func(c *model.Category) (*int64, *time.Time) { return &c.ID, &c.DeletedAt },
+	}
+}
+
+// encodeCategory returns journal line of category.
+func encodeCategory(c *model.Category) string {
+	l := newLine("category", c.ID).str("title", c.Title)
+	if c.Parent != nil {
+		l.int("parent", c.Parent.ID)
+	}
+
+	return l.str("kind", string(c.Kind)).time("deleted", c.DeletedAt).String()
+}
+
+// decodeCategory returns category from journal record.
+func decodeCategory(r *record) *model.Category {
+	c := &model.Category{ID: r.id, Title: r.str("title")}
+	if id := r.int("parent"); id != 0 {
+		c.Parent = &model.Category{ID: id}
+	}
+	c.Kind = model.CategoryKind(r.str("kind"))
+	c.DeletedAt = r.time("deleted")
+
+	return c
+}
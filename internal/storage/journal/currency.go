@@ -0,0 +1,106 @@
+package journal
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Currency is used to access currencies kept in journal.
+type Currency struct {
+	journal *Journal
+}
+
+// Insert currency into journal.
+func (s *Currency) Insert(c *model.Currency) (id int64, err error) {
+	err = s.journal.InTx(func() error {
+		id = s.journal.currencies.insert(c)
+		return nil
+	})
+
+	return id, err
+}
+
+// Update currency in journal.
+func (s *Currency) Update(c *model.Currency) error {
+	return s.journal.InTx(func() error { return s.journal.currencies.update(c) })
+}
+
+// SetMain marks currency with given id as main and unmarks all others at once.
+func (s *Currency) SetMain(id int64) error {
+	return s.journal.InTx(func() error {
+		currencies := s.journal.currencies
+		if _, err := currencies.find(id, nil); err != nil {
+			return err
+		}
+
+		for _, c := range currencies.rows {
+			if c.IsMain != (c.ID == id) {
+				if err := currencies.change(c.ID, nil, func(c *model.Currency) { c.IsMain = !c.IsMain }); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete moves currency to trash.
+func (s *Currency) Delete(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.currencies.softDelete(id) })
+}
+
+// Restore brings currency back from trash.
+func (s *Currency) Restore(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.currencies.restore(id) })
+}
+
+// Purge permanently deletes currency from trash.
+func (s *Currency) Purge(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.currencies.purge(id) })
+}
+
+// GetAll currencies from journal, currencies in trash are omitted.
+func (s *Currency) GetAll() ([]*model.Currency, error) {
+	return s.journal.currencies.getAll(false), nil
+}
+
+// GetDeleted returns currencies from trash.
+func (s *Currency) GetDeleted() ([]*model.Currency, error) {
+	return s.journal.currencies.getAll(true), nil
+}
+
+// newCurrencyTable returns empty table of currencies.
+func newCurrencyTable() *table[model.Currency] {
+	return &table[model.Currency]{
+		clone: func(c *model.Currency) *model.Currency {
+			res := *c
+			return &res
+		},
+		fields: func(c *model.Currency) (*int64, *time.Time) { return &c.ID, &c.DeletedAt },
+	}
+}
+
+// encodeCurrency returns journal line of currency. Precision is always written, since zero is a
+// meaningful precision, currency without precision has the default one.
+func encodeCurrency(c *model.Currency) string {
+	l := newLine("currency", c.ID).str("abbreviation", c.Abbreviation).bool("main", c.IsMain)
+	l.field("precision", strconv.FormatInt(c.Precision, 10))
+
+	return l.time("deleted", c.DeletedAt).String()
+}
+
+// decodeCurrency returns currency from journal record.
+func decodeCurrency(r *record) *model.Currency {
+	c := &model.Currency{ID: r.id, Abbreviation: r.str("abbreviation"), IsMain: r.bool("main")}
+
+	c.Precision = model.DefaultPrecision
+	if _, ok := r.values["precision"]; ok {
+		c.Precision = r.int("precision")
+	}
+	c.DeletedAt = r.time("deleted")
+
+	return c
+}
@@ -0,0 +1,207 @@
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is a layout of times at midnight UTC, other times are written in RFC 3339 format.
+const dateLayout = "2006-01-02"
+
+// line builds a single record of journal: kind and id of record followed by space separated
+// key=value fields. Fields with zero values are omitted, string values are quoted.
+type line struct {
+	b strings.Builder
+}
+
+// newLine starts record of given kind with given id.
+func newLine(kind string, id int64) *line {
+	l := &line{}
+	l.b.WriteString(kind)
+	l.b.WriteByte(' ')
+	l.b.WriteString(strconv.FormatInt(id, 10))
+
+	return l
+}
+
+// str appends string field.
+func (l *line) str(key, v string) *line {
+	if v != "" {
+		l.field(key, strconv.Quote(v))
+	}
+	return l
+}
+
+// int appends integer field.
+func (l *line) int(key string, v int64) *line {
+	if v != 0 {
+		l.field(key, strconv.FormatInt(v, 10))
+	}
+	return l
+}
+
+// bool appends boolean field.
+func (l *line) bool(key string, v bool) *line {
+	if v {
+		l.field(key, strconv.FormatBool(v))
+	}
+	return l
+}
+
+// time appends time field, times at midnight UTC are written as dates.
+func (l *line) time(key string, v time.Time) *line {
+	if v.IsZero() {
+		return l
+	}
+
+	if v.Location() == time.UTC && v.Equal(v.Truncate(24*time.Hour)) {
+		l.field(key, v.Format(dateLayout))
+	} else {
+		l.field(key, v.Format(time.RFC3339Nano))
+	}
+
+	return l
+}
+
+// field appends key=value pair.
+func (l *line) field(key, value string) {
+	l.b.WriteByte(' ')
+	l.b.WriteString(key)
+	l.b.WriteByte('=')
+	l.b.WriteString(value)
+}
+
+// String returns the record.
+func (l *line) String() string {
+	return l.b.String()
+}
+
+// record is a parsed line of journal. Values are consumed by getters, so values left after
+// decoding are unknown fields. The first error is kept, following getters return zero values.
+type record struct {
+	kind   string
+	id     int64
+	values map[string]string
+	err    error
+}
+
+// parseLine splits line into kind, id and fields of the record.
+func parseLine(s string) (*record, error) {
+	r := &record{values: make(map[string]string)}
+
+	kind, rest, _ := strings.Cut(strings.TrimSpace(s), " ")
+	id, rest, _ := strings.Cut(strings.TrimSpace(rest), " ")
+
+	var err error
+	if r.id, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid id %q of %s", id, kind)
+	}
+	r.kind = kind
+
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok || key == "" || strings.Contains(key, " ") {
+			return nil, fmt.Errorf("invalid field %q", rest)
+		}
+
+		if strings.HasPrefix(value, `"`) {
+			quoted, err := strconv.QuotedPrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("strconv.QuotedPrefix: field %s: %w", key, err)
+			}
+			rest = value[len(quoted):]
+			value, _ = strconv.Unquote(quoted)
+		} else {
+			value, rest, _ = strings.Cut(value, " ")
+		}
+
+		if _, ok := r.values[key]; ok {
+			return nil, fmt.Errorf("duplicate field %s", key)
+		}
+		r.values[key] = value
+	}
+
+	return r, nil
+}
+
+// str returns string field.
+func (r *record) str(key string) string {
+	v, ok := r.values[key]
+	delete(r.values, key)
+
+	if !ok || r.err != nil {
+		return ""
+	}
+
+	return v
+}
+
+// int returns integer field.
+func (r *record) int(key string) int64 {
+	v := r.str(key)
+	if v == "" {
+		return 0
+	}
+
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		r.err = fmt.Errorf("field %s: %w", key, err)
+	}
+
+	return i
+}
+
+// bool returns boolean field.
+func (r *record) bool(key string) bool {
+	v := r.str(key)
+	if v == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		r.err = fmt.Errorf("field %s: %w", key, err)
+	}
+
+	return b
+}
+
+// time returns time field, it could be either a date or a time in RFC 3339 format.
+func (r *record) time(key string) time.Time {
+	v := r.str(key)
+	if v == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(dateLayout, v)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			r.err = fmt.Errorf("field %s: %w", key, err)
+		}
+	}
+
+	return t
+}
+
+// close returns the first error occurred while decoding the record, fields which weren't
+// consumed are reported as unknown.
+func (r *record) close() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if len(r.values) > 0 {
+		keys := make([]string, 0, len(r.values))
+		for k := range r.values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		return fmt.Errorf("unknown fields %s of %s", strings.Join(keys, ", "), r.kind)
+	}
+
+	return nil
+}
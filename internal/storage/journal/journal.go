@@ -0,0 +1,336 @@
+package journal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// header is written at the beginning of journal, lines starting with # are ignored.
+const header = "# gentlemoney journal: one record per line, fields with zero values are omitted.\n"
+
+// Journal is a facade structure which aggregates all journal storages. Journal keeps categories,
+// currencies, accounts and transactions in a plain text file, one record per line in a stable
+// order, so the file is easy to read and to keep under version control. The whole file is
+// rewritten on each change through temporary file, so it is never left half written.
+type Journal struct {
+	path  string
+	depth int
+
+	currencies   *table[model.Currency]
+	categories   *table[model.Category]
+	accounts     *table[model.Account]
+	transactions *table[model.Transaction]
+
+	category     *Category
+	currency     *Currency
+	account      *Account
+	transaction  *Transaction
+	transfer     *Transfer
+	exchangeRate *ExchangeRate
+	budget       *Budget
+	recurrence   *Recurrence
+	tag          *Tag
+	split        *Split
+	payee        *Payee
+	attachment   *Attachment
+	goal         *Goal
+	counterparty *Counterparty
+	debt         *Debt
+}
+
+// New creates object which aggregates all storages kept in journal file at given path. Missing
+// file is created on the first change.
+func New(path string) (*Journal, error) {
+	j := &Journal{
+		path:         path,
+		currencies:   newCurrencyTable(),
+		categories:   newCategoryTable(),
+		accounts:     newAccountTable(),
+		transactions: newTransactionTable(),
+		transfer:     &Transfer{},
+		exchangeRate: &ExchangeRate{},
+		budget:       &Budget{},
+		recurrence:   &Recurrence{},
+		tag:          &Tag{},
+		split:        &Split{},
+		payee:        &Payee{},
+		attachment:   &Attachment{},
+		goal:         &Goal{},
+		counterparty: &Counterparty{},
+		debt:         &Debt{},
+	}
+	j.category = &Category{j}
+	j.currency = &Currency{j}
+	j.account = &Account{j}
+	j.transaction = &Transaction{j}
+
+	if err := j.load(); err != nil {
+		return nil, fmt.Errorf("j.load: %w", err)
+	}
+
+	return j, nil
+}
+
+// InTx executes fn as a single change of journal, so changes made through storages are written
+// only if fn succeeds, otherwise they are rolled back. Change which is already in progress is
+// joined, so changes are written or rolled back by the outermost call.
+func (j *Journal) InTx(fn func() error) error {
+	if j.depth > 0 {
+		return fn()
+	}
+
+	currencies, categories := j.currencies.snapshot(), j.categories.snapshot()
+	accounts, transactions := j.accounts.snapshot(), j.transactions.snapshot()
+
+	j.depth++
+	err := fn()
+	j.depth--
+
+	if err == nil {
+		if err = j.save(); err != nil {
+			err = fmt.Errorf("j.save: %w", err)
+		}
+	}
+
+	if err != nil {
+		j.currencies.rows, j.categories.rows = currencies, categories
+		j.accounts.rows, j.transactions.rows = accounts, transactions
+		return err
+	}
+
+	return nil
+}
+
+// load reads journal file, missing file is treated as empty journal.
+func (j *Journal) load() error {
+	f, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+
+	for n := 1; scanner.Scan(); n++ {
+		if err = j.decode(scanner.Text()); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("scanner.Err: %w", err)
+	}
+
+	if err = j.currencies.sort(); err != nil {
+		return fmt.Errorf("currency: %w", err)
+	}
+	if err = j.categories.sort(); err != nil {
+		return fmt.Errorf("category: %w", err)
+	}
+	if err = j.accounts.sort(); err != nil {
+		return fmt.Errorf("account: %w", err)
+	}
+	if err = j.transactions.sort(); err != nil {
+		return fmt.Errorf("transaction: %w", err)
+	}
+
+	return nil
+}
+
+// decode appends record of given line to corresponding table, empty lines and comments are
+// skipped.
+func (j *Journal) decode(line string) error {
+	if line = strings.TrimSpace(line); line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	r, err := parseLine(line)
+	if err != nil {
+		return err
+	}
+
+	switch r.kind {
+	case "currency":
+		j.currencies.rows = append(j.currencies.rows, decodeCurrency(r))
+	case "category":
+		j.categories.rows = append(j.categories.rows, decodeCategory(r))
+	case "account":
+		j.accounts.rows = append(j.accounts.rows, decodeAccount(r))
+	case "transaction":
+		j.transactions.rows = append(j.transactions.rows, decodeTransaction(r))
+	default:
+		return fmt.Errorf("unknown record %q", r.kind)
+	}
+
+	return r.close()
+}
+
+// encode returns content of journal file. Records are grouped by kind and ordered by id, except
+// of transactions which are ordered by date.
+func (j *Journal) encode() string {
+	var b strings.Builder
+	b.WriteString(header)
+
+	section := func(lines []string) {
+		if len(lines) > 0 {
+			b.WriteString("\n" + strings.Join(lines, "\n") + "\n")
+		}
+	}
+
+	lines := make([]string, 0, len(j.currencies.rows))
+	for _, c := range j.currencies.rows {
+		lines = append(lines, encodeCurrency(c))
+	}
+	section(lines)
+
+	lines = make([]string, 0, len(j.categories.rows))
+	for _, c := range j.categories.rows {
+		lines = append(lines, encodeCategory(c))
+	}
+	section(lines)
+
+	lines = make([]string, 0, len(j.accounts.rows))
+	for _, a := range j.accounts.rows {
+		lines = append(lines, encodeAccount(a))
+	}
+	section(lines)
+
+	transactions := j.transactions.snapshot()
+	sort.SliceStable(transactions, func(i, k int) bool {
+		return transactions[i].Date.Before(transactions[k].Date)
+	})
+
+	lines = make([]string, 0, len(transactions))
+	for _, t := range transactions {
+		lines = append(lines, encodeTransaction(t))
+	}
+	section(lines)
+
+	return b.String()
+}
+
+// save writes journal to temporary file next to the journal and renames it over the journal, so
+// the journal is replaced at once.
+func (j *Journal) save() error {
+	f, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("os.CreateTemp: %w", err)
+	}
+
+	if err = write(f, j.encode()); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err = os.Rename(f.Name(), j.path); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("os.Rename: %w", err)
+	}
+
+	return nil
+}
+
+// write writes content to file, flushes it to disk and closes the file.
+func write(f *os.File, content string) error {
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return fmt.Errorf("f.WriteString: %w", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("f.Sync: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("f.Close: %w", err)
+	}
+
+	return nil
+}
+
+// Category returns category journal storage.
+func (j *Journal) Category() *Category {
+	return j.category
+}
+
+// Currency returns currency journal storage.
+func (j *Journal) Currency() *Currency {
+	return j.currency
+}
+
+// Account returns account journal storage.
+func (j *Journal) Account() *Account {
+	return j.account
+}
+
+// Transaction returns transaction journal storage.
+func (j *Journal) Transaction() *Transaction {
+	return j.transaction
+}
+
+// Transfer returns transfer journal storage.
+func (j *Journal) Transfer() *Transfer {
+	return j.transfer
+}
+
+// ExchangeRate returns exchange rate journal storage.
+func (j *Journal) ExchangeRate() *ExchangeRate {
+	return j.exchangeRate
+}
+
+// Budget returns budget journal storage.
+func (j *Journal) Budget() *Budget {
+	return j.budget
+}
+
+// Recurrence returns recurrence journal storage.
+func (j *Journal) Recurrence() *Recurrence {
+	return j.recurrence
+}
+
+// Tag returns tag journal storage.
+func (j *Journal) Tag() *Tag {
+	return j.tag
+}
+
+// Split returns split journal storage.
+func (j *Journal) Split() *Split {
+	return j.split
+}
+
+// Payee returns payee journal storage.
+func (j *Journal) Payee() *Payee {
+	return j.payee
+}
+
+// Attachment returns attachment journal storage.
+func (j *Journal) Attachment() *Attachment {
+	return j.attachment
+}
+
+// Goal returns goal journal storage.
+func (j *Journal) Goal() *Goal {
+	return j.goal
+}
+
+// Counterparty returns counterparty journal storage.
+func (j *Journal) Counterparty() *Counterparty {
+	return j.counterparty
+}
+
+// Debt returns debt journal storage.
+func (j *Journal) Debt() *Debt {
+	return j.debt
+}
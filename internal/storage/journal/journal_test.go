@@ -0,0 +1,272 @@
+package journal_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/journal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type JournalTestSuite struct {
+	suite.Suite
+	path    string
+	journal *journal.Journal
+}
+
+func (s *JournalTestSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "journal.txt")
+
+	var err error
+	s.journal, err = journal.New(s.path)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+}
+
+// fill inserts a few records of each kind kept in journal.
+func (s *JournalTestSuite) fill() {
+	uah := &model.Currency{Abbreviation: "UAH", Precision: 2}
+	yen := &model.Currency{Abbreviation: "JPY", Precision: 0}
+	grocery := &model.Category{Title: "Grocery", Kind: model.Expense}
+	bakery := &model.Category{Title: "Bakery \"Paska\"", Parent: grocery, Kind: model.Expense}
+	cash := &model.Account{Name: "Cash", Currency: uah, Type: model.Cash, OpeningBalance: 10000,
+		OpeningDate: time.Date(2022, time.Month(1), 1, 0, 0, 0, 0, time.UTC)}
+
+	for _, c := range []*model.Currency{uah, yen} {
+		id, err := s.journal.Currency().Insert(c)
+		require.NoError(s.T(), err)
+		c.ID = id
+	}
+	require.NoError(s.T(), s.journal.Currency().SetMain(uah.ID))
+
+	for _, c := range []*model.Category{grocery, bakery} {
+		id, err := s.journal.Category().Insert(c)
+		require.NoError(s.T(), err)
+		c.ID = id
+	}
+
+	id, err := s.journal.Account().Insert(cash)
+	require.NoError(s.T(), err)
+	cash.ID = id
+
+	for _, t := range []*model.Transaction{
+		{Date: time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC), Account: cash, Category: bakery,
+			Amount: -1250, Note: "bread", Status: model.Pending},
+		{Date: time.Date(2022, time.Month(2), 20, 18, 30, 0, 0, time.UTC), Account: cash, Category: grocery,
+			Amount: -30000, Note: "weekly", Status: model.Cleared},
+	} {
+		_, err := s.journal.Transaction().Insert(t)
+		require.NoError(s.T(), err)
+	}
+
+	require.NoError(s.T(), s.journal.Currency().Delete(yen.ID))
+}
+
+func (s *JournalTestSuite) TestFormat() {
+	s.fill()
+
+	content, err := os.ReadFile(s.path)
+	require.NoError(s.T(), err)
+
+	deleted, err := s.journal.Currency().GetDeleted()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), deleted, 1)
+
+	// transactions are ordered by date, the rest of records by id
+	assert.Equal(s.T(), `# gentlemoney journal: one record per line, fields with zero values are omitted.
+
+currency 1 abbreviation="UAH" main=true precision=2
+currency 2 abbreviation="JPY" precision=0 deleted=`+deleted[0].DeletedAt.Format(time.RFC3339Nano)+`
+
+category 1 title="Grocery" kind="expense"
+category 2 title="Bakery \"Paska\"" parent=1 kind="expense"
+
+account 1 name="Cash" currency=1 type="cash" openingBalance=10000 openingDate=2022-01-01
+
+transaction 2 date=2022-02-20T18:30:00Z account=1 category=1 amount=-30000 status="cleared" note="weekly"
+transaction 1 date=2022-02-21 account=1 category=2 amount=-1250 status="pending" note="bread"
+`, string(content))
+
+	// temporary files are cleaned up by rename
+	entries, err := os.ReadDir(filepath.Dir(s.path))
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), entries, 1)
+}
+
+func (s *JournalTestSuite) TestReload() {
+	s.fill()
+
+	reloaded, err := journal.New(s.path)
+	require.NoError(s.T(), err)
+
+	currencies, err := s.journal.Currency().GetAll()
+	require.NoError(s.T(), err)
+	deletedCurrencies, err := s.journal.Currency().GetDeleted()
+	require.NoError(s.T(), err)
+	categories, err := s.journal.Category().GetAll()
+	require.NoError(s.T(), err)
+	accounts, err := s.journal.Account().GetAll()
+	require.NoError(s.T(), err)
+	transactions, err := s.journal.Transaction().GetAll()
+	require.NoError(s.T(), err)
+
+	actualCurrencies, err := reloaded.Currency().GetAll()
+	require.NoError(s.T(), err)
+	actualDeletedCurrencies, err := reloaded.Currency().GetDeleted()
+	require.NoError(s.T(), err)
+	actualCategories, err := reloaded.Category().GetAll()
+	require.NoError(s.T(), err)
+	actualAccounts, err := reloaded.Account().GetAll()
+	require.NoError(s.T(), err)
+	actualTransactions, err := reloaded.Transaction().GetAll()
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), currencies, actualCurrencies)
+	require.Len(s.T(), actualDeletedCurrencies, 1)
+	assert.True(s.T(), deletedCurrencies[0].DeletedAt.Equal(actualDeletedCurrencies[0].DeletedAt))
+	assert.Equal(s.T(), categories, actualCategories)
+	assert.Equal(s.T(), accounts, actualAccounts)
+	assert.Equal(s.T(), transactions, actualTransactions)
+
+	// ids are continued after reload
+	id, err := reloaded.Transaction().Insert(transactions[0])
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(3), id)
+}
+
+func (s *JournalTestSuite) TestChanges() {
+	s.fill()
+
+	// stored records aren't affected by changes of models after insert
+	c := &model.Category{Title: "Health"}
+	id, err := s.journal.Category().Insert(c)
+	require.NoError(s.T(), err)
+	c.ID, c.Title = id, "Pharmacy"
+
+	categories, err := s.journal.Category().GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Health", categories[2].Title)
+
+	require.NoError(s.T(), s.journal.Category().Update(c))
+	require.NoError(s.T(), s.journal.Category().Delete(c.ID))
+	assert.ErrorIs(s.T(), s.journal.Category().Delete(c.ID), journal.ErrNotFound)
+	assert.ErrorIs(s.T(), s.journal.Category().Update(&model.Category{ID: 10}), journal.ErrNotFound)
+
+	deleted, err := s.journal.Category().GetDeleted()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), deleted, 1)
+	assert.Equal(s.T(), "Pharmacy", deleted[0].Title)
+
+	require.NoError(s.T(), s.journal.Category().Restore(c.ID))
+	assert.ErrorIs(s.T(), s.journal.Category().Purge(c.ID), journal.ErrNotFound)
+	require.NoError(s.T(), s.journal.Category().Delete(c.ID))
+	require.NoError(s.T(), s.journal.Category().Purge(c.ID))
+
+	categories, err = s.journal.Category().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), categories, 2)
+
+	// main currency is switched at once
+	require.NoError(s.T(), s.journal.Currency().SetMain(2))
+	deletedCurrencies, err := s.journal.Currency().GetDeleted()
+	require.NoError(s.T(), err)
+	currencies, err := s.journal.Currency().GetAll()
+	require.NoError(s.T(), err)
+	assert.True(s.T(), deletedCurrencies[0].IsMain)
+	assert.False(s.T(), currencies[0].IsMain)
+	assert.ErrorIs(s.T(), s.journal.Currency().SetMain(10), journal.ErrNotFound)
+}
+
+func (s *JournalTestSuite) TestInTxRollback() {
+	s.fill()
+	before, err := os.ReadFile(s.path)
+	require.NoError(s.T(), err)
+
+	failure := errors.New("failure")
+	err = s.journal.InTx(func() error {
+		if _, err := s.journal.Currency().Insert(&model.Currency{Abbreviation: "USD"}); err != nil {
+			return err
+		}
+		if err := s.journal.Currency().SetMain(3); err != nil {
+			return err
+		}
+		if err := s.journal.Account().Delete(1); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(s.T(), err, failure)
+
+	after, err := os.ReadFile(s.path)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), string(before), string(after))
+
+	currencies, err := s.journal.Currency().GetAll()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), currencies, 1)
+	assert.True(s.T(), currencies[0].IsMain)
+
+	accounts, err := s.journal.Account().GetAll()
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), accounts, 1)
+}
+
+func (s *JournalTestSuite) TestSaveNegative() {
+	j, err := journal.New(filepath.Join(s.T().TempDir(), "missing", "journal.txt"))
+	require.NoError(s.T(), err)
+
+	_, err = j.Currency().Insert(&model.Currency{Abbreviation: "USD"})
+	assert.ErrorContains(s.T(), err, "j.save: os.CreateTemp")
+
+	currencies, err := j.Currency().GetAll()
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), currencies)
+}
+
+func (s *JournalTestSuite) TestLoadNegative() {
+	for name, tc := range map[string]struct {
+		content string
+		err     string
+	}{
+		"UnknownRecord":  {"budget 1 amount=10", `line 1: unknown record "budget"`},
+		"UnknownField":   {"\n# comment\ncategory 1 title=\"Grocery\" color=red", "line 3: unknown fields color of category"},
+		"InvalidID":      {"category one", `line 1: invalid id "one" of category`},
+		"InvalidValue":   {"currency 1 precision=two", `line 1: field precision: strconv.ParseInt: parsing "two": invalid syntax`},
+		"InvalidQuote":   {`category 1 title="Grocery`, "line 1: strconv.QuotedPrefix: field title: invalid syntax"},
+		"InvalidField":   {"category 1 Grocery", `line 1: invalid field "Grocery"`},
+		"DuplicateField": {"category 1 title=a title=b", "line 1: duplicate field title"},
+		"DuplicateID":    {"category 1\ncategory 1", "category: duplicate id 1"},
+	} {
+		s.Run(name, func() {
+			path := filepath.Join(s.T().TempDir(), "journal.txt")
+			require.NoError(s.T(), os.WriteFile(path, []byte(tc.content), 0600))
+
+			_, err := journal.New(path)
+			assert.EqualError(s.T(), err, "j.load: "+tc.err)
+		})
+	}
+}
+
+func (s *JournalTestSuite) TestUnsupported() {
+	_, err := s.journal.Payee().Insert(&model.Payee{Name: "Bakery"})
+	assert.ErrorIs(s.T(), err, journal.ErrUnsupported)
+	assert.ErrorIs(s.T(), s.journal.Tag().SetTransactionTags(1, []int64{1}), journal.ErrUnsupported)
+
+	// links are allowed to be cleared, since there are none
+	assert.NoError(s.T(), s.journal.Tag().SetTransactionTags(1, nil))
+	assert.NoError(s.T(), s.journal.Split().SetTransactionSplits(1, nil))
+
+	budgets, err := s.journal.Budget().GetAll()
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), budgets)
+}
+
+func TestJournalTestSuite(t *testing.T) {
+	suite.Run(t, new(JournalTestSuite))
+}
@@ -0,0 +1,157 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// ErrNotFound is returned when record with given id doesn't exist or isn't in expected state, e.g.
+// on attempt to restore record which isn't in trash.
+var ErrNotFound = errors.New("record not found")
+
+// table keeps records of one kind ordered by id. Records are copied on the way in and out, so
+// models owned by services are never shared with the journal. Stored records are never changed in
+// place, they are replaced by changed copies instead, so snapshot of rows stays intact.
+type table[T model.Any] struct {
+	rows []*T
+
+	// clone returns copy of record, references to other records are reduced to their ids.
+	clone func(r *T) *T
+	// fields returns addresses of id and deletion time of record.
+	fields func(r *T) (*int64, *time.Time)
+}
+
+// insert appends copy of record with the next free id and returns this id.
+func (t *table[T]) insert(r *T) int64 {
+	var next int64 = 1
+	if len(t.rows) > 0 {
+		next = t.id(t.rows[len(t.rows)-1]) + 1
+	}
+
+	c := t.clone(r)
+	id, deletedAt := t.fields(c)
+	*id, *deletedAt = next, time.Time{}
+	t.rows = append(t.rows, c)
+
+	return next
+}
+
+// update replaces stored record with copy of given one, deletion time of stored record is kept.
+func (t *table[T]) update(r *T) error {
+	c := t.clone(r)
+	id, deletedAt := t.fields(c)
+
+	i, err := t.find(*id, nil)
+	if err != nil {
+		return err
+	}
+
+	_, stored := t.fields(t.rows[i])
+	*deletedAt = *stored
+	t.rows[i] = c
+
+	return nil
+}
+
+// softDelete moves record with given id to trash by marking it as deleted now.
+func (t *table[T]) softDelete(id int64) error {
+	deleted := false
+	return t.change(id, &deleted, func(r *T) {
+		_, deletedAt := t.fields(r)
+		*deletedAt = time.Now()
+	})
+}
+
+// restore brings record with given id back from trash.
+func (t *table[T]) restore(id int64) error {
+	deleted := true
+	return t.change(id, &deleted, func(r *T) {
+		_, deletedAt := t.fields(r)
+		*deletedAt = time.Time{}
+	})
+}
+
+// purge permanently deletes record with given id, only records in trash could be purged.
+func (t *table[T]) purge(id int64) error {
+	deleted := true
+	i, err := t.find(id, &deleted)
+	if err != nil {
+		return err
+	}
+
+	t.rows = append(t.rows[:i:i], t.rows[i+1:]...)
+
+	return nil
+}
+
+// getAll returns copies of records which are in trash or not according to deleted.
+func (t *table[T]) getAll(deleted bool) []*T {
+	res := make([]*T, 0, len(t.rows))
+	for _, r := range t.rows {
+		if t.deleted(r) == deleted {
+			res = append(res, t.clone(r))
+		}
+	}
+
+	return res
+}
+
+// change replaces record with given id by its copy changed by fn, see find for deleted.
+func (t *table[T]) change(id int64, deleted *bool, fn func(r *T)) error {
+	i, err := t.find(id, deleted)
+	if err != nil {
+		return err
+	}
+
+	c := t.clone(t.rows[i])
+	fn(c)
+	t.rows[i] = c
+
+	return nil
+}
+
+// find returns index of record with given id. If deleted isn't nil, record is expected to be in
+// trash or not accordingly.
+func (t *table[T]) find(id int64, deleted *bool) (int, error) {
+	for i, r := range t.rows {
+		if t.id(r) == id && (deleted == nil || t.deleted(r) == *deleted) {
+			return i, nil
+		}
+	}
+
+	return -1, fmt.Errorf("id %d: %w", id, ErrNotFound)
+}
+
+// snapshot returns copy of rows, it is used to roll back changes.
+func (t *table[T]) snapshot() []*T {
+	return append([]*T(nil), t.rows...)
+}
+
+// id returns id of record.
+func (t *table[T]) id(r *T) int64 {
+	id, _ := t.fields(r)
+	return *id
+}
+
+// deleted reports whether record is in trash.
+func (t *table[T]) deleted(r *T) bool {
+	_, deletedAt := t.fields(r)
+	return !deletedAt.IsZero()
+}
+
+// sort orders loaded records by id, records with the same id aren't allowed.
+func (t *table[T]) sort() error {
+	sort.SliceStable(t.rows, func(i, j int) bool { return t.id(t.rows[i]) < t.id(t.rows[j]) })
+
+	for i := 1; i < len(t.rows); i++ {
+		if id := t.id(t.rows[i]); id == t.id(t.rows[i-1]) {
+			return fmt.Errorf("duplicate id %d", id)
+		}
+	}
+
+	return nil
+}
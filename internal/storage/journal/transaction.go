@@ -0,0 +1,94 @@
+package journal
+
+import (
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Transaction is used to access transactions kept in journal. Payees, tags and splits of
+// transactions aren't kept.
+type Transaction struct {
+	journal *Journal
+}
+
+// Insert transaction into journal.
+func (s *Transaction) Insert(t *model.Transaction) (id int64, err error) {
+	err = s.journal.InTx(func() error {
+		id = s.journal.transactions.insert(t)
+		return nil
+	})
+
+	return id, err
+}
+
+// Update transaction in journal.
+func (s *Transaction) Update(t *model.Transaction) error {
+	return s.journal.InTx(func() error { return s.journal.transactions.update(t) })
+}
+
+// Delete moves transaction to trash.
+func (s *Transaction) Delete(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.transactions.softDelete(id) })
+}
+
+// Restore brings transaction back from trash.
+func (s *Transaction) Restore(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.transactions.restore(id) })
+}
+
+// Purge permanently deletes transaction from trash.
+func (s *Transaction) Purge(id int64) error {
+	return s.journal.InTx(func() error { return s.journal.transactions.purge(id) })
+}
+
+// GetAll transactions from journal, transactions in trash are omitted.
+func (s *Transaction) GetAll() ([]*model.Transaction, error) {
+	return s.journal.transactions.getAll(false), nil
+}
+
+// GetDeleted returns transactions from trash.
+func (s *Transaction) GetDeleted() ([]*model.Transaction, error) {
+	return s.journal.transactions.getAll(true), nil
+}
+
+// newTransactionTable returns empty table of transactions.
+func newTransactionTable() *table[model.Transaction] {
+	return &table[model.Transaction]{
+		clone: func(t *model.Transaction) *model.Transaction {
+			res := *t
+			res.Account = &model.Account{ID: t.Account.ID}
+			res.Category = &model.Category{ID: t.Category.ID}
+			res.Payee, res.Tags, res.Splits = nil, nil, nil
+			return &res
+		},
+		fields: func(t *model.Transaction) (*int64, *time.Time) { return &t.ID, &t.DeletedAt },
+	}
+}
+
+// encodeTransaction returns journal line of transaction.
+func encodeTransaction(t *model.Transaction) string {
+	return newLine("transaction", t.ID).
+		time("date", t.Date).
+		int("account", t.Account.ID).
+		int("category", t.Category.ID).
+		int("amount", t.Amount).
+		str("status", string(t.Status)).
+		str("note", t.Note).
+		time("deleted", t.DeletedAt).
+		String()
+}
+
+// decodeTransaction returns transaction from journal record.
+func decodeTransaction(r *record) *model.Transaction {
+	return &model.Transaction{
+		ID:        r.id,
+		Date:      r.time("date"),
+		Account:   &model.Account{ID: r.int("account")},
+		Category:  &model.Category{ID: r.int("category")},
+		Amount:    r.int("amount"),
+		Status:    model.TransactionStatus(r.str("status")),
+		Note:      r.str("note"),
+		DeletedAt: r.time("deleted"),
+	}
+}
@@ -0,0 +1,142 @@
+package journal
+
+import (
+	"errors"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// ErrUnsupported is returned on attempt to store data which isn't kept in journal.
+var ErrUnsupported = errors.New("not supported by journal storage")
+
+// unsupported is a storage of data which isn't kept in journal. It is always empty and refuses
+// any changes, so features which depend on it are unavailable.
+type unsupported[T model.Any] struct{}
+
+// Insert refuses to store the record.
+func (unsupported[T]) Insert(*T) (int64, error) {
+	return -1, ErrUnsupported
+}
+
+// Update refuses to store the record.
+func (unsupported[T]) Update(*T) error {
+	return ErrUnsupported
+}
+
+// Delete refuses to delete the record, since there are no records.
+func (unsupported[T]) Delete(int64) error {
+	return ErrUnsupported
+}
+
+// Restore refuses to restore the record, since there are no records.
+func (unsupported[T]) Restore(int64) error {
+	return ErrUnsupported
+}
+
+// Purge refuses to purge the record, since there are no records.
+func (unsupported[T]) Purge(int64) error {
+	return ErrUnsupported
+}
+
+// GetAll returns no records.
+func (unsupported[T]) GetAll() ([]*T, error) {
+	return []*T{}, nil
+}
+
+// Transfer is a storage of transfers, they aren't kept in journal.
+type Transfer struct {
+	unsupported[model.Transfer]
+}
+
+// Split is a storage of splits, they aren't kept in journal.
+type Split struct {
+	unsupported[model.Split]
+}
+
+// SetTransactionSplits accepts only removal of all splits of transaction.
+func (s *Split) SetTransactionSplits(transactionID int64, ss []*model.Split) error {
+	if len(ss) > 0 {
+		return ErrUnsupported
+	}
+
+	return nil
+}
+
+// ExchangeRate is a storage of exchange rates, they aren't kept in journal.
+type ExchangeRate struct {
+	unsupported[model.ExchangeRate]
+}
+
+// Budget is a storage of budgets, they aren't kept in journal.
+type Budget struct {
+	unsupported[model.Budget]
+}
+
+// Recurrence is a storage of recurrences, they aren't kept in journal.
+type Recurrence struct {
+	unsupported[model.Recurrence]
+}
+
+// Tag is a storage of tags, they aren't kept in journal.
+type Tag struct {
+	unsupported[model.Tag]
+}
+
+// SetTransactionTags accepts only removal of all tags of transaction.
+func (s *Tag) SetTransactionTags(transactionID int64, tagIDs []int64) error {
+	if len(tagIDs) > 0 {
+		return ErrUnsupported
+	}
+
+	return nil
+}
+
+// GetAllTransactionTags returns no links between transactions and tags.
+func (s *Tag) GetAllTransactionTags() ([]*model.TransactionTag, error) {
+	return []*model.TransactionTag{}, nil
+}
+
+// Payee is a storage of payees, they aren't kept in journal.
+type Payee struct {
+	unsupported[model.Payee]
+}
+
+// Attachment is a storage of attachments, they aren't kept in journal.
+type Attachment struct {
+	unsupported[model.Attachment]
+}
+
+// GetByTransactionID returns no attachments.
+func (s *Attachment) GetByTransactionID(transactionID int64) ([]*model.Attachment, error) {
+	return []*model.Attachment{}, nil
+}
+
+// Goal is a storage of goals, they aren't kept in journal.
+type Goal struct {
+	unsupported[model.Goal]
+}
+
+// Counterparty is a storage of counterparties, they aren't kept in journal.
+type Counterparty struct {
+	unsupported[model.Counterparty]
+}
+
+// Debt is a storage of debts, they aren't kept in journal.
+type Debt struct {
+	unsupported[model.Debt]
+}
+
+// InsertRepayment refuses to store the repayment.
+func (s *Debt) InsertRepayment(r *model.DebtRepayment) error {
+	return ErrUnsupported
+}
+
+// DeleteRepayment refuses to delete the repayment, since there are no repayments.
+func (s *Debt) DeleteRepayment(r *model.DebtRepayment) error {
+	return ErrUnsupported
+}
+
+// GetAllRepayments returns no repayments.
+func (s *Debt) GetAllRepayments() ([]*model.DebtRepayment, error) {
+	return []*model.DebtRepayment{}, nil
+}
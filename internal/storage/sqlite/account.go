@@ -1,9 +1,6 @@
 package sqlite
 
 import (
-	"database/sql"
-	"fmt"
-
 	"github.com/kotlw/gentlemoney/internal/model"
 )
 
@@ -13,51 +10,58 @@ type Account struct {
 }
 
 // NewAccount returns new account storage.
-func NewAccount(db *sql.DB) (*Account, error) {
-	s := &Account{executor[model.Account]{db}}
-
-	if err := s.CreateTableIfNotExists(); err != nil {
-		return nil, fmt.Errorf("s.CreateTableIfNotExists: %w", err)
-	}
-
-	return s, nil
-}
-
-// CreateTableIfNotExists creates account table if not exists.
-func (s *Account) CreateTableIfNotExists() error {
-	q := `CREATE TABLE IF NOT EXISTS account(
-            id INTEGER PRIMARY KEY,
-            name TEXT NOT NULL UNIQUE,
-            currencyId INTEGER NOT NULL,
-            FOREIGN KEY(currencyId) REFERENCES currency(id));`
-	_, err := s.executor.db.Exec(q)
-	return err
+func NewAccount(db *DB) *Account {
+	return &Account{executor[model.Account]{db}}
 }
 
 // Insert account into persistent storage.
 func (s *Account) Insert(a *model.Account) (int64, error) {
 	return s.executor.insert(
-		`INSERT INTO account(name, currencyId) VALUES (?, ?);`,
-		a.Name, a.Currency.ID)
+		`INSERT INTO account(name, currencyId, openingBalance, openingDate, type, creditLimit, archived)
+         VALUES (?, ?, ?, ?, ?, ?, ?);`,
+		a.Name, a.Currency.ID, a.OpeningBalance, a.OpeningDate, a.Type, a.CreditLimit, a.Archived)
 }
 
 // Update account in persistand storage.
 func (s *Account) Update(a *model.Account) error {
 	return s.executor.update(
-		`UPDATE account SET name = ?, currencyId = ? WHERE id = ?;`,
-		a.Name, a.Currency.ID, a.ID)
+		`UPDATE account SET name = ?, currencyId = ?, openingBalance = ?, openingDate = ?, type = ?, creditLimit = ?,
+         archived = ? WHERE id = ?;`,
+		a.Name, a.Currency.ID, a.OpeningBalance, a.OpeningDate, a.Type, a.CreditLimit, a.Archived, a.ID)
 }
 
-// Delete account from persistent storage.
+// Delete moves account to trash.
 func (s *Account) Delete(id int64) error {
-	return s.executor.update(`DELETE FROM account WHERE id = ?;`, id)
+	return s.executor.softDelete("account", id)
+}
+
+// Restore brings account back from trash.
+func (s *Account) Restore(id int64) error {
+	return s.executor.restore("account", id)
 }
 
-// GetAll accounts from persistent storage.
+// Purge permanently deletes account from trash.
+func (s *Account) Purge(id int64) error {
+	return s.executor.purge("account", id)
+}
+
+// GetAll accounts from persistent storage, accounts in trash are omitted.
 func (s *Account) GetAll() ([]*model.Account, error) {
-	return s.executor.getAll(`SELECT id, name, currencyId FROM account;`,
+	return s.getAll("deletedAt IS NULL")
+}
+
+// GetDeleted returns accounts from trash.
+func (s *Account) GetDeleted() ([]*model.Account, error) {
+	return s.getAll("deletedAt IS NOT NULL")
+}
+
+// getAll returns accounts which match given condition.
+func (s *Account) getAll(cond string) ([]*model.Account, error) {
+	return s.executor.getAll(`SELECT id, name, currencyId, openingBalance, openingDate, type, creditLimit, archived, deletedAt
+                              FROM account WHERE `+cond+`;`,
 		func() (*model.Account, []any) {
 			t := model.NewEmptyAccount()
-			return t, []any{&t.ID, &t.Name, &t.Currency.ID}
+			return t, []any{&t.ID, &t.Name, &t.Currency.ID, &t.OpeningBalance, &t.OpeningDate, &t.Type, &t.CreditLimit,
+				&t.Archived, timeScanner{&t.DeletedAt}}
 		})
 }
@@ -3,6 +3,7 @@ package sqlite_test
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/kotlw/gentlemoney/internal/model"
 	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
@@ -21,18 +22,22 @@ type AccountSqliteStorageTestSuite struct {
 }
 
 func (s *AccountSqliteStorageTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
-	s.storage, err = sqlite.NewAccount(db)
+	err = sqlite.Migrate(db)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
+	insertReferences(s.T(), db, "currency")
+
+	s.storage = sqlite.NewAccount(sqlite.NewDB(db))
+
 	// id's settled by sqlite on insert incrementally starting from 1,
 	// so here they are initialized for match purpose
 	s.InitAccounts = []*model.Account{
-		{ID: 1, Name: "Card1", Currency: model.NewEmptyCurrency()},
-		{ID: 2, Name: "Card2", Currency: model.NewEmptyCurrency()},
+		{ID: 1, Name: "Card1", Currency: &model.Currency{ID: 1}, Type: model.Checking},
+		{ID: 2, Name: "Card2", Currency: &model.Currency{ID: 1}, Type: model.Checking},
 	}
 }
 
@@ -47,7 +52,7 @@ func (s *AccountSqliteStorageTestSuite) SetupTest() {
 }
 
 func (s *AccountSqliteStorageTestSuite) TestInsertPositive() {
-	account := &model.Account{ID: 3, Name: "Card3", Currency: &model.Currency{ID: 2}}
+	account := &model.Account{ID: 3, Name: "Card3", Currency: &model.Currency{ID: 2}, Type: model.Savings}
 	expectedAccounts := append(s.InitAccounts, account)
 
 	_, err := s.storage.Insert(account)
@@ -76,11 +81,37 @@ func (s *AccountSqliteStorageTestSuite) TestUpdate() {
 }
 
 func (s *AccountSqliteStorageTestSuite) TestDeletePositive() {
-	expectedAccounts := []*model.Account{s.InitAccounts[0]}
-
 	err := s.storage.Delete(2)
 	require.NoError(s.T(), err)
-	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedAccounts)
+
+	actual, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Account{s.InitAccounts[0]}, actual)
+
+	// deleted row is kept in trash.
+	assert.Len(s.T(), s.fetchActualData(), 2)
+	deleted, err := s.storage.GetDeleted()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), deleted, 1)
+	assert.Equal(s.T(), int64(2), deleted[0].ID)
+	assert.False(s.T(), deleted[0].DeletedAt.IsZero())
+}
+
+func (s *AccountSqliteStorageTestSuite) TestRestoreAndPurge() {
+	require.NoError(s.T(), s.storage.Delete(2))
+	require.NoError(s.T(), s.storage.Restore(2))
+
+	actual, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitAccounts, actual)
+
+	// only rows in trash could be restored or purged.
+	assert.EqualError(s.T(), s.storage.Restore(2), "total affected rows 0 while expected 1")
+	assert.EqualError(s.T(), s.storage.Purge(2), "total affected rows 0 while expected 1")
+
+	require.NoError(s.T(), s.storage.Delete(2))
+	require.NoError(s.T(), s.storage.Purge(2))
+	assert.ElementsMatch(s.T(), s.fetchActualData(), []*model.Account{s.InitAccounts[0]})
 }
 
 func (s *AccountSqliteStorageTestSuite) TestDeleteNegative() {
@@ -94,8 +125,45 @@ func (s *AccountSqliteStorageTestSuite) TestGetAll() {
 	assert.Equal(s.T(), allAccounts, s.InitAccounts)
 }
 
+func (s *AccountSqliteStorageTestSuite) TestOpeningBalance() {
+	account := &model.Account{ID: 3, Name: "Card3", Currency: &model.Currency{ID: 2}, Type: model.Cash,
+		OpeningBalance: -1050, OpeningDate: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+	_, err := s.storage.Insert(account)
+	require.NoError(s.T(), err)
+
+	allAccounts, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), append(s.InitAccounts, account), allAccounts)
+
+	account.OpeningBalance = 2000
+	err = s.storage.Update(account)
+	require.NoError(s.T(), err)
+
+	allAccounts, err = s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(2000), allAccounts[2].OpeningBalance)
+}
+
+func (s *AccountSqliteStorageTestSuite) TestTypeAndArchived() {
+	account := &model.Account{ID: 3, Name: "Card3", Currency: &model.Currency{ID: 2}, Type: model.CreditCard,
+		CreditLimit: 500000}
+
+	_, err := s.storage.Insert(account)
+	require.NoError(s.T(), err)
+
+	account.Archived = true
+	err = s.storage.Update(account)
+	require.NoError(s.T(), err)
+
+	allAccounts, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), append(s.InitAccounts, account), allAccounts)
+}
+
 func (s *AccountSqliteStorageTestSuite) fetchActualData() []*model.Account {
-	rows, err := s.db.Query(`SELECT id, name, currencyId FROM account;`)
+	rows, err := s.db.Query(`SELECT id, name, currencyId, openingBalance, openingDate, type, creditLimit, archived
+                              FROM account;`)
 	require.NoError(s.T(), err)
 	defer func() {
 		err = rows.Close()
@@ -105,7 +173,8 @@ func (s *AccountSqliteStorageTestSuite) fetchActualData() []*model.Account {
 	res := make([]*model.Account, 0, 3)
 	for rows.Next() {
 		t := model.NewEmptyAccount()
-		err = rows.Scan(&t.ID, &t.Name, &t.Currency.ID)
+		err = rows.Scan(&t.ID, &t.Name, &t.Currency.ID, &t.OpeningBalance, &t.OpeningDate, &t.Type, &t.CreditLimit,
+			&t.Archived)
 		require.NoError(s.T(), err)
 		res = append(res, t)
 	}
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Attachment is used to acces the persistent storage.
+type Attachment struct {
+	executor executor[model.Attachment]
+}
+
+// NewAttachment returns new attachment storage.
+func NewAttachment(db *DB) *Attachment {
+	return &Attachment{executor[model.Attachment]{db}}
+}
+
+// Insert attachment into persistent storage.
+func (s *Attachment) Insert(a *model.Attachment) (int64, error) {
+	return s.executor.insert(`INSERT INTO attachment (transactionId, name, hash) VALUES (?, ?, ?);`,
+		a.TransactionID, a.Name, a.Hash)
+}
+
+// Delete attachment from persistent storage.
+func (s *Attachment) Delete(id int64) error {
+	return s.executor.update(`DELETE FROM attachment WHERE id = ?;`, id)
+}
+
+// GetAll attachments from persistent storage.
+func (s *Attachment) GetAll() ([]*model.Attachment, error) {
+	return s.executor.getAll(`SELECT id, transactionId, name, hash FROM attachment;`, scanAttachment)
+}
+
+// GetByTransactionID returns attachments of given transaction from persistent storage.
+func (s *Attachment) GetByTransactionID(transactionID int64) ([]*model.Attachment, error) {
+	return s.executor.getAll(`SELECT id, transactionId, name, hash FROM attachment WHERE transactionId = ?;`,
+		scanAttachment, transactionID)
+}
+
+// scanAttachment returns empty attachment along with destinations of its columns.
+func scanAttachment() (*model.Attachment, []any) {
+	a := model.NewEmptyAttachment()
+	return a, []any{&a.ID, &a.TransactionID, &a.Name, &a.Hash}
+}
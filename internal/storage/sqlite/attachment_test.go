@@ -0,0 +1,100 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentSqliteStorageTestSuite struct {
+	suite.Suite
+	db              *sql.DB
+	storage         *sqlite.Attachment
+	InitAttachments []*model.Attachment
+}
+
+func (s *AttachmentSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "currency", "category", "account", "transaction")
+
+	s.storage = sqlite.NewAttachment(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitAttachments = []*model.Attachment{
+		{ID: 1, TransactionID: 1, Name: "receipt.jpg", Hash: "aaa"},
+		{ID: 2, TransactionID: 2, Name: "warranty.pdf", Hash: "bbb"},
+	}
+}
+
+func (s *AttachmentSqliteStorageTestSuite) SetupTest() {
+	for _, a := range s.InitAttachments {
+		_, err := s.storage.Insert(a)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *AttachmentSqliteStorageTestSuite) TestInsertPositive() {
+	attachment := &model.Attachment{ID: 3, TransactionID: 1, Name: "invoice.pdf", Hash: "ccc"}
+	expectedAttachments := append(s.InitAttachments, attachment)
+
+	_, err := s.storage.Insert(attachment)
+	require.NoError(s.T(), err)
+
+	actualAttachments, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), expectedAttachments, actualAttachments)
+}
+
+func (s *AttachmentSqliteStorageTestSuite) TestDeletePositive() {
+	err := s.storage.Delete(2)
+	require.NoError(s.T(), err)
+
+	actualAttachments, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Attachment{s.InitAttachments[0]}, actualAttachments)
+}
+
+func (s *AttachmentSqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *AttachmentSqliteStorageTestSuite) TestGetAll() {
+	actualAttachments, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitAttachments, actualAttachments)
+}
+
+func (s *AttachmentSqliteStorageTestSuite) TestGetByTransactionID() {
+	actualAttachments, err := s.storage.GetByTransactionID(2)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Attachment{s.InitAttachments[1]}, actualAttachments)
+}
+
+func (s *AttachmentSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM attachment;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *AttachmentSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestAttachmentSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentSqliteStorageTestSuite))
+}
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Budget is used to acces the persistent storage.
+type Budget struct {
+	executor executor[model.Budget]
+}
+
+// NewBudget returns new budget storage.
+func NewBudget(db *DB) *Budget {
+	return &Budget{executor[model.Budget]{db}}
+}
+
+// Insert budget into persistent storage.
+func (s *Budget) Insert(b *model.Budget) (int64, error) {
+	return s.executor.insert(`INSERT INTO budget(categoryId, period, limitAmount) VALUES (?, ?, ?);`,
+		b.Category.ID, b.Period, b.Limit)
+}
+
+// Update budget in persistand storage.
+func (s *Budget) Update(b *model.Budget) error {
+	return s.executor.update(`UPDATE budget SET categoryId = ?, period = ?, limitAmount = ? WHERE id = ?;`,
+		b.Category.ID, b.Period, b.Limit, b.ID)
+}
+
+// Delete budget from persistent storage.
+func (s *Budget) Delete(id int64) error {
+	return s.executor.update(`DELETE FROM budget WHERE id = ?;`, id)
+}
+
+// GetAll budgets from persistent storage.
+func (s *Budget) GetAll() ([]*model.Budget, error) {
+	return s.executor.getAll(`SELECT id, categoryId, period, limitAmount FROM budget;`,
+		func() (*model.Budget, []any) {
+			b := model.NewEmptyBudget()
+			return b, []any{&b.ID, &b.Category.ID, &b.Period, &b.Limit}
+		})
+}
@@ -0,0 +1,150 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetSqliteStorageTestSuite struct {
+	suite.Suite
+	db          *sql.DB
+	storage     *sqlite.Budget
+	InitBudgets []*model.Budget
+}
+
+func (s *BudgetSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "category")
+
+	s.storage = sqlite.NewBudget(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitBudgets = []*model.Budget{
+		{
+			ID:       1,
+			Category: &model.Category{ID: 1},
+			Period:   time.Date(2022, time.Month(2), 1, 0, 0, 0, 0, time.UTC),
+			Limit:    10000,
+		},
+		{
+			ID:       2,
+			Category: &model.Category{ID: 2},
+			Period:   time.Date(2022, time.Month(2), 1, 0, 0, 0, 0, time.UTC),
+			Limit:    5000,
+		},
+	}
+}
+
+func (s *BudgetSqliteStorageTestSuite) SetupTest() {
+	stmt, err := s.db.Prepare(`INSERT INTO budget(categoryId, period, limitAmount) VALUES (?, ?, ?);`)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+
+	for _, b := range s.InitBudgets {
+		_, err := stmt.Exec(b.Category.ID, b.Period, b.Limit)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *BudgetSqliteStorageTestSuite) TestInsertPositive() {
+	budget := &model.Budget{
+		ID:       3,
+		Category: &model.Category{ID: 1},
+		Period:   time.Date(2022, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+		Limit:    12000,
+	}
+	expectedBudgets := append(s.InitBudgets, budget)
+
+	_, err := s.storage.Insert(budget)
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedBudgets)
+}
+
+func (s *BudgetSqliteStorageTestSuite) TestInsertNegative() {
+	_, err := s.storage.Insert(s.InitBudgets[1])
+	assert.EqualError(s.T(), err, "e.db.Exec: UNIQUE constraint failed: budget.categoryId, budget.period")
+}
+
+func (s *BudgetSqliteStorageTestSuite) TestUpdatePositive() {
+	expectedBudgets := make([]*model.Budget, len(s.InitBudgets))
+	copy(expectedBudgets, s.InitBudgets)
+	expectedBudgets[1].Limit = 7500
+
+	err := s.storage.Update(expectedBudgets[1])
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedBudgets)
+}
+
+func (s *BudgetSqliteStorageTestSuite) TestUpdateNegative() {
+	b := model.NewEmptyBudget()
+	b.ID = 10
+	err := s.storage.Update(b)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *BudgetSqliteStorageTestSuite) TestDeletePositive() {
+	expectedBudgets := []*model.Budget{s.InitBudgets[0]}
+
+	err := s.storage.Delete(2)
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedBudgets)
+}
+
+func (s *BudgetSqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *BudgetSqliteStorageTestSuite) TestGetAll() {
+	allBudgets, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitBudgets, allBudgets)
+}
+
+func (s *BudgetSqliteStorageTestSuite) fetchActualData() []*model.Budget {
+	rows, err := s.db.Query(`SELECT id, categoryId, period, limitAmount FROM budget;`)
+	require.NoError(s.T(), err)
+	defer func() {
+		err = rows.Close()
+		require.NoError(s.T(), err)
+	}()
+
+	res := make([]*model.Budget, 0, 3)
+	for rows.Next() {
+		b := model.NewEmptyBudget()
+		err = rows.Scan(&b.ID, &b.Category.ID, &b.Period, &b.Limit)
+		require.NoError(s.T(), err)
+		res = append(res, b)
+	}
+
+	return res
+}
+
+func (s *BudgetSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM budget;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *BudgetSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestBudgetSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetSqliteStorageTestSuite))
+}
@@ -2,7 +2,6 @@ package sqlite
 
 import (
 	"database/sql"
-	"fmt"
 
 	"github.com/kotlw/gentlemoney/internal/model"
 )
@@ -13,45 +12,79 @@ type Category struct {
 }
 
 // NewCategory returns new category storage.
-func NewCategory(db *sql.DB) (*Category, error) {
-	s := &Category{executor[model.Category]{db}}
-
-	if err := s.CreateTableIfNotExists(); err != nil {
-		return nil, fmt.Errorf("s.CreateTableIfNotExists: %w", err)
-	}
-
-	return s, nil
-}
-
-// CreateTableIfNotExists creates category table if not exists.
-func (s *Category) CreateTableIfNotExists() error {
-	q := `CREATE TABLE IF NOT EXISTS category(
-            id INTEGER PRIMARY KEY,
-            title TEXT NOT NULL UNIQUE);`
-	_, err := s.executor.db.Exec(q)
-	return err
+func NewCategory(db *DB) *Category {
+	return &Category{executor[model.Category]{db}}
 }
 
 // Insert category into persistent storage.
 func (s *Category) Insert(c *model.Category) (int64, error) {
-	return s.executor.insert(`INSERT INTO category (title) VALUES (?);`, c.Title)
+	return s.executor.insert(`INSERT INTO category (title, parentId, kind) VALUES (?, ?, ?);`,
+		c.Title, parentID(c), c.Kind)
 }
 
 // Update category in persistand storage.
 func (s *Category) Update(c *model.Category) error {
-	return s.executor.update(`UPDATE category SET title = ? WHERE id = ?;`, c.Title, c.ID)
+	return s.executor.update(`UPDATE category SET title = ?, parentId = ?, kind = ? WHERE id = ?;`,
+		c.Title, parentID(c), c.Kind, c.ID)
 }
 
-// Delete category from persistent storage.
+// Delete moves category to trash.
 func (s *Category) Delete(id int64) error {
-	return s.executor.update(`DELETE FROM category WHERE id = ?;`, id)
+	return s.executor.softDelete("category", id)
+}
+
+// Restore brings category back from trash.
+func (s *Category) Restore(id int64) error {
+	return s.executor.restore("category", id)
+}
+
+// Purge permanently deletes category from trash.
+func (s *Category) Purge(id int64) error {
+	return s.executor.purge("category", id)
 }
 
-// GetAll categories from persistent storage.
+// GetAll categories from persistent storage, categories in trash are omitted.
 func (s *Category) GetAll() ([]*model.Category, error) {
-	return s.executor.getAll(`SELECT id, title FROM category;`,
+	return s.getAll("deletedAt IS NULL")
+}
+
+// GetDeleted returns categories from trash.
+func (s *Category) GetDeleted() ([]*model.Category, error) {
+	return s.getAll("deletedAt IS NOT NULL")
+}
+
+// getAll returns categories which match given condition.
+func (s *Category) getAll(cond string) ([]*model.Category, error) {
+	return s.executor.getAll(`SELECT id, title, parentId, kind, deletedAt FROM category WHERE `+cond+`;`,
 		func() (*model.Category, []any) {
 			t := model.NewEmptyCategory()
-			return t, []any{&t.ID, &t.Title}
+			return t, []any{&t.ID, &t.Title, &parentScanner{t}, &t.Kind, timeScanner{&t.DeletedAt}}
 		})
 }
+
+// parentID returns id of the parent category or nil for top level category.
+func parentID(c *model.Category) any {
+	if c.Parent == nil {
+		return nil
+	}
+	return c.Parent.ID
+}
+
+// parentScanner scans nullable parentId column into Category.Parent, only ID of the parent is set.
+type parentScanner struct {
+	c *model.Category
+}
+
+// Scan implements sql.Scanner.
+func (p *parentScanner) Scan(value any) error {
+	var id sql.NullInt64
+	if err := id.Scan(value); err != nil {
+		return err
+	}
+
+	if id.Valid {
+		p.c.Parent = &model.Category{ID: id.Int64}
+	}
+
+	return nil
+}
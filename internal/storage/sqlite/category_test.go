@@ -21,18 +21,20 @@ type CategorySqliteStorageTestSuite struct {
 }
 
 func (s *CategorySqliteStorageTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
-	s.storage, err = sqlite.NewCategory(db)
+	err = sqlite.Migrate(db)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
+	s.storage = sqlite.NewCategory(sqlite.NewDB(db))
+
 	// id's settled by sqlite on insert incrementally starting from 1,
 	// so here they are initialized for match purpose
 	s.InitCategories = []*model.Category{
-		{ID: 1, Title: "Grocery"},
-		{ID: 2, Title: "Health"},
+		{ID: 1, Title: "Grocery", Kind: model.Both},
+		{ID: 2, Title: "Health", Kind: model.Both},
 	}
 }
 
@@ -47,7 +49,7 @@ func (s *CategorySqliteStorageTestSuite) SetupTest() {
 }
 
 func (s *CategorySqliteStorageTestSuite) TestInsertPositive() {
-	category := &model.Category{ID: 3, Title: "Sport"}
+	category := &model.Category{ID: 3, Title: "Sport", Kind: model.Expense}
 	expectedCategories := append(s.InitCategories, category)
 
 	_, err := s.storage.Insert(category)
@@ -55,9 +57,37 @@ func (s *CategorySqliteStorageTestSuite) TestInsertPositive() {
 	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedCategories)
 }
 
+func (s *CategorySqliteStorageTestSuite) TestParent() {
+	category := &model.Category{ID: 3, Title: "Sport", Parent: s.InitCategories[1]}
+
+	_, err := s.storage.Insert(category)
+	require.NoError(s.T(), err)
+
+	category.Parent = nil
+	err = s.storage.Update(category)
+	require.NoError(s.T(), err)
+
+	category.Parent = s.InitCategories[0]
+	err = s.storage.Update(category)
+	require.NoError(s.T(), err)
+
+	actualCategories, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), &model.Category{ID: 3, Title: "Sport", Parent: &model.Category{ID: 1}}, actualCategories[2])
+	assert.Nil(s.T(), actualCategories[0].Parent)
+}
+
 func (s *CategorySqliteStorageTestSuite) TestInsertNegative() {
 	_, err := s.storage.Insert(s.InitCategories[1])
-	assert.ErrorContains(s.T(), err, "e.db.Exec: UNIQUE constraint failed: category.title")
+	assert.ErrorContains(s.T(), err, "e.db.Exec: UNIQUE constraint failed: index 'category_title'")
+}
+
+func (s *CategorySqliteStorageTestSuite) TestTitleWithinParent() {
+	_, err := s.storage.Insert(&model.Category{Title: "Health", Parent: s.InitCategories[0]})
+	require.NoError(s.T(), err)
+
+	_, err = s.storage.Insert(&model.Category{Title: "Health", Parent: s.InitCategories[0]})
+	assert.ErrorContains(s.T(), err, "e.db.Exec: UNIQUE constraint failed: index 'category_title'")
 }
 
 func (s *CategorySqliteStorageTestSuite) TestUpdatePositive() {
@@ -76,11 +106,37 @@ func (s *CategorySqliteStorageTestSuite) TestUpdateNegative() {
 }
 
 func (s *CategorySqliteStorageTestSuite) TestDeletePositive() {
-	expectedCategories := []*model.Category{s.InitCategories[0]}
-
 	err := s.storage.Delete(2)
 	require.NoError(s.T(), err)
-	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedCategories)
+
+	actual, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Category{s.InitCategories[0]}, actual)
+
+	// deleted row is kept in trash.
+	assert.Len(s.T(), s.fetchActualData(), 2)
+	deleted, err := s.storage.GetDeleted()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), deleted, 1)
+	assert.Equal(s.T(), int64(2), deleted[0].ID)
+	assert.False(s.T(), deleted[0].DeletedAt.IsZero())
+}
+
+func (s *CategorySqliteStorageTestSuite) TestRestoreAndPurge() {
+	require.NoError(s.T(), s.storage.Delete(2))
+	require.NoError(s.T(), s.storage.Restore(2))
+
+	actual, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitCategories, actual)
+
+	// only rows in trash could be restored or purged.
+	assert.EqualError(s.T(), s.storage.Restore(2), "total affected rows 0 while expected 1")
+	assert.EqualError(s.T(), s.storage.Purge(2), "total affected rows 0 while expected 1")
+
+	require.NoError(s.T(), s.storage.Delete(2))
+	require.NoError(s.T(), s.storage.Purge(2))
+	assert.ElementsMatch(s.T(), s.fetchActualData(), []*model.Category{s.InitCategories[0]})
 }
 
 func (s *CategorySqliteStorageTestSuite) TestDeleteNegative() {
@@ -95,7 +151,7 @@ func (s *CategorySqliteStorageTestSuite) TestGetAll() {
 }
 
 func (s *CategorySqliteStorageTestSuite) fetchActualData() []*model.Category {
-	rows, err := s.db.Query(`SELECT id, title FROM category;`)
+	rows, err := s.db.Query(`SELECT id, title, kind FROM category;`)
 	require.NoError(s.T(), err)
 	defer func() {
 		err = rows.Close()
@@ -105,7 +161,7 @@ func (s *CategorySqliteStorageTestSuite) fetchActualData() []*model.Category {
 	res := make([]*model.Category, 0, 3)
 	for rows.Next() {
 		t := model.NewEmptyCategory()
-		err = rows.Scan(&t.ID, &t.Title)
+		err = rows.Scan(&t.ID, &t.Title, &t.Kind)
 		require.NoError(s.T(), err)
 		res = append(res, t)
 	}
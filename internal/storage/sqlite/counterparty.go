@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Counterparty is used to acces the persistent storage.
+type Counterparty struct {
+	executor executor[model.Counterparty]
+}
+
+// NewCounterparty returns new counterparty storage.
+func NewCounterparty(db *DB) *Counterparty {
+	return &Counterparty{executor[model.Counterparty]{db}}
+}
+
+// Insert counterparty into persistent storage.
+func (s *Counterparty) Insert(c *model.Counterparty) (int64, error) {
+	return s.executor.insert(`INSERT INTO counterparty (name) VALUES (?);`, c.Name)
+}
+
+// Update counterparty in persistand storage.
+func (s *Counterparty) Update(c *model.Counterparty) error {
+	return s.executor.update(`UPDATE counterparty SET name = ? WHERE id = ?;`, c.Name, c.ID)
+}
+
+// Delete counterparty from persistent storage.
+func (s *Counterparty) Delete(id int64) error {
+	return s.executor.update(`DELETE FROM counterparty WHERE id = ?;`, id)
+}
+
+// GetAll counterparties from persistent storage.
+func (s *Counterparty) GetAll() ([]*model.Counterparty, error) {
+	return s.executor.getAll(`SELECT id, name FROM counterparty;`,
+		func() (*model.Counterparty, []any) {
+			c := model.NewEmptyCounterparty()
+			return c, []any{&c.ID, &c.Name}
+		})
+}
@@ -0,0 +1,114 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CounterpartySqliteStorageTestSuite struct {
+	suite.Suite
+	db                 *sql.DB
+	storage            *sqlite.Counterparty
+	InitCounterparties []*model.Counterparty
+}
+
+func (s *CounterpartySqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	s.storage = sqlite.NewCounterparty(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitCounterparties = []*model.Counterparty{
+		{ID: 1, Name: "John"},
+		{ID: 2, Name: "Bank"},
+	}
+}
+
+func (s *CounterpartySqliteStorageTestSuite) SetupTest() {
+	for _, c := range s.InitCounterparties {
+		_, err := s.storage.Insert(c)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *CounterpartySqliteStorageTestSuite) TestInsertPositive() {
+	counterparty := &model.Counterparty{ID: 3, Name: "Alice"}
+	expectedCounterparties := append(s.InitCounterparties, counterparty)
+
+	_, err := s.storage.Insert(counterparty)
+	require.NoError(s.T(), err)
+
+	actualCounterparties, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), expectedCounterparties, actualCounterparties)
+}
+
+func (s *CounterpartySqliteStorageTestSuite) TestInsertNegative() {
+	_, err := s.storage.Insert(s.InitCounterparties[1])
+	assert.EqualError(s.T(), err, "e.db.Exec: UNIQUE constraint failed: counterparty.name")
+}
+
+func (s *CounterpartySqliteStorageTestSuite) TestUpdatePositive() {
+	counterparty := &model.Counterparty{ID: 2, Name: "Credit union"}
+	expectedCounterparties := []*model.Counterparty{s.InitCounterparties[0], counterparty}
+
+	err := s.storage.Update(counterparty)
+	require.NoError(s.T(), err)
+
+	actualCounterparties, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), expectedCounterparties, actualCounterparties)
+}
+
+func (s *CounterpartySqliteStorageTestSuite) TestUpdateNegative() {
+	err := s.storage.Update(&model.Counterparty{ID: 10})
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *CounterpartySqliteStorageTestSuite) TestDeletePositive() {
+	err := s.storage.Delete(2)
+	require.NoError(s.T(), err)
+
+	actualCounterparties, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitCounterparties[:1], actualCounterparties)
+}
+
+func (s *CounterpartySqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *CounterpartySqliteStorageTestSuite) TestGetAll() {
+	actualCounterparties, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitCounterparties, actualCounterparties)
+}
+
+func (s *CounterpartySqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM counterparty;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *CounterpartySqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestCounterpartySqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(CounterpartySqliteStorageTestSuite))
+}
@@ -13,46 +13,74 @@ type Currency struct {
 }
 
 // NewCurrency returns new currency storage.
-func NewCurrency(db *sql.DB) (*Currency, error) {
-	s := &Currency{executor[model.Currency]{db}}
-
-	if err := s.CreateTableIfNotExists(); err != nil {
-		return nil, fmt.Errorf("s.CreateTableIfNotExists: %w", err)
-	}
-
-	return s, nil
-}
-
-// CreateTableIfNotExists creates currency table if not exists.
-func (s *Currency) CreateTableIfNotExists() error {
-	q := `CREATE TABLE IF NOT EXISTS currency(
-            id INTEGER PRIMARY KEY,
-            abbreviation TEXT NOT NULL UNIQUE);`
-	_, err := s.executor.db.Exec(q)
-	return err
+func NewCurrency(db *DB) *Currency {
+	return &Currency{executor[model.Currency]{db}}
 }
 
 // Insert currency into persistent storage.
 func (s *Currency) Insert(c *model.Currency) (int64, error) {
-	return s.executor.insert(`INSERT INTO currency(abbreviation) VALUES (?);`, c.Abbreviation)
+	return s.executor.insert(`INSERT INTO currency(abbreviation, isMain, precision) VALUES (?, ?, ?);`,
+		c.Abbreviation, c.IsMain, c.Precision)
 }
 
 // Update currency in persistand storage.
 func (s *Currency) Update(c *model.Currency) error {
 	return s.executor.update(
-		`UPDATE currency SET abbreviation = ? WHERE id = ?;`, c.Abbreviation, c.ID)
+		`UPDATE currency SET abbreviation = ?, isMain = ?, precision = ? WHERE id = ?;`,
+		c.Abbreviation, c.IsMain, c.Precision, c.ID)
+}
+
+// SetMain marks currency with given id as main and unmarks all others in a single database
+// transaction.
+func (s *Currency) SetMain(id int64) error {
+	return s.executor.inTx(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`UPDATE currency SET isMain = 1 WHERE id = ?;`, id)
+		if err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		if err = affectedOne(res); err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(`UPDATE currency SET isMain = 0 WHERE id != ?;`, id); err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		return nil
+	})
 }
 
-// Delete currency from persistent storage.
+// Delete moves currency to trash.
 func (s *Currency) Delete(id int64) error {
-	return s.executor.update(`DELETE FROM currency WHERE id = ?;`, id)
+	return s.executor.softDelete("currency", id)
 }
 
-// GetAll currency from persistent storage.
+// Restore brings currency back from trash.
+func (s *Currency) Restore(id int64) error {
+	return s.executor.restore("currency", id)
+}
+
+// Purge permanently deletes currency from trash.
+func (s *Currency) Purge(id int64) error {
+	return s.executor.purge("currency", id)
+}
+
+// GetAll currency from persistent storage, currencies in trash are omitted.
 func (s *Currency) GetAll() ([]*model.Currency, error) {
-	return s.executor.getAll(`SELECT id, abbreviation FROM currency;`,
+	return s.getAll("deletedAt IS NULL")
+}
+
+// GetDeleted returns currencies from trash.
+func (s *Currency) GetDeleted() ([]*model.Currency, error) {
+	return s.getAll("deletedAt IS NOT NULL")
+}
+
+// getAll returns currencies which match given condition.
+func (s *Currency) getAll(cond string) ([]*model.Currency, error) {
+	return s.executor.getAll(`SELECT id, abbreviation, isMain, precision, deletedAt FROM currency WHERE `+cond+`;`,
 		func() (*model.Currency, []any) {
 			t := model.NewEmptyCurrency()
-			return t, []any{&t.ID, &t.Abbreviation}
+			return t, []any{&t.ID, &t.Abbreviation, &t.IsMain, &t.Precision, timeScanner{&t.DeletedAt}}
 		})
 }
@@ -21,32 +21,34 @@ type CurrencySqliteStorageTestSuite struct {
 }
 
 func (s *CurrencySqliteStorageTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
-	s.storage, err = sqlite.NewCurrency(db)
+	err = sqlite.Migrate(db)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
+	s.storage = sqlite.NewCurrency(sqlite.NewDB(db))
+
 	// id's settled by sqlite on insert incrementally starting from 1,
 	// so here they are initialized for match purpose
 	s.InitCurrencies = []*model.Currency{
-		{ID: 1, Abbreviation: "USD"},
-		{ID: 2, Abbreviation: "EUR"}}
+		{ID: 1, Abbreviation: "USD", IsMain: true, Precision: 2},
+		{ID: 2, Abbreviation: "JPY", Precision: 0}}
 }
 
 func (s *CurrencySqliteStorageTestSuite) SetupTest() {
-	stmt, err := s.db.Prepare(`INSERT INTO currency(abbreviation) VALUES (?);`)
+	stmt, err := s.db.Prepare(`INSERT INTO currency(abbreviation, isMain, precision) VALUES (?, ?, ?);`)
 	require.NoError(s.T(), err, "occurred in SetupTest")
 
 	for _, currency := range s.InitCurrencies {
-		_, err := stmt.Exec(currency.Abbreviation)
+		_, err := stmt.Exec(currency.Abbreviation, currency.IsMain, currency.Precision)
 		require.NoError(s.T(), err, "occurred in SetupTest")
 	}
 }
 
 func (s *CurrencySqliteStorageTestSuite) TestInsertPositive() {
-	currency := &model.Currency{ID: 3, Abbreviation: "PLN"}
+	currency := &model.Currency{ID: 3, Abbreviation: "KWD", Precision: 3}
 	expectedCurrencies := append(s.InitCurrencies, currency)
 
 	_, err := s.storage.Insert(currency)
@@ -63,6 +65,7 @@ func (s *CurrencySqliteStorageTestSuite) TestUpdatePositive() {
 	expectedCurrencies := make([]*model.Currency, len(s.InitCurrencies))
 	copy(expectedCurrencies, s.InitCurrencies)
 	expectedCurrencies[1].Abbreviation = "CZN"
+	expectedCurrencies[1].Precision = 2
 
 	err := s.storage.Update(expectedCurrencies[1])
 	require.NoError(s.T(), err)
@@ -75,11 +78,37 @@ func (s *CurrencySqliteStorageTestSuite) TestUpdateNegative() {
 }
 
 func (s *CurrencySqliteStorageTestSuite) TestDeletePositive() {
-	expectedCurrencies := []*model.Currency{s.InitCurrencies[0]}
-
 	err := s.storage.Delete(2)
 	require.NoError(s.T(), err)
-	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedCurrencies)
+
+	actual, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Currency{s.InitCurrencies[0]}, actual)
+
+	// deleted row is kept in trash.
+	assert.Len(s.T(), s.fetchActualData(), 2)
+	deleted, err := s.storage.GetDeleted()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), deleted, 1)
+	assert.Equal(s.T(), int64(2), deleted[0].ID)
+	assert.False(s.T(), deleted[0].DeletedAt.IsZero())
+}
+
+func (s *CurrencySqliteStorageTestSuite) TestRestoreAndPurge() {
+	require.NoError(s.T(), s.storage.Delete(2))
+	require.NoError(s.T(), s.storage.Restore(2))
+
+	actual, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitCurrencies, actual)
+
+	// only rows in trash could be restored or purged.
+	assert.EqualError(s.T(), s.storage.Restore(2), "total affected rows 0 while expected 1")
+	assert.EqualError(s.T(), s.storage.Purge(2), "total affected rows 0 while expected 1")
+
+	require.NoError(s.T(), s.storage.Delete(2))
+	require.NoError(s.T(), s.storage.Purge(2))
+	assert.ElementsMatch(s.T(), s.fetchActualData(), []*model.Currency{s.InitCurrencies[0]})
 }
 
 func (s *CurrencySqliteStorageTestSuite) TestDeleteNegative() {
@@ -87,6 +116,23 @@ func (s *CurrencySqliteStorageTestSuite) TestDeleteNegative() {
 	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
 }
 
+func (s *CurrencySqliteStorageTestSuite) TestSetMainPositive() {
+	err := s.storage.SetMain(2)
+	require.NoError(s.T(), err)
+
+	actual := s.fetchActualData()
+	assert.False(s.T(), actual[0].IsMain)
+	assert.True(s.T(), actual[1].IsMain)
+}
+
+func (s *CurrencySqliteStorageTestSuite) TestSetMainNegative() {
+	err := s.storage.SetMain(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+
+	actual := s.fetchActualData()
+	assert.True(s.T(), actual[0].IsMain)
+}
+
 func (s *CurrencySqliteStorageTestSuite) TestGetAll() {
 	allCurrencies, err := s.storage.GetAll()
 	require.NoError(s.T(), err)
@@ -94,7 +140,7 @@ func (s *CurrencySqliteStorageTestSuite) TestGetAll() {
 }
 
 func (s *CurrencySqliteStorageTestSuite) fetchActualData() []*model.Currency {
-	rows, err := s.db.Query(`SELECT id, abbreviation FROM currency;`)
+	rows, err := s.db.Query(`SELECT id, abbreviation, isMain, precision FROM currency;`)
 	require.NoError(s.T(), err)
 	defer func() {
 		err = rows.Close()
@@ -104,7 +150,7 @@ func (s *CurrencySqliteStorageTestSuite) fetchActualData() []*model.Currency {
 	res := make([]*model.Currency, 0, 3)
 	for rows.Next() {
 		t := model.NewEmptyCurrency()
-		err = rows.Scan(&t.ID, &t.Abbreviation)
+		err = rows.Scan(&t.ID, &t.Abbreviation, &t.IsMain, &t.Precision)
 		require.NoError(s.T(), err)
 		res = append(res, t)
 	}
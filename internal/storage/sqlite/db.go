@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// querier is implemented by both sql.DB and sql.Tx.
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// DB is a database handle shared by storages. Queries are executed directly against database
+// unless transaction is in progress, then they are executed inside of it, so changes made through
+// several storages are committed or rolled back together.
+type DB struct {
+	db *sql.DB
+	tx *sql.Tx
+}
+
+// NewDB returns DB which works against given database.
+func NewDB(db *sql.DB) *DB {
+	return &DB{db: db}
+}
+
+// InTx executes fn inside of database transaction. Changes are committed only if fn succeeds,
+// otherwise they are rolled back. Transaction which is already in progress is joined, so changes
+// are committed or rolled back by the outermost call.
+func (d *DB) InTx(fn func(tx *sql.Tx) error) error {
+	if d.tx != nil {
+		return fn(d.tx)
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("d.db.Begin: %w", err)
+	}
+
+	d.tx = tx
+	defer func() { d.tx = nil }()
+
+	if err = fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx.Rollback: %v: %w", rbErr, err)
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// conn returns transaction in progress if any, otherwise database itself.
+func (d *DB) conn() querier {
+	if d.tx != nil {
+		return d.tx
+	}
+
+	return d.db
+}
@@ -0,0 +1,86 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DBTestSuite struct {
+	suite.Suite
+	db      *sql.DB
+	storage *sqlite.SqliteStorage
+}
+
+func (s *DBTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	db.SetMaxOpenConns(1)
+	s.db = db
+
+	s.storage, err = sqlite.New(db)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+}
+
+func (s *DBTestSuite) TestInTxCommit() {
+	err := s.storage.InTx(func() error {
+		if _, err := s.storage.Currency().Insert(&model.Currency{Abbreviation: "USD"}); err != nil {
+			return err
+		}
+		_, err := s.storage.Category().Insert(&model.Category{Title: "Grocery"})
+		return err
+	})
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), 1, s.count("currency"))
+	assert.Equal(s.T(), 1, s.count("category"))
+}
+
+func (s *DBTestSuite) TestInTxRollback() {
+	failure := errors.New("failure")
+
+	err := s.storage.InTx(func() error {
+		if _, err := s.storage.Currency().Insert(&model.Currency{Abbreviation: "USD"}); err != nil {
+			return err
+		}
+
+		// nested transaction joins the outer one, so it is rolled back as well
+		err := s.storage.InTx(func() error {
+			_, err := s.storage.Category().Insert(&model.Category{Title: "Grocery"})
+			return err
+		})
+		require.NoError(s.T(), err)
+
+		return failure
+	})
+	assert.ErrorIs(s.T(), err, failure)
+
+	assert.Equal(s.T(), 0, s.count("currency"))
+	assert.Equal(s.T(), 0, s.count("category"))
+}
+
+// count returns number of rows in given table.
+func (s *DBTestSuite) count(table string) int {
+	var n int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM ` + table + `;`).Scan(&n)
+	require.NoError(s.T(), err)
+
+	return n
+}
+
+func (s *DBTestSuite) TearDownTest() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func TestDBTestSuite(t *testing.T) {
+	suite.Run(t, new(DBTestSuite))
+}
@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Debt is used to acces the persistent storage. It also manages links between debts and
+// transactions which repay them.
+type Debt struct {
+	executor     executor[model.Debt]
+	linkExecutor executor[model.DebtRepayment]
+}
+
+// NewDebt returns new debt storage.
+func NewDebt(db *DB) *Debt {
+	return &Debt{executor[model.Debt]{db}, executor[model.DebtRepayment]{db}}
+}
+
+// Insert debt into persistent storage.
+func (s *Debt) Insert(d *model.Debt) (int64, error) {
+	return s.executor.insert(`INSERT INTO debt (counterpartyId, direction, principal, currencyId, interestRate,
+                              date, installments, note) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`,
+		d.Counterparty.ID, d.Direction, d.Principal, d.Currency.ID, d.InterestRate, d.Date, d.Installments, d.Note)
+}
+
+// Update debt in persistand storage.
+func (s *Debt) Update(d *model.Debt) error {
+	return s.executor.update(`UPDATE debt SET counterpartyId = ?, direction = ?, principal = ?, currencyId = ?,
+                              interestRate = ?, date = ?, installments = ?, note = ? WHERE id = ?;`,
+		d.Counterparty.ID, d.Direction, d.Principal, d.Currency.ID, d.InterestRate, d.Date, d.Installments, d.Note,
+		d.ID)
+}
+
+// Delete debt along with its links to repayments from persistent storage in a single database
+// transaction.
+func (s *Debt) Delete(id int64) error {
+	return s.executor.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM debt_repayment WHERE debtId = ?;`, id); err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		res, err := tx.Exec(`DELETE FROM debt WHERE id = ?;`, id)
+		if err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		return affectedOne(res)
+	})
+}
+
+// GetAll debts from persistent storage.
+func (s *Debt) GetAll() ([]*model.Debt, error) {
+	return s.executor.getAll(`SELECT id, counterpartyId, direction, principal, currencyId, interestRate, date,
+                              installments, note FROM debt;`,
+		func() (*model.Debt, []any) {
+			d := model.NewEmptyDebt()
+			return d, []any{&d.ID, &d.Counterparty.ID, &d.Direction, &d.Principal, &d.Currency.ID,
+				&d.InterestRate, &d.Date, &d.Installments, &d.Note}
+		})
+}
+
+// InsertRepayment links transaction to debt as its repayment.
+func (s *Debt) InsertRepayment(r *model.DebtRepayment) error {
+	_, err := s.linkExecutor.insert(`INSERT INTO debt_repayment (debtId, transactionId) VALUES (?, ?);`,
+		r.DebtID, r.TransactionID)
+	return err
+}
+
+// DeleteRepayment unlinks transaction from debt.
+func (s *Debt) DeleteRepayment(r *model.DebtRepayment) error {
+	return s.linkExecutor.update(`DELETE FROM debt_repayment WHERE debtId = ? AND transactionId = ?;`,
+		r.DebtID, r.TransactionID)
+}
+
+// GetAllRepayments returns all links between debts and transactions from persistent storage.
+func (s *Debt) GetAllRepayments() ([]*model.DebtRepayment, error) {
+	return s.linkExecutor.getAll(`SELECT debtId, transactionId FROM debt_repayment;`,
+		func() (*model.DebtRepayment, []any) {
+			r := &model.DebtRepayment{}
+			return r, []any{&r.DebtID, &r.TransactionID}
+		})
+}
@@ -0,0 +1,180 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DebtSqliteStorageTestSuite struct {
+	suite.Suite
+	db        *sql.DB
+	storage   *sqlite.Debt
+	InitDebts []*model.Debt
+}
+
+func (s *DebtSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "currency", "category", "account", "counterparty", "transaction")
+
+	s.storage = sqlite.NewDebt(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitDebts = []*model.Debt{
+		{
+			ID:           1,
+			Counterparty: &model.Counterparty{ID: 1},
+			Direction:    model.Given,
+			Principal:    50000,
+			Currency:     &model.Currency{ID: 1},
+			Date:         time.Date(2023, time.Month(1), 15, 0, 0, 0, 0, time.UTC),
+			Note:         "for a bike",
+		},
+		{
+			ID:           2,
+			Counterparty: &model.Counterparty{ID: 2},
+			Direction:    model.Taken,
+			Principal:    1200000,
+			Currency:     &model.Currency{ID: 2},
+			InterestRate: 12.5,
+			Date:         time.Date(2023, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+			Installments: 12,
+		},
+	}
+}
+
+func (s *DebtSqliteStorageTestSuite) SetupTest() {
+	for _, d := range s.InitDebts {
+		_, err := s.storage.Insert(d)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *DebtSqliteStorageTestSuite) TestInsertPositive() {
+	debt := &model.Debt{
+		ID:           3,
+		Counterparty: &model.Counterparty{ID: 1},
+		Direction:    model.Taken,
+		Principal:    10000,
+		Currency:     &model.Currency{ID: 1},
+		Date:         time.Date(2023, time.Month(4), 1, 0, 0, 0, 0, time.UTC),
+	}
+	expectedDebts := append(s.InitDebts, debt)
+
+	_, err := s.storage.Insert(debt)
+	require.NoError(s.T(), err)
+
+	actualDebts, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), expectedDebts, actualDebts)
+}
+
+func (s *DebtSqliteStorageTestSuite) TestUpdatePositive() {
+	debt := &model.Debt{
+		ID:           2,
+		Counterparty: &model.Counterparty{ID: 2},
+		Direction:    model.Taken,
+		Principal:    1000000,
+		Currency:     &model.Currency{ID: 2},
+		InterestRate: 10,
+		Date:         time.Date(2023, time.Month(3), 1, 0, 0, 0, 0, time.UTC),
+		Installments: 24,
+		Note:         "mortgage",
+	}
+	expectedDebts := []*model.Debt{s.InitDebts[0], debt}
+
+	err := s.storage.Update(debt)
+	require.NoError(s.T(), err)
+
+	actualDebts, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), expectedDebts, actualDebts)
+}
+
+func (s *DebtSqliteStorageTestSuite) TestUpdateNegative() {
+	err := s.storage.Update(&model.Debt{ID: 10, Counterparty: &model.Counterparty{}, Currency: &model.Currency{}})
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *DebtSqliteStorageTestSuite) TestDeletePositive() {
+	err := s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 1, TransactionID: 1})
+	require.NoError(s.T(), err)
+	err = s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 2, TransactionID: 2})
+	require.NoError(s.T(), err)
+
+	err = s.storage.Delete(2)
+	require.NoError(s.T(), err)
+
+	actualDebts, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitDebts[:1], actualDebts)
+
+	actualRepayments, err := s.storage.GetAllRepayments()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.DebtRepayment{{DebtID: 1, TransactionID: 1}}, actualRepayments)
+}
+
+func (s *DebtSqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *DebtSqliteStorageTestSuite) TestInsertRepaymentNegative() {
+	err := s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 1, TransactionID: 1})
+	require.NoError(s.T(), err)
+
+	err = s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 2, TransactionID: 1})
+	assert.EqualError(s.T(), err, "e.db.Exec: UNIQUE constraint failed: debt_repayment.transactionId")
+}
+
+func (s *DebtSqliteStorageTestSuite) TestDeleteRepayment() {
+	err := s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 1, TransactionID: 1})
+	require.NoError(s.T(), err)
+	err = s.storage.InsertRepayment(&model.DebtRepayment{DebtID: 1, TransactionID: 2})
+	require.NoError(s.T(), err)
+
+	err = s.storage.DeleteRepayment(&model.DebtRepayment{DebtID: 1, TransactionID: 1})
+	require.NoError(s.T(), err)
+
+	actualRepayments, err := s.storage.GetAllRepayments()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.DebtRepayment{{DebtID: 1, TransactionID: 2}}, actualRepayments)
+
+	err = s.storage.DeleteRepayment(&model.DebtRepayment{DebtID: 1, TransactionID: 1})
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *DebtSqliteStorageTestSuite) TestGetAll() {
+	actualDebts, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitDebts, actualDebts)
+}
+
+func (s *DebtSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM debt_repayment; DELETE FROM debt;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *DebtSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestDebtSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(DebtSqliteStorageTestSuite))
+}
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// ExchangeRate is used to acces the persistent storage.
+type ExchangeRate struct {
+	executor executor[model.ExchangeRate]
+}
+
+// NewExchangeRate returns new exchange rate storage.
+func NewExchangeRate(db *DB) *ExchangeRate {
+	return &ExchangeRate{executor[model.ExchangeRate]{db}}
+}
+
+// Insert exchange rate into persistent storage.
+func (s *ExchangeRate) Insert(r *model.ExchangeRate) (int64, error) {
+	return s.executor.insert(
+		`INSERT INTO exchange_rate(date, fromCurrencyId, toCurrencyId, rate) VALUES (?, ?, ?, ?);`,
+		r.Date, r.From.ID, r.To.ID, r.Rate)
+}
+
+// Update exchange rate in persistand storage.
+func (s *ExchangeRate) Update(r *model.ExchangeRate) error {
+	return s.executor.update(
+		`UPDATE exchange_rate SET date = ?, fromCurrencyId = ?, toCurrencyId = ?, rate = ? WHERE id = ?;`,
+		r.Date, r.From.ID, r.To.ID, r.Rate, r.ID)
+}
+
+// Delete exchange rate from persistent storage.
+func (s *ExchangeRate) Delete(id int64) error {
+	return s.executor.update(`DELETE FROM exchange_rate WHERE id = ?;`, id)
+}
+
+// GetAll exchange rates from persistent storage.
+func (s *ExchangeRate) GetAll() ([]*model.ExchangeRate, error) {
+	return s.executor.getAll(`SELECT id, date, fromCurrencyId, toCurrencyId, rate FROM exchange_rate;`,
+		func() (*model.ExchangeRate, []any) {
+			r := model.NewEmptyExchangeRate()
+			return r, []any{&r.ID, &r.Date, &r.From.ID, &r.To.ID, &r.Rate}
+		})
+}
@@ -0,0 +1,154 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExchangeRateSqliteStorageTestSuite struct {
+	suite.Suite
+	db                *sql.DB
+	storage           *sqlite.ExchangeRate
+	InitExchangeRates []*model.ExchangeRate
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "currency")
+
+	s.storage = sqlite.NewExchangeRate(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitExchangeRates = []*model.ExchangeRate{
+		{
+			ID:   1,
+			Date: time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC),
+			From: &model.Currency{ID: 2},
+			To:   &model.Currency{ID: 1},
+			Rate: 1.13,
+		},
+		{
+			ID:   2,
+			Date: time.Date(2022, time.Month(2), 22, 0, 0, 0, 0, time.UTC),
+			From: &model.Currency{ID: 2},
+			To:   &model.Currency{ID: 1},
+			Rate: 1.14,
+		},
+	}
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) SetupTest() {
+	stmt, err := s.db.Prepare(`INSERT INTO exchange_rate(date, fromCurrencyId, toCurrencyId, rate) VALUES (?, ?, ?, ?);`)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+
+	for _, r := range s.InitExchangeRates {
+		_, err := stmt.Exec(r.Date, r.From.ID, r.To.ID, r.Rate)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) TestInsertPositive() {
+	rate := &model.ExchangeRate{
+		ID:   3,
+		Date: time.Date(2022, time.Month(2), 23, 0, 0, 0, 0, time.UTC),
+		From: &model.Currency{ID: 2},
+		To:   &model.Currency{ID: 1},
+		Rate: 1.12,
+	}
+	expectedExchangeRates := append(s.InitExchangeRates, rate)
+
+	_, err := s.storage.Insert(rate)
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedExchangeRates)
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) TestInsertNegative() {
+	_, err := s.storage.Insert(s.InitExchangeRates[1])
+	assert.EqualError(s.T(), err,
+		"e.db.Exec: UNIQUE constraint failed: exchange_rate.date, exchange_rate.fromCurrencyId, exchange_rate.toCurrencyId")
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) TestUpdatePositive() {
+	expectedExchangeRates := make([]*model.ExchangeRate, len(s.InitExchangeRates))
+	copy(expectedExchangeRates, s.InitExchangeRates)
+	expectedExchangeRates[1].Rate = 1.2
+
+	err := s.storage.Update(expectedExchangeRates[1])
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedExchangeRates)
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) TestUpdateNegative() {
+	r := model.NewEmptyExchangeRate()
+	r.ID = 10
+	err := s.storage.Update(r)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) TestDeletePositive() {
+	expectedExchangeRates := []*model.ExchangeRate{s.InitExchangeRates[0]}
+
+	err := s.storage.Delete(2)
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedExchangeRates)
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) TestGetAll() {
+	allExchangeRates, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitExchangeRates, allExchangeRates)
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) fetchActualData() []*model.ExchangeRate {
+	rows, err := s.db.Query(`SELECT id, date, fromCurrencyId, toCurrencyId, rate FROM exchange_rate;`)
+	require.NoError(s.T(), err)
+	defer func() {
+		err = rows.Close()
+		require.NoError(s.T(), err)
+	}()
+
+	res := make([]*model.ExchangeRate, 0, 3)
+	for rows.Next() {
+		r := model.NewEmptyExchangeRate()
+		err = rows.Scan(&r.ID, &r.Date, &r.From.ID, &r.To.ID, &r.Rate)
+		require.NoError(s.T(), err)
+		res = append(res, r)
+	}
+
+	return res
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM exchange_rate;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *ExchangeRateSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestExchangeRateSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRateSqliteStorageTestSuite))
+}
@@ -3,53 +3,61 @@ package sqlite
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/kotlw/gentlemoney/internal/model"
 )
 
-// executor is a wrapper for sql.DB.Exec() and sql.DB.Query().
+// executor is a wrapper for Exec() and Query() of shared database handle.
 type executor[T model.Any] struct {
-	db *sql.DB
+	db *DB
 }
 
 // insert executes insert query with given arguments.
 func (e *executor[_]) insert(query string, args ...any) (int64, error) {
-	res, err := e.db.Exec(query, args...)
+	res, err := e.db.conn().Exec(query, args...)
 	if err != nil {
 		return -1, fmt.Errorf("e.db.Exec: %w", err)
 	}
 
-	id, err := res.LastInsertId()
-	if err != nil {
-		return -1, fmt.Errorf("res.LastInsertId: %w", err)
-	}
-
-	return id, nil
+	return insertedID(res)
 }
 
 // update executes update and delete queries with given arguments.
 func (e *executor[_]) update(query string, args ...any) error {
-	res, err := e.db.Exec(query, args...)
+	res, err := e.db.conn().Exec(query, args...)
 	if err != nil {
 		return fmt.Errorf("e.db.Exec: %w", err)
 	}
 
-	rowsAfected, err := res.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("res.RowsAffected: %w", err)
-	}
-	if rowsAfected != 1 {
-		return fmt.Errorf("total affected rows %d while expected 1", rowsAfected)
-	}
+	return affectedOne(res)
+}
 
-	return nil
+// inTx executes fn inside of database transaction, see DB.InTx.
+func (e *executor[_]) inTx(fn func(tx *sql.Tx) error) error {
+	return e.db.InTx(fn)
+}
+
+// softDelete moves row with given id of given table to trash by marking it as deleted now.
+func (e *executor[_]) softDelete(table string, id int64) error {
+	return e.update(`UPDATE "`+table+`" SET deletedAt = ? WHERE id = ? AND deletedAt IS NULL;`, time.Now(), id)
+}
+
+// restore brings row with given id of given table back from trash.
+func (e *executor[_]) restore(table string, id int64) error {
+	return e.update(`UPDATE "`+table+`" SET deletedAt = NULL WHERE id = ? AND deletedAt IS NOT NULL;`, id)
+}
+
+// purge permanently deletes row with given id of given table, only rows in trash could be purged.
+func (e *executor[_]) purge(table string, id int64) error {
+	return e.update(`DELETE FROM "`+table+`" WHERE id = ? AND deletedAt IS NOT NULL;`, id)
 }
 
 // getAll returns all rows from persistent storage, it requires dest func which should return new
 // object of certain type, and addreses of its fields to Scan. Order of addreses should match with
-// order of coresponding columns in query.
-func (e *executor[T]) getAll(query string, dest func() (*T, []any)) ([]*T, error) {
-	rows, err := e.db.Query(query)
+// order of coresponding columns in query. Optional args are passed to the query.
+func (e *executor[T]) getAll(query string, dest func() (*T, []any), args ...any) ([]*T, error) {
+	rows, err := e.db.conn().Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("stmt.Query: %w", err)
 	}
@@ -70,3 +78,61 @@ func (e *executor[T]) getAll(query string, dest func() (*T, []any)) ([]*T, error
 
 	return res, err
 }
+
+// insertedID returns id of inserted row from result of insert query.
+func insertedID(res sql.Result) (int64, error) {
+	id, err := res.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("res.LastInsertId: %w", err)
+	}
+
+	return id, nil
+}
+
+// affectedOne checks if result of update or delete query affected exactly one row.
+func affectedOne(res sql.Result) error {
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %w", err)
+	}
+
+	if rowsAfected != 1 {
+		return fmt.Errorf("total affected rows %d while expected 1", rowsAfected)
+	}
+
+	return nil
+}
+
+// idScanner scans nullable id column, the func is called only for non null values.
+type idScanner func(id int64)
+
+// Scan implements sql.Scanner.
+func (f idScanner) Scan(value any) error {
+	var id sql.NullInt64
+	if err := id.Scan(value); err != nil {
+		return err
+	}
+
+	if id.Valid {
+		f(id.Int64)
+	}
+
+	return nil
+}
+
+// timeScanner scans nullable datetime column, null is scanned as zero time.
+type timeScanner struct {
+	t *time.Time
+}
+
+// Scan implements sql.Scanner.
+func (s timeScanner) Scan(value any) error {
+	var t sql.NullTime
+	if err := t.Scan(value); err != nil {
+		return err
+	}
+
+	*s.t = t.Time
+
+	return nil
+}
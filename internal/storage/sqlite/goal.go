@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Goal is used to acces the persistent storage.
+type Goal struct {
+	executor executor[model.Goal]
+}
+
+// NewGoal returns new goal storage.
+func NewGoal(db *DB) *Goal {
+	return &Goal{executor[model.Goal]{db}}
+}
+
+// Insert goal into persistent storage.
+func (s *Goal) Insert(g *model.Goal) (int64, error) {
+	return s.executor.insert(`INSERT INTO goal (name, target, currencyId, deadline, accountId, categoryId)
+                              VALUES (?, ?, ?, ?, ?, ?);`,
+		g.Name, g.Target, g.Currency.ID, g.Deadline, goalAccountID(g), goalCategoryID(g))
+}
+
+// Update goal in persistand storage.
+func (s *Goal) Update(g *model.Goal) error {
+	return s.executor.update(`UPDATE goal SET name = ?, target = ?, currencyId = ?, deadline = ?, accountId = ?,
+                              categoryId = ? WHERE id = ?;`,
+		g.Name, g.Target, g.Currency.ID, g.Deadline, goalAccountID(g), goalCategoryID(g), g.ID)
+}
+
+// Delete goal from persistent storage.
+func (s *Goal) Delete(id int64) error {
+	return s.executor.update(`DELETE FROM goal WHERE id = ?;`, id)
+}
+
+// GetAll goals from persistent storage.
+func (s *Goal) GetAll() ([]*model.Goal, error) {
+	return s.executor.getAll(`SELECT id, name, target, currencyId, deadline, accountId, categoryId FROM goal;`,
+		func() (*model.Goal, []any) {
+			g := model.NewEmptyGoal()
+			return g, []any{&g.ID, &g.Name, &g.Target, &g.Currency.ID, &g.Deadline,
+				idScanner(func(id int64) { g.Account = &model.Account{ID: id} }),
+				idScanner(func(id int64) { g.Category = &model.Category{ID: id} })}
+		})
+}
+
+// goalAccountID returns id of the account linked to goal or nil if it isn't set.
+func goalAccountID(g *model.Goal) any {
+	if g.Account == nil {
+		return nil
+	}
+	return g.Account.ID
+}
+
+// goalCategoryID returns id of the category linked to goal or nil if it isn't set.
+func goalCategoryID(g *model.Goal) any {
+	if g.Category == nil {
+		return nil
+	}
+	return g.Category.ID
+}
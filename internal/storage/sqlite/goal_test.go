@@ -0,0 +1,145 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type GoalSqliteStorageTestSuite struct {
+	suite.Suite
+	db        *sql.DB
+	storage   *sqlite.Goal
+	InitGoals []*model.Goal
+}
+
+func (s *GoalSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "currency", "category", "account")
+
+	s.storage = sqlite.NewGoal(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitGoals = []*model.Goal{
+		{
+			ID:       1,
+			Name:     "Car",
+			Target:   1000000,
+			Currency: &model.Currency{ID: 1},
+			Deadline: time.Date(2024, time.Month(6), 1, 0, 0, 0, 0, time.UTC),
+			Account:  &model.Account{ID: 2},
+		},
+		{
+			ID:       2,
+			Name:     "Emergency fund",
+			Target:   300000,
+			Currency: &model.Currency{ID: 2},
+			Deadline: time.Date(2023, time.Month(12), 31, 0, 0, 0, 0, time.UTC),
+			Category: &model.Category{ID: 3},
+		},
+	}
+}
+
+func (s *GoalSqliteStorageTestSuite) SetupTest() {
+	for _, g := range s.InitGoals {
+		_, err := s.storage.Insert(g)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *GoalSqliteStorageTestSuite) TestInsertPositive() {
+	goal := &model.Goal{
+		ID:       3,
+		Name:     "Vacation",
+		Target:   200000,
+		Currency: &model.Currency{ID: 1},
+		Deadline: time.Date(2023, time.Month(7), 1, 0, 0, 0, 0, time.UTC),
+		Category: &model.Category{ID: 1},
+	}
+	expectedGoals := append(s.InitGoals, goal)
+
+	_, err := s.storage.Insert(goal)
+	require.NoError(s.T(), err)
+
+	actualGoals, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), expectedGoals, actualGoals)
+}
+
+func (s *GoalSqliteStorageTestSuite) TestInsertNegative() {
+	_, err := s.storage.Insert(s.InitGoals[0])
+	assert.EqualError(s.T(), err, "e.db.Exec: UNIQUE constraint failed: goal.name")
+}
+
+func (s *GoalSqliteStorageTestSuite) TestUpdatePositive() {
+	goal := &model.Goal{
+		ID:       2,
+		Name:     "Emergency fund",
+		Target:   500000,
+		Currency: &model.Currency{ID: 1},
+		Deadline: time.Date(2024, time.Month(12), 31, 0, 0, 0, 0, time.UTC),
+		Account:  &model.Account{ID: 1},
+	}
+	expectedGoals := []*model.Goal{s.InitGoals[0], goal}
+
+	err := s.storage.Update(goal)
+	require.NoError(s.T(), err)
+
+	actualGoals, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), expectedGoals, actualGoals)
+}
+
+func (s *GoalSqliteStorageTestSuite) TestUpdateNegative() {
+	err := s.storage.Update(&model.Goal{ID: 10, Currency: &model.Currency{ID: 1}})
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *GoalSqliteStorageTestSuite) TestDeletePositive() {
+	err := s.storage.Delete(2)
+	require.NoError(s.T(), err)
+
+	actualGoals, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Goal{s.InitGoals[0]}, actualGoals)
+}
+
+func (s *GoalSqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *GoalSqliteStorageTestSuite) TestGetAll() {
+	actualGoals, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitGoals, actualGoals)
+}
+
+func (s *GoalSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM goal;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *GoalSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestGoalSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(GoalSqliteStorageTestSuite))
+}
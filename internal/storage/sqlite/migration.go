@@ -0,0 +1,287 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSchemaTooNew is returned when database was upgraded by a newer version of the app.
+var ErrSchemaTooNew = errors.New("database schema is newer than supported, update the app")
+
+// migration is a numbered change of database schema, migrations are applied in order of their
+// versions, each one exactly once.
+type migration struct {
+	version     int
+	description string
+	up          func(tx *sql.Tx) error
+}
+
+// migrations is a list of all schema changes, version of the last one is the version of schema
+// supported by the app. New migration is appended to the end, released ones are never changed.
+// Databases created before versioning have no schema_version table and are upgraded from the first
+// migration, so all the statements are idempotent.
+var migrations = []migration{
+	{1, "create categories, currencies, accounts and transactions", exec(
+		`CREATE TABLE IF NOT EXISTS category(
+            id INTEGER PRIMARY KEY,
+            title TEXT NOT NULL UNIQUE);
+         CREATE TABLE IF NOT EXISTS currency(
+            id INTEGER PRIMARY KEY,
+            abbreviation TEXT NOT NULL UNIQUE);
+         CREATE TABLE IF NOT EXISTS account(
+            id INTEGER PRIMARY KEY,
+            name TEXT NOT NULL UNIQUE,
+            currencyId INTEGER NOT NULL,
+            FOREIGN KEY(currencyId) REFERENCES currency(id));
+         CREATE TABLE IF NOT EXISTS "transaction"(
+            id INTEGER PRIMARY KEY,
+            date DATETIME NOT NULL,
+            amount INTEGER NOT NULL,
+            note TEXT,
+            accountId INTEGER NOT NULL,
+            categoryId INTEGER NOT NULL,
+            FOREIGN KEY(accountId) REFERENCES account(id),
+            FOREIGN KEY(categoryId) REFERENCES category(id));`)},
+	{2, "add transfers", exec(
+		`CREATE TABLE IF NOT EXISTS transfer(
+            id INTEGER PRIMARY KEY,
+            fromTransactionId INTEGER NOT NULL UNIQUE,
+            toTransactionId INTEGER NOT NULL UNIQUE,
+            FOREIGN KEY(fromTransactionId) REFERENCES "transaction"(id),
+            FOREIGN KEY(toTransactionId) REFERENCES "transaction"(id));`)},
+	{3, "add main currency and exchange rates", steps(
+		addColumn("currency", "isMain", "INTEGER NOT NULL DEFAULT 0"),
+		exec(`CREATE TABLE IF NOT EXISTS exchange_rate(
+            id INTEGER PRIMARY KEY,
+            date DATETIME NOT NULL,
+            fromCurrencyId INTEGER NOT NULL,
+            toCurrencyId INTEGER NOT NULL,
+            rate REAL NOT NULL,
+            UNIQUE(date, fromCurrencyId, toCurrencyId),
+            FOREIGN KEY(fromCurrencyId) REFERENCES currency(id),
+            FOREIGN KEY(toCurrencyId) REFERENCES currency(id));`))},
+	{4, "add budgets", exec(
+		`CREATE TABLE IF NOT EXISTS budget(
+            id INTEGER PRIMARY KEY,
+            categoryId INTEGER NOT NULL,
+            period DATETIME NOT NULL,
+            limitAmount INTEGER NOT NULL,
+            UNIQUE(categoryId, period),
+            FOREIGN KEY(categoryId) REFERENCES category(id));`)},
+	// template transaction is stored along with the recurrence.
+	{5, "add recurrences", exec(
+		`CREATE TABLE IF NOT EXISTS recurrence(
+            id INTEGER PRIMARY KEY,
+            rule TEXT NOT NULL,
+            startDate DATETIME NOT NULL,
+            endDate DATETIME NOT NULL,
+            nextDate DATETIME NOT NULL,
+            paused INTEGER NOT NULL DEFAULT 0,
+            amount INTEGER NOT NULL,
+            note TEXT,
+            accountId INTEGER NOT NULL,
+            categoryId INTEGER NOT NULL,
+            FOREIGN KEY(accountId) REFERENCES account(id),
+            FOREIGN KEY(categoryId) REFERENCES category(id));`)},
+	{6, "add parent categories", addColumn("category", "parentId", "INTEGER REFERENCES category(id)")},
+	{7, "add tags", exec(
+		`CREATE TABLE IF NOT EXISTS tag(
+            id INTEGER PRIMARY KEY,
+            name TEXT NOT NULL UNIQUE);
+         CREATE TABLE IF NOT EXISTS transaction_tag(
+            transactionId INTEGER NOT NULL,
+            tagId INTEGER NOT NULL,
+            PRIMARY KEY(transactionId, tagId),
+            FOREIGN KEY(transactionId) REFERENCES "transaction"(id),
+            FOREIGN KEY(tagId) REFERENCES tag(id));`)},
+	{8, "add splits", exec(
+		`CREATE TABLE IF NOT EXISTS split(
+            id INTEGER PRIMARY KEY,
+            transactionId INTEGER NOT NULL,
+            categoryId INTEGER NOT NULL,
+            amount INTEGER NOT NULL,
+            note TEXT,
+            FOREIGN KEY(transactionId) REFERENCES "transaction"(id),
+            FOREIGN KEY(categoryId) REFERENCES category(id));`)},
+	{9, "add account opening balances", steps(
+		addColumn("account", "openingBalance", "INTEGER NOT NULL DEFAULT 0"),
+		addColumn("account", "openingDate", "DATETIME NOT NULL DEFAULT '0001-01-01 00:00:00+00:00'"))},
+	{10, "add account types, credit limits and archiving", steps(
+		addColumn("account", "type", "TEXT NOT NULL DEFAULT 'checking'"),
+		addColumn("account", "creditLimit", "INTEGER NOT NULL DEFAULT 0"),
+		addColumn("account", "archived", "BOOLEAN NOT NULL DEFAULT 0"))},
+	{11, "add payees", steps(
+		exec(`CREATE TABLE IF NOT EXISTS payee(
+            id INTEGER PRIMARY KEY,
+            name TEXT NOT NULL UNIQUE,
+            categoryId INTEGER REFERENCES category(id));`),
+		addColumn("transaction", "payeeId", "INTEGER REFERENCES payee(id)"))},
+	{12, "add attachments", exec(
+		`CREATE TABLE IF NOT EXISTS attachment(
+            id INTEGER PRIMARY KEY,
+            transactionId INTEGER NOT NULL,
+            name TEXT NOT NULL,
+            hash TEXT NOT NULL,
+            FOREIGN KEY(transactionId) REFERENCES "transaction"(id));`)},
+	{13, "add goals", exec(
+		`CREATE TABLE IF NOT EXISTS goal(
+            id INTEGER PRIMARY KEY,
+            name TEXT NOT NULL UNIQUE,
+            target INTEGER NOT NULL,
+            currencyId INTEGER NOT NULL,
+            deadline DATETIME NOT NULL,
+            accountId INTEGER,
+            categoryId INTEGER,
+            FOREIGN KEY(currencyId) REFERENCES currency(id),
+            FOREIGN KEY(accountId) REFERENCES account(id),
+            FOREIGN KEY(categoryId) REFERENCES category(id));`)},
+	// transaction could repay only one debt.
+	{14, "add counterparties and debts", exec(
+		`CREATE TABLE IF NOT EXISTS counterparty(
+            id INTEGER PRIMARY KEY,
+            name TEXT NOT NULL UNIQUE);
+         CREATE TABLE IF NOT EXISTS debt(
+            id INTEGER PRIMARY KEY,
+            counterpartyId INTEGER NOT NULL,
+            direction TEXT NOT NULL,
+            principal INTEGER NOT NULL,
+            currencyId INTEGER NOT NULL,
+            interestRate REAL NOT NULL DEFAULT 0,
+            date DATETIME NOT NULL,
+            installments INTEGER NOT NULL DEFAULT 0,
+            note TEXT NOT NULL DEFAULT '',
+            FOREIGN KEY(counterpartyId) REFERENCES counterparty(id),
+            FOREIGN KEY(currencyId) REFERENCES currency(id));
+         CREATE TABLE IF NOT EXISTS debt_repayment(
+            debtId INTEGER NOT NULL,
+            transactionId INTEGER NOT NULL UNIQUE,
+            PRIMARY KEY(debtId, transactionId),
+            FOREIGN KEY(debtId) REFERENCES debt(id),
+            FOREIGN KEY(transactionId) REFERENCES "transaction"(id));`)},
+	{15, "add transaction status", addColumn("transaction", "status", "TEXT NOT NULL DEFAULT 'pending'")},
+	{16, "add currency precision", addColumn("currency", "precision", "INTEGER NOT NULL DEFAULT 2")},
+	{17, "add category kinds", addColumn("category", "kind", "TEXT NOT NULL DEFAULT 'both'")},
+	{18, "add trash", steps(
+		addColumn("transaction", "deletedAt", "DATETIME"),
+		addColumn("account", "deletedAt", "DATETIME"),
+		addColumn("category", "deletedAt", "DATETIME"),
+		addColumn("currency", "deletedAt", "DATETIME"))},
+	// column constraint can't be dropped, so table is recreated. Foreign keys are checked on
+	// commit, since categories are missing for a moment. Top level categories have no parent.
+	{19, "make category titles unique within parent", exec(
+		`PRAGMA defer_foreign_keys = ON;
+         CREATE TEMP TABLE category_old AS SELECT id, title, parentId, kind, deletedAt FROM category;
+         DROP TABLE category;
+         CREATE TABLE category(
+            id INTEGER PRIMARY KEY,
+            title TEXT NOT NULL,
+            parentId INTEGER REFERENCES category(id),
+            kind TEXT NOT NULL DEFAULT 'both',
+            deletedAt DATETIME);
+         CREATE UNIQUE INDEX category_title ON category(COALESCE(parentId, 0), title);
+         INSERT INTO category(id, title, parentId, kind, deletedAt)
+         SELECT id, title, parentId, kind, deletedAt FROM category_old;
+         DROP TABLE category_old;`)},
+}
+
+// Migrate upgrades database schema to the latest version. All the pending migrations are applied
+// inside of a single database transaction, so database is left untouched if any of them fails.
+// Database upgraded by a newer version of the app isn't opened, since its schema is unknown.
+func Migrate(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+
+	if err = migrate(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx.Rollback: %v: %w", rbErr, err)
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// migrate applies migrations newer than the version stored in schema_version table.
+func migrate(tx *sql.Tx) error {
+	q := `CREATE TABLE IF NOT EXISTS schema_version(
+            version INTEGER PRIMARY KEY,
+            appliedAt DATETIME NOT NULL);`
+	if _, err := tx.Exec(q); err != nil {
+		return fmt.Errorf("tx.Exec: %w", err)
+	}
+
+	var current int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version;`).Scan(&current); err != nil {
+		return fmt.Errorf("tx.QueryRow: %w", err)
+	}
+
+	if latest := migrations[len(migrations)-1].version; current > latest {
+		return fmt.Errorf("%w: version %d while %d is supported", ErrSchemaTooNew, current, latest)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		if err := m.up(tx); err != nil {
+			return fmt.Errorf("migration %d %q: %w", m.version, m.description, err)
+		}
+
+		if _, err := tx.Exec(`INSERT INTO schema_version(version, appliedAt) VALUES (?, ?);`, m.version, time.Now()); err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// exec returns migration step which executes given statements.
+func exec(q string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		_, err := tx.Exec(q)
+		return err
+	}
+}
+
+// steps returns migration step which consists of given steps.
+func steps(ss ...func(tx *sql.Tx) error) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, s := range ss {
+			if err := s(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// addColumn returns migration step which adds column with given definition to existing table if it
+// is missing. Existing rows get default value of the column.
+func addColumn(table, column, definition string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		var n int
+		q := `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?;`
+		if err := tx.QueryRow(q, table, column).Scan(&n); err != nil {
+			return fmt.Errorf("tx.QueryRow: %w", err)
+		}
+
+		if n > 0 {
+			return nil
+		}
+
+		if _, err := tx.Exec(`ALTER TABLE "` + table + `" ADD COLUMN ` + column + ` ` + definition + `;`); err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		return nil
+	}
+}
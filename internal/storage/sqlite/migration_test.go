@@ -0,0 +1,200 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrationTestSuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func (s *MigrationTestSuite) SetupTest() {
+	s.db = s.openDB()
+}
+
+// openDB opens new empty database, single connection keeps in-memory database alive.
+func (s *MigrationTestSuite) openDB() *sql.DB {
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(s.T(), err)
+	db.SetMaxOpenConns(1)
+
+	return db
+}
+
+func (s *MigrationTestSuite) TestMigrate() {
+	require.NoError(s.T(), sqlite.Migrate(s.db))
+	version := s.version(s.db)
+	assert.Greater(s.T(), version, 0)
+
+	// already migrated database is left as is
+	require.NoError(s.T(), sqlite.Migrate(s.db))
+	assert.Equal(s.T(), version, s.version(s.db))
+}
+
+// TestUpgrade upgrades databases created by each of the releases before versioning, they have no
+// schema_version table, so all the migrations are applied to them.
+func (s *MigrationTestSuite) TestUpgrade() {
+	latest := s.openDB()
+	defer latest.Close()
+	require.NoError(s.T(), sqlite.Migrate(latest))
+
+	fixtures, err := filepath.Glob("testdata/migration/*.sql")
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), fixtures)
+
+	for _, fixture := range fixtures {
+		s.Run(filepath.Base(fixture), func() {
+			db := s.openDB()
+			defer db.Close()
+
+			schema, err := os.ReadFile(fixture)
+			require.NoError(s.T(), err)
+			_, err = db.Exec(string(schema))
+			require.NoError(s.T(), err)
+
+			date := time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC)
+			_, err = db.Exec(`INSERT INTO currency(abbreviation) VALUES ('UAH');
+                              INSERT INTO account(name, currencyId) VALUES ('Cash', 1);
+                              INSERT INTO category(title) VALUES ('Grocery');
+                              INSERT INTO "transaction"(date, amount, note, accountId, categoryId)
+                              VALUES (?, -1250, 'bread', 1, 1);`, date)
+			require.NoError(s.T(), err)
+
+			storage, err := sqlite.New(db)
+			require.NoError(s.T(), err)
+
+			assert.Equal(s.T(), s.version(latest), s.version(db))
+			assert.Equal(s.T(), s.columns(latest), s.columns(db))
+
+			currencies, err := storage.Currency().GetAll()
+			require.NoError(s.T(), err)
+			assert.Equal(s.T(), []*model.Currency{{ID: 1, Abbreviation: "UAH", Precision: model.DefaultPrecision}}, currencies)
+
+			transactions, err := storage.Transaction().GetAll()
+			require.NoError(s.T(), err)
+			require.Len(s.T(), transactions, 1)
+			assert.Equal(s.T(), "bread", transactions[0].Note)
+			assert.Equal(s.T(), int64(-1250), transactions[0].Amount)
+			assert.Equal(s.T(), model.Pending, transactions[0].Status)
+		})
+	}
+}
+
+// TestUpgradeVersioned upgrades database which has schema_version table, so only the migrations
+// newer than its version are applied.
+func (s *MigrationTestSuite) TestUpgradeVersioned() {
+	latest := s.openDB()
+	defer latest.Close()
+	require.NoError(s.T(), sqlite.Migrate(latest))
+
+	schema, err := os.ReadFile("testdata/migration/versioned/v15.sql")
+	require.NoError(s.T(), err)
+	_, err = s.db.Exec(string(schema))
+	require.NoError(s.T(), err)
+
+	_, err = s.db.Exec(`INSERT INTO currency(abbreviation) VALUES ('UAH');
+                        INSERT INTO category(title) VALUES ('Grocery');`)
+	require.NoError(s.T(), err)
+
+	storage, err := sqlite.New(s.db)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), s.version(latest), s.version(s.db))
+	assert.Equal(s.T(), s.columns(latest), s.columns(s.db))
+
+	// versions of the fixture are kept as they were
+	rows, err := s.db.Query(`SELECT version FROM schema_version WHERE appliedAt <> '2022-01-01 00:00:00+00:00' ORDER BY version;`)
+	require.NoError(s.T(), err)
+	defer rows.Close()
+
+	var applied []int
+	for rows.Next() {
+		var v int
+		require.NoError(s.T(), rows.Scan(&v))
+		applied = append(applied, v)
+	}
+	require.NoError(s.T(), rows.Err())
+
+	var expected []int
+	for v := 16; v <= s.version(latest); v++ {
+		expected = append(expected, v)
+	}
+	assert.Equal(s.T(), expected, applied)
+
+	categories, err := storage.Category().GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Category{{ID: 1, Title: "Grocery", Kind: model.Both}}, categories)
+}
+
+func (s *MigrationTestSuite) TestNewerSchema() {
+	require.NoError(s.T(), sqlite.Migrate(s.db))
+	_, err := s.db.Exec(`INSERT INTO schema_version(version, appliedAt) VALUES (?, ?);`, s.version(s.db)+1, time.Now())
+	require.NoError(s.T(), err)
+
+	_, err = sqlite.New(s.db)
+	assert.ErrorIs(s.T(), err, sqlite.ErrSchemaTooNew)
+}
+
+func (s *MigrationTestSuite) TestRollback() {
+	schema, err := os.ReadFile("testdata/migration/v01.sql")
+	require.NoError(s.T(), err)
+	_, err = s.db.Exec(string(schema) + `CREATE UNIQUE INDEX transfer ON currency (id);`)
+	require.NoError(s.T(), err)
+
+	err = sqlite.Migrate(s.db)
+	assert.ErrorContains(s.T(), err, "migration 2 \"add transfers\": there is already an index named transfer")
+
+	var n int
+	err = s.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'schema_version';`).Scan(&n)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 0, n)
+}
+
+// version returns the latest version of migrations applied to db.
+func (s *MigrationTestSuite) version(db *sql.DB) int {
+	var v int
+	err := db.QueryRow(`SELECT MAX(version) FROM schema_version;`).Scan(&v)
+	require.NoError(s.T(), err)
+
+	return v
+}
+
+// columns returns definitions of columns of all the tables in db, except of their order.
+func (s *MigrationTestSuite) columns(db *sql.DB) map[string]string {
+	rows, err := db.Query(`SELECT m.name || '.' || p.name, p.type || ' ' || p."notnull" || ' ' || COALESCE(p.dflt_value, '') || ' ' || p.pk
+                           FROM sqlite_master m, pragma_table_info(m.name) p WHERE m.type = 'table';`)
+	require.NoError(s.T(), err)
+	defer rows.Close()
+
+	res := make(map[string]string)
+	for rows.Next() {
+		var name, definition string
+		require.NoError(s.T(), rows.Scan(&name, &definition))
+		res[name] = definition
+	}
+	require.NoError(s.T(), rows.Err())
+
+	return res
+}
+
+func (s *MigrationTestSuite) TearDownTest() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func TestMigrationTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationTestSuite))
+}
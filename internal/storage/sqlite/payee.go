@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Payee is used to acces the persistent storage.
+type Payee struct {
+	executor executor[model.Payee]
+}
+
+// NewPayee returns new payee storage.
+func NewPayee(db *DB) *Payee {
+	return &Payee{executor[model.Payee]{db}}
+}
+
+// Insert payee into persistent storage.
+func (s *Payee) Insert(p *model.Payee) (int64, error) {
+	return s.executor.insert(`INSERT INTO payee (name, categoryId) VALUES (?, ?);`, p.Name, payeeCategoryID(p))
+}
+
+// Update payee in persistand storage.
+func (s *Payee) Update(p *model.Payee) error {
+	return s.executor.update(`UPDATE payee SET name = ?, categoryId = ? WHERE id = ?;`,
+		p.Name, payeeCategoryID(p), p.ID)
+}
+
+// Delete payee from persistent storage in a single database transaction, transactions of payee
+// are unlinked from it.
+func (s *Payee) Delete(id int64) error {
+	return s.executor.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`UPDATE "transaction" SET payeeId = NULL WHERE payeeId = ?;`, id); err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		res, err := tx.Exec(`DELETE FROM payee WHERE id = ?;`, id)
+		if err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		return affectedOne(res)
+	})
+}
+
+// GetAll payees from persistent storage.
+func (s *Payee) GetAll() ([]*model.Payee, error) {
+	return s.executor.getAll(`SELECT id, name, categoryId FROM payee;`,
+		func() (*model.Payee, []any) {
+			p := model.NewEmptyPayee()
+			return p, []any{&p.ID, &p.Name, idScanner(func(id int64) { p.Category = &model.Category{ID: id} })}
+		})
+}
+
+// payeeCategoryID returns id of the default category of payee or nil if it isn't set.
+func payeeCategoryID(p *model.Payee) any {
+	if p.Category == nil {
+		return nil
+	}
+	return p.Category.ID
+}
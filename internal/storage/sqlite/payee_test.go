@@ -0,0 +1,116 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PayeeSqliteStorageTestSuite struct {
+	suite.Suite
+	db         *sql.DB
+	storage    *sqlite.Payee
+	InitPayees []*model.Payee
+}
+
+func (s *PayeeSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "category")
+
+	s.storage = sqlite.NewPayee(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitPayees = []*model.Payee{
+		{ID: 1, Name: "Supermarket", Category: &model.Category{ID: 2}},
+		{ID: 2, Name: "Landlord"},
+	}
+}
+
+func (s *PayeeSqliteStorageTestSuite) SetupTest() {
+	for _, p := range s.InitPayees {
+		_, err := s.storage.Insert(p)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *PayeeSqliteStorageTestSuite) TestInsertPositive() {
+	payee := &model.Payee{ID: 3, Name: "Pharmacy", Category: &model.Category{ID: 1}}
+	expectedPayees := append(s.InitPayees, payee)
+
+	_, err := s.storage.Insert(payee)
+	require.NoError(s.T(), err)
+
+	actualPayees, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), expectedPayees, actualPayees)
+}
+
+func (s *PayeeSqliteStorageTestSuite) TestInsertNegative() {
+	_, err := s.storage.Insert(s.InitPayees[0])
+	assert.EqualError(s.T(), err, "e.db.Exec: UNIQUE constraint failed: payee.name")
+}
+
+func (s *PayeeSqliteStorageTestSuite) TestUpdatePositive() {
+	payee := &model.Payee{ID: 2, Name: "Landlady", Category: &model.Category{ID: 3}}
+	expectedPayees := []*model.Payee{s.InitPayees[0], payee}
+
+	err := s.storage.Update(payee)
+	require.NoError(s.T(), err)
+
+	actualPayees, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), expectedPayees, actualPayees)
+}
+
+func (s *PayeeSqliteStorageTestSuite) TestUpdateNegative() {
+	err := s.storage.Update(&model.Payee{ID: 10})
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *PayeeSqliteStorageTestSuite) TestDeletePositive() {
+	err := s.storage.Delete(2)
+	require.NoError(s.T(), err)
+
+	actualPayees, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Payee{s.InitPayees[0]}, actualPayees)
+}
+
+func (s *PayeeSqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *PayeeSqliteStorageTestSuite) TestGetAll() {
+	actualPayees, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitPayees, actualPayees)
+}
+
+func (s *PayeeSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM payee;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *PayeeSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestPayeeSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(PayeeSqliteStorageTestSuite))
+}
@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Recurrence is used to acces the persistent storage.
+type Recurrence struct {
+	executor executor[model.Recurrence]
+}
+
+// NewRecurrence returns new recurrence storage.
+func NewRecurrence(db *DB) *Recurrence {
+	return &Recurrence{executor[model.Recurrence]{db}}
+}
+
+// Insert recurrence into persistent storage.
+func (s *Recurrence) Insert(r *model.Recurrence) (int64, error) {
+	return s.executor.insert(`INSERT INTO recurrence(rule, startDate, endDate, nextDate, paused, amount, note, accountId, categoryId)
+                              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+		r.Rule, r.Start, r.End, r.Next, r.Paused, r.Template.Amount, r.Template.Note, r.Template.Account.ID,
+		r.Template.Category.ID)
+}
+
+// Update recurrence in persistand storage.
+func (s *Recurrence) Update(r *model.Recurrence) error {
+	return s.executor.update(`UPDATE recurrence SET rule = ?, startDate = ?, endDate = ?, nextDate = ?, paused = ?,
+                              amount = ?, note = ?, accountId = ?, categoryId = ? WHERE id = ?;`,
+		r.Rule, r.Start, r.End, r.Next, r.Paused, r.Template.Amount, r.Template.Note, r.Template.Account.ID,
+		r.Template.Category.ID, r.ID)
+}
+
+// Delete recurrence from persistent storage.
+func (s *Recurrence) Delete(id int64) error {
+	return s.executor.update(`DELETE FROM recurrence WHERE id = ?;`, id)
+}
+
+// GetAll recurrences from persistent storage.
+func (s *Recurrence) GetAll() ([]*model.Recurrence, error) {
+	return s.executor.getAll(`SELECT id, rule, startDate, endDate, nextDate, paused, amount, note, accountId, categoryId
+                              FROM recurrence;`,
+		func() (*model.Recurrence, []any) {
+			r := model.NewEmptyRecurrence()
+			return r, []any{&r.ID, &r.Rule, &r.Start, &r.End, &r.Next, &r.Paused, &r.Template.Amount,
+				&r.Template.Note, &r.Template.Account.ID, &r.Template.Category.ID}
+		})
+}
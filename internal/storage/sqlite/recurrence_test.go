@@ -0,0 +1,143 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecurrenceSqliteStorageTestSuite struct {
+	suite.Suite
+	db              *sql.DB
+	storage         *sqlite.Recurrence
+	InitRecurrences []*model.Recurrence
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "currency", "category", "account")
+
+	s.storage = sqlite.NewRecurrence(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitRecurrences = []*model.Recurrence{
+		s.newRecurrence(1, model.Monthly, time.Time{}, false, -50000, "rent"),
+		s.newRecurrence(2, model.Weekly, time.Date(2022, time.Month(6), 1, 0, 0, 0, 0, time.UTC), true, -1000, "coffee"),
+	}
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) SetupTest() {
+	for _, r := range s.InitRecurrences {
+		_, err := s.db.Exec(`INSERT INTO recurrence(rule, startDate, endDate, nextDate, paused, amount, note, accountId, categoryId)
+                             VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+			r.Rule, r.Start, r.End, r.Next, r.Paused, r.Template.Amount, r.Template.Note, r.Template.Account.ID,
+			r.Template.Category.ID)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) TestInsertPositive() {
+	recurrence := s.newRecurrence(3, model.Yearly, time.Time{}, false, 100000, "bonus")
+	expectedRecurrences := append(s.InitRecurrences, recurrence)
+
+	_, err := s.storage.Insert(recurrence)
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedRecurrences)
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) TestUpdatePositive() {
+	expectedRecurrences := make([]*model.Recurrence, len(s.InitRecurrences))
+	copy(expectedRecurrences, s.InitRecurrences)
+	expectedRecurrences[0].Paused = true
+	expectedRecurrences[0].Next = time.Date(2022, time.Month(3), 1, 0, 0, 0, 0, time.UTC)
+
+	err := s.storage.Update(expectedRecurrences[0])
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedRecurrences)
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) TestUpdateNegative() {
+	r := model.NewEmptyRecurrence()
+	r.ID = 10
+	err := s.storage.Update(r)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) TestDeletePositive() {
+	expectedRecurrences := []*model.Recurrence{s.InitRecurrences[0]}
+
+	err := s.storage.Delete(2)
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedRecurrences)
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) TestGetAll() {
+	allRecurrences, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitRecurrences, allRecurrences)
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) newRecurrence(
+	id int64, rule model.Rule, end time.Time, paused bool, amount int64, note string) *model.Recurrence {
+
+	start := time.Date(2022, time.Month(2), 1, 0, 0, 0, 0, time.UTC)
+	r := model.NewEmptyRecurrence()
+	r.ID, r.Rule, r.Start, r.End, r.Next, r.Paused = id, rule, start, end, start, paused
+	r.Template.Account.ID, r.Template.Category.ID, r.Template.Amount, r.Template.Note = 1, 2, amount, note
+	return r
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) fetchActualData() []*model.Recurrence {
+	rows, err := s.db.Query(`SELECT id, rule, startDate, endDate, nextDate, paused, amount, note, accountId, categoryId
+                             FROM recurrence;`)
+	require.NoError(s.T(), err)
+	defer func() {
+		err = rows.Close()
+		require.NoError(s.T(), err)
+	}()
+
+	res := make([]*model.Recurrence, 0, 3)
+	for rows.Next() {
+		r := model.NewEmptyRecurrence()
+		err = rows.Scan(&r.ID, &r.Rule, &r.Start, &r.End, &r.Next, &r.Paused, &r.Template.Amount, &r.Template.Note,
+			&r.Template.Account.ID, &r.Template.Category.ID)
+		require.NoError(s.T(), err)
+		res = append(res, r)
+	}
+
+	return res
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM recurrence;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *RecurrenceSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestRecurrenceSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(RecurrenceSqliteStorageTestSuite))
+}
@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Split is used to acces the persistent storage.
+type Split struct {
+	executor executor[model.Split]
+}
+
+// NewSplit returns new split storage.
+func NewSplit(db *DB) *Split {
+	return &Split{executor[model.Split]{db}}
+}
+
+// SetTransactionSplits replaces splits of transaction with given ones in a single database
+// transaction, ids of inserted splits are set to corresponding elements of ss.
+func (s *Split) SetTransactionSplits(transactionID int64, ss []*model.Split) error {
+	return s.executor.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM split WHERE transactionId = ?;`, transactionID); err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		for _, e := range ss {
+			res, err := tx.Exec(`INSERT INTO split (transactionId, categoryId, amount, note) VALUES (?, ?, ?, ?);`,
+				transactionID, e.Category.ID, e.Amount, e.Note)
+			if err != nil {
+				return fmt.Errorf("tx.Exec: %w", err)
+			}
+
+			if e.ID, err = insertedID(res); err != nil {
+				return err
+			}
+			e.TransactionID = transactionID
+		}
+
+		return nil
+	})
+}
+
+// GetAll splits from persistent storage.
+func (s *Split) GetAll() ([]*model.Split, error) {
+	return s.executor.getAll(`SELECT id, transactionId, categoryId, amount, note FROM split;`,
+		func() (*model.Split, []any) {
+			e := model.NewEmptySplit()
+			return e, []any{&e.ID, &e.TransactionID, &e.Category.ID, &e.Amount, &e.Note}
+		})
+}
@@ -0,0 +1,92 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SplitSqliteStorageTestSuite struct {
+	suite.Suite
+	db         *sql.DB
+	storage    *sqlite.Split
+	InitSplits []*model.Split
+}
+
+func (s *SplitSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "currency", "category", "account", "transaction")
+
+	s.storage = sqlite.NewSplit(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitSplits = []*model.Split{
+		{ID: 1, TransactionID: 1, Category: &model.Category{ID: 1}, Amount: -1000, Note: "bread"},
+		{ID: 2, TransactionID: 1, Category: &model.Category{ID: 2}, Amount: -500, Note: "soap"},
+	}
+}
+
+func (s *SplitSqliteStorageTestSuite) SetupTest() {
+	stmt, err := s.db.Prepare(`INSERT INTO split (transactionId, categoryId, amount, note) VALUES (?, ?, ?, ?);`)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+
+	for _, e := range s.InitSplits {
+		_, err := stmt.Exec(e.TransactionID, e.Category.ID, e.Amount, e.Note)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *SplitSqliteStorageTestSuite) TestSetTransactionSplits() {
+	splits := []*model.Split{
+		{Category: &model.Category{ID: 3}, Amount: -700, Note: "wine"},
+		{Category: &model.Category{ID: 1}, Amount: -300},
+	}
+
+	err := s.storage.SetTransactionSplits(2, splits)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []int64{3, 4}, []int64{splits[0].ID, splits[1].ID})
+
+	err = s.storage.SetTransactionSplits(1, nil)
+	require.NoError(s.T(), err)
+
+	actualSplits, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Split{
+		{ID: 3, TransactionID: 2, Category: &model.Category{ID: 3}, Amount: -700, Note: "wine"},
+		{ID: 4, TransactionID: 2, Category: &model.Category{ID: 1}, Amount: -300},
+	}, actualSplits)
+}
+
+func (s *SplitSqliteStorageTestSuite) TestGetAll() {
+	actualSplits, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitSplits, actualSplits)
+}
+
+func (s *SplitSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM split;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *SplitSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestSplitSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(SplitSqliteStorageTestSuite))
+}
@@ -7,30 +7,57 @@ import (
 
 // SqliteStorage is a facade structure which aggregates all sqlite storages. It is used for convenience.
 type SqliteStorage struct {
-	category    *Category
-	currency    *Currency
-	account     *Account
-	transaction *Transaction
+	db           *DB
+	category     *Category
+	currency     *Currency
+	account      *Account
+	transaction  *Transaction
+	transfer     *Transfer
+	exchangeRate *ExchangeRate
+	budget       *Budget
+	recurrence   *Recurrence
+	tag          *Tag
+	split        *Split
+	payee        *Payee
+	attachment   *Attachment
+	goal         *Goal
+	counterparty *Counterparty
+	debt         *Debt
 }
 
-// New creates object which aggregates all storages.
-func New(db *sql.DB) (s *SqliteStorage, err error) {
-	s = &SqliteStorage{}
-
-	if s.category, err = NewCategory(db); err != nil {
-		return nil, fmt.Errorf("NewCategory: %w", err)
-	}
-	if s.currency, err = NewCurrency(db); err != nil {
-		return nil, fmt.Errorf("NewCurrency: %w", err)
-	}
-	if s.account, err = NewAccount(db); err != nil {
-		return nil, fmt.Errorf("NewAccount: %w", err)
-	}
-	if s.transaction, err = NewTransaction(db); err != nil {
-		return nil, fmt.Errorf("NewTransaction: %w", err)
+// New creates object which aggregates all storages. Database schema is upgraded to the latest
+// version first.
+func New(db *sql.DB) (*SqliteStorage, error) {
+	if err := Migrate(db); err != nil {
+		return nil, fmt.Errorf("Migrate: %w", err)
 	}
 
-	return s, nil
+	conn := NewDB(db)
+
+	return &SqliteStorage{
+		db:           conn,
+		category:     NewCategory(conn),
+		currency:     NewCurrency(conn),
+		account:      NewAccount(conn),
+		transaction:  NewTransaction(conn),
+		transfer:     NewTransfer(conn),
+		exchangeRate: NewExchangeRate(conn),
+		budget:       NewBudget(conn),
+		recurrence:   NewRecurrence(conn),
+		tag:          NewTag(conn),
+		split:        NewSplit(conn),
+		payee:        NewPayee(conn),
+		attachment:   NewAttachment(conn),
+		goal:         NewGoal(conn),
+		counterparty: NewCounterparty(conn),
+		debt:         NewDebt(conn),
+	}, nil
+}
+
+// InTx executes fn inside of database transaction shared by all storages, so changes made through
+// them are committed only if fn succeeds.
+func (s *SqliteStorage) InTx(fn func() error) error {
+	return s.db.InTx(func(*sql.Tx) error { return fn() })
 }
 
 // Category returns category sqlite storage.
@@ -52,3 +79,58 @@ func (s *SqliteStorage) Account() *Account {
 func (s *SqliteStorage) Transaction() *Transaction {
 	return s.transaction
 }
+
+// Transfer returns transfer sqlite storage.
+func (s *SqliteStorage) Transfer() *Transfer {
+	return s.transfer
+}
+
+// ExchangeRate returns exchange rate sqlite storage.
+func (s *SqliteStorage) ExchangeRate() *ExchangeRate {
+	return s.exchangeRate
+}
+
+// Budget returns budget sqlite storage.
+func (s *SqliteStorage) Budget() *Budget {
+	return s.budget
+}
+
+// Recurrence returns recurrence sqlite storage.
+func (s *SqliteStorage) Recurrence() *Recurrence {
+	return s.recurrence
+}
+
+// Tag returns tag sqlite storage.
+func (s *SqliteStorage) Tag() *Tag {
+	return s.tag
+}
+
+// Split returns split sqlite storage.
+func (s *SqliteStorage) Split() *Split {
+	return s.split
+}
+
+// Payee returns payee sqlite storage.
+func (s *SqliteStorage) Payee() *Payee {
+	return s.payee
+}
+
+// Attachment returns attachment sqlite storage.
+func (s *SqliteStorage) Attachment() *Attachment {
+	return s.attachment
+}
+
+// Goal returns goal sqlite storage.
+func (s *SqliteStorage) Goal() *Goal {
+	return s.goal
+}
+
+// Counterparty returns counterparty sqlite storage.
+func (s *SqliteStorage) Counterparty() *Counterparty {
+	return s.counterparty
+}
+
+// Debt returns debt sqlite storage.
+func (s *SqliteStorage) Debt() *Debt {
+	return s.debt
+}
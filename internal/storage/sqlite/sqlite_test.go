@@ -17,80 +17,68 @@ type SqliteStorageTestSuite struct {
 	db *sql.DB
 }
 
-func (s *SqliteStorageTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
-	require.NoError(s.T(), err, "occurred in SetupSuite")
+func (s *SqliteStorageTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupTest")
+	db.SetMaxOpenConns(1)
 	s.db = db
-
-	_, err = db.Exec(`CREATE TABLE t(id INTEGER);`)
-	require.NoError(s.T(), err, "occurred in SetupSuite")
-}
-
-func (s *SqliteStorageTestSuite) TestNewCategoryNegative() {
-	_, err := s.db.Exec(`CREATE UNIQUE INDEX category ON t (id);`)
-	require.NoError(s.T(), err)
-
-	_, err = sqlite.New(s.db)
-	assert.ErrorContains(s.T(), err, "NewCategory: s.CreateTableIfNotExists: there is already an index named category")
-
-	_, err = s.db.Exec(`DROP INDEX category;`)
-	require.NoError(s.T(), err)
-}
-
-func (s *SqliteStorageTestSuite) TestNewCurrencyNegative() {
-	_, err := s.db.Exec(`CREATE UNIQUE INDEX currency ON t (id);`)
-	require.NoError(s.T(), err)
-
-	_, err = sqlite.New(s.db)
-	assert.ErrorContains(s.T(), err, "NewCurrency: s.CreateTableIfNotExists: there is already an index named currency")
-
-	_, err = s.db.Exec(`DROP INDEX currency;`)
-	require.NoError(s.T(), err)
 }
 
-func (s *SqliteStorageTestSuite) TestNewAccountNegative() {
-	_, err := s.db.Exec(`CREATE UNIQUE INDEX account ON t (id);`)
+func (s *SqliteStorageTestSuite) TestNewNegative() {
+	_, err := s.db.Exec(`CREATE TABLE t(id INTEGER);
+                         CREATE UNIQUE INDEX category ON t (id);`)
 	require.NoError(s.T(), err)
 
 	_, err = sqlite.New(s.db)
-	assert.ErrorContains(s.T(), err, "NewAccount: s.CreateTableIfNotExists: there is already an index named account")
-
-	_, err = s.db.Exec(`DROP INDEX account;`)
-	require.NoError(s.T(), err)
-}
-
-func (s *SqliteStorageTestSuite) TestNewTransactionNegative() {
-	storage, _ := sqlite.New(s.db)
-	storage.Category()
-	storage.Currency()
-	storage.Account()
-	storage.Transaction()
+	assert.ErrorContains(s.T(), err, "Migrate: migration 1 \"create categories, currencies, accounts and transactions\": there is already an index named category")
 }
 
 func (s *SqliteStorageTestSuite) TestStorageGet() {
-	_, err := s.db.Exec(`CREATE UNIQUE INDEX "transaction" ON t (id);`)
+	storage, err := sqlite.New(s.db)
 	require.NoError(s.T(), err)
 
-	_, err = sqlite.New(s.db)
-	assert.ErrorContains(s.T(), err, "NewTransaction: s.CreateTableIfNotExists: there is already an index named transaction")
-
-	_, err = s.db.Exec(`DROP INDEX "transaction";`)
-	require.NoError(s.T(), err)
+	assert.NotNil(s.T(), storage.Category())
+	assert.NotNil(s.T(), storage.Currency())
+	assert.NotNil(s.T(), storage.Account())
+	assert.NotNil(s.T(), storage.Transaction())
+	assert.NotNil(s.T(), storage.Transfer())
+	assert.NotNil(s.T(), storage.ExchangeRate())
+	assert.NotNil(s.T(), storage.Budget())
+	assert.NotNil(s.T(), storage.Recurrence())
+	assert.NotNil(s.T(), storage.Tag())
+	assert.NotNil(s.T(), storage.Split())
+	assert.NotNil(s.T(), storage.Payee())
+	assert.NotNil(s.T(), storage.Attachment())
+	assert.NotNil(s.T(), storage.Goal())
+	assert.NotNil(s.T(), storage.Counterparty())
+	assert.NotNil(s.T(), storage.Debt())
 }
 
 func (s *SqliteStorageTestSuite) TearDownTest() {
-	_, err := s.db.Exec(`DROP TABLE IF EXISTS category;
-                         DROP TABLE IF EXISTS currency;
-                         DROP TABLE IF EXISTS account;
-                         DROP TABLE IF EXISTS "transaction";`)
-	require.NoError(s.T(), err, "occurred in TearDownTest")
-}
-
-func (s *SqliteStorageTestSuite) TearDownSuite() {
 	err := s.db.Close()
-	require.NoError(s.T(), err, "occurred in TearDownSuite")
+	require.NoError(s.T(), err, "occurred in TearDownTest")
 }
 
 func TestSqliteStorageTestSuite(t *testing.T) {
 	suite.Run(t, new(SqliteStorageTestSuite))
 }
+
+// references holds rows which rows of tested tables refer to, each table has rows with ids from
+// 1 to 3.
+var references = map[string]string{
+	"currency":     `INSERT INTO currency(abbreviation) VALUES ('USD'), ('EUR'), ('UAH');`,
+	"category":     `INSERT INTO category(title) VALUES ('Health'), ('Grocery'), ('Savings');`,
+	"account":      `INSERT INTO account(name, currencyId) VALUES ('Cash', 1), ('Card', 1), ('Deposit', 2);`,
+	"counterparty": `INSERT INTO counterparty(name) VALUES ('John'), ('Bank'), ('Jane');`,
+	"payee":        `INSERT INTO payee(name) VALUES ('Supermarket'), ('Landlord'), ('Pharmacy');`,
+	"transaction": `INSERT INTO "transaction"(date, amount, note, accountId, categoryId)
+                    VALUES ('2022-02-21', -100, '', 1, 1), ('2022-02-22', -200, '', 1, 2), ('2022-02-23', 300, '', 2, 1);`,
+}
+
+// insertReferences inserts rows of given tables in given order, since foreign keys are enforced.
+func insertReferences(t *testing.T, db *sql.DB, tables ...string) {
+	for _, table := range tables {
+		_, err := db.Exec(references[table])
+		require.NoError(t, err, "occurred in insertReferences")
+	}
+}
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Tag is used to acces the persistent storage. It also manages links between transactions and tags.
+type Tag struct {
+	executor     executor[model.Tag]
+	linkExecutor executor[model.TransactionTag]
+}
+
+// NewTag returns new tag storage.
+func NewTag(db *DB) *Tag {
+	return &Tag{executor[model.Tag]{db}, executor[model.TransactionTag]{db}}
+}
+
+// Insert tag into persistent storage.
+func (s *Tag) Insert(t *model.Tag) (int64, error) {
+	return s.executor.insert(`INSERT INTO tag (name) VALUES (?);`, t.Name)
+}
+
+// Update tag in persistand storage.
+func (s *Tag) Update(t *model.Tag) error {
+	return s.executor.update(`UPDATE tag SET name = ? WHERE id = ?;`, t.Name, t.ID)
+}
+
+// Delete tag along with its links to transactions from persistent storage in a single database
+// transaction.
+func (s *Tag) Delete(id int64) error {
+	return s.executor.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM transaction_tag WHERE tagId = ?;`, id); err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		res, err := tx.Exec(`DELETE FROM tag WHERE id = ?;`, id)
+		if err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		return affectedOne(res)
+	})
+}
+
+// GetAll tags from persistent storage.
+func (s *Tag) GetAll() ([]*model.Tag, error) {
+	return s.executor.getAll(`SELECT id, name FROM tag;`,
+		func() (*model.Tag, []any) {
+			t := model.NewEmptyTag()
+			return t, []any{&t.ID, &t.Name}
+		})
+}
+
+// SetTransactionTags replaces tags of transaction with given ones in a single database transaction.
+func (s *Tag) SetTransactionTags(transactionID int64, tagIDs []int64) error {
+	return s.linkExecutor.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM transaction_tag WHERE transactionId = ?;`, transactionID); err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		for _, id := range tagIDs {
+			_, err := tx.Exec(`INSERT INTO transaction_tag (transactionId, tagId) VALUES (?, ?);`, transactionID, id)
+			if err != nil {
+				return fmt.Errorf("tx.Exec: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetAllTransactionTags returns all links between transactions and tags from persistent storage.
+func (s *Tag) GetAllTransactionTags() ([]*model.TransactionTag, error) {
+	return s.linkExecutor.getAll(`SELECT transactionId, tagId FROM transaction_tag;`,
+		func() (*model.TransactionTag, []any) {
+			t := &model.TransactionTag{}
+			return t, []any{&t.TransactionID, &t.TagID}
+		})
+}
@@ -0,0 +1,149 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TagSqliteStorageTestSuite struct {
+	suite.Suite
+	db       *sql.DB
+	storage  *sqlite.Tag
+	InitTags []*model.Tag
+}
+
+func (s *TagSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "currency", "category", "account", "transaction")
+
+	s.storage = sqlite.NewTag(sqlite.NewDB(db))
+
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitTags = []*model.Tag{
+		{ID: 1, Name: "vacation"},
+		{ID: 2, Name: "reimbursable"},
+	}
+}
+
+func (s *TagSqliteStorageTestSuite) SetupTest() {
+	stmt, err := s.db.Prepare(`INSERT INTO tag (name) VALUES (?);`)
+	require.NoError(s.T(), err, "occurred in SetupTest")
+
+	for _, t := range s.InitTags {
+		_, err := stmt.Exec(t.Name)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *TagSqliteStorageTestSuite) TestInsertPositive() {
+	tag := &model.Tag{ID: 3, Name: "gift"}
+	expectedTags := append(s.InitTags, tag)
+
+	_, err := s.storage.Insert(tag)
+	require.NoError(s.T(), err)
+
+	actualTags, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), actualTags, expectedTags)
+}
+
+func (s *TagSqliteStorageTestSuite) TestInsertNegative() {
+	_, err := s.storage.Insert(s.InitTags[1])
+	assert.ErrorContains(s.T(), err, "e.db.Exec: UNIQUE constraint failed: tag.name")
+}
+
+func (s *TagSqliteStorageTestSuite) TestUpdatePositive() {
+	expectedTags := make([]*model.Tag, len(s.InitTags))
+	copy(expectedTags, s.InitTags)
+	expectedTags[1].Name = "work"
+
+	err := s.storage.Update(expectedTags[1])
+	require.NoError(s.T(), err)
+
+	actualTags, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), actualTags, expectedTags)
+}
+
+func (s *TagSqliteStorageTestSuite) TestUpdateNegative() {
+	err := s.storage.Update(&model.Tag{ID: 10})
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *TagSqliteStorageTestSuite) TestDeletePositive() {
+	err := s.storage.SetTransactionTags(1, []int64{1, 2})
+	require.NoError(s.T(), err)
+
+	err = s.storage.Delete(2)
+	require.NoError(s.T(), err)
+
+	actualTags, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), actualTags, s.InitTags[:1])
+
+	actualLinks, err := s.storage.GetAllTransactionTags()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.TransactionTag{{TransactionID: 1, TagID: 1}}, actualLinks)
+}
+
+func (s *TagSqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+}
+
+func (s *TagSqliteStorageTestSuite) TestSetTransactionTags() {
+	err := s.storage.SetTransactionTags(1, []int64{1, 2})
+	require.NoError(s.T(), err)
+	err = s.storage.SetTransactionTags(2, []int64{2})
+	require.NoError(s.T(), err)
+	err = s.storage.SetTransactionTags(1, []int64{1})
+	require.NoError(s.T(), err)
+
+	actualLinks, err := s.storage.GetAllTransactionTags()
+	require.NoError(s.T(), err)
+	assert.ElementsMatch(s.T(), []*model.TransactionTag{
+		{TransactionID: 1, TagID: 1},
+		{TransactionID: 2, TagID: 2},
+	}, actualLinks)
+}
+
+func (s *TagSqliteStorageTestSuite) TestSetTransactionTagsNegative() {
+	err := s.storage.SetTransactionTags(1, []int64{1, 1})
+	assert.EqualError(s.T(), err,
+		"tx.Exec: UNIQUE constraint failed: transaction_tag.transactionId, transaction_tag.tagId")
+}
+
+func (s *TagSqliteStorageTestSuite) TestGetAll() {
+	actualTags, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitTags, actualTags)
+}
+
+func (s *TagSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM transaction_tag; DELETE FROM tag;`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *TagSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestTagSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(TagSqliteStorageTestSuite))
+}
@@ -0,0 +1,21 @@
+-- Schema of a database created by the app released with migration 1, before versioning.
+CREATE TABLE category(
+            id INTEGER PRIMARY KEY,
+            title TEXT NOT NULL UNIQUE);
+CREATE TABLE currency(
+            id INTEGER PRIMARY KEY,
+            abbreviation TEXT NOT NULL UNIQUE);
+CREATE TABLE account(
+            id INTEGER PRIMARY KEY,
+            name TEXT NOT NULL UNIQUE,
+            currencyId INTEGER NOT NULL,
+            FOREIGN KEY(currencyId) REFERENCES currency(id));
+CREATE TABLE IF NOT EXISTS "transaction"(
+            id INTEGER PRIMARY KEY,
+            date DATETIME NOT NULL,
+            amount INTEGER NOT NULL,
+            note TEXT,
+            accountId INTEGER NOT NULL,
+            categoryId INTEGER NOT NULL,
+            FOREIGN KEY(accountId) REFERENCES account(id),
+            FOREIGN KEY(categoryId) REFERENCES category(id));
@@ -0,0 +1,75 @@
+-- Schema of a database created by the app released with migration 8, before versioning.
+CREATE TABLE category(
+            id INTEGER PRIMARY KEY,
+            title TEXT NOT NULL UNIQUE,
+            parentId INTEGER REFERENCES category(id));
+CREATE TABLE currency(
+            id INTEGER PRIMARY KEY,
+            abbreviation TEXT NOT NULL UNIQUE,
+            isMain INTEGER NOT NULL DEFAULT 0);
+CREATE TABLE account(
+            id INTEGER PRIMARY KEY,
+            name TEXT NOT NULL UNIQUE,
+            currencyId INTEGER NOT NULL,
+            FOREIGN KEY(currencyId) REFERENCES currency(id));
+CREATE TABLE IF NOT EXISTS "transaction"(
+            id INTEGER PRIMARY KEY,
+            date DATETIME NOT NULL,
+            amount INTEGER NOT NULL,
+            note TEXT,
+            accountId INTEGER NOT NULL,
+            categoryId INTEGER NOT NULL,
+            FOREIGN KEY(accountId) REFERENCES account(id),
+            FOREIGN KEY(categoryId) REFERENCES category(id));
+CREATE TABLE transfer(
+            id INTEGER PRIMARY KEY,
+            fromTransactionId INTEGER NOT NULL UNIQUE,
+            toTransactionId INTEGER NOT NULL UNIQUE,
+            FOREIGN KEY(fromTransactionId) REFERENCES "transaction"(id),
+            FOREIGN KEY(toTransactionId) REFERENCES "transaction"(id));
+CREATE TABLE exchange_rate(
+            id INTEGER PRIMARY KEY,
+            date DATETIME NOT NULL,
+            fromCurrencyId INTEGER NOT NULL,
+            toCurrencyId INTEGER NOT NULL,
+            rate REAL NOT NULL,
+            UNIQUE(date, fromCurrencyId, toCurrencyId),
+            FOREIGN KEY(fromCurrencyId) REFERENCES currency(id),
+            FOREIGN KEY(toCurrencyId) REFERENCES currency(id));
+CREATE TABLE budget(
+            id INTEGER PRIMARY KEY,
+            categoryId INTEGER NOT NULL,
+            period DATETIME NOT NULL,
+            limitAmount INTEGER NOT NULL,
+            UNIQUE(categoryId, period),
+            FOREIGN KEY(categoryId) REFERENCES category(id));
+CREATE TABLE recurrence(
+            id INTEGER PRIMARY KEY,
+            rule TEXT NOT NULL,
+            startDate DATETIME NOT NULL,
+            endDate DATETIME NOT NULL,
+            nextDate DATETIME NOT NULL,
+            paused INTEGER NOT NULL DEFAULT 0,
+            amount INTEGER NOT NULL,
+            note TEXT,
+            accountId INTEGER NOT NULL,
+            categoryId INTEGER NOT NULL,
+            FOREIGN KEY(accountId) REFERENCES account(id),
+            FOREIGN KEY(categoryId) REFERENCES category(id));
+CREATE TABLE tag(
+            id INTEGER PRIMARY KEY,
+            name TEXT NOT NULL UNIQUE);
+CREATE TABLE transaction_tag(
+            transactionId INTEGER NOT NULL,
+            tagId INTEGER NOT NULL,
+            PRIMARY KEY(transactionId, tagId),
+            FOREIGN KEY(transactionId) REFERENCES "transaction"(id),
+            FOREIGN KEY(tagId) REFERENCES tag(id));
+CREATE TABLE split(
+            id INTEGER PRIMARY KEY,
+            transactionId INTEGER NOT NULL,
+            categoryId INTEGER NOT NULL,
+            amount INTEGER NOT NULL,
+            note TEXT,
+            FOREIGN KEY(transactionId) REFERENCES "transaction"(id),
+            FOREIGN KEY(categoryId) REFERENCES category(id));
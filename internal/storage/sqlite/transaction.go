@@ -1,67 +1,76 @@
 package sqlite
 
 import (
-	"database/sql"
-	"fmt"
-
 	"github.com/kotlw/gentlemoney/internal/model"
 )
 
+const (
+	insertTransactionQuery = `INSERT INTO "transaction" (date, amount, note, accountId, categoryId, payeeId, status) VALUES (?, ?, ?, ?, ?, ?, ?);`
+	updateTransactionQuery = `UPDATE "transaction" SET date = ?, amount = ?, note = ?, accountId = ?, categoryId = ?, payeeId = ?, status = ? WHERE id = ?;`
+)
+
 // Transaction is used to acces the persistent storage.
 type Transaction struct {
 	executor executor[model.Transaction]
 }
 
 // NewTransaction returns new transaction storage.
-func NewTransaction(db *sql.DB) (*Transaction, error) {
-	s := &Transaction{executor[model.Transaction]{db}}
-
-	if err := s.CreateTableIfNotExists(); err != nil {
-		return nil, fmt.Errorf("s.CreateTableIfNotExists: %w", err)
-	}
-
-	return s, nil
-}
-
-// CreateTableIfNotExists creates transaction table if not exists.
-func (s *Transaction) CreateTableIfNotExists() error {
-	q := `CREATE TABLE IF NOT EXISTS "transaction"(
-            id INTEGER PRIMARY KEY,
-            date DATETIME NOT NULL,
-            amount INTEGER NOT NULL,
-            note TEXT,
-            accountId INTEGER NOT NULL,
-            categoryId INTEGER NOT NULL,
-            FOREIGN KEY(accountId) REFERENCES account(id),
-            FOREIGN KEY(categoryId) REFERENCES category(id));`
-	_, err := s.executor.db.Exec(q)
-	return err
+func NewTransaction(db *DB) *Transaction {
+	return &Transaction{executor[model.Transaction]{db}}
 }
 
 // Insert transaction into persistent storage.
 func (s *Transaction) Insert(t *model.Transaction) (int64, error) {
-	return s.executor.insert(
-		`INSERT INTO "transaction" (date, amount, note, accountId, categoryId) VALUES (?, ?, ?, ?, ?);`,
-		t.Date, t.Amount, t.Note, t.Account.ID, t.Category.ID)
+	return s.executor.insert(insertTransactionQuery,
+		t.Date, t.Amount, t.Note, t.Account.ID, t.Category.ID, payeeID(t), t.Status)
 }
 
 // Update transaction in persistand storage.
 func (s *Transaction) Update(t *model.Transaction) error {
-	return s.executor.update(
-		`UPDATE "transaction" SET date = ?, amount = ?, note = ?, accountId = ?, categoryId = ? WHERE id = ?;`,
-		t.Date, t.Amount, t.Note, t.Account.ID, t.Category.ID, t.ID)
+	return s.executor.update(updateTransactionQuery,
+		t.Date, t.Amount, t.Note, t.Account.ID, t.Category.ID, payeeID(t), t.Status, t.ID)
 }
 
-// Delete transaction from persistent storage.
+// Delete moves transaction to trash.
 func (s *Transaction) Delete(id int64) error {
-	return s.executor.update(`DELETE FROM "transaction" WHERE id = ?;`, id)
+	return s.executor.softDelete("transaction", id)
+}
+
+// Restore brings transaction back from trash.
+func (s *Transaction) Restore(id int64) error {
+	return s.executor.restore("transaction", id)
 }
 
-// GetAll transaction from persistent storage.
+// Purge permanently deletes transaction from trash.
+func (s *Transaction) Purge(id int64) error {
+	return s.executor.purge("transaction", id)
+}
+
+// GetAll transaction from persistent storage, transactions in trash are omitted.
 func (s *Transaction) GetAll() ([]*model.Transaction, error) {
-	return s.executor.getAll(`SELECT id, date, amount, note, accountId, categoryId FROM "transaction";`,
+	return s.getAll("deletedAt IS NULL")
+}
+
+// GetDeleted returns transactions from trash.
+func (s *Transaction) GetDeleted() ([]*model.Transaction, error) {
+	return s.getAll("deletedAt IS NOT NULL")
+}
+
+// getAll returns transactions which match given condition.
+func (s *Transaction) getAll(cond string) ([]*model.Transaction, error) {
+	return s.executor.getAll(`SELECT id, date, amount, note, accountId, categoryId, payeeId, status, deletedAt
+                              FROM "transaction" WHERE `+cond+`;`,
 		func() (*model.Transaction, []any) {
 			t := model.NewEmptyTransaction()
-			return t, []any{&t.ID, &t.Date, &t.Amount, &t.Note, &t.Account.ID, &t.Category.ID}
+			return t, []any{&t.ID, &t.Date, &t.Amount, &t.Note, &t.Account.ID, &t.Category.ID,
+				idScanner(func(id int64) { t.Payee = &model.Payee{ID: id} }), &t.Status, timeScanner{&t.DeletedAt}}
 		})
 }
+
+// payeeID returns id of the transaction payee or nil if it isn't set.
+func payeeID(t *model.Transaction) any {
+	if t.Payee == nil {
+		return nil
+	}
+	return t.Payee.ID
+}
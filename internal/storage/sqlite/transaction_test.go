@@ -22,31 +22,37 @@ type TransactionSqliteStorageTestSuite struct {
 }
 
 func (s *TransactionSqliteStorageTestSuite) SetupSuite() {
-	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 	s.db = db
 
-	s.storage, err = sqlite.NewTransaction(db)
+	err = sqlite.Migrate(db)
 	require.NoError(s.T(), err, "occurred in SetupSuite")
 
+	insertReferences(s.T(), db, "currency", "category", "account", "payee")
+
+	s.storage = sqlite.NewTransaction(sqlite.NewDB(db))
+
 	// id's settled by sqlite on insert incrementally starting from 1,
 	// so here they are initialized for match purpose
 	s.InitTransactions = []*model.Transaction{
 		{
 			ID:       1,
 			Date:     time.Date(2022, time.Month(2), 21, 1, 10, 30, 0, time.UTC),
-			Account:  model.NewEmptyAccount(),
-			Category: model.NewEmptyCategory(),
+			Account:  &model.Account{ID: 1, Currency: model.NewEmptyCurrency()},
+			Category: &model.Category{ID: 1},
 			Amount:   12345,
 			Note:     "note1",
+			Status:   model.Pending,
 		},
 		{
 			ID:       2,
 			Date:     time.Date(2022, time.Month(2), 22, 1, 10, 30, 0, time.UTC),
-			Account:  model.NewEmptyAccount(),
-			Category: model.NewEmptyCategory(),
+			Account:  &model.Account{ID: 1, Currency: model.NewEmptyCurrency()},
+			Category: &model.Category{ID: 1},
 			Amount:   67890,
 			Note:     "note2",
+			Status:   model.Pending,
 		},
 	}
 }
@@ -65,10 +71,11 @@ func (s *TransactionSqliteStorageTestSuite) TestInsertPositive() {
 	transaction := &model.Transaction{
 		ID:       3,
 		Date:     time.Date(2022, time.Month(2), 23, 1, 10, 30, 0, time.UTC),
-		Account:  model.NewEmptyAccount(),
-		Category: model.NewEmptyCategory(),
+		Account:  &model.Account{ID: 1, Currency: model.NewEmptyCurrency()},
+		Category: &model.Category{ID: 1},
 		Amount:   4321,
 		Note:     "note3",
+		Status:   model.Cleared,
 	}
 	expectedTransactions := append(s.InitTransactions, transaction)
 
@@ -96,11 +103,37 @@ func (s *TransactionSqliteStorageTestSuite) TestUpdateNegative() {
 }
 
 func (s *TransactionSqliteStorageTestSuite) TestDeletePositive() {
-	expectedTransactions := []*model.Transaction{s.InitTransactions[0]}
-
 	err := s.storage.Delete(2)
 	require.NoError(s.T(), err)
-	assert.ElementsMatch(s.T(), s.fetchActualData(), expectedTransactions)
+
+	actual, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []*model.Transaction{s.InitTransactions[0]}, actual)
+
+	// deleted row is kept in trash.
+	assert.Len(s.T(), s.fetchActualData(), 2)
+	deleted, err := s.storage.GetDeleted()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), deleted, 1)
+	assert.Equal(s.T(), int64(2), deleted[0].ID)
+	assert.False(s.T(), deleted[0].DeletedAt.IsZero())
+}
+
+func (s *TransactionSqliteStorageTestSuite) TestRestoreAndPurge() {
+	require.NoError(s.T(), s.storage.Delete(2))
+	require.NoError(s.T(), s.storage.Restore(2))
+
+	actual, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.InitTransactions, actual)
+
+	// only rows in trash could be restored or purged.
+	assert.EqualError(s.T(), s.storage.Restore(2), "total affected rows 0 while expected 1")
+	assert.EqualError(s.T(), s.storage.Purge(2), "total affected rows 0 while expected 1")
+
+	require.NoError(s.T(), s.storage.Delete(2))
+	require.NoError(s.T(), s.storage.Purge(2))
+	assert.ElementsMatch(s.T(), s.fetchActualData(), []*model.Transaction{s.InitTransactions[0]})
 }
 
 func (s *TransactionSqliteStorageTestSuite) TestDeleteNegative() {
@@ -114,8 +147,48 @@ func (s *TransactionSqliteStorageTestSuite) TestGetAll() {
 	assert.Equal(s.T(), s.InitTransactions, allTransactions)
 }
 
+func (s *TransactionSqliteStorageTestSuite) TestPayee() {
+	transaction := &model.Transaction{
+		ID:       3,
+		Date:     time.Date(2022, time.Month(2), 23, 1, 10, 30, 0, time.UTC),
+		Account:  &model.Account{ID: 1, Currency: model.NewEmptyCurrency()},
+		Category: &model.Category{ID: 1},
+		Payee:    &model.Payee{ID: 1},
+		Amount:   4321,
+		Status:   model.Pending,
+	}
+
+	_, err := s.storage.Insert(transaction)
+	require.NoError(s.T(), err)
+
+	allTransactions, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), append(s.InitTransactions, transaction), allTransactions)
+
+	transaction.Payee = nil
+	err = s.storage.Update(transaction)
+	require.NoError(s.T(), err)
+
+	allTransactions, err = s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), allTransactions[2].Payee)
+}
+
+func (s *TransactionSqliteStorageTestSuite) TestStatus() {
+	transaction := *s.InitTransactions[0]
+	transaction.Status = model.Reconciled
+
+	err := s.storage.Update(&transaction)
+	require.NoError(s.T(), err)
+
+	allTransactions, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), model.Reconciled, allTransactions[0].Status)
+	assert.Equal(s.T(), model.Pending, allTransactions[1].Status)
+}
+
 func (s *TransactionSqliteStorageTestSuite) fetchActualData() []*model.Transaction {
-	rows, err := s.db.Query(`SELECT id, date, amount, note, accountId, categoryId FROM "transaction";`)
+	rows, err := s.db.Query(`SELECT id, date, amount, note, accountId, categoryId, status FROM "transaction";`)
 	require.NoError(s.T(), err)
 	defer func() {
 		err = rows.Close()
@@ -125,7 +198,7 @@ func (s *TransactionSqliteStorageTestSuite) fetchActualData() []*model.Transacti
 	res := make([]*model.Transaction, 0, 3)
 	for rows.Next() {
 		t := model.NewEmptyTransaction()
-		err = rows.Scan(&t.ID, &t.Date, &t.Amount, &t.Note, &t.Account.ID, &t.Category.ID)
+		err = rows.Scan(&t.ID, &t.Date, &t.Amount, &t.Note, &t.Account.ID, &t.Category.ID, &t.Status)
 		require.NoError(s.T(), err)
 		res = append(res, t)
 	}
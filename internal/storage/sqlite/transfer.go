@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+)
+
+// Transfer is used to acces the persistent storage.
+type Transfer struct {
+	executor executor[model.Transfer]
+}
+
+// NewTransfer returns new transfer storage.
+func NewTransfer(db *DB) *Transfer {
+	return &Transfer{executor[model.Transfer]{db}}
+}
+
+// Insert transfer into persistent storage. Both legs are inserted to transaction table along with
+// the link in a single database transaction, ids of inserted legs are set to t.From and t.To.
+func (s *Transfer) Insert(t *model.Transfer) (int64, error) {
+	var id int64
+
+	err := s.executor.inTx(func(tx *sql.Tx) error {
+		fromID, err := insertLeg(tx, t.From)
+		if err != nil {
+			return fmt.Errorf("insertLeg: %w", err)
+		}
+
+		toID, err := insertLeg(tx, t.To)
+		if err != nil {
+			return fmt.Errorf("insertLeg: %w", err)
+		}
+
+		res, err := tx.Exec(`INSERT INTO transfer(fromTransactionId, toTransactionId) VALUES (?, ?);`, fromID, toID)
+		if err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		if id, err = insertedID(res); err != nil {
+			return err
+		}
+
+		t.From.ID, t.To.ID = fromID, toID
+
+		return nil
+	})
+
+	return id, err
+}
+
+// Update both legs of transfer in persistent storage in a single database transaction.
+func (s *Transfer) Update(t *model.Transfer) error {
+	return s.executor.inTx(func(tx *sql.Tx) error {
+		for _, leg := range []*model.Transaction{t.From, t.To} {
+			res, err := tx.Exec(updateTransactionQuery,
+				leg.Date, leg.Amount, leg.Note, leg.Account.ID, leg.Category.ID, payeeID(leg), leg.Status, leg.ID)
+			if err != nil {
+				return fmt.Errorf("tx.Exec: %w", err)
+			}
+
+			if err = affectedOne(res); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete moves both legs of transfer to trash, the link is kept to restore them together.
+func (s *Transfer) Delete(id int64) error {
+	return s.updateLegs(`UPDATE "transaction" SET deletedAt = ? WHERE deletedAt IS NULL AND id IN (
+                                 SELECT fromTransactionId FROM transfer WHERE id = ?
+                                 UNION SELECT toTransactionId FROM transfer WHERE id = ?);`, time.Now(), id, id)
+}
+
+// Restore brings both legs of transfer back from trash.
+func (s *Transfer) Restore(id int64) error {
+	return s.updateLegs(`UPDATE "transaction" SET deletedAt = NULL WHERE deletedAt IS NOT NULL AND id IN (
+                                 SELECT fromTransactionId FROM transfer WHERE id = ?
+                                 UNION SELECT toTransactionId FROM transfer WHERE id = ?);`, id, id)
+}
+
+// Purge permanently deletes transfer with both its legs from trash in a single database
+// transaction.
+func (s *Transfer) Purge(id int64) error {
+	return s.executor.inTx(func(tx *sql.Tx) error {
+		var fromID, toID int64
+		err := tx.QueryRow(`SELECT fromTransactionId, toTransactionId FROM transfer WHERE id = ?;`, id).Scan(&fromID, &toID)
+		if err != nil {
+			return fmt.Errorf("tx.QueryRow: %w", err)
+		}
+
+		res, err := tx.Exec(`DELETE FROM transfer WHERE id = ?;`, id)
+		if err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		if err = affectedOne(res); err != nil {
+			return err
+		}
+
+		res, err = tx.Exec(`DELETE FROM "transaction" WHERE id IN (?, ?) AND deletedAt IS NOT NULL;`, fromID, toID)
+		if err != nil {
+			return fmt.Errorf("tx.Exec: %w", err)
+		}
+
+		return affectedLegs(res)
+	})
+}
+
+// updateLegs executes query which updates both legs of transfer, it fails unless both legs are
+// affected.
+func (s *Transfer) updateLegs(query string, args ...any) error {
+	res, err := s.executor.db.conn().Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("e.db.Exec: %w", err)
+	}
+
+	return affectedLegs(res)
+}
+
+// GetAll transfers from persistent storage. Only ids of legs are filled.
+func (s *Transfer) GetAll() ([]*model.Transfer, error) {
+	return s.executor.getAll(`SELECT id, fromTransactionId, toTransactionId FROM transfer;`,
+		func() (*model.Transfer, []any) {
+			t := model.NewEmptyTransfer()
+			return t, []any{&t.ID, &t.From.ID, &t.To.ID}
+		})
+}
+
+// insertLeg inserts transfer leg into transaction table and returns its id.
+func insertLeg(tx *sql.Tx, t *model.Transaction) (int64, error) {
+	res, err := tx.Exec(insertTransactionQuery,
+		t.Date, t.Amount, t.Note, t.Account.ID, t.Category.ID, payeeID(t), t.Status)
+	if err != nil {
+		return -1, fmt.Errorf("tx.Exec: %w", err)
+	}
+
+	return insertedID(res)
+}
+
+// affectedLegs checks if result of query affected both legs of transfer.
+func affectedLegs(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %w", err)
+	}
+
+	if n != 2 {
+		return fmt.Errorf("total affected legs %d while expected 2", n)
+	}
+
+	return nil
+}
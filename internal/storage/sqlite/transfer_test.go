@@ -0,0 +1,216 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/storage/sqlite"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransferSqliteStorageTestSuite struct {
+	suite.Suite
+	db            *sql.DB
+	storage       *sqlite.Transfer
+	InitTransfers []*model.Transfer
+}
+
+func (s *TransferSqliteStorageTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_foreign_keys=on")
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+	s.db = db
+
+	err = sqlite.Migrate(db)
+	require.NoError(s.T(), err, "occurred in SetupSuite")
+
+	insertReferences(s.T(), db, "currency", "category", "account")
+
+	s.storage = sqlite.NewTransfer(sqlite.NewDB(db))
+}
+
+func (s *TransferSqliteStorageTestSuite) SetupTest() {
+	// id's settled by sqlite on insert incrementally starting from 1,
+	// so here they are initialized for match purpose
+	s.InitTransfers = []*model.Transfer{
+		s.newTransfer(1, 1, 2, 1000, 1000),
+		s.newTransfer(2, 3, 4, 2000, 5000),
+	}
+
+	for _, t := range s.InitTransfers {
+		for _, leg := range []*model.Transaction{t.From, t.To} {
+			_, err := s.db.Exec(`INSERT INTO "transaction" (date, accountId, categoryId, amount, note) VALUES (?, ?, ?, ?, ?);`,
+				leg.Date, leg.Account.ID, leg.Category.ID, leg.Amount, leg.Note)
+			require.NoError(s.T(), err, "occurred in SetupTest")
+		}
+
+		_, err := s.db.Exec(`INSERT INTO transfer (fromTransactionId, toTransactionId) VALUES (?, ?);`, t.From.ID, t.To.ID)
+		require.NoError(s.T(), err, "occurred in SetupTest")
+	}
+}
+
+func (s *TransferSqliteStorageTestSuite) TestInsertPositive() {
+	transfer := s.newTransfer(3, 5, 6, 300, 300)
+	transfer.From.ID, transfer.To.ID = 0, 0
+
+	id, err := s.storage.Insert(transfer)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), int64(3), id)
+	assert.Equal(s.T(), int64(5), transfer.From.ID)
+	assert.Equal(s.T(), int64(6), transfer.To.ID)
+	assert.Len(s.T(), s.fetchActualTransactions(), 6)
+	assert.ElementsMatch(s.T(), s.fetchActualLinks(), [][2]int64{{1, 2}, {3, 4}, {5, 6}})
+}
+
+func (s *TransferSqliteStorageTestSuite) TestUpdatePositive() {
+	transfer := s.InitTransfers[1]
+	transfer.From.Amount = -2500
+	transfer.To.Amount = 6000
+	transfer.From.Note = "changed"
+
+	err := s.storage.Update(transfer)
+	require.NoError(s.T(), err)
+
+	actual := s.fetchActualTransactions()
+	assert.Equal(s.T(), int64(-2500), actual[3].Amount)
+	assert.Equal(s.T(), "changed", actual[3].Note)
+	assert.Equal(s.T(), int64(6000), actual[4].Amount)
+}
+
+func (s *TransferSqliteStorageTestSuite) TestUpdateNegative() {
+	transfer := s.newTransfer(1, 1, 10, 7777, 7777)
+
+	err := s.storage.Update(transfer)
+	assert.EqualError(s.T(), err, "total affected rows 0 while expected 1")
+
+	// first leg should be rolled back
+	assert.Equal(s.T(), int64(-1000), s.fetchActualTransactions()[1].Amount)
+}
+
+func (s *TransferSqliteStorageTestSuite) TestDeletePositive() {
+	err := s.storage.Delete(1)
+	require.NoError(s.T(), err)
+
+	// legs are moved to trash while the link is kept.
+	assert.Len(s.T(), s.fetchActualTransactions(), 4)
+	assert.ElementsMatch(s.T(), s.fetchActualLinks(), [][2]int64{{1, 2}, {3, 4}})
+	assert.Len(s.T(), s.fetchDeletedTransactions(), 2)
+
+	require.NoError(s.T(), s.storage.Restore(1))
+	assert.Empty(s.T(), s.fetchDeletedTransactions())
+}
+
+func (s *TransferSqliteStorageTestSuite) TestPurge() {
+	assert.EqualError(s.T(), s.storage.Purge(1), "total affected legs 0 while expected 2")
+
+	require.NoError(s.T(), s.storage.Delete(1))
+	require.NoError(s.T(), s.storage.Purge(1))
+
+	assert.Len(s.T(), s.fetchActualTransactions(), 2)
+	assert.ElementsMatch(s.T(), s.fetchActualLinks(), [][2]int64{{3, 4}})
+}
+
+func (s *TransferSqliteStorageTestSuite) TestDeleteNegative() {
+	err := s.storage.Delete(10)
+	assert.EqualError(s.T(), err, "total affected legs 0 while expected 2")
+}
+
+func (s *TransferSqliteStorageTestSuite) TestGetAll() {
+	allTransfers, err := s.storage.GetAll()
+	require.NoError(s.T(), err)
+
+	expected := make([]*model.Transfer, len(s.InitTransfers))
+	for i, t := range s.InitTransfers {
+		expected[i] = model.NewEmptyTransfer()
+		expected[i].ID, expected[i].From.ID, expected[i].To.ID = t.ID, t.From.ID, t.To.ID
+	}
+
+	assert.Equal(s.T(), expected, allTransfers)
+}
+
+func (s *TransferSqliteStorageTestSuite) newTransfer(id, fromID, toID, fromAmount, toAmount int64) *model.Transfer {
+	date := time.Date(2022, time.Month(2), 21, 0, 0, 0, 0, time.UTC)
+	return &model.Transfer{
+		ID: id,
+		From: &model.Transaction{ID: fromID, Date: date, Account: &model.Account{ID: 1},
+			Category: &model.Category{ID: 1}, Amount: -fromAmount, Note: "transfer"},
+		To: &model.Transaction{ID: toID, Date: date, Account: &model.Account{ID: 2},
+			Category: &model.Category{ID: 1}, Amount: toAmount, Note: "transfer"},
+	}
+}
+
+func (s *TransferSqliteStorageTestSuite) fetchActualTransactions() map[int64]*model.Transaction {
+	rows, err := s.db.Query(`SELECT id, amount, note FROM "transaction";`)
+	require.NoError(s.T(), err)
+	defer func() {
+		err = rows.Close()
+		require.NoError(s.T(), err)
+	}()
+
+	res := make(map[int64]*model.Transaction)
+	for rows.Next() {
+		t := model.NewEmptyTransaction()
+		err = rows.Scan(&t.ID, &t.Amount, &t.Note)
+		require.NoError(s.T(), err)
+		res[t.ID] = t
+	}
+
+	return res
+}
+
+func (s *TransferSqliteStorageTestSuite) fetchActualLinks() [][2]int64 {
+	rows, err := s.db.Query(`SELECT fromTransactionId, toTransactionId FROM transfer;`)
+	require.NoError(s.T(), err)
+	defer func() {
+		err = rows.Close()
+		require.NoError(s.T(), err)
+	}()
+
+	res := make([][2]int64, 0, 3)
+	for rows.Next() {
+		var link [2]int64
+		err = rows.Scan(&link[0], &link[1])
+		require.NoError(s.T(), err)
+		res = append(res, link)
+	}
+
+	return res
+}
+
+func (s *TransferSqliteStorageTestSuite) TearDownTest() {
+	_, err := s.db.Exec(`DELETE FROM transfer; DELETE FROM "transaction";`)
+	require.NoError(s.T(), err, "occurred in TearDownTest")
+}
+
+func (s *TransferSqliteStorageTestSuite) TearDownSuite() {
+	err := s.db.Close()
+	require.NoError(s.T(), err, "occurred in TearDownSuite")
+}
+
+func TestTransferSqliteStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(TransferSqliteStorageTestSuite))
+}
+
+func (s *TransferSqliteStorageTestSuite) fetchDeletedTransactions() []int64 {
+	rows, err := s.db.Query(`SELECT id FROM "transaction" WHERE deletedAt IS NOT NULL;`)
+	require.NoError(s.T(), err)
+	defer func() {
+		err = rows.Close()
+		require.NoError(s.T(), err)
+	}()
+
+	res := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		require.NoError(s.T(), rows.Scan(&id))
+		res = append(res, id)
+	}
+
+	return res
+}
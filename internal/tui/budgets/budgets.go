@@ -0,0 +1,274 @@
+package budgets
+
+import (
+	"strings"
+
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// View is a budgets view.
+type View struct {
+	*tview.Pages
+
+	service      *service.Service
+	presenter    *presenter.Presenter
+	dataProvider *DataProvider
+
+	table       *ext.Table
+	createForm  *ext.Form
+	updateForm  *ext.Form
+	deleteModal *tview.Modal
+	errorModal  *tview.Modal
+}
+
+// New returns new budgets view.
+func New(service *service.Service, presenter *presenter.Presenter) *View {
+	v := &View{
+		Pages: tview.NewPages(),
+
+		service:   service,
+		presenter: presenter,
+	}
+
+	v.dataProvider = NewDataProvider(v.service, v.presenter)
+
+	// table
+	cols := []string{"Category", "Limit", "Spent", "Remaining", "Progress"}
+	v.table = ext.NewTable(cols, v.dataProvider).SetOrder("Category", false)
+	v.Refresh()
+	v.AddPage("table", v.table, true, true)
+
+	// create form
+	v.createForm = v.newForm("Create Budget", v.submitCreateForm, v.hideCreateForm, v.dataProvider)
+	v.AddPage("createForm", ext.WrapIntoModal(v.createForm, 40, 11), true, false)
+
+	// update form
+	v.updateForm = v.newForm("Update Budget", v.submitUpdateForm, v.hideUpdateForm, v.dataProvider)
+	v.AddPage("updateForm", ext.WrapIntoModal(v.updateForm, 40, 11), true, false)
+
+	// delete modal
+	v.deleteModal = ext.NewAskModal("Are you sure?", v.submitDeleteModal, v.hideDeleteModal)
+	v.AddPage("deleteModal", v.deleteModal, true, false)
+
+	// error modal
+	v.errorModal = ext.NewErrorModal(v.hideError)
+	v.AddPage("errorModal", v.errorModal, true, false)
+
+	return v
+}
+
+// Refresh refreshes budgets of the current period.
+func (v *View) Refresh() {
+	v.table.SetTitle("Budgets " + v.dataProvider.Period().Format("2006-01"))
+	v.table.Refresh()
+}
+
+// ModalHasFocus returns true if any of modal is currently on focus.
+func (v *View) ModalHasFocus() bool {
+	for _, modal := range []tview.Primitive{v.createForm, v.updateForm, v.deleteModal, v.errorModal} {
+		if modal.HasFocus() {
+			return true
+		}
+	}
+	return false
+}
+
+// InputHandler returns the handler for this primitive.
+func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if v.table.HasFocus() {
+			switch event.Rune() {
+			case 'c':
+				v.showCreateForm()
+			case 'u':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showUpdateForm()
+				} else {
+					v.showError("Nothing to update")
+				}
+			case 'd':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showDeleteModal()
+				} else {
+					v.showError("Nothing to delete")
+				}
+			case '<':
+				v.dataProvider.ShiftPeriod(-1)
+				v.Refresh()
+			case '>':
+				v.dataProvider.ShiftPeriod(1)
+				v.Refresh()
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.table.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
+		// give control to the child view.
+		for _, modal := range []tview.Primitive{v.createForm, v.updateForm, v.deleteModal, v.errorModal} {
+			if modal.HasFocus() {
+				if handler := modal.InputHandler(); handler != nil {
+					handler(event, setFocus)
+
+					return
+				}
+			}
+		}
+
+	})
+}
+
+// newForm returns new form with corresponding budget fields.
+func (v *View) newForm(title string, submit func(), cancel func(), dataProvider *DataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddInputField("Period", "", 0, nil, nil).
+		AddDropDown("Category", nil, 0, nil).
+		AddInputField("Limit", "", 0, tview.InputFieldFloat, nil).
+		AddButton(strings.Split(title, " ")[0], submit).
+		AddButton("Cancel", cancel)
+
+	form.SetBorder(true)
+	form.SetTitle(title)
+	form.SetCancelFunc(cancel)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// showCreateForm shows create form with initialized empty fields.
+func (v *View) showCreateForm() {
+	p := v.dataProvider.Period().Format("2006-01")
+	v.createForm.SetFields(map[string]string{"Period": p, "Category": "", "Limit": ""})
+	v.Pages.ShowPage("createForm")
+}
+
+// hideCreateForm hides create form.
+func (v *View) hideCreateForm() {
+	v.Pages.HidePage("createForm")
+}
+
+// isValidCreateForm checks if all necessary fields are filled.
+func (v *View) isValidCreateForm(m map[string]string) bool {
+	for _, label := range []string{"Period", "Category", "Limit"} {
+		if value, ok := m[label]; !ok || value == "" {
+			v.showError("Can't create budget without " + strings.ToLower(label) + ".")
+			return false
+		}
+	}
+
+	return true
+}
+
+// submitCreateForm create form submit handler.
+func (v *View) submitCreateForm() {
+	m := v.createForm.GetFields()
+	if !v.isValidCreateForm(m) {
+		return
+	}
+
+	b, err := v.presenter.Budget().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Budget().Insert(b); err != nil {
+		v.showError("Error insert budget: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideCreateForm()
+}
+
+// showUpdateForm shows update form with initialized with selected budget fields.
+func (v *View) showUpdateForm() {
+	m := v.getSelectedRef()
+	v.updateForm.SetFields(map[string]string{"Period": m["Period"], "Category": m["Category"], "Limit": m["Limit"]})
+	v.Pages.ShowPage("updateForm")
+}
+
+// hideUpdateForm hides update form.
+func (v *View) hideUpdateForm() {
+	v.Pages.HidePage("updateForm")
+}
+
+// submitUpdateForm update form submit handler.
+func (v *View) submitUpdateForm() {
+	m := v.updateForm.GetFields()
+	if !v.isValidCreateForm(m) {
+		return
+	}
+
+	ref := v.getSelectedRef()
+	m["ID"] = ref["ID"]
+
+	b, err := v.presenter.Budget().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Budget().Update(b); err != nil {
+		v.showError("Error update budget: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideUpdateForm()
+}
+
+// showDeleteModal shows delete modal.
+func (v *View) showDeleteModal() {
+	v.Pages.ShowPage("deleteModal")
+}
+
+// hideDeleteModal hides delete modal.
+func (v *View) hideDeleteModal() {
+	v.Pages.HidePage("deleteModal")
+}
+
+// submitDeleteModal delete modal submit handler.
+func (v *View) submitDeleteModal() {
+	ref := v.getSelectedRef()
+	b, err := v.presenter.Budget().FromMap(ref)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Budget().Delete(b); err != nil {
+		v.showError("Error delete budget: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideDeleteModal()
+}
+
+// showError shows error modal.
+func (v *View) showError(text string) {
+	v.errorModal.SetText(text)
+	v.Pages.ShowPage("errorModal")
+}
+
+// hideError hides error modal.
+func (v *View) hideError() {
+	v.Pages.HidePage("errorModal")
+}
+
+// getSelectedRef returns reference of selected row with category cleared from highlighting.
+func (v *View) getSelectedRef() map[string]string {
+	ref := v.table.GetSelectedRef()
+	ref["Category"] = strings.Replace(ref["Category"], "[red]", "", -1)
+	ref["Category"] = strings.Replace(ref["Category"], "[white]", "", -1)
+	return ref
+}
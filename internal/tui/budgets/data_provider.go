@@ -0,0 +1,77 @@
+package budgets
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// DataProvider implements ext.TableDataProvider and ext.FromDataProvider for interaction with budgets
+// of the current period.
+type DataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+	period    time.Time
+}
+
+// NewDataProvider returns new DataProvider.
+func NewDataProvider(service *service.Service, presenter *presenter.Presenter) *DataProvider {
+	now := time.Now()
+	period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return &DataProvider{service: service, presenter: presenter, period: period}
+}
+
+// GetAll returns slice of maps which represents budget struct with its progress.
+func (d *DataProvider) GetAll() []map[string]string {
+	data := d.service.Budget().GetByPeriod(d.period)
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		spent, err := d.service.Budget().Spent(e)
+		if err != nil {
+			res[i] = d.presenter.Budget().ToMap(e)
+			res[i]["Progress"] = "[red]" + err.Error() + "[white]"
+			continue
+		}
+		res[i] = d.presenter.Budget().ToProgressMap(e, spent)
+	}
+
+	return res
+}
+
+// GetDropDownOptions returns dropdown obtions for given label.
+func (d *DataProvider) GetDropDownOptions(label string) []string {
+	switch label {
+	case "Category":
+		return d.categoryOptions()
+	}
+	return nil
+}
+
+// Period returns the current period.
+func (d *DataProvider) Period() time.Time {
+	return d.period
+}
+
+// ShiftPeriod moves the current period by given number of months.
+func (d *DataProvider) ShiftPeriod(months int) {
+	d.period = d.period.AddDate(0, months, 0)
+}
+
+// categoryOptions returns category dropdown options.
+func (d *DataProvider) categoryOptions() []string {
+	categories := d.service.Category().GetAll()
+
+	res := make([]string, len(categories))
+
+	for i, e := range categories {
+		res[i] = d.service.Category().Path(e)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -0,0 +1,245 @@
+package debts
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// DataProvider implements ext.TableDataProvider and ext.FromDataProvider for interaction with debts.
+type DataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+}
+
+// NewDataProvider returns new DataProvider.
+func NewDataProvider(service *service.Service, presenter *presenter.Presenter) *DataProvider {
+	return &DataProvider{service: service, presenter: presenter}
+}
+
+// GetAll returns slice of maps which represents debt struct with its status as of today.
+func (d *DataProvider) GetAll() []map[string]string {
+	data := d.service.Debt().GetAll()
+	now := time.Now()
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		m, err := d.toStatusMap(e, now)
+		if err != nil {
+			m = d.presenter.Debt().ToMap(e)
+			m["Progress"] = "[red]" + err.Error() + "[white]"
+		}
+		res[i] = m
+	}
+
+	return res
+}
+
+// toStatusMap returns map which represents debt along with its status as of given date.
+func (d *DataProvider) toStatusMap(e *model.Debt, date time.Time) (map[string]string, error) {
+	repaid, err := d.service.Debt().Repaid(e, date)
+	if err != nil {
+		return nil, err
+	}
+
+	outstanding, err := d.service.Debt().Outstanding(e, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.presenter.Debt().ToStatusMap(e, repaid, outstanding), nil
+}
+
+// GetDropDownOptions returns dropdown obtions for given label.
+func (d *DataProvider) GetDropDownOptions(label string) []string {
+	switch label {
+	case "Counterparty":
+		return d.counterpartyOptions()
+	case "Direction":
+		return d.directionOptions()
+	case "Currency":
+		return d.currencyOptions()
+	case "Transaction":
+		return d.transactionOptions()
+	}
+	return nil
+}
+
+// counterpartyOptions returns counterparty autocomplete options.
+func (d *DataProvider) counterpartyOptions() []string {
+	counterparties := d.service.Counterparty().GetAll()
+
+	res := make([]string, len(counterparties))
+
+	for i, e := range counterparties {
+		res[i] = e.Name
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// directionOptions returns debt direction dropdown options.
+func (d *DataProvider) directionOptions() []string {
+	directions := model.DebtDirections()
+
+	res := make([]string, len(directions))
+
+	for i, e := range directions {
+		res[i] = string(e)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// currencyOptions returns currency dropdown options.
+func (d *DataProvider) currencyOptions() []string {
+	currencies := d.service.Currency().GetAll()
+
+	res := make([]string, len(currencies))
+
+	for i, e := range currencies {
+		res[i] = e.Abbreviation
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// transactionOptions returns dropdown options of transactions which don't repay any debt yet. Each
+// option is ended by transaction id, see parseTransactionOption.
+func (d *DataProvider) transactionOptions() []string {
+	res := make([]string, 0)
+
+	for _, e := range d.service.Transaction().GetAll() {
+		if d.service.Debt().GetByTransaction(e) != nil {
+			continue
+		}
+
+		m := d.presenter.Transaction().ToMap(e)
+		res = append(res, strings.Join([]string{m["Date"], m["Account"], m["Amount"], m["Currency"], m["Note"]}, " ")+
+			" #"+m["ID"])
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// parseTransactionOption returns id of transaction from transaction dropdown option.
+func parseTransactionOption(option string) (int64, error) {
+	return strconv.ParseInt(option[strings.LastIndex(option, "#")+1:], 10, 64)
+}
+
+// SummaryDataProvider implements ext.TableDataProvider for interaction with summary of debts by
+// counterparty.
+type SummaryDataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+}
+
+// NewSummaryDataProvider returns new SummaryDataProvider.
+func NewSummaryDataProvider(service *service.Service, presenter *presenter.Presenter) *SummaryDataProvider {
+	return &SummaryDataProvider{service: service, presenter: presenter}
+}
+
+// GetAll returns slice of maps which represents outstanding balances with counterparties as of
+// today. Summary which can't be obtained is represented as empty list.
+func (d *SummaryDataProvider) GetAll() []map[string]string {
+	data, err := d.service.Debt().Summary(time.Now())
+	if err != nil {
+		return nil
+	}
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = d.presenter.Debt().BalanceToMap(e)
+	}
+
+	return res
+}
+
+// RepaymentDataProvider implements ext.TableDataProvider for interaction with repayments of debt.
+type RepaymentDataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+	debt      *model.Debt
+}
+
+// NewRepaymentDataProvider returns new RepaymentDataProvider.
+func NewRepaymentDataProvider(service *service.Service, presenter *presenter.Presenter) *RepaymentDataProvider {
+	return &RepaymentDataProvider{service: service, presenter: presenter}
+}
+
+// SetDebt sets debt which repayments are provided.
+func (d *RepaymentDataProvider) SetDebt(e *model.Debt) {
+	d.debt = e
+}
+
+// Debt returns debt which repayments are provided.
+func (d *RepaymentDataProvider) Debt() *model.Debt {
+	return d.debt
+}
+
+// GetAll returns slice of maps which represents repayment transactions of debt.
+func (d *RepaymentDataProvider) GetAll() []map[string]string {
+	if d.debt == nil {
+		return nil
+	}
+
+	data := d.service.Debt().Repayments(d.debt)
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = d.presenter.Transaction().ToMap(e)
+	}
+
+	return res
+}
+
+// ScheduleDataProvider implements ext.TableDataProvider for interaction with repayment schedule of
+// debt.
+type ScheduleDataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+	debt      *model.Debt
+}
+
+// NewScheduleDataProvider returns new ScheduleDataProvider.
+func NewScheduleDataProvider(service *service.Service, presenter *presenter.Presenter) *ScheduleDataProvider {
+	return &ScheduleDataProvider{service: service, presenter: presenter}
+}
+
+// SetDebt sets debt which schedule is provided.
+func (d *ScheduleDataProvider) SetDebt(e *model.Debt) {
+	d.debt = e
+}
+
+// GetAll returns slice of maps which represents installments of debt.
+func (d *ScheduleDataProvider) GetAll() []map[string]string {
+	if d.debt == nil {
+		return nil
+	}
+
+	data := d.service.Debt().Schedule(d.debt)
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = d.presenter.Debt().InstallmentToMap(e, d.debt.Currency)
+	}
+
+	return res
+}
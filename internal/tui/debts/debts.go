@@ -0,0 +1,346 @@
+package debts
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// View is a debts view. It lists debts above the summary of who owes what.
+type View struct {
+	*tview.Pages
+
+	service               *service.Service
+	presenter             *presenter.Presenter
+	dataProvider          *DataProvider
+	repaymentDataProvider *RepaymentDataProvider
+	scheduleDataProvider  *ScheduleDataProvider
+
+	table                *ext.Table
+	summaryTable         *ext.Table
+	createForm           *ext.Form
+	updateForm           *ext.Form
+	deleteModal          *tview.Modal
+	repaymentTable       *ext.Table
+	repaymentForm        *ext.Form
+	repaymentDeleteModal *tview.Modal
+	scheduleTable        *ext.Table
+	errorModal           *tview.Modal
+}
+
+// New returns new debts view.
+func New(service *service.Service, presenter *presenter.Presenter) *View {
+	v := &View{
+		Pages: tview.NewPages(),
+
+		service:   service,
+		presenter: presenter,
+	}
+
+	v.dataProvider = NewDataProvider(v.service, v.presenter)
+
+	// tables
+	cols := []string{"Counterparty", "Direction", "Principal", "Currency", "Date", "Repaid", "Outstanding", "Progress",
+		"Note"}
+	v.table = ext.NewTable(cols, v.dataProvider).SetOrder("Date", false)
+	v.table.SetTitle("Debts")
+	summaryCols := []string{"Counterparty", "Currency", "Owes me", "I owe"}
+	v.summaryTable = ext.NewTable(summaryCols, NewSummaryDataProvider(v.service, v.presenter)).
+		SetOrder("Counterparty", false)
+	v.summaryTable.SetTitle("Summary")
+	v.Refresh()
+	v.AddPage("table", tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.table, 0, 2, true).
+		AddItem(v.summaryTable, 0, 1, false), true, true)
+
+	// create form
+	v.createForm = v.newForm("Create Debt", v.submitCreateForm, v.hideCreateForm, v.dataProvider)
+	v.AddPage("createForm", ext.WrapIntoModal(v.createForm, 40, 21), true, false)
+
+	// update form
+	v.updateForm = v.newForm("Update Debt", v.submitUpdateForm, v.hideUpdateForm, v.dataProvider)
+	v.AddPage("updateForm", ext.WrapIntoModal(v.updateForm, 40, 21), true, false)
+
+	// delete modal
+	v.deleteModal = ext.NewAskModal("Are you sure?", v.submitDeleteModal, v.hideDeleteModal)
+	v.AddPage("deleteModal", v.deleteModal, true, false)
+
+	// repayments
+	v.repaymentDataProvider = NewRepaymentDataProvider(v.service, v.presenter)
+	v.repaymentTable = v.newRepaymentTable(v.repaymentDataProvider)
+	v.AddPage("repaymentTable", ext.WrapIntoModal(v.repaymentTable, 80, 12), true, false)
+	v.repaymentForm = v.newRepaymentForm(v.dataProvider)
+	v.AddPage("repaymentForm", ext.WrapIntoModal(v.repaymentForm, 80, 7), true, false)
+	v.repaymentDeleteModal = ext.NewAskModal("Are you sure?", v.submitRepaymentDeleteModal, v.hideRepaymentDeleteModal)
+	v.AddPage("repaymentDeleteModal", v.repaymentDeleteModal, true, false)
+
+	// schedule
+	v.scheduleDataProvider = NewScheduleDataProvider(v.service, v.presenter)
+	v.scheduleTable = v.newScheduleTable(v.scheduleDataProvider)
+	v.AddPage("scheduleTable", ext.WrapIntoModal(v.scheduleTable, 60, 16), true, false)
+
+	// error modal
+	v.errorModal = ext.NewErrorModal(v.hideError)
+	v.AddPage("errorModal", v.errorModal, true, false)
+
+	return v
+}
+
+// Refresh refreshes debts along with the summary.
+func (v *View) Refresh() {
+	v.table.Refresh()
+	v.summaryTable.Refresh()
+}
+
+// ModalHasFocus returns true if any of modal is currently on focus.
+func (v *View) ModalHasFocus() bool {
+	for _, modal := range []tview.Primitive{
+		v.createForm, v.updateForm, v.deleteModal, v.repaymentTable, v.repaymentForm, v.repaymentDeleteModal,
+		v.scheduleTable, v.errorModal,
+	} {
+		if modal.HasFocus() {
+			return true
+		}
+	}
+	return false
+}
+
+// InputHandler returns the handler for this primitive.
+func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if v.table.HasFocus() {
+			switch event.Rune() {
+			case 'c':
+				v.showCreateForm()
+			case 'u':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showUpdateForm()
+				} else {
+					v.showError("Nothing to update")
+				}
+			case 'd':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showDeleteModal()
+				} else {
+					v.showError("Nothing to delete")
+				}
+			case 'r':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showRepaymentTable()
+				} else {
+					v.showError("Nothing to repay")
+				}
+			case 's':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showScheduleTable()
+				} else {
+					v.showError("Nothing to schedule")
+				}
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.table.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
+		if v.repaymentTable.HasFocus() {
+			switch event.Rune() {
+			case 'a':
+				v.showRepaymentForm()
+			case 'd':
+				if len(v.repaymentTable.GetSelectedRef()) != 0 {
+					v.showRepaymentDeleteModal()
+				} else {
+					v.showError("Nothing to delete")
+				}
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.repaymentTable.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
+		// give control to the child view.
+		for _, modal := range []tview.Primitive{
+			v.createForm, v.updateForm, v.deleteModal, v.repaymentForm, v.repaymentDeleteModal, v.scheduleTable,
+			v.errorModal,
+		} {
+			if modal.HasFocus() {
+				if handler := modal.InputHandler(); handler != nil {
+					handler(event, setFocus)
+
+					return
+				}
+			}
+		}
+
+	})
+}
+
+// newForm returns new form with corresponding debt fields.
+func (v *View) newForm(title string, submit func(), cancel func(), dataProvider *DataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddFormItem(ext.NewAutocompleteField().SetLabel("Counterparty")).
+		AddDropDown("Direction", nil, 0, nil).
+		AddInputField("Principal", "", 0, tview.InputFieldFloat, nil).
+		AddDropDown("Currency", nil, 0, nil).
+		AddInputField("Interest Rate", "", 0, tview.InputFieldFloat, nil).
+		AddFormItem(ext.NewDateField().SetLabel("Date")).
+		AddInputField("Installments", "", 0, tview.InputFieldInteger, nil).
+		AddInputField("Note", "", 0, nil, nil).
+		AddButton(strings.Split(title, " ")[0], submit).
+		AddButton("Cancel", cancel)
+
+	form.SetBorder(true)
+	form.SetTitle(title)
+	form.SetCancelFunc(cancel)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// showCreateForm shows create form with initialized empty fields, date is today.
+func (v *View) showCreateForm() {
+	m := map[string]string{"Counterparty": "", "Direction": "", "Principal": "", "Currency": "",
+		"Interest Rate": "", "Date": time.Now().Format("2006-01-02"), "Installments": "", "Note": ""}
+	v.createForm.SetFields(m)
+	v.Pages.ShowPage("createForm")
+}
+
+// hideCreateForm hides create form.
+func (v *View) hideCreateForm() {
+	v.Pages.HidePage("createForm")
+}
+
+// isValidCreateForm checks if all necessary fields are filled.
+func (v *View) isValidCreateForm(m map[string]string) bool {
+	for _, label := range []string{"Counterparty", "Direction", "Principal", "Currency", "Date"} {
+		if value, ok := m[label]; !ok || value == "" {
+			v.showError("Can't create debt without " + strings.ToLower(label) + ".")
+			return false
+		}
+	}
+	return true
+}
+
+// submitCreateForm create form submit handler.
+func (v *View) submitCreateForm() {
+	m := v.createForm.GetFields()
+	if !v.isValidCreateForm(m) {
+		return
+	}
+
+	d, err := v.presenter.Debt().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Debt().Insert(d); err != nil {
+		v.showError("Error insert debt: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideCreateForm()
+}
+
+// showUpdateForm shows update form with initialized with selected debt fields.
+func (v *View) showUpdateForm() {
+	m := v.table.GetSelectedRef()
+	v.updateForm.SetFields(map[string]string{
+		"Counterparty": m["Counterparty"], "Direction": m["Direction"], "Principal": m["Principal"],
+		"Currency": m["Currency"], "Interest Rate": m["Interest Rate"], "Date": m["Date"],
+		"Installments": m["Installments"], "Note": m["Note"]})
+	v.Pages.ShowPage("updateForm")
+}
+
+// hideUpdateForm hides update form.
+func (v *View) hideUpdateForm() {
+	v.Pages.HidePage("updateForm")
+}
+
+// submitUpdateForm update form submit handler.
+func (v *View) submitUpdateForm() {
+	m := v.updateForm.GetFields()
+	if !v.isValidCreateForm(m) {
+		return
+	}
+
+	ref := v.table.GetSelectedRef()
+	m["ID"] = ref["ID"]
+
+	d, err := v.presenter.Debt().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Debt().Update(d); err != nil {
+		v.showError("Error update debt: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideUpdateForm()
+}
+
+// showDeleteModal shows delete modal.
+func (v *View) showDeleteModal() {
+	v.Pages.ShowPage("deleteModal")
+}
+
+// hideDeleteModal hides delete modal.
+func (v *View) hideDeleteModal() {
+	v.Pages.HidePage("deleteModal")
+}
+
+// submitDeleteModal delete modal submit handler.
+func (v *View) submitDeleteModal() {
+	d, err := v.presenter.Debt().FromMap(v.table.GetSelectedRef())
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Debt().Delete(d); err != nil {
+		v.showError("Error delete debt: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideDeleteModal()
+}
+
+// getSelectedDebt returns stored instance of selected debt.
+func (v *View) getSelectedDebt() *model.Debt {
+	id, err := strconv.ParseInt(v.table.GetSelectedRef()["ID"], 10, 64)
+	if err != nil {
+		return nil
+	}
+	return v.service.Debt().GetByID(id)
+}
+
+// showError shows error modal.
+func (v *View) showError(text string) {
+	v.errorModal.SetText(text)
+	v.Pages.ShowPage("errorModal")
+}
+
+// hideError hides error modal.
+func (v *View) hideError() {
+	v.Pages.HidePage("errorModal")
+}
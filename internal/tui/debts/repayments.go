@@ -0,0 +1,163 @@
+package debts
+
+import (
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// newRepaymentTable returns new table which lists transactions repaying debt.
+func (v *View) newRepaymentTable(dataProvider *RepaymentDataProvider) *ext.Table {
+	cols := []string{"Date", "Account", "Amount", "Currency", "Note"}
+	table := ext.NewTable(cols, dataProvider).SetOrder("Date", false)
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			v.hideRepaymentTable()
+		}
+	})
+
+	return table
+}
+
+// newRepaymentForm returns new form for choosing transaction which repays debt.
+func (v *View) newRepaymentForm(dataProvider *DataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddDropDown("Transaction", nil, 0, nil).
+		AddButton("Link", v.submitRepaymentForm).
+		AddButton("Cancel", v.hideRepaymentForm)
+
+	form.SetBorder(true)
+	form.SetTitle("Link Repayment")
+	form.SetCancelFunc(v.hideRepaymentForm)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// newScheduleTable returns new table which lists installments of debt.
+func (v *View) newScheduleTable(dataProvider *ScheduleDataProvider) *ext.Table {
+	cols := []string{"Date", "Amount", "Principal", "Interest"}
+	table := ext.NewTable(cols, dataProvider).SetOrder("Date", false)
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			v.hideScheduleTable()
+		}
+	})
+
+	return table
+}
+
+// showRepaymentTable shows repayments of selected debt.
+func (v *View) showRepaymentTable() {
+	d := v.getSelectedDebt()
+	if d == nil {
+		v.showError("Debt not found")
+		return
+	}
+
+	v.repaymentDataProvider.SetDebt(d)
+	v.repaymentTable.SetTitle("Repayments: " + d.Counterparty.Name + " " + string(d.Direction))
+	v.repaymentTable.Refresh()
+	v.Pages.ShowPage("repaymentTable")
+}
+
+// hideRepaymentTable hides repayments table along with refreshing debts, since their status
+// depends on repayments.
+func (v *View) hideRepaymentTable() {
+	v.repaymentDataProvider.SetDebt(nil)
+	v.Refresh()
+	v.Pages.HidePage("repaymentTable")
+}
+
+// showRepaymentForm shows link form with no transaction chosen.
+func (v *View) showRepaymentForm() {
+	v.repaymentForm.SetFields(map[string]string{"Transaction": ""})
+	v.Pages.ShowPage("repaymentForm")
+}
+
+// hideRepaymentForm hides link form.
+func (v *View) hideRepaymentForm() {
+	v.Pages.HidePage("repaymentForm")
+}
+
+// submitRepaymentForm link form submit handler.
+func (v *View) submitRepaymentForm() {
+	option := v.repaymentForm.GetFields()["Transaction"]
+	if option == "" {
+		v.showError("Can't link repayment without transaction.")
+		return
+	}
+
+	id, err := parseTransactionOption(option)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	t := v.service.Transaction().GetByID(id)
+	if t == nil {
+		v.showError("Transaction not found")
+		return
+	}
+
+	if err := v.service.Debt().AddRepayment(v.repaymentDataProvider.Debt(), t); err != nil {
+		v.showError("Error link repayment: \n" + err.Error())
+		return
+	}
+
+	v.repaymentTable.Refresh()
+	v.hideRepaymentForm()
+}
+
+// showRepaymentDeleteModal shows repayment delete modal.
+func (v *View) showRepaymentDeleteModal() {
+	v.Pages.ShowPage("repaymentDeleteModal")
+}
+
+// hideRepaymentDeleteModal hides repayment delete modal.
+func (v *View) hideRepaymentDeleteModal() {
+	v.Pages.HidePage("repaymentDeleteModal")
+}
+
+// submitRepaymentDeleteModal repayment delete modal submit handler, it unlinks transaction from
+// debt but keeps the transaction.
+func (v *View) submitRepaymentDeleteModal() {
+	t, err := v.presenter.Transaction().FromMap(v.repaymentTable.GetSelectedRef())
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Debt().RemoveRepayment(v.repaymentDataProvider.Debt(), t); err != nil {
+		v.showError("Error unlink repayment: \n" + err.Error())
+		return
+	}
+
+	v.repaymentTable.Refresh()
+	v.hideRepaymentDeleteModal()
+}
+
+// showScheduleTable shows repayment schedule of selected debt.
+func (v *View) showScheduleTable() {
+	d := v.getSelectedDebt()
+	if d == nil {
+		v.showError("Debt not found")
+		return
+	}
+
+	if d.Installments == 0 {
+		v.showError("Debt has no repayment schedule")
+		return
+	}
+
+	v.scheduleDataProvider.SetDebt(d)
+	v.scheduleTable.SetTitle("Schedule: " + d.Counterparty.Name + " " + string(d.Direction))
+	v.scheduleTable.Refresh()
+	v.Pages.ShowPage("scheduleTable")
+}
+
+// hideScheduleTable hides schedule table.
+func (v *View) hideScheduleTable() {
+	v.scheduleDataProvider.SetDebt(nil)
+	v.Pages.HidePage("scheduleTable")
+}
@@ -0,0 +1,51 @@
+package ext
+
+import (
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// AutocompleteField is an input field which suggests existing options matching typed text, values
+// which aren't among options can be typed as is.
+type AutocompleteField struct {
+	*tview.InputField
+
+	options []string
+}
+
+// NewAutocompleteField returns new AutocompleteField.
+func NewAutocompleteField() *AutocompleteField {
+	f := &AutocompleteField{InputField: tview.NewInputField()}
+	f.SetAutocompleteFunc(f.autocomplete)
+	return f
+}
+
+// SetLabel sets the text to be displayed before the input area.
+func (f *AutocompleteField) SetLabel(label string) *AutocompleteField {
+	f.InputField.SetLabel(label)
+	return f
+}
+
+// SetOptions sets existing values to be suggested.
+func (f *AutocompleteField) SetOptions(options []string) *AutocompleteField {
+	f.options = options
+	return f
+}
+
+// autocomplete returns options which contain typed text regardless of case.
+func (f *AutocompleteField) autocomplete(text string) []string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return nil
+	}
+
+	res := make([]string, 0)
+	for _, opt := range f.options {
+		if strings.Contains(strings.ToLower(opt), text) && strings.ToLower(opt) != text {
+			res = append(res, opt)
+		}
+	}
+
+	return res
+}
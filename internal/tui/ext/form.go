@@ -2,6 +2,7 @@ package ext
 
 import (
 	"sort"
+	"strconv"
 
 	"github.com/rivo/tview"
 )
@@ -18,6 +19,9 @@ type Form struct {
 	dateFields   map[string]*DateField
 	inputFields  map[string]*tview.InputField
 	dropDowns    map[string]*tview.DropDown
+	checkboxes   map[string]*tview.Checkbox
+	tagFields    map[string]*TagField
+	autoFields   map[string]*AutocompleteField
 	dataProvider FormDataProvider
 }
 
@@ -29,6 +33,9 @@ func NewForm(form *tview.Form, dataProvider FormDataProvider) *Form {
 		dateFields:   make(map[string]*DateField),
 		inputFields:  make(map[string]*tview.InputField),
 		dropDowns:    make(map[string]*tview.DropDown),
+		checkboxes:   make(map[string]*tview.Checkbox),
+		tagFields:    make(map[string]*TagField),
+		autoFields:   make(map[string]*AutocompleteField),
 		dataProvider: dataProvider,
 	}
 
@@ -48,6 +55,18 @@ func NewForm(form *tview.Form, dataProvider FormDataProvider) *Form {
 		if ok {
 			f.dropDowns[item.GetLabel()] = dropDown
 		}
+		checkbox, ok := item.(*tview.Checkbox)
+		if ok {
+			f.checkboxes[item.GetLabel()] = checkbox
+		}
+		tagField, ok := item.(*TagField)
+		if ok {
+			f.tagFields[item.GetLabel()] = tagField
+		}
+		autoField, ok := item.(*AutocompleteField)
+		if ok {
+			f.autoFields[item.GetLabel()] = autoField
+		}
 	}
 
 	return f
@@ -56,33 +75,60 @@ func NewForm(form *tview.Form, dataProvider FormDataProvider) *Form {
 // SetFields sets fields value from map where key is field label and value is a value.
 func (f *Form) SetFields(m map[string]string) {
 	for label, value := range m {
+		f.SetField(label, value)
+	}
+	f.Form.SetFocus(0)
+}
 
-		dateField, ok := f.dateFields[label]
-		if ok {
-			dateField.SetTextDate(value)
-			continue
-		}
+// SetField sets value of field with given label, unlike SetFields it doesn't change the focus.
+func (f *Form) SetField(label, value string) {
+	dateField, ok := f.dateFields[label]
+	if ok {
+		dateField.SetTextDate(value)
+		return
+	}
 
-		inputField, ok := f.inputFields[label]
-		if ok {
-			inputField.SetText(value)
-			continue
-		}
+	inputField, ok := f.inputFields[label]
+	if ok {
+		inputField.SetText(value)
+		return
+	}
 
-		dropDown, ok := f.dropDowns[label]
-		if ok {
-			opts := f.dataProvider.GetDropDownOptions(label)
+	dropDown, ok := f.dropDowns[label]
+	if ok {
+		opts := f.dataProvider.GetDropDownOptions(label)
 
-			index := -1
-			if value != "" {
-				index = sort.SearchStrings(opts, value)
-			}
+		index := -1
+		if value != "" {
+			index = sort.SearchStrings(opts, value)
 
-			dropDown.SetOptions(opts, nil).SetCurrentOption(index)
-			continue
+			// keep current value selectable even if it isn't offered anymore.
+			if index == len(opts) || opts[index] != value {
+				opts = append(opts[:index], append([]string{value}, opts[index:]...)...)
+			}
 		}
+
+		dropDown.SetOptions(opts, nil).SetCurrentOption(index)
+		return
+	}
+
+	checkbox, ok := f.checkboxes[label]
+	if ok {
+		checkbox.SetChecked(value == "true")
+		return
+	}
+
+	tagField, ok := f.tagFields[label]
+	if ok {
+		tagField.SetOptions(f.dataProvider.GetDropDownOptions(label)).SetText(value)
+		return
+	}
+
+	autoField, ok := f.autoFields[label]
+	if ok {
+		autoField.SetOptions(f.dataProvider.GetDropDownOptions(label)).SetText(value)
+		return
 	}
-	f.Form.SetFocus(0)
 }
 
 // GetFields returns fields values as map of strings where the key is field label.
@@ -101,5 +147,17 @@ func (f *Form) GetFields() map[string]string {
 		_, res[label] = dropDown.GetCurrentOption()
 	}
 
+	for label, checkbox := range f.checkboxes {
+		res[label] = strconv.FormatBool(checkbox.IsChecked())
+	}
+
+	for label, tagField := range f.tagFields {
+		res[label] = tagField.GetText()
+	}
+
+	for label, autoField := range f.autoFields {
+		res[label] = autoField.GetText()
+	}
+
 	return res
 }
@@ -47,6 +47,12 @@ func (t *Table) SetOrder(orderCol string, reversed bool) *Table {
 	return t
 }
 
+// SetCols sets the columns of displayed table data.
+func (t *Table) SetCols(cols []string) *Table {
+	t.cols = cols
+	return t
+}
+
 // GetSelectedRef returns a reference map[string]string of current selected row.
 func (t *Table) GetSelectedRef() map[string]string {
 	row, _ := t.GetSelection()
@@ -0,0 +1,62 @@
+package ext
+
+import (
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// TagField is an input field for selecting multiple tags separated by comma. Existing tags matching
+// the last typed one are suggested, tags which don't exist yet can be typed as is.
+type TagField struct {
+	*tview.InputField
+
+	options []string
+}
+
+// NewTagField returns new TagField.
+func NewTagField() *TagField {
+	f := &TagField{InputField: tview.NewInputField()}
+	f.SetAutocompleteFunc(f.autocomplete)
+	return f
+}
+
+// SetLabel sets the text to be displayed before the input area.
+func (f *TagField) SetLabel(label string) *TagField {
+	f.InputField.SetLabel(label)
+	return f
+}
+
+// SetOptions sets existing tags to be suggested.
+func (f *TagField) SetOptions(options []string) *TagField {
+	f.options = options
+	return f
+}
+
+// autocomplete returns entries which complete the last typed tag with existing ones, tags which are
+// already selected aren't suggested.
+func (f *TagField) autocomplete(text string) []string {
+	i := strings.LastIndex(text, ",")
+	prefix, current := text[:i+1], strings.TrimSpace(text[i+1:])
+	if current == "" {
+		return nil
+	}
+
+	selected := make(map[string]bool)
+	for _, tag := range strings.Split(prefix, ",") {
+		selected[strings.TrimSpace(tag)] = true
+	}
+
+	if prefix != "" {
+		prefix += " "
+	}
+
+	res := make([]string, 0)
+	for _, opt := range f.options {
+		if strings.HasPrefix(opt, current) && opt != current && !selected[opt] {
+			res = append(res, prefix+opt)
+		}
+	}
+
+	return res
+}
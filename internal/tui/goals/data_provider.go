@@ -0,0 +1,115 @@
+package goals
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// DataProvider implements ext.TableDataProvider and ext.FromDataProvider for interaction with goals.
+type DataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+}
+
+// NewDataProvider returns new DataProvider.
+func NewDataProvider(service *service.Service, presenter *presenter.Presenter) *DataProvider {
+	return &DataProvider{service: service, presenter: presenter}
+}
+
+// GetAll returns slice of maps which represents goal struct with its progress as of today.
+func (d *DataProvider) GetAll() []map[string]string {
+	data := d.service.Goal().GetAll()
+	now := time.Now()
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		m, err := d.toProgressMap(e, now)
+		if err != nil {
+			m = d.presenter.Goal().ToMap(e)
+			m["Progress"] = "[red]" + err.Error() + "[white]"
+		}
+		res[i] = m
+	}
+
+	return res
+}
+
+// toProgressMap returns map which represents goal along with its progress as of given date.
+func (d *DataProvider) toProgressMap(g *model.Goal, date time.Time) (map[string]string, error) {
+	saved, err := d.service.Goal().Saved(g, date)
+	if err != nil {
+		return nil, err
+	}
+
+	monthly, err := d.service.Goal().MonthlyContribution(g, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.presenter.Goal().ToProgressMap(g, saved, monthly), nil
+}
+
+// GetDropDownOptions returns dropdown obtions for given label.
+func (d *DataProvider) GetDropDownOptions(label string) []string {
+	switch label {
+	case "Currency":
+		return d.currencyOptions()
+	case "Account":
+		return d.accountOptions()
+	case "Category":
+		return d.categoryOptions()
+	}
+	return nil
+}
+
+// currencyOptions returns currency dropdown options.
+func (d *DataProvider) currencyOptions() []string {
+	currencies := d.service.Currency().GetAll()
+
+	res := make([]string, len(currencies))
+
+	for i, e := range currencies {
+		res[i] = e.Abbreviation
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// accountOptions returns account dropdown options, archived accounts are omitted. Empty option
+// stands for goal which isn't linked to account.
+func (d *DataProvider) accountOptions() []string {
+	accounts := d.service.Account().GetActive()
+
+	res := make([]string, len(accounts)+1)
+
+	for i, e := range accounts {
+		res[i+1] = e.Name
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// categoryOptions returns category dropdown options. Empty option stands for goal which isn't
+// linked to category.
+func (d *DataProvider) categoryOptions() []string {
+	categories := d.service.Category().GetAll()
+
+	res := make([]string, len(categories)+1)
+
+	for i, e := range categories {
+		res[i+1] = d.service.Category().Path(e)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
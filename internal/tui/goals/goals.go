@@ -0,0 +1,271 @@
+package goals
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// View is a goals view.
+type View struct {
+	*tview.Pages
+
+	service      *service.Service
+	presenter    *presenter.Presenter
+	dataProvider *DataProvider
+
+	table       *ext.Table
+	createForm  *ext.Form
+	updateForm  *ext.Form
+	deleteModal *tview.Modal
+	errorModal  *tview.Modal
+}
+
+// New returns new goals view.
+func New(service *service.Service, presenter *presenter.Presenter) *View {
+	v := &View{
+		Pages: tview.NewPages(),
+
+		service:   service,
+		presenter: presenter,
+	}
+
+	v.dataProvider = NewDataProvider(v.service, v.presenter)
+
+	// table
+	cols := []string{"Name", "Target", "Saved", "Remaining", "Currency", "Deadline", "Monthly", "Progress"}
+	v.table = ext.NewTable(cols, v.dataProvider).SetOrder("Deadline", false)
+	v.table.SetTitle("Goals")
+	v.Refresh()
+	v.AddPage("table", v.table, true, true)
+
+	// create form
+	v.createForm = v.newForm("Create Goal", v.submitCreateForm, v.hideCreateForm, v.dataProvider)
+	v.AddPage("createForm", ext.WrapIntoModal(v.createForm, 40, 17), true, false)
+
+	// update form
+	v.updateForm = v.newForm("Update Goal", v.submitUpdateForm, v.hideUpdateForm, v.dataProvider)
+	v.AddPage("updateForm", ext.WrapIntoModal(v.updateForm, 40, 17), true, false)
+
+	// delete modal
+	v.deleteModal = ext.NewAskModal("Are you sure?", v.submitDeleteModal, v.hideDeleteModal)
+	v.AddPage("deleteModal", v.deleteModal, true, false)
+
+	// error modal
+	v.errorModal = ext.NewErrorModal(v.hideError)
+	v.AddPage("errorModal", v.errorModal, true, false)
+
+	return v
+}
+
+// Refresh refreshes goals along with their progress.
+func (v *View) Refresh() {
+	v.table.Refresh()
+}
+
+// ModalHasFocus returns true if any of modal is currently on focus.
+func (v *View) ModalHasFocus() bool {
+	for _, modal := range []tview.Primitive{v.createForm, v.updateForm, v.deleteModal, v.errorModal} {
+		if modal.HasFocus() {
+			return true
+		}
+	}
+	return false
+}
+
+// InputHandler returns the handler for this primitive.
+func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if v.table.HasFocus() {
+			switch event.Rune() {
+			case 'c':
+				v.showCreateForm()
+			case 'u':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showUpdateForm()
+				} else {
+					v.showError("Nothing to update")
+				}
+			case 'd':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showDeleteModal()
+				} else {
+					v.showError("Nothing to delete")
+				}
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.table.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
+		// give control to the child view.
+		for _, modal := range []tview.Primitive{v.createForm, v.updateForm, v.deleteModal, v.errorModal} {
+			if modal.HasFocus() {
+				if handler := modal.InputHandler(); handler != nil {
+					handler(event, setFocus)
+
+					return
+				}
+			}
+		}
+
+	})
+}
+
+// newForm returns new form with corresponding goal fields.
+func (v *View) newForm(title string, submit func(), cancel func(), dataProvider *DataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddInputField("Name", "", 0, nil, nil).
+		AddInputField("Target", "", 0, tview.InputFieldFloat, nil).
+		AddDropDown("Currency", nil, 0, nil).
+		AddFormItem(ext.NewDateField().SetLabel("Deadline")).
+		AddDropDown("Account", nil, 0, nil).
+		AddDropDown("Category", nil, 0, nil).
+		AddButton(strings.Split(title, " ")[0], submit).
+		AddButton("Cancel", cancel)
+
+	form.SetBorder(true)
+	form.SetTitle(title)
+	form.SetCancelFunc(cancel)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// showCreateForm shows create form with initialized empty fields, deadline is a year from now.
+func (v *View) showCreateForm() {
+	d := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+	m := map[string]string{"Name": "", "Target": "", "Currency": "", "Deadline": d, "Account": "", "Category": ""}
+	v.createForm.SetFields(m)
+	v.Pages.ShowPage("createForm")
+}
+
+// hideCreateForm hides create form.
+func (v *View) hideCreateForm() {
+	v.Pages.HidePage("createForm")
+}
+
+// isValidCreateForm checks if all necessary fields are filled.
+func (v *View) isValidCreateForm(m map[string]string) bool {
+	for _, label := range []string{"Name", "Target", "Currency", "Deadline"} {
+		if value, ok := m[label]; !ok || value == "" {
+			v.showError("Can't create goal without " + strings.ToLower(label) + ".")
+			return false
+		}
+	}
+
+	if (m["Account"] == "") == (m["Category"] == "") {
+		v.showError("Goal should be linked to either account or category.")
+		return false
+	}
+
+	return true
+}
+
+// submitCreateForm create form submit handler.
+func (v *View) submitCreateForm() {
+	m := v.createForm.GetFields()
+	if !v.isValidCreateForm(m) {
+		return
+	}
+
+	g, err := v.presenter.Goal().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Goal().Insert(g); err != nil {
+		v.showError("Error insert goal: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideCreateForm()
+}
+
+// showUpdateForm shows update form with initialized with selected goal fields.
+func (v *View) showUpdateForm() {
+	m := v.table.GetSelectedRef()
+	v.updateForm.SetFields(map[string]string{
+		"Name": m["Name"], "Target": m["Target"], "Currency": m["Currency"], "Deadline": m["Deadline"],
+		"Account": m["Account"], "Category": m["Category"]})
+	v.Pages.ShowPage("updateForm")
+}
+
+// hideUpdateForm hides update form.
+func (v *View) hideUpdateForm() {
+	v.Pages.HidePage("updateForm")
+}
+
+// submitUpdateForm update form submit handler.
+func (v *View) submitUpdateForm() {
+	m := v.updateForm.GetFields()
+	if !v.isValidCreateForm(m) {
+		return
+	}
+
+	ref := v.table.GetSelectedRef()
+	m["ID"] = ref["ID"]
+
+	g, err := v.presenter.Goal().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Goal().Update(g); err != nil {
+		v.showError("Error update goal: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideUpdateForm()
+}
+
+// showDeleteModal shows delete modal.
+func (v *View) showDeleteModal() {
+	v.Pages.ShowPage("deleteModal")
+}
+
+// hideDeleteModal hides delete modal.
+func (v *View) hideDeleteModal() {
+	v.Pages.HidePage("deleteModal")
+}
+
+// submitDeleteModal delete modal submit handler.
+func (v *View) submitDeleteModal() {
+	g, err := v.presenter.Goal().FromMap(v.table.GetSelectedRef())
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Goal().Delete(g); err != nil {
+		v.showError("Error delete goal: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideDeleteModal()
+}
+
+// showError shows error modal.
+func (v *View) showError(text string) {
+	v.errorModal.SetText(text)
+	v.Pages.ShowPage("errorModal")
+}
+
+// hideError hides error modal.
+func (v *View) hideError() {
+	v.Pages.HidePage("errorModal")
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/rivo/tview"
+)
+
+// Picker is a view for choosing profile to open, typing name of missing profile creates it.
+type Picker struct {
+	*tview.Pages
+
+	profiles Profiles
+	open     func(name string) error
+	cancel   func()
+
+	form       *ext.Form
+	errorModal *tview.Modal
+}
+
+// NewPicker returns new profile picker. Open is called with name of chosen profile, cancel is
+// called when picking is canceled.
+func NewPicker(profiles Profiles, open func(name string) error, cancel func()) *Picker {
+	p := &Picker{
+		Pages: tview.NewPages(),
+
+		profiles: profiles,
+		open:     open,
+		cancel:   cancel,
+	}
+
+	form := tview.NewForm().
+		AddFormItem(ext.NewAutocompleteField().SetLabel("Profile")).
+		AddButton("Open", p.submit).
+		AddButton("Cancel", cancel)
+
+	form.SetBorder(true)
+	form.SetTitle("Choose Profile")
+	form.SetCancelFunc(cancel)
+
+	p.form = ext.NewForm(form, NewProfileDataProvider(profiles))
+	p.AddPage("form", ext.WrapIntoModal(p.form, 40, 7), true, true)
+
+	p.errorModal = ext.NewErrorModal(p.hideError)
+	p.AddPage("errorModal", p.errorModal, true, false)
+
+	return p
+}
+
+// Show shows form initialized with name of open profile.
+func (p *Picker) Show() {
+	p.form.SetFields(map[string]string{"Profile": p.profiles.Current()})
+	p.SwitchToPage("form")
+}
+
+// submit form submit handler.
+func (p *Picker) submit() {
+	name := p.form.GetFields()["Profile"]
+	if name == "" {
+		p.showError("Can't open profile without name.")
+		return
+	}
+
+	if err := p.open(name); err != nil {
+		p.showError("Error open profile: \n" + err.Error())
+	}
+}
+
+// showError shows error modal.
+func (p *Picker) showError(text string) {
+	p.errorModal.SetText(text)
+	p.ShowPage("errorModal")
+}
+
+// hideError hides error modal.
+func (p *Picker) hideError() {
+	p.HidePage("errorModal")
+}
+
+// ProfileDataProvider implements ext.FromDataProvider for interaction with profiles.
+type ProfileDataProvider struct {
+	profiles Profiles
+}
+
+// NewProfileDataProvider returns new ProfileDataProvider.
+func NewProfileDataProvider(profiles Profiles) *ProfileDataProvider {
+	return &ProfileDataProvider{profiles: profiles}
+}
+
+// GetDropDownOptions returns names of existing profiles to suggest in profile field, profiles which
+// can't be listed are omitted.
+func (d *ProfileDataProvider) GetDropDownOptions(label string) []string {
+	if label != "Profile" {
+		return nil
+	}
+
+	res, err := d.profiles.List()
+	if err != nil {
+		return nil
+	}
+
+	return res
+}
@@ -0,0 +1,91 @@
+package recurrences
+
+import (
+	"sort"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// DataProvider implements ext.TableDataProvider and ext.FromDataProvider for interaction with recurrences.
+type DataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+}
+
+// NewDataProvider returns new DataProvider.
+func NewDataProvider(service *service.Service, presenter *presenter.Presenter) *DataProvider {
+	return &DataProvider{service: service, presenter: presenter}
+}
+
+// GetAll returns slice of maps which represents recurrence struct.
+func (d *DataProvider) GetAll() []map[string]string {
+	data := d.service.Recurrence().GetAll()
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = d.presenter.Recurrence().ToMap(e)
+	}
+
+	return res
+}
+
+// GetDropDownOptions returns dropdown obtions for given label.
+func (d *DataProvider) GetDropDownOptions(label string) []string {
+	switch label {
+	case "Rule":
+		return d.ruleOptions()
+	case "Account":
+		return d.accountOptions()
+	case "Category":
+		return d.categoryOptions()
+	}
+	return nil
+}
+
+// ruleOptions returns rule dropdown options.
+func (d *DataProvider) ruleOptions() []string {
+	rules := model.Rules()
+
+	res := make([]string, len(rules))
+
+	for i, e := range rules {
+		res[i] = string(e)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// accountOptions returns account dropdown options, archived accounts are omitted.
+func (d *DataProvider) accountOptions() []string {
+	accounts := d.service.Account().GetActive()
+
+	res := make([]string, len(accounts))
+
+	for i, e := range accounts {
+		res[i] = e.Name
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// categoryOptions returns category dropdown options.
+func (d *DataProvider) categoryOptions() []string {
+	categories := d.service.Category().GetAll()
+
+	res := make([]string, len(categories))
+
+	for i, e := range categories {
+		res[i] = d.service.Category().Path(e)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
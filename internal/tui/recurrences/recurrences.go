@@ -0,0 +1,291 @@
+package recurrences
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// View is a recurrences view.
+type View struct {
+	*tview.Pages
+
+	service   *service.Service
+	presenter *presenter.Presenter
+
+	table       *ext.Table
+	createForm  *ext.Form
+	updateForm  *ext.Form
+	deleteModal *tview.Modal
+	errorModal  *tview.Modal
+}
+
+// New returns new recurrences view.
+func New(service *service.Service, presenter *presenter.Presenter) *View {
+	v := &View{
+		Pages: tview.NewPages(),
+
+		service:   service,
+		presenter: presenter,
+	}
+
+	dataProvider := NewDataProvider(v.service, v.presenter)
+
+	// table
+	cols := []string{"Rule", "Start", "End", "Next", "Account", "Category", "Amount", "Note", "Paused"}
+	v.table = ext.NewTable(cols, dataProvider).SetOrder("Next", false)
+	v.table.SetTitle("Recurring")
+	v.table.Refresh()
+	v.AddPage("table", v.table, true, true)
+
+	// create form
+	v.createForm = v.newForm("Create Recurrence", v.submitCreateForm, v.hideCreateForm, dataProvider)
+	v.AddPage("createForm", ext.WrapIntoModal(v.createForm, 40, 19), true, false)
+
+	// update form
+	v.updateForm = v.newForm("Update Recurrence", v.submitUpdateForm, v.hideUpdateForm, dataProvider)
+	v.AddPage("updateForm", ext.WrapIntoModal(v.updateForm, 40, 19), true, false)
+
+	// delete modal
+	v.deleteModal = ext.NewAskModal("Are you sure?", v.submitDeleteModal, v.hideDeleteModal)
+	v.AddPage("deleteModal", v.deleteModal, true, false)
+
+	// error modal
+	v.errorModal = ext.NewErrorModal(v.hideError)
+	v.AddPage("errorModal", v.errorModal, true, false)
+
+	return v
+}
+
+// Refresh refreshes recurrences table.
+func (v *View) Refresh() {
+	v.table.Refresh()
+}
+
+// ModalHasFocus returns true if any of modal is currently on focus.
+func (v *View) ModalHasFocus() bool {
+	for _, modal := range []tview.Primitive{v.createForm, v.updateForm, v.deleteModal, v.errorModal} {
+		if modal.HasFocus() {
+			return true
+		}
+	}
+	return false
+}
+
+// InputHandler returns the handler for this primitive.
+func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if v.table.HasFocus() {
+			switch event.Rune() {
+			case 'c':
+				v.showCreateForm()
+			case 'u':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showUpdateForm()
+				} else {
+					v.showError("Nothing to update")
+				}
+			case 'd':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showDeleteModal()
+				} else {
+					v.showError("Nothing to delete")
+				}
+			case 'p':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.togglePaused()
+				} else {
+					v.showError("Nothing to pause")
+				}
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.table.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
+		// give control to the child view.
+		for _, modal := range []tview.Primitive{v.createForm, v.updateForm, v.deleteModal, v.errorModal} {
+			if modal.HasFocus() {
+				if handler := modal.InputHandler(); handler != nil {
+					handler(event, setFocus)
+
+					return
+				}
+			}
+		}
+
+	})
+}
+
+// newForm returns new form with corresponding recurrence fields.
+func (v *View) newForm(title string, submit func(), cancel func(), dataProvider *DataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddDropDown("Rule", nil, 0, nil).
+		AddFormItem(ext.NewDateField().SetLabel("Start")).
+		AddInputField("End", "", 0, nil, nil).
+		AddDropDown("Account", nil, 0, nil).
+		AddDropDown("Category", nil, 0, nil).
+		AddInputField("Amount", "", 0, tview.InputFieldFloat, nil).
+		AddInputField("Note", "", 0, nil, nil).
+		AddButton(strings.Split(title, " ")[0], submit).
+		AddButton("Cancel", cancel)
+
+	form.SetBorder(true)
+	form.SetTitle(title)
+	form.SetCancelFunc(cancel)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// showCreateForm shows create form with initialized empty fields.
+func (v *View) showCreateForm() {
+	d := time.Now().Format("2006-01-02")
+	m := map[string]string{
+		"Rule": "", "Start": d, "End": "", "Account": "", "Category": "", "Amount": "", "Note": ""}
+	v.createForm.SetFields(m)
+	v.Pages.ShowPage("createForm")
+}
+
+// hideCreateForm hides create form.
+func (v *View) hideCreateForm() {
+	v.Pages.HidePage("createForm")
+}
+
+// isValidCreateForm checks if all necessary fields are filled.
+func (v *View) isValidCreateForm(m map[string]string) bool {
+	for _, label := range []string{"Rule", "Start", "Account", "Category", "Amount"} {
+		if value, ok := m[label]; !ok || value == "" {
+			v.showError("Can't create recurrence without " + strings.ToLower(label) + ".")
+			return false
+		}
+	}
+
+	return true
+}
+
+// submitCreateForm create form submit handler.
+func (v *View) submitCreateForm() {
+	m := v.createForm.GetFields()
+	if !v.isValidCreateForm(m) {
+		return
+	}
+
+	r, err := v.presenter.Recurrence().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Recurrence().Insert(r); err != nil {
+		v.showError("Error insert recurrence: \n" + err.Error())
+		return
+	}
+
+	v.table.Refresh()
+	v.hideCreateForm()
+}
+
+// showUpdateForm shows update form with initialized with selected recurrence fields.
+func (v *View) showUpdateForm() {
+	m := v.table.GetSelectedRef()
+	v.updateForm.SetFields(map[string]string{
+		"Rule": m["Rule"], "Start": m["Start"], "End": m["End"], "Account": m["Account"],
+		"Category": m["Category"], "Amount": m["Amount"], "Note": m["Note"]})
+	v.Pages.ShowPage("updateForm")
+}
+
+// hideUpdateForm hides update form.
+func (v *View) hideUpdateForm() {
+	v.Pages.HidePage("updateForm")
+}
+
+// submitUpdateForm update form submit handler. Next occurrence and pause state are kept.
+func (v *View) submitUpdateForm() {
+	m := v.updateForm.GetFields()
+	if !v.isValidCreateForm(m) {
+		return
+	}
+
+	ref := v.table.GetSelectedRef()
+	m["ID"], m["Next"], m["Paused"] = ref["ID"], ref["Next"], ref["Paused"]
+
+	r, err := v.presenter.Recurrence().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Recurrence().Update(r); err != nil {
+		v.showError("Error update recurrence: \n" + err.Error())
+		return
+	}
+
+	v.table.Refresh()
+	v.hideUpdateForm()
+}
+
+// showDeleteModal shows delete modal.
+func (v *View) showDeleteModal() {
+	v.Pages.ShowPage("deleteModal")
+}
+
+// hideDeleteModal hides delete modal.
+func (v *View) hideDeleteModal() {
+	v.Pages.HidePage("deleteModal")
+}
+
+// submitDeleteModal delete modal submit handler.
+func (v *View) submitDeleteModal() {
+	ref := v.table.GetSelectedRef()
+	r, err := v.presenter.Recurrence().FromMap(ref)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Recurrence().Delete(r); err != nil {
+		v.showError("Error delete recurrence: \n" + err.Error())
+		return
+	}
+
+	v.table.Refresh()
+	v.hideDeleteModal()
+}
+
+// togglePaused pauses selected recurrence if it is active and resumes it otherwise.
+func (v *View) togglePaused() {
+	ref := v.table.GetSelectedRef()
+	r, err := v.presenter.Recurrence().FromMap(ref)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Recurrence().SetPaused(r, !r.Paused, time.Now()); err != nil {
+		v.showError("Error pause recurrence: \n" + err.Error())
+		return
+	}
+
+	v.table.Refresh()
+}
+
+// showError shows error modal.
+func (v *View) showError(text string) {
+	v.errorModal.SetText(text)
+	v.Pages.ShowPage("errorModal")
+}
+
+// hideError hides error modal.
+func (v *View) hideError() {
+	v.Pages.HidePage("errorModal")
+}
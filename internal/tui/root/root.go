@@ -36,8 +36,8 @@ func New(app *tview.Application, service *service.Service, presenter *presenter.
 	root.AddItem(root.navbar, 1, 1, false)
 	root.AddItem(root.pages, 0, 16, true)
 
-	root.transactions = transactions.New(service, presenter)
-	root.settings = settings.New(app, service, presenter)
+	root.transactions = transactions.New(service, presenter, false)
+	root.settings = settings.New(app, service, presenter, false)
 	root.AddView('1', "Transactions", root.transactions)
 	root.AddView('2', "Settings", root.settings)
 
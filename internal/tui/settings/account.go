@@ -3,6 +3,8 @@ package settings
 import (
 	"strings"
 
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
 	"github.com/kotlw/gentlemoney/internal/tui/ext"
 
 	"github.com/rivo/tview"
@@ -13,6 +15,11 @@ func (v *View) newAccountForm(title string, submit func(), cancel func(), dataPr
 	form := tview.NewForm().
 		AddInputField("Name", "", 0, nil, nil).
 		AddDropDown("Currency", nil, 0, nil).
+		AddDropDown("Type", nil, 0, nil).
+		AddInputField("Opening Balance", "", 0, tview.InputFieldFloat, nil).
+		AddInputField("Opening Date", "", 0, nil, nil).
+		AddInputField("Credit Limit", "", 0, tview.InputFieldFloat, nil).
+		AddCheckbox("Archived", false, nil).
 		AddButton(strings.Split(title, " ")[0], submit).
 		AddButton("Cancel", cancel)
 
@@ -25,7 +32,9 @@ func (v *View) newAccountForm(title string, submit func(), cancel func(), dataPr
 
 // showAccountCreateForm shows account create form with initialized empty fields.
 func (v *View) showAccountCreateForm() {
-	v.accountCreateForm.SetFields(map[string]string{"Name": "", "Currency": ""})
+	v.accountCreateForm.SetFields(map[string]string{
+		"Name": "", "Currency": "", "Type": string(model.Checking), "Opening Balance": "", "Opening Date": "",
+		"Credit Limit": "", "Archived": "false"})
 	v.Pages.ShowPage("accountCreateForm")
 }
 
@@ -112,19 +121,27 @@ func (v *View) submitAccountUpdateForm() {
 	v.hideAccountCreateForm()
 }
 
-// showAccountDeleteModal shows delete modal.
-func (v *View) showAccountDeleteModal() {
-	v.Pages.ShowPage("accountDeleteModal")
+// showAccountDeleteForm shows delete form which refuses to delete account in use by default.
+func (v *View) showAccountDeleteForm() {
+	resetDeleteForm(v.accountDeleteForm)
+	v.Pages.ShowPage("accountDeleteForm")
 }
 
-// hideAccountDeleteModal hides delete modal.
-func (v *View) hideAccountDeleteModal() {
-	v.Pages.HidePage("accountDeleteModal")
+// hideAccountDeleteForm hides delete form.
+func (v *View) hideAccountDeleteForm() {
+	v.Pages.HidePage("accountDeleteForm")
 	v.tuiApp.SetFocus(v.accountTable)
 }
 
-// submitAccountDeleteModaldelete modal submit handler.
-func (v *View) submitAccountDeleteModal() {
+// submitAccountDeleteForm delete form submit handler, the data which refers to account is treated according
+// to selected action.
+func (v *View) submitAccountDeleteForm() {
+	m := v.accountDeleteForm.GetFields()
+	policy := service.DeletePolicy[model.Account]{Action: deleteActions[m["If in use"]]}
+	if policy.Action == service.Reassign {
+		policy.Target = v.service.Account().GetByName(m["Reassign to"])
+	}
+
 	ref := v.accountTable.GetSelectedRef()
 	c, err := v.presenter.Account().FromMap(ref)
 	if err != nil {
@@ -132,11 +149,11 @@ func (v *View) submitAccountDeleteModal() {
 		return
 	}
 
-	if err := v.service.Account().Delete(c); err != nil {
-		v.showError("Error update transaction: \n" + err.Error())
+	if err := v.service.Account().Delete(c, policy); err != nil {
+		v.showError("Error delete account: \n" + err.Error())
 		return
 	}
 
 	v.accountTable.Refresh()
-	v.hideAccountDeleteModal()
+	v.hideAccountDeleteForm()
 }
@@ -3,6 +3,8 @@ package settings
 import (
 	"strings"
 
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
 	"github.com/kotlw/gentlemoney/internal/tui/ext"
 
 	"github.com/rivo/tview"
@@ -12,6 +14,8 @@ import (
 func (v *View) newCategoryForm(title string, submit func(), cancel func(), dataProvider *CategoryDataProvider) *ext.Form {
 	form := tview.NewForm().
 		AddInputField("Title", "", 0, nil, nil).
+		AddDropDown("Parent", nil, 0, nil).
+		AddDropDown("Kind", nil, 0, nil).
 		AddButton(strings.Split(title, " ")[0], submit).
 		AddButton("Cancel", cancel)
 
@@ -24,7 +28,7 @@ func (v *View) newCategoryForm(title string, submit func(), cancel func(), dataP
 
 // showCategoryCreateForm shows category create form with initialized empty fields.
 func (v *View) showCategoryCreateForm() {
-	v.categoryCreateForm.SetFields(map[string]string{"Title": ""})
+	v.categoryCreateForm.SetFields(map[string]string{"Title": "", "Parent": "", "Kind": string(model.Both)})
 	v.Pages.ShowPage("categoryCreateForm")
 }
 
@@ -105,19 +109,27 @@ func (v *View) submitCategoryUpdateForm() {
 	v.hideCategoryCreateForm()
 }
 
-// showCategoryDeleteModal shows delete modal.
-func (v *View) showCategoryDeleteModal() {
-	v.Pages.ShowPage("categoryDeleteModal")
+// showCategoryDeleteForm shows delete form which refuses to delete category in use by default.
+func (v *View) showCategoryDeleteForm() {
+	resetDeleteForm(v.categoryDeleteForm)
+	v.Pages.ShowPage("categoryDeleteForm")
 }
 
-// hideCategoryDeleteModal hides delete modal.
-func (v *View) hideCategoryDeleteModal() {
-	v.Pages.HidePage("categoryDeleteModal")
+// hideCategoryDeleteForm hides delete form.
+func (v *View) hideCategoryDeleteForm() {
+	v.Pages.HidePage("categoryDeleteForm")
 	v.tuiApp.SetFocus(v.categoryTable)
 }
 
-// submitCategoryDeleteModaldelete modal submit handler.
-func (v *View) submitCategoryDeleteModal() {
+// submitCategoryDeleteForm delete form submit handler, the data which refers to category is treated according
+// to selected action.
+func (v *View) submitCategoryDeleteForm() {
+	m := v.categoryDeleteForm.GetFields()
+	policy := service.DeletePolicy[model.Category]{Action: deleteActions[m["If in use"]]}
+	if policy.Action == service.Reassign {
+		policy.Target = v.service.Category().GetByPath(m["Reassign to"])
+	}
+
 	ref := v.categoryTable.GetSelectedRef()
 	c, err := v.presenter.Category().FromMap(ref)
 	if err != nil {
@@ -125,11 +137,11 @@ func (v *View) submitCategoryDeleteModal() {
 		return
 	}
 
-	if err := v.service.Category().Delete(c); err != nil {
-		v.showError("Error update transaction: \n" + err.Error())
+	if err := v.service.Category().Delete(c, policy); err != nil {
+		v.showError("Error delete category: \n" + err.Error())
 		return
 	}
 
 	v.categoryTable.Refresh()
-	v.hideCategoryDeleteModal()
+	v.hideCategoryDeleteForm()
 }
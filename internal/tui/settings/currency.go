@@ -1,8 +1,11 @@
 package settings
 
 import (
+	"strconv"
 	"strings"
 
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/service"
 	"github.com/kotlw/gentlemoney/internal/tui/ext"
 
 	"github.com/rivo/tview"
@@ -12,6 +15,8 @@ import (
 func (v *View) newCurrencyForm(title string, submit func(), cancel func(), dataProvider *CurrencyDataProvider) *ext.Form {
 	form := tview.NewForm().
 		AddInputField("Abbreviation", "", 0, nil, nil).
+		AddCheckbox("Main", false, nil).
+		AddDropDown("Precision", nil, 0, nil).
 		AddButton(strings.Split(title, " ")[0], submit).
 		AddButton("Cancel", cancel)
 
@@ -24,7 +29,7 @@ func (v *View) newCurrencyForm(title string, submit func(), cancel func(), dataP
 
 // showCurrencyCreateForm shows currency create form with initialized empty fields.
 func (v *View) showCurrencyCreateForm() {
-	v.currencyCreateForm.SetFields(map[string]string{"Abbreviation": ""})
+	v.currencyCreateForm.SetFields(map[string]string{"Abbreviation": "", "Main": "false", "Precision": strconv.Itoa(model.DefaultPrecision)})
 	v.Pages.ShowPage("currencyCreateForm")
 }
 
@@ -105,19 +110,27 @@ func (v *View) submitCurrencyUpdateForm() {
 	v.hideCurrencyCreateForm()
 }
 
-// showCurrencyDeleteModal shows delete modal.
-func (v *View) showCurrencyDeleteModal() {
-	v.Pages.ShowPage("currencyDeleteModal")
+// showCurrencyDeleteForm shows delete form which refuses to delete currency in use by default.
+func (v *View) showCurrencyDeleteForm() {
+	resetDeleteForm(v.currencyDeleteForm)
+	v.Pages.ShowPage("currencyDeleteForm")
 }
 
-// hideCurrencyDeleteModal hides delete modal.
-func (v *View) hideCurrencyDeleteModal() {
-	v.Pages.HidePage("currencyDeleteModal")
+// hideCurrencyDeleteForm hides delete form.
+func (v *View) hideCurrencyDeleteForm() {
+	v.Pages.HidePage("currencyDeleteForm")
 	v.tuiApp.SetFocus(v.currencyTable)
 }
 
-// submitCurrencyDeleteModaldelete modal submit handler.
-func (v *View) submitCurrencyDeleteModal() {
+// submitCurrencyDeleteForm delete form submit handler, the data which refers to currency is treated according
+// to selected action.
+func (v *View) submitCurrencyDeleteForm() {
+	m := v.currencyDeleteForm.GetFields()
+	policy := service.DeletePolicy[model.Currency]{Action: deleteActions[m["If in use"]]}
+	if policy.Action == service.Reassign {
+		policy.Target = v.service.Currency().GetByAbbreviation(m["Reassign to"])
+	}
+
 	ref := v.currencyTable.GetSelectedRef()
 	c, err := v.presenter.Currency().FromMap(ref)
 	if err != nil {
@@ -125,11 +138,11 @@ func (v *View) submitCurrencyDeleteModal() {
 		return
 	}
 
-	if err := v.service.Currency().Delete(c); err != nil {
-		v.showError("Error update transaction: \n" + err.Error())
+	if err := v.service.Currency().Delete(c, policy); err != nil {
+		v.showError("Error delete currency: \n" + err.Error())
 		return
 	}
 
 	v.currencyTable.Refresh()
-	v.hideCurrencyDeleteModal()
+	v.hideCurrencyDeleteForm()
 }
@@ -2,7 +2,10 @@ package settings
 
 import (
 	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/kotlw/gentlemoney/internal/model"
 	"github.com/kotlw/gentlemoney/internal/presenter"
 	"github.com/kotlw/gentlemoney/internal/service"
 )
@@ -18,7 +21,9 @@ func NewCategoryDataProvider(service *service.Service, presenter *presenter.Pres
 	return &CategoryDataProvider{service: service, presenter: presenter}
 }
 
-// GetAll returns slice of maps which represents category struct.
+// GetAll returns slice of maps which represents category struct. Categories are rendered as a
+// tree, where "Category" is an indented title and "Path" is used to order subcategories right after
+// their parents.
 func (d *CategoryDataProvider) GetAll() []map[string]string {
 	data := d.service.Category().GetAll()
 
@@ -26,6 +31,8 @@ func (d *CategoryDataProvider) GetAll() []map[string]string {
 
 	for i, e := range data {
 		res[i] = d.presenter.Category().ToMap(e)
+		res[i]["Path"] = d.service.Category().Path(e)
+		res[i]["Category"] = d.treeTitle(e)
 	}
 
 	return res
@@ -33,9 +40,52 @@ func (d *CategoryDataProvider) GetAll() []map[string]string {
 
 // GetDropDownOptions returns dropdown obtions for given label.
 func (d *CategoryDataProvider) GetDropDownOptions(label string) []string {
+	switch label {
+	case "Parent", "Reassign to":
+		return d.parentOptions()
+	case "If in use":
+		return deleteActionOptions()
+	case "Kind":
+		kinds := model.CategoryKinds()
+		res := make([]string, len(kinds))
+		for i, e := range kinds {
+			res[i] = string(e)
+		}
+		sort.Strings(res)
+		return res
+	}
 	return nil
 }
 
+// parentOptions returns parent dropdown options, empty option stands for top level category.
+func (d *CategoryDataProvider) parentOptions() []string {
+	categories := d.service.Category().GetAll()
+
+	res := make([]string, len(categories)+1)
+
+	for i, e := range categories {
+		res[i+1] = d.service.Category().Path(e)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// treeTitle returns title of category indented according to its depth.
+func (d *CategoryDataProvider) treeTitle(c *model.Category) string {
+	if c.Parent == nil {
+		return c.Title
+	}
+
+	depth := 0
+	for p := c.Parent; p != nil; p = p.Parent {
+		depth++
+	}
+
+	return strings.Repeat("   ", depth-1) + " └─ " + c.Title
+}
+
 // CurrencyDataProvider implements ext.TableDataProvider for interaction with currencies.
 type CurrencyDataProvider struct {
 	service   *service.Service
@@ -62,9 +112,36 @@ func (d *CurrencyDataProvider) GetAll() []map[string]string {
 
 // GetDropDownOptions returns dropdown obtions for given label.
 func (d *CurrencyDataProvider) GetDropDownOptions(label string) []string {
+	switch label {
+	case "Precision":
+		res := make([]string, model.MaxPrecision+1)
+		for i := range res {
+			res[i] = strconv.Itoa(i)
+		}
+		return res
+	case "If in use":
+		return deleteActionOptions()
+	case "Reassign to":
+		return d.currencyOptions()
+	}
 	return nil
 }
 
+// currencyOptions returns currency dropdown options.
+func (d *CurrencyDataProvider) currencyOptions() []string {
+	currencies := d.service.Currency().GetAll()
+
+	res := make([]string, len(currencies))
+
+	for i, e := range currencies {
+		res[i] = e.Abbreviation
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
 // AccountDataProvider implements ext.TableDataProvider and ext.FromDataProvider for interaction with accounts.
 type AccountDataProvider struct {
 	service   *service.Service
@@ -76,7 +153,8 @@ func NewAccountDataProvider(service *service.Service, presenter *presenter.Prese
 	return &AccountDataProvider{service: service, presenter: presenter}
 }
 
-// GetAll returns slice of maps which represents account struct.
+// GetAll returns slice of maps which represents account struct along with its current balance,
+// balance which can't be calculated is replaced with the error.
 func (d *AccountDataProvider) GetAll() []map[string]string {
 	data := d.service.Account().GetAll()
 
@@ -84,6 +162,13 @@ func (d *AccountDataProvider) GetAll() []map[string]string {
 
 	for i, e := range data {
 		res[i] = d.presenter.Account().ToMap(e)
+
+		balance, err := d.service.Transaction().Balance(e)
+		if err != nil {
+			res[i]["Balance"] = "[red]" + err.Error() + "[white]"
+			continue
+		}
+		res[i]["Balance"] = d.presenter.Account().ReprBalance(e, balance)
 	}
 
 	return res
@@ -94,10 +179,46 @@ func (d *AccountDataProvider) GetDropDownOptions(label string) []string {
 	switch label {
 	case "Currency":
 		return d.currencyOptions()
+	case "Type":
+		return d.typeOptions()
+	case "If in use":
+		return deleteActionOptions()
+	case "Reassign to":
+		return d.accountOptions()
 	}
 	return nil
 }
 
+// accountOptions returns account dropdown options.
+func (d *AccountDataProvider) accountOptions() []string {
+	accounts := d.service.Account().GetAll()
+
+	res := make([]string, len(accounts))
+
+	for i, e := range accounts {
+		res[i] = e.Name
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// typeOptions returns account type dropdown options.
+func (d *AccountDataProvider) typeOptions() []string {
+	types := model.AccountTypes()
+
+	res := make([]string, len(types))
+
+	for i, e := range types {
+		res[i] = string(e)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
 // currencyOptions returns currency dropdown options.
 func (d *AccountDataProvider) currencyOptions() []string {
 	currencies := d.service.Currency().GetAll()
@@ -112,3 +233,101 @@ func (d *AccountDataProvider) currencyOptions() []string {
 
 	return res
 }
+
+// PayeeDataProvider implements ext.TableDataProvider and ext.FromDataProvider for interaction with payees.
+type PayeeDataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+}
+
+// NewPayeeDataProvider returns new PayeeDataProvider.
+func NewPayeeDataProvider(service *service.Service, presenter *presenter.Presenter) *PayeeDataProvider {
+	return &PayeeDataProvider{service: service, presenter: presenter}
+}
+
+// GetAll returns slice of maps which represents payee struct.
+func (d *PayeeDataProvider) GetAll() []map[string]string {
+	data := d.service.Payee().GetAll()
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = d.presenter.Payee().ToMap(e)
+	}
+
+	return res
+}
+
+// GetDropDownOptions returns dropdown obtions for given label.
+func (d *PayeeDataProvider) GetDropDownOptions(label string) []string {
+	switch label {
+	case "Category":
+		return d.categoryOptions()
+	}
+	return nil
+}
+
+// categoryOptions returns default category dropdown options, empty option stands for payee without
+// default category.
+func (d *PayeeDataProvider) categoryOptions() []string {
+	categories := d.service.Category().GetAll()
+
+	res := make([]string, len(categories)+1)
+
+	for i, e := range categories {
+		res[i+1] = d.service.Category().Path(e)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// ExchangeRateDataProvider implements ext.TableDataProvider and ext.FromDataProvider for interaction with
+// exchange rates.
+type ExchangeRateDataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+}
+
+// NewExchangeRateDataProvider returns new ExchangeRateDataProvider.
+func NewExchangeRateDataProvider(service *service.Service, presenter *presenter.Presenter) *ExchangeRateDataProvider {
+	return &ExchangeRateDataProvider{service: service, presenter: presenter}
+}
+
+// GetAll returns slice of maps which represents exchange rate struct.
+func (d *ExchangeRateDataProvider) GetAll() []map[string]string {
+	data := d.service.ExchangeRate().GetAll()
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = d.presenter.ExchangeRate().ToMap(e)
+	}
+
+	return res
+}
+
+// GetDropDownOptions returns dropdown obtions for given label.
+func (d *ExchangeRateDataProvider) GetDropDownOptions(label string) []string {
+	switch label {
+	case "From", "To":
+		return d.currencyOptions()
+	}
+	return nil
+}
+
+// currencyOptions returns currency dropdown options.
+func (d *ExchangeRateDataProvider) currencyOptions() []string {
+	currencies := d.service.Currency().GetAll()
+
+	res := make([]string, len(currencies))
+
+	for i, e := range currencies {
+		res[i] = e.Abbreviation
+	}
+
+	sort.Strings(res)
+
+	return res
+}
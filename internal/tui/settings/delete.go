@@ -0,0 +1,50 @@
+package settings
+
+import (
+	"sort"
+
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/rivo/tview"
+)
+
+// deleteActions maps options of "If in use" dropdown of delete form to delete actions.
+var deleteActions = map[string]service.DeleteAction{
+	"Cascade":  service.Cascade,
+	"Reassign": service.Reassign,
+	"Refuse":   service.Refuse,
+}
+
+// deleteActionOptions returns options of "If in use" dropdown of delete form.
+func deleteActionOptions() []string {
+	res := make([]string, 0, len(deleteActions))
+	for option := range deleteActions {
+		res = append(res, option)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// newDeleteForm returns new form which asks what to do with the data which refers to deleted item:
+// refuse to delete item, delete the data along with it, or reassign the data to another item.
+func (v *View) newDeleteForm(title string, submit func(), cancel func(), dataProvider ext.FormDataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddDropDown("If in use", nil, 0, nil).
+		AddDropDown("Reassign to", nil, 0, nil).
+		AddButton("Delete", submit).
+		AddButton("Cancel", cancel)
+
+	form.SetBorder(true)
+	form.SetTitle(title)
+	form.SetCancelFunc(cancel)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// resetDeleteForm sets delete form to refuse deletion of item which is in use.
+func resetDeleteForm(form *ext.Form) {
+	form.SetFields(map[string]string{"If in use": "Refuse", "Reassign to": ""})
+}
@@ -0,0 +1,141 @@
+package settings
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/rivo/tview"
+)
+
+// newForm returns new form with corresponding exchange rate fields.
+func (v *View) newExchangeRateForm(title string, submit func(), cancel func(), dataProvider *ExchangeRateDataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddFormItem(ext.NewDateField().SetLabel("Date")).
+		AddDropDown("From", nil, 0, nil).
+		AddDropDown("To", nil, 0, nil).
+		AddInputField("Rate", "", 0, tview.InputFieldFloat, nil).
+		AddButton(strings.Split(title, " ")[0], submit).
+		AddButton("Cancel", cancel)
+
+	form.SetBorder(true)
+	form.SetTitle(title)
+	form.SetCancelFunc(cancel)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// showExchangeRateCreateForm shows exchange rate create form with initialized empty fields.
+func (v *View) showExchangeRateCreateForm() {
+	d := time.Now().Format("2006-01-02")
+	v.exchangeRateCreateForm.SetFields(map[string]string{"Date": d, "From": "", "To": "", "Rate": ""})
+	v.Pages.ShowPage("exchangeRateCreateForm")
+}
+
+// hideExchangeRateCreateForm hides exchange rate create form.
+func (v *View) hideExchangeRateCreateForm() {
+	v.Pages.HidePage("exchangeRateCreateForm")
+	v.tuiApp.SetFocus(v.exchangeRateTable)
+}
+
+// isValidCreateForm checks if all necessary fields are filled.
+func (v *View) isValidExchangeRateCreateForm(m map[string]string) bool {
+	for _, label := range []string{"From", "To", "Rate"} {
+		if value, ok := m[label]; !ok || value == "" {
+			v.showError("Can't create exchange rate without " + strings.ToLower(label) + ".")
+			return false
+		}
+	}
+
+	return true
+}
+
+// submitExchangeRateCreateForm exchange rate create form submit handler.
+func (v *View) submitExchangeRateCreateForm() {
+	m := v.exchangeRateCreateForm.GetFields()
+	if !v.isValidExchangeRateCreateForm(m) {
+		return
+	}
+
+	r, err := v.presenter.ExchangeRate().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.ExchangeRate().Insert(r); err != nil {
+		v.showError("Error insert exchange rate: \n" + err.Error())
+		return
+	}
+
+	v.exchangeRateTable.Refresh()
+	v.hideExchangeRateCreateForm()
+}
+
+// showExchangeRateUpdateForm shows update form with initialized with selected exchange rate fields.
+func (v *View) showExchangeRateUpdateForm() {
+	m := v.exchangeRateTable.GetSelectedRef()
+	v.exchangeRateUpdateForm.SetFields(m)
+	v.Pages.ShowPage("exchangeRateUpdateForm")
+}
+
+// hideExchangeRateUpdateForm hides update form.
+func (v *View) hideExchangeRateUpdateForm() {
+	v.Pages.HidePage("exchangeRateUpdateForm")
+	v.tuiApp.SetFocus(v.exchangeRateTable)
+}
+
+// submitExchangeRateUpdateForm update form submit handler.
+func (v *View) submitExchangeRateUpdateForm() {
+	m := v.exchangeRateUpdateForm.GetFields()
+	if !v.isValidExchangeRateCreateForm(m) {
+		return
+	}
+
+	ref := v.exchangeRateTable.GetSelectedRef()
+	m["ID"] = ref["ID"]
+
+	r, err := v.presenter.ExchangeRate().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.ExchangeRate().Update(r); err != nil {
+		v.showError("Error update exchange rate: \n" + err.Error())
+		return
+	}
+
+	v.exchangeRateTable.Refresh()
+	v.hideExchangeRateUpdateForm()
+}
+
+// showExchangeRateDeleteModal shows delete modal.
+func (v *View) showExchangeRateDeleteModal() {
+	v.Pages.ShowPage("exchangeRateDeleteModal")
+}
+
+// hideExchangeRateDeleteModal hides delete modal.
+func (v *View) hideExchangeRateDeleteModal() {
+	v.Pages.HidePage("exchangeRateDeleteModal")
+	v.tuiApp.SetFocus(v.exchangeRateTable)
+}
+
+// submitExchangeRateDeleteModal delete modal submit handler.
+func (v *View) submitExchangeRateDeleteModal() {
+	ref := v.exchangeRateTable.GetSelectedRef()
+	r, err := v.presenter.ExchangeRate().FromMap(ref)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.ExchangeRate().Delete(r); err != nil {
+		v.showError("Error delete exchange rate: \n" + err.Error())
+		return
+	}
+
+	v.exchangeRateTable.Refresh()
+	v.hideExchangeRateDeleteModal()
+}
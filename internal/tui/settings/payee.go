@@ -0,0 +1,136 @@
+package settings
+
+import (
+	"strings"
+
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/rivo/tview"
+)
+
+// newPayeeForm returns new form with corresponding payee fields.
+func (v *View) newPayeeForm(title string, submit func(), cancel func(), dataProvider *PayeeDataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddInputField("Name", "", 0, nil, nil).
+		AddDropDown("Category", nil, 0, nil).
+		AddButton(strings.Split(title, " ")[0], submit).
+		AddButton("Cancel", cancel)
+
+	form.SetBorder(true)
+	form.SetTitle(title)
+	form.SetCancelFunc(cancel)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// showPayeeCreateForm shows payee create form with initialized empty fields.
+func (v *View) showPayeeCreateForm() {
+	v.payeeCreateForm.SetFields(map[string]string{"Name": "", "Category": ""})
+	v.Pages.ShowPage("payeeCreateForm")
+}
+
+// hidePayeeCreateForm hides payee create form.
+func (v *View) hidePayeeCreateForm() {
+	v.Pages.HidePage("payeeCreateForm")
+	v.tuiApp.SetFocus(v.payeeTable)
+}
+
+// isValidPayeeCreateForm checks if all necessary fields are filled.
+func (v *View) isValidPayeeCreateForm(m map[string]string) bool {
+	value, ok := m["Name"]
+	if !ok || value == "" {
+		v.showError("Can't create payee without name.")
+		return false
+	}
+
+	return true
+}
+
+// submitPayeeCreateForm payee create form submit handler.
+func (v *View) submitPayeeCreateForm() {
+	m := v.payeeCreateForm.GetFields()
+	if !v.isValidPayeeCreateForm(m) {
+		return
+	}
+
+	p, err := v.presenter.Payee().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Payee().Insert(p); err != nil {
+		v.showError("Error insert payee: \n" + err.Error())
+		return
+	}
+
+	v.payeeTable.Refresh()
+	v.hidePayeeCreateForm()
+}
+
+// showPayeeUpdateForm shows update form initialized with selected payee fields.
+func (v *View) showPayeeUpdateForm() {
+	m := v.payeeTable.GetSelectedRef()
+	v.payeeUpdateForm.SetFields(m)
+	v.Pages.ShowPage("payeeUpdateForm")
+}
+
+// hidePayeeUpdateForm hides update form.
+func (v *View) hidePayeeUpdateForm() {
+	v.Pages.HidePage("payeeUpdateForm")
+	v.tuiApp.SetFocus(v.payeeTable)
+}
+
+// submitPayeeUpdateForm update form submit handler.
+func (v *View) submitPayeeUpdateForm() {
+	m := v.payeeUpdateForm.GetFields()
+	if !v.isValidPayeeCreateForm(m) {
+		return
+	}
+
+	ref := v.payeeTable.GetSelectedRef()
+	m["ID"] = ref["ID"]
+
+	p, err := v.presenter.Payee().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Payee().Update(p); err != nil {
+		v.showError("Error update payee: \n" + err.Error())
+		return
+	}
+
+	v.payeeTable.Refresh()
+	v.hidePayeeUpdateForm()
+}
+
+// showPayeeDeleteModal shows delete modal.
+func (v *View) showPayeeDeleteModal() {
+	v.Pages.ShowPage("payeeDeleteModal")
+}
+
+// hidePayeeDeleteModal hides delete modal.
+func (v *View) hidePayeeDeleteModal() {
+	v.Pages.HidePage("payeeDeleteModal")
+	v.tuiApp.SetFocus(v.payeeTable)
+}
+
+// submitPayeeDeleteModal delete modal submit handler.
+func (v *View) submitPayeeDeleteModal() {
+	ref := v.payeeTable.GetSelectedRef()
+	p, err := v.presenter.Payee().FromMap(ref)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Payee().Delete(p); err != nil {
+		v.showError("Error delete payee: \n" + err.Error())
+		return
+	}
+
+	v.payeeTable.Refresh()
+	v.hidePayeeDeleteModal()
+}
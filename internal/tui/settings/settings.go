@@ -17,28 +17,40 @@ type View struct {
 	service   *service.Service
 	presenter *presenter.Presenter
 
-	flex *tview.Flex
-
-	categoryTable       *ext.Table
-	categoryCreateForm  *ext.Form
-	categoryUpdateForm  *ext.Form
-	categoryDeleteModal *tview.Modal
-
-	currencyTable       *ext.Table
-	currencyCreateForm  *ext.Form
-	currencyUpdateForm  *ext.Form
-	currencyDeleteModal *tview.Modal
-
-	accountTable       *ext.Table
-	accountCreateForm  *ext.Form
-	accountUpdateForm  *ext.Form
-	accountDeleteModal *tview.Modal
+	flex   *tview.Flex
+	tables []*ext.Table
+
+	categoryTable      *ext.Table
+	categoryCreateForm *ext.Form
+	categoryUpdateForm *ext.Form
+	categoryDeleteForm *ext.Form
+
+	currencyTable      *ext.Table
+	currencyCreateForm *ext.Form
+	currencyUpdateForm *ext.Form
+	currencyDeleteForm *ext.Form
+
+	accountTable      *ext.Table
+	accountCreateForm *ext.Form
+	accountUpdateForm *ext.Form
+	accountDeleteForm *ext.Form
+
+	payeeTable       *ext.Table
+	payeeCreateForm  *ext.Form
+	payeeUpdateForm  *ext.Form
+	payeeDeleteModal *tview.Modal
+
+	exchangeRateTable       *ext.Table
+	exchangeRateCreateForm  *ext.Form
+	exchangeRateUpdateForm  *ext.Form
+	exchangeRateDeleteModal *tview.Modal
 
 	errorModal *tview.Modal
 }
 
-// New returns new settings view.
-func New(tuiApp *tview.Application, service *service.Service, presenter *presenter.Presenter) *View {
+// New returns new settings view. Basic view offers neither payees nor exchange rates, since they
+// aren't kept by every storage.
+func New(tuiApp *tview.Application, service *service.Service, presenter *presenter.Presenter, basic bool) *View {
 	v := &View{
 		Pages: tview.NewPages(),
 
@@ -52,42 +64,64 @@ func New(tuiApp *tview.Application, service *service.Service, presenter *present
 	categoryDataProvider := NewCategoryDataProvider(service, presenter)
 	currencyDataProvider := NewCurrencyDataProvider(service, presenter)
 	accountDataProvider := NewAccountDataProvider(service, presenter)
+	payeeDataProvider := NewPayeeDataProvider(service, presenter)
+	exchangeRateDataProvider := NewExchangeRateDataProvider(service, presenter)
 
 	// table
-	v.categoryTable = ext.NewTable([]string{"Title"}, categoryDataProvider).SetOrder("Title", false).Refresh()
-	v.currencyTable = ext.NewTable([]string{"Abbreviation"}, currencyDataProvider).SetOrder("Abbreviation", false).Refresh()
-	v.accountTable = ext.NewTable([]string{"Name", "Currency"}, accountDataProvider).SetOrder("Name", false).Refresh()
+	v.categoryTable = ext.NewTable([]string{"Category", "Kind"}, categoryDataProvider).SetOrder("Path", false).Refresh()
+	v.currencyTable = ext.NewTable([]string{"Abbreviation", "Main", "Precision"}, currencyDataProvider).SetOrder("Abbreviation", false).Refresh()
+	v.accountTable = ext.NewTable([]string{"Name", "Type", "Currency", "Balance", "Archived"}, accountDataProvider).SetOrder("Name", false).Refresh()
+	v.payeeTable = ext.NewTable([]string{"Name", "Category"}, payeeDataProvider).SetOrder("Name", false).Refresh()
+	v.exchangeRateTable = ext.NewTable([]string{"Date", "From", "To", "Rate"}, exchangeRateDataProvider).SetOrder("Date", true).Refresh()
 	v.categoryTable.SetTitle("Category")
 	v.currencyTable.SetTitle("Currency")
 	v.accountTable.SetTitle("Account")
-	v.flex.AddItem(v.categoryTable, 0, 1, true)
-	v.flex.AddItem(v.currencyTable, 0, 1, false)
-	v.flex.AddItem(v.accountTable, 0, 1, false)
+	v.payeeTable.SetTitle("Payee")
+	v.exchangeRateTable.SetTitle("Exchange Rate")
+	v.tables = []*ext.Table{v.categoryTable, v.currencyTable, v.accountTable}
+	if !basic {
+		v.tables = append(v.tables, v.payeeTable, v.exchangeRateTable)
+	}
+	for i, t := range v.tables {
+		v.flex.AddItem(t, 0, 1, i == 0)
+	}
 	v.AddPage("flex", v.flex, true, true)
 
 	// create form
 	v.categoryCreateForm = v.newCategoryForm("Create Category", v.submitCategoryCreateForm, v.hideCategoryCreateForm, categoryDataProvider)
 	v.currencyCreateForm = v.newCurrencyForm("Create Currency", v.submitCurrencyCreateForm, v.hideCurrencyCreateForm, currencyDataProvider)
 	v.accountCreateForm = v.newAccountForm("Create Account", v.submitAccountCreateForm, v.hideAccountCreateForm, accountDataProvider)
-	v.AddPage("categoryCreateForm", ext.WrapIntoModal(v.categoryCreateForm, 40, 7), true, false)
-	v.AddPage("currencyCreateForm", ext.WrapIntoModal(v.currencyCreateForm, 40, 7), true, false)
-	v.AddPage("accountCreateForm", ext.WrapIntoModal(v.accountCreateForm, 40, 9), true, false)
+	v.payeeCreateForm = v.newPayeeForm("Create Payee", v.submitPayeeCreateForm, v.hidePayeeCreateForm, payeeDataProvider)
+	v.exchangeRateCreateForm = v.newExchangeRateForm("Create Exchange Rate", v.submitExchangeRateCreateForm, v.hideExchangeRateCreateForm, exchangeRateDataProvider)
+	v.AddPage("categoryCreateForm", ext.WrapIntoModal(v.categoryCreateForm, 40, 11), true, false)
+	v.AddPage("currencyCreateForm", ext.WrapIntoModal(v.currencyCreateForm, 40, 11), true, false)
+	v.AddPage("accountCreateForm", ext.WrapIntoModal(v.accountCreateForm, 40, 19), true, false)
+	v.AddPage("payeeCreateForm", ext.WrapIntoModal(v.payeeCreateForm, 40, 9), true, false)
+	v.AddPage("exchangeRateCreateForm", ext.WrapIntoModal(v.exchangeRateCreateForm, 40, 13), true, false)
 
 	// update form
 	v.categoryUpdateForm = v.newCategoryForm("Update Category", v.submitCategoryUpdateForm, v.hideCategoryUpdateForm, categoryDataProvider)
 	v.currencyUpdateForm = v.newCurrencyForm("Update Currency", v.submitCurrencyUpdateForm, v.hideCurrencyUpdateForm, currencyDataProvider)
 	v.accountUpdateForm = v.newAccountForm("Update Account", v.submitAccountUpdateForm, v.hideAccountUpdateForm, accountDataProvider)
-	v.AddPage("categoryUpdateForm", ext.WrapIntoModal(v.categoryUpdateForm, 40, 7), true, false)
-	v.AddPage("currencyUpdateForm", ext.WrapIntoModal(v.currencyUpdateForm, 40, 7), true, false)
-	v.AddPage("accountUpdateForm", ext.WrapIntoModal(v.accountUpdateForm, 40, 9), true, false)
+	v.payeeUpdateForm = v.newPayeeForm("Update Payee", v.submitPayeeUpdateForm, v.hidePayeeUpdateForm, payeeDataProvider)
+	v.exchangeRateUpdateForm = v.newExchangeRateForm("Update Exchange Rate", v.submitExchangeRateUpdateForm, v.hideExchangeRateUpdateForm, exchangeRateDataProvider)
+	v.AddPage("categoryUpdateForm", ext.WrapIntoModal(v.categoryUpdateForm, 40, 11), true, false)
+	v.AddPage("currencyUpdateForm", ext.WrapIntoModal(v.currencyUpdateForm, 40, 11), true, false)
+	v.AddPage("accountUpdateForm", ext.WrapIntoModal(v.accountUpdateForm, 40, 19), true, false)
+	v.AddPage("payeeUpdateForm", ext.WrapIntoModal(v.payeeUpdateForm, 40, 9), true, false)
+	v.AddPage("exchangeRateUpdateForm", ext.WrapIntoModal(v.exchangeRateUpdateForm, 40, 13), true, false)
 
 	// delete modal
-	v.categoryDeleteModal = ext.NewAskModal("Are you sure?", v.submitCategoryDeleteModal, v.hideCategoryDeleteModal)
-	v.currencyDeleteModal = ext.NewAskModal("Are you sure?", v.submitCurrencyDeleteModal, v.hideCurrencyDeleteModal)
-	v.accountDeleteModal = ext.NewAskModal("Are you sure?", v.submitAccountDeleteModal, v.hideAccountDeleteModal)
-	v.AddPage("categoryDeleteModal", v.categoryDeleteModal, true, false)
-	v.AddPage("currencyDeleteModal", v.currencyDeleteModal, true, false)
-	v.AddPage("accountDeleteModal", v.accountDeleteModal, true, false)
+	v.categoryDeleteForm = v.newDeleteForm("Delete Category", v.submitCategoryDeleteForm, v.hideCategoryDeleteForm, categoryDataProvider)
+	v.currencyDeleteForm = v.newDeleteForm("Delete Currency", v.submitCurrencyDeleteForm, v.hideCurrencyDeleteForm, currencyDataProvider)
+	v.accountDeleteForm = v.newDeleteForm("Delete Account", v.submitAccountDeleteForm, v.hideAccountDeleteForm, accountDataProvider)
+	v.AddPage("categoryDeleteForm", ext.WrapIntoModal(v.categoryDeleteForm, 40, 9), true, false)
+	v.AddPage("currencyDeleteForm", ext.WrapIntoModal(v.currencyDeleteForm, 40, 9), true, false)
+	v.exchangeRateDeleteModal = ext.NewAskModal("Are you sure?", v.submitExchangeRateDeleteModal, v.hideExchangeRateDeleteModal)
+	v.AddPage("accountDeleteForm", ext.WrapIntoModal(v.accountDeleteForm, 40, 9), true, false)
+	v.payeeDeleteModal = ext.NewAskModal("Are you sure?", v.submitPayeeDeleteModal, v.hidePayeeDeleteModal)
+	v.AddPage("payeeDeleteModal", v.payeeDeleteModal, true, false)
+	v.AddPage("exchangeRateDeleteModal", v.exchangeRateDeleteModal, true, false)
 
 	// error modal
 	v.errorModal = ext.NewErrorModal(v.hideError)
@@ -105,16 +139,39 @@ func (v *View) Table(label string) *ext.Table {
 		return v.currencyTable
 	case "Account":
 		return v.accountTable
+	case "Payee":
+		return v.payeeTable
+	case "Exchange Rate":
+		return v.exchangeRateTable
 	}
 	return nil
 }
 
+// Refresh refreshes account and payee tables, since they depend on changes made in transactions view.
+func (v *View) Refresh() {
+	v.accountTable.Refresh()
+	v.payeeTable.Refresh()
+}
+
+// focusTable moves focus to the table which is given number of tables away from the focused one,
+// tables are cycled in the order they are shown.
+func (v *View) focusTable(step int) {
+	for i, t := range v.tables {
+		if t.HasFocus() {
+			v.tuiApp.SetFocus(v.tables[(i+step+len(v.tables))%len(v.tables)])
+			return
+		}
+	}
+}
+
 // ModalHasFocus returns true if any of modal is currently on focus.
 func (v *View) ModalHasFocus() bool {
 	for _, modal := range []tview.Primitive{
-		v.categoryCreateForm, v.categoryUpdateForm, v.categoryDeleteModal,
-		v.currencyCreateForm, v.currencyUpdateForm, v.currencyDeleteModal,
-		v.accountCreateForm, v.accountUpdateForm, v.accountDeleteModal,
+		v.categoryCreateForm, v.categoryUpdateForm, v.categoryDeleteForm,
+		v.currencyCreateForm, v.currencyUpdateForm, v.currencyDeleteForm,
+		v.accountCreateForm, v.accountUpdateForm, v.accountDeleteForm,
+		v.payeeCreateForm, v.payeeUpdateForm, v.payeeDeleteModal,
+		v.exchangeRateCreateForm, v.exchangeRateUpdateForm, v.exchangeRateDeleteModal,
 		v.errorModal,
 	} {
 		if modal.HasFocus() {
@@ -140,7 +197,7 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
         }
 			case 'd':
         if len(v.categoryTable.GetSelectedRef()) != 0 {
-          v.showCategoryDeleteModal()
+          v.showCategoryDeleteForm()
         } else {
           v.showError("Nothing to delete")
         }
@@ -149,9 +206,9 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 			// navigation between settings
 			switch event.Key() {
 			case tcell.KeyTab:
-				v.tuiApp.SetFocus(v.currencyTable)
+				v.focusTable(1)
 			case tcell.KeyBacktab:
-				v.tuiApp.SetFocus(v.accountTable)
+				v.focusTable(-1)
 			}
 
 			// if none of keys has pressed use standard table input handler.
@@ -174,7 +231,7 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
         }
 			case 'd':
         if len(v.currencyTable.GetSelectedRef()) != 0 {
-          v.showCurrencyDeleteModal()
+          v.showCurrencyDeleteForm()
         } else {
           v.showError("Nothing to delete")
         }
@@ -183,9 +240,9 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 			// navigation between settings
 			switch event.Key() {
 			case tcell.KeyTab:
-				v.tuiApp.SetFocus(v.accountTable)
+				v.focusTable(1)
 			case tcell.KeyBacktab:
-				v.tuiApp.SetFocus(v.categoryTable)
+				v.focusTable(-1)
 			}
 
 			// if none of keys has pressed use standard table input handler.
@@ -209,7 +266,7 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
         }
 			case 'd':
         if len(v.accountTable.GetSelectedRef()) != 0 {
-          v.showAccountDeleteModal()
+          v.showAccountDeleteForm()
         } else {
           v.showError("Nothing to delete")
         }
@@ -218,9 +275,9 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 			// navigation between settings
 			switch event.Key() {
 			case tcell.KeyTab:
-				v.tuiApp.SetFocus(v.categoryTable)
+				v.focusTable(1)
 			case tcell.KeyBacktab:
-				v.tuiApp.SetFocus(v.currencyTable)
+				v.focusTable(-1)
 			}
 
 			// if none of keys has pressed use standard table input handler.
@@ -231,11 +288,83 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 			}
 		}
 
+		if v.payeeTable.HasFocus() {
+			// table controllers
+			switch event.Rune() {
+			case 'c':
+				v.showPayeeCreateForm()
+			case 'u':
+				if len(v.payeeTable.GetSelectedRef()) != 0 {
+					v.showPayeeUpdateForm()
+				} else {
+					v.showError("Nothing to update")
+				}
+			case 'd':
+				if len(v.payeeTable.GetSelectedRef()) != 0 {
+					v.showPayeeDeleteModal()
+				} else {
+					v.showError("Nothing to delete")
+				}
+			}
+
+			// navigation between settings
+			switch event.Key() {
+			case tcell.KeyTab:
+				v.focusTable(1)
+			case tcell.KeyBacktab:
+				v.focusTable(-1)
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.payeeTable.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
+		if v.exchangeRateTable.HasFocus() {
+			// table controllers
+			switch event.Rune() {
+			case 'c':
+				v.showExchangeRateCreateForm()
+			case 'u':
+        if len(v.exchangeRateTable.GetSelectedRef()) != 0 {
+          v.showExchangeRateUpdateForm()
+        } else {
+          v.showError("Nothing to update")
+        }
+			case 'd':
+        if len(v.exchangeRateTable.GetSelectedRef()) != 0 {
+          v.showExchangeRateDeleteModal()
+        } else {
+          v.showError("Nothing to delete")
+        }
+			}
+
+			// navigation between settings
+			switch event.Key() {
+			case tcell.KeyTab:
+				v.focusTable(1)
+			case tcell.KeyBacktab:
+				v.focusTable(-1)
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.exchangeRateTable.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
 		// give control to the child view.
 		for _, modal := range []tview.Primitive{
-			v.categoryCreateForm, v.categoryUpdateForm, v.categoryDeleteModal,
-			v.currencyCreateForm, v.currencyUpdateForm, v.currencyDeleteModal,
-			v.accountCreateForm, v.accountUpdateForm, v.accountDeleteModal,
+			v.categoryCreateForm, v.categoryUpdateForm, v.categoryDeleteForm,
+			v.currencyCreateForm, v.currencyUpdateForm, v.currencyDeleteForm,
+			v.accountCreateForm, v.accountUpdateForm, v.accountDeleteForm,
+			v.payeeCreateForm, v.payeeUpdateForm, v.payeeDeleteModal,
+			v.exchangeRateCreateForm, v.exchangeRateUpdateForm, v.exchangeRateDeleteModal,
 			v.errorModal,
 		} {
 			if modal.HasFocus() {
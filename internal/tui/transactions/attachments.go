@@ -0,0 +1,112 @@
+package transactions
+
+import (
+	"strings"
+
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// newAttachmentTable returns new table which lists attachments of transaction.
+func (v *View) newAttachmentTable(dataProvider *AttachmentDataProvider) *ext.Table {
+	table := ext.NewTable([]string{"Name", "Path"}, dataProvider).SetOrder("Name", false)
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			v.hideAttachmentTable()
+		}
+	})
+
+	return table
+}
+
+// newAttachmentForm returns new form for entering path of the file to attach.
+func (v *View) newAttachmentForm(dataProvider *DataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddInputField("File", "", 0, nil, nil).
+		AddButton("Attach", v.submitAttachmentForm).
+		AddButton("Cancel", v.hideAttachmentForm)
+
+	form.SetBorder(true)
+	form.SetTitle("Attach File")
+	form.SetCancelFunc(v.hideAttachmentForm)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// showAttachmentTable shows attachments of selected transaction.
+func (v *View) showAttachmentTable() {
+	ref := v.getSelectedRef()
+	tr, err := v.presenter.Transaction().FromMap(ref)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	v.attachmentDataProvider.SetTransaction(tr)
+	v.attachmentTable.SetTitle("Attachments: " + ref["Date"] + " " + ref["Note"])
+	v.attachmentTable.Refresh()
+	v.Pages.ShowPage("attachmentTable")
+}
+
+// hideAttachmentTable hides attachments table.
+func (v *View) hideAttachmentTable() {
+	v.attachmentDataProvider.SetTransaction(nil)
+	v.Pages.HidePage("attachmentTable")
+}
+
+// showAttachmentForm shows attach form with empty file path.
+func (v *View) showAttachmentForm() {
+	v.attachmentForm.SetFields(map[string]string{"File": ""})
+	v.Pages.ShowPage("attachmentForm")
+}
+
+// hideAttachmentForm hides attach form.
+func (v *View) hideAttachmentForm() {
+	v.Pages.HidePage("attachmentForm")
+}
+
+// submitAttachmentForm attach form submit handler.
+func (v *View) submitAttachmentForm() {
+	path := strings.TrimSpace(v.attachmentForm.GetFields()["File"])
+	if path == "" {
+		v.showError("Can't attach file without path.")
+		return
+	}
+
+	if _, err := v.service.Attachment().Add(v.attachmentDataProvider.Transaction(), path); err != nil {
+		v.showError("Error attach file: \n" + err.Error())
+		return
+	}
+
+	v.attachmentTable.Refresh()
+	v.hideAttachmentForm()
+}
+
+// showAttachmentDeleteModal shows attachment delete modal.
+func (v *View) showAttachmentDeleteModal() {
+	v.Pages.ShowPage("attachmentDeleteModal")
+}
+
+// hideAttachmentDeleteModal hides attachment delete modal.
+func (v *View) hideAttachmentDeleteModal() {
+	v.Pages.HidePage("attachmentDeleteModal")
+}
+
+// submitAttachmentDeleteModal attachment delete modal submit handler.
+func (v *View) submitAttachmentDeleteModal() {
+	a, err := v.presenter.Attachment().FromMap(v.attachmentTable.GetSelectedRef())
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Attachment().Remove(a); err != nil {
+		v.showError("Error remove attachment: \n" + err.Error())
+		return
+	}
+
+	v.attachmentTable.Refresh()
+	v.hideAttachmentDeleteModal()
+}
@@ -1,8 +1,12 @@
 package transactions
 
 import (
+	"fmt"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/kotlw/gentlemoney/internal/model"
 	"github.com/kotlw/gentlemoney/internal/presenter"
 	"github.com/kotlw/gentlemoney/internal/service"
 )
@@ -11,6 +15,7 @@ import (
 type DataProvider struct {
 	service   *service.Service
 	presenter *presenter.Presenter
+	account   *model.Account
 }
 
 // NewDataProvider returns new DataProvider.
@@ -18,40 +23,93 @@ func NewDataProvider(service *service.Service, presenter *presenter.Presenter) *
 	return &DataProvider{service: service, presenter: presenter}
 }
 
-// GetAll returns slice of maps which represents transaction struct.
+// SetAccount sets account which register is provided, nil means all transactions are provided.
+func (d *DataProvider) SetAccount(a *model.Account) {
+	d.account = a
+}
+
+// Account returns account which register is provided.
+func (d *DataProvider) Account() *model.Account {
+	return d.account
+}
+
+// GetAll returns slice of maps which represents transaction struct. In account register mode only
+// transactions of the account are returned along with "Balance" and "Order" keys.
 func (d *DataProvider) GetAll() []map[string]string {
+	if d.account != nil {
+		return d.getRegister()
+	}
+
 	data := d.service.Transaction().GetAll()
 
 	res := make([]map[string]string, len(data))
 
 	for i, e := range data {
-		m := d.presenter.Transaction().ToMap(e)
-		if m["Amount"][0] == '+' {
-			m["Amount"] = "[green]" + m["Amount"] + "[white]"
-		}
-		if m["Amount"][0] == '-' {
-			m["Amount"] = "[red]" + m["Amount"] + "[white]"
-		}
-		res[i] = m
+		res[i] = d.toMap(e)
 	}
 
 	return res
 }
 
+// getRegister returns transactions of account register with running balance, register which
+// balance can't be calculated is empty, the error is shown instead of balance of account.
+func (d *DataProvider) getRegister() []map[string]string {
+	data, err := d.service.Transaction().Register(d.account)
+	if err != nil {
+		return nil
+	}
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = d.toMap(e.Transaction)
+		res[i]["Balance"] = d.presenter.Account().ReprBalance(d.account, e.Balance)
+		res[i]["Order"] = fmt.Sprintf("%010d", i)
+	}
+
+	return res
+}
+
+// toMap converts transaction to map with colored amount.
+func (d *DataProvider) toMap(t *model.Transaction) map[string]string {
+	return colorAmount(d.presenter.Transaction().ToMap(t))
+}
+
+// colorAmount colors amount of transaction map depending on its sign.
+func colorAmount(m map[string]string) map[string]string {
+	if m["Amount"][0] == '+' {
+		m["Amount"] = "[green]" + m["Amount"] + "[white]"
+	}
+	if m["Amount"][0] == '-' {
+		m["Amount"] = "[red]" + m["Amount"] + "[white]"
+	}
+
+	return m
+}
+
 // GetDropDownOptions returns dropdown obtions for given label.
 func (d *DataProvider) GetDropDownOptions(label string) []string {
 	switch label {
-	case "Account":
+	case "Account", "From", "To":
 		return d.accountOptions()
 	case "Category":
 		return d.categoryOptions()
+	case "Tags":
+		return d.tagOptions()
+	case "Payee":
+		return d.payeeOptions()
+	case "Register":
+		return d.registerOptions()
+	}
+	if strings.HasPrefix(label, "Category #") {
+		return d.categoryOptions()
 	}
 	return nil
 }
 
-// accountOptions returns account dropdown options.
+// accountOptions returns account dropdown options, archived accounts are omitted.
 func (d *DataProvider) accountOptions() []string {
-	accounts := d.service.Account().GetAll()
+	accounts := d.service.Account().GetActive()
 
 	res := make([]string, len(accounts))
 
@@ -64,17 +122,151 @@ func (d *DataProvider) accountOptions() []string {
 	return res
 }
 
-// categoryOptions returns category dropdown options.
+// registerOptions returns names of all accounts including archived ones, empty option stands for
+// all transactions.
+func (d *DataProvider) registerOptions() []string {
+	accounts := d.service.Account().GetAll()
+
+	res := make([]string, len(accounts)+1)
+
+	for i, e := range accounts {
+		res[i+1] = e.Name
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// categoryOptions returns category dropdown options represented as "Parent / Child" paths.
 func (d *DataProvider) categoryOptions() []string {
 	categories := d.service.Category().GetAll()
 
 	res := make([]string, len(categories))
 
 	for i, e := range categories {
-		res[i] = e.Title
+		res[i] = d.service.Category().Path(e)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// payeeOptions returns names of existing payees to suggest in payee field.
+func (d *DataProvider) payeeOptions() []string {
+	payees := d.service.Payee().GetAll()
+
+	res := make([]string, len(payees))
+
+	for i, e := range payees {
+		res[i] = e.Name
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// tagOptions returns names of existing tags to suggest in tags field.
+func (d *DataProvider) tagOptions() []string {
+	tags := d.service.Tag().GetAll()
+
+	res := make([]string, len(tags))
+
+	for i, e := range tags {
+		res[i] = e.Name
 	}
 
 	sort.Strings(res)
 
 	return res
 }
+
+// AttachmentDataProvider implements ext.TableDataProvider for interaction with attachments of
+// transaction.
+type AttachmentDataProvider struct {
+	service     *service.Service
+	presenter   *presenter.Presenter
+	transaction *model.Transaction
+}
+
+// NewAttachmentDataProvider returns new AttachmentDataProvider.
+func NewAttachmentDataProvider(service *service.Service, presenter *presenter.Presenter) *AttachmentDataProvider {
+	return &AttachmentDataProvider{service: service, presenter: presenter}
+}
+
+// SetTransaction sets transaction which attachments are provided.
+func (d *AttachmentDataProvider) SetTransaction(t *model.Transaction) {
+	d.transaction = t
+}
+
+// Transaction returns transaction which attachments are provided.
+func (d *AttachmentDataProvider) Transaction() *model.Transaction {
+	return d.transaction
+}
+
+// GetAll returns slice of maps which represents attachment struct.
+func (d *AttachmentDataProvider) GetAll() []map[string]string {
+	if d.transaction == nil {
+		return nil
+	}
+
+	data := d.service.Attachment().GetByTransaction(d.transaction)
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = d.presenter.Attachment().ToMap(e)
+	}
+
+	return res
+}
+
+// ReconcileDataProvider implements ext.TableDataProvider for interaction with transactions of
+// account which are not reconciled yet with bank statement.
+type ReconcileDataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+	account   *model.Account
+	date      time.Time
+	balance   int64
+}
+
+// NewReconcileDataProvider returns new ReconcileDataProvider.
+func NewReconcileDataProvider(service *service.Service, presenter *presenter.Presenter) *ReconcileDataProvider {
+	return &ReconcileDataProvider{service: service, presenter: presenter}
+}
+
+// SetStatement sets account, ending date and ending balance of bank statement being reconciled.
+func (d *ReconcileDataProvider) SetStatement(a *model.Account, date time.Time, balance int64) {
+	d.account, d.date, d.balance = a, date, balance
+}
+
+// Statement returns account, ending date and ending balance of bank statement being reconciled.
+func (d *ReconcileDataProvider) Statement() (*model.Account, time.Time, int64) {
+	return d.account, d.date, d.balance
+}
+
+// GetAll returns slice of maps which represents unreconciled transactions of statement account up
+// to statement date along with "Order" key. Transactions aren't returned if register of account
+// can't be calculated, the error is shown instead of cleared balance.
+func (d *ReconcileDataProvider) GetAll() []map[string]string {
+	if d.account == nil {
+		return nil
+	}
+
+	data, err := d.service.Transaction().Unreconciled(d.account, d.date)
+	if err != nil {
+		return nil
+	}
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = colorAmount(d.presenter.Transaction().ToMap(e))
+		res[i]["Order"] = fmt.Sprintf("%010d", i)
+	}
+
+	return res
+}
@@ -0,0 +1,136 @@
+package transactions
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// reconcileCols are the columns of reconcile table.
+var reconcileCols = []string{"Date", "Payee", "Category", "Amount", "Status", "Note"}
+
+// newReconcileForm returns new form for entering bank statement to reconcile account against.
+func (v *View) newReconcileForm(dataProvider *DataProvider) *ext.Form {
+	form := tview.NewForm()
+	form = form.AddDropDown("Account", nil, 0, nil).
+		AddFormItem(ext.NewDateField().SetLabel("Statement Date")).
+		AddInputField("Statement Balance", "", 0, v.amountAccept(form, "Statement Balance", v.precisionOf(form, "Account")), nil).
+		AddButton("Start", v.submitReconcileForm).
+		AddButton("Cancel", v.hideReconcileForm)
+
+	form.SetBorder(true)
+	form.SetTitle("Reconcile Account")
+	form.SetCancelFunc(v.hideReconcileForm)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// newReconcileTable returns new table which lists transactions not reconciled yet.
+func (v *View) newReconcileTable(dataProvider *ReconcileDataProvider) *ext.Table {
+	table := ext.NewTable(reconcileCols, dataProvider).SetOrder("Order", false)
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			v.hideReconcileTable()
+		}
+	})
+
+	return table
+}
+
+// showReconcileForm shows reconcile form initialized with account of current register and today
+// as statement date.
+func (v *View) showReconcileForm() {
+	name := ""
+	if a := v.dataProvider.Account(); a != nil {
+		name = a.Name
+	}
+
+	m := map[string]string{"Account": name, "Statement Date": time.Now().Format("2006-01-02"), "Statement Balance": ""}
+	v.reconcileForm.SetFields(m)
+	v.Pages.ShowPage("reconcileForm")
+}
+
+// hideReconcileForm hides reconcile form.
+func (v *View) hideReconcileForm() {
+	v.Pages.HidePage("reconcileForm")
+}
+
+// submitReconcileForm reconcile form submit handler. It shows transactions of chosen account which
+// should be matched with the statement.
+func (v *View) submitReconcileForm() {
+	a, date, balance, err := v.presenter.Transaction().StatementFromMap(v.reconcileForm.GetFields())
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	v.reconcileDataProvider.SetStatement(a, date, balance)
+	v.refreshReconcileTable()
+	v.hideReconcileForm()
+	v.Pages.ShowPage("reconcileTable")
+}
+
+// hideReconcileTable hides reconcile table along with refreshing transactions, since their status
+// could be changed.
+func (v *View) hideReconcileTable() {
+	v.reconcileDataProvider.SetStatement(nil, time.Time{}, 0)
+	v.Refresh()
+	v.Pages.HidePage("reconcileTable")
+}
+
+// refreshReconcileTable refreshes reconcile table and shows difference between cleared balance
+// and statement balance in its title.
+func (v *View) refreshReconcileTable() {
+	a, date, balance := v.reconcileDataProvider.Statement()
+	cleared, err := v.service.Transaction().ClearedBalanceAt(a, date)
+	if err != nil {
+		v.reconcileTable.SetTitle("Reconcile " + a.Name + ": " + err.Error())
+		v.reconcileTable.Refresh()
+		return
+	}
+
+	v.reconcileTable.SetTitle("Reconcile " + a.Name +
+		": cleared " + v.presenter.Account().ReprBalance(a, cleared) +
+		", statement " + v.presenter.Account().ReprBalance(a, balance) +
+		", difference " + v.presenter.Account().ReprBalance(a, cleared-balance))
+	v.reconcileTable.Refresh()
+}
+
+// toggleCleared switches selected transaction of reconcile table between pending and cleared.
+func (v *View) toggleCleared() {
+	id, err := strconv.Atoi(v.reconcileTable.GetSelectedRef()["ID"])
+	if err != nil {
+		v.showError("Nothing to clear")
+		return
+	}
+
+	tr := v.service.Transaction().GetByID(int64(id))
+
+	status := model.Cleared
+	if tr.Status == model.Cleared {
+		status = model.Pending
+	}
+
+	if err := v.service.Transaction().SetStatus(tr, status); err != nil {
+		v.showError("Error update transaction: \n" + err.Error())
+		return
+	}
+
+	v.refreshReconcileTable()
+}
+
+// finishReconcile locks cleared transactions as reconciled if difference with statement is zero.
+func (v *View) finishReconcile() {
+	a, date, balance := v.reconcileDataProvider.Statement()
+	if err := v.service.Transaction().Reconcile(a, date, balance); err != nil {
+		v.showError("Error reconcile account: \n" + err.Error())
+		return
+	}
+
+	v.hideReconcileTable()
+}
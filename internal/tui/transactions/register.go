@@ -0,0 +1,55 @@
+package transactions
+
+import (
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/rivo/tview"
+)
+
+// newRegisterForm returns new form for choosing account which register is shown.
+func (v *View) newRegisterForm(dataProvider *DataProvider) *ext.Form {
+	form := tview.NewForm().
+		AddDropDown("Register", nil, 0, nil).
+		AddButton("Show", v.submitRegisterForm).
+		AddButton("Cancel", v.hideRegisterForm)
+
+	form.SetBorder(true)
+	form.SetTitle("Account Register")
+	form.SetCancelFunc(v.hideRegisterForm)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// showRegisterForm shows register form initialized with account of current register.
+func (v *View) showRegisterForm() {
+	name := ""
+	if a := v.dataProvider.Account(); a != nil {
+		name = a.Name
+	}
+
+	v.registerForm.SetFields(map[string]string{"Register": name})
+	v.Pages.ShowPage("registerForm")
+}
+
+// hideRegisterForm hides register form.
+func (v *View) hideRegisterForm() {
+	v.Pages.HidePage("registerForm")
+}
+
+// submitRegisterForm register form submit handler. It switches table to the register of chosen
+// account with running balance column, empty account switches table back to all transactions.
+func (v *View) submitRegisterForm() {
+	a := v.service.Account().GetByName(v.registerForm.GetFields()["Register"])
+	v.dataProvider.SetAccount(a)
+
+	if a == nil {
+		v.table.SetCols(v.cols).SetOrder("Date", true)
+		v.table.SetTitle("")
+	} else {
+		v.table.SetCols(v.registerCols).SetOrder("Order", true)
+		v.table.SetTitle("Register: " + a.Name)
+	}
+
+	v.Refresh()
+	v.hideRegisterForm()
+}
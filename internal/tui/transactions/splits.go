@@ -0,0 +1,122 @@
+package transactions
+
+import (
+	"strconv"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/rivo/tview"
+)
+
+// maxSplits is the maximum number of splits which fits into split form.
+const maxSplits = 4
+
+// showSplitForm shows split editor of the transaction being edited in given parent form. If transaction
+// has no splits yet, the first split is prefilled with category and amount of the parent form.
+func (v *View) showSplitForm(parent *ext.Form) {
+	m := parent.GetFields()
+	if len(v.splits) == 0 {
+		v.splits = []map[string]string{{"Category": m["Category"], "Amount": m["Amount"], "Note": ""}}
+	}
+
+	// splits are in currency of the transaction account.
+	v.splitPrecision = model.DefaultPrecision
+	if a := v.service.Account().GetByName(m["Account"]); a != nil {
+		v.splitPrecision = a.Currency.Precision
+	}
+
+	v.buildSplitForm(v.splits)
+	v.Pages.ShowPage("splitForm")
+}
+
+// buildSplitForm (re)builds split form with a row of fields for each of given splits.
+func (v *View) buildSplitForm(splits []map[string]string) {
+	form := tview.NewForm()
+	m := make(map[string]string)
+
+	for i, e := range splits {
+		n := " #" + strconv.Itoa(i+1)
+		form.AddDropDown("Category"+n, nil, 0, nil).
+			AddInputField("Amount"+n, "", 0, v.amountAccept(form, "Amount"+n, func() int64 { return v.splitPrecision }), nil).
+			AddInputField("Note"+n, "", 0, nil, nil)
+
+		m["Category"+n], m["Amount"+n], m["Note"+n] = e["Category"], e["Amount"], e["Note"]
+	}
+
+	form.AddButton("Add", v.addSplit).
+		AddButton("Remove", v.removeSplit).
+		AddButton("Done", v.submitSplitForm).
+		AddButton("Cancel", v.hideSplitForm)
+
+	form.SetBorder(true)
+	form.SetTitle("Splits")
+	form.SetCancelFunc(v.hideSplitForm)
+
+	v.splitForm = ext.NewForm(form, v.dataProvider)
+	v.splitForm.SetFields(m)
+	v.AddPage("splitForm", ext.WrapIntoModal(v.splitForm, 44, 6*len(splits)+5), true, false)
+}
+
+// getSplitFormFields returns splits currently entered in split form.
+func (v *View) getSplitFormFields() []map[string]string {
+	m := v.splitForm.GetFields()
+	res := make([]map[string]string, 0)
+
+	for i := 1; ; i++ {
+		n := " #" + strconv.Itoa(i)
+		if _, ok := m["Category"+n]; !ok {
+			break
+		}
+		res = append(res, map[string]string{"Category": m["Category"+n], "Amount": m["Amount"+n], "Note": m["Note"+n]})
+	}
+
+	return res
+}
+
+// addSplit adds empty row to split form.
+func (v *View) addSplit() {
+	splits := v.getSplitFormFields()
+	if len(splits) >= maxSplits {
+		v.showError("Can't add more than " + strconv.Itoa(maxSplits) + " splits.")
+		return
+	}
+
+	v.buildSplitForm(append(splits, map[string]string{"Category": "", "Amount": "", "Note": ""}))
+	v.Pages.ShowPage("splitForm")
+}
+
+// removeSplit removes last row from split form.
+func (v *View) removeSplit() {
+	splits := v.getSplitFormFields()
+	if len(splits) == 1 {
+		v.showError("Can't remove the only split.")
+		return
+	}
+
+	v.buildSplitForm(splits[:len(splits)-1])
+	v.Pages.ShowPage("splitForm")
+}
+
+// submitSplitForm split form submit handler. Single split is dropped as it means transaction isn't split.
+func (v *View) submitSplitForm() {
+	splits := v.getSplitFormFields()
+	for _, e := range splits {
+		if e["Category"] == "" || e["Amount"] == "" {
+			v.showError("Can't create split without category or amount.")
+			return
+		}
+	}
+
+	v.splits = nil
+	if len(splits) > 1 {
+		v.splits = splits
+	}
+
+	v.hideSplitForm()
+}
+
+// hideSplitForm hides split form.
+func (v *View) hideSplitForm() {
+	v.Pages.HidePage("splitForm")
+}
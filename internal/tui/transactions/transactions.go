@@ -5,6 +5,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/kotlw/gentlemoney/internal/model"
 	"github.com/kotlw/gentlemoney/internal/presenter"
 	"github.com/kotlw/gentlemoney/internal/service"
 	"github.com/kotlw/gentlemoney/internal/tui/ext"
@@ -13,6 +14,20 @@ import (
 	"github.com/rivo/tview"
 )
 
+var (
+	// cols are the columns of all transactions table.
+	cols = []string{"Date", "Account", "Payee", "Category", "Amount", "Currency", "Status", "Note", "Tags", "Splits"}
+
+	// registerCols are the columns of account register table.
+	registerCols = []string{"Date", "Payee", "Category", "Amount", "Balance", "Status", "Note", "Tags", "Splits"}
+
+	// basicCols are the columns of all transactions table without payees, tags and splits.
+	basicCols = []string{"Date", "Account", "Category", "Amount", "Currency", "Status", "Note"}
+
+	// basicRegisterCols are the columns of account register table without payees, tags and splits.
+	basicRegisterCols = []string{"Date", "Category", "Amount", "Balance", "Status", "Note"}
+)
+
 // View is a transactions view.
 type View struct {
 	*tview.Pages
@@ -20,36 +35,101 @@ type View struct {
 	service   *service.Service
 	presenter *presenter.Presenter
 
-	table       *ext.Table
-	createForm  *ext.Form
-	updateForm  *ext.Form
-	deleteModal *tview.Modal
-	errorModal  *tview.Modal
+	basic        bool
+	cols         []string
+	registerCols []string
+
+	table              *ext.Table
+	total              *tview.TextView
+	createForm         *ext.Form
+	updateForm         *ext.Form
+	transferCreateForm *ext.Form
+	transferUpdateForm *ext.Form
+	splitForm          *ext.Form
+	registerForm       *ext.Form
+	deleteModal        *tview.Modal
+	errorModal         *tview.Modal
+
+	attachmentTable       *ext.Table
+	attachmentForm        *ext.Form
+	attachmentDeleteModal *tview.Modal
+
+	reconcileForm  *ext.Form
+	reconcileTable *ext.Table
+
+	dataProvider           *DataProvider
+	attachmentDataProvider *AttachmentDataProvider
+	reconcileDataProvider  *ReconcileDataProvider
+	splits                 []map[string]string
+	splitPrecision         int64
 }
 
-// New returns new transactions view.
-func New(service *service.Service, presenter *presenter.Presenter) *View {
+// New returns new transactions view. Basic view offers neither payees, tags and splits nor
+// transfers and attachments, since they aren't kept by every storage.
+func New(service *service.Service, presenter *presenter.Presenter, basic bool) *View {
 	v := &View{
 		Pages: tview.NewPages(),
 
 		service:   service,
 		presenter: presenter,
+
+		basic:        basic,
+		cols:         cols,
+		registerCols: registerCols,
+	}
+
+	if basic {
+		v.cols, v.registerCols = basicCols, basicRegisterCols
 	}
 
 	dataProvider := NewDataProvider(v.service, v.presenter)
+	v.dataProvider = dataProvider
 
 	// table
-	cols := []string{"Date", "Account", "Category", "Amount", "Currency", "Note"}
-	v.table = ext.NewTable(cols, dataProvider).SetOrder("Date", true).Refresh()
-	v.AddPage("table", v.table, true, true)
+	v.table = ext.NewTable(v.cols, dataProvider).SetOrder("Date", true)
+	v.total = tview.NewTextView().SetTextAlign(tview.AlignRight)
+	v.Refresh()
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.total, 1, 0, false)
+	v.AddPage("table", flex, true, true)
 
 	// create form
 	v.createForm = v.newForm("Create Transaction", v.submitCreateForm, v.hideCreateForm, dataProvider)
-	v.AddPage("createForm", ext.WrapIntoModal(v.createForm, 40, 15), true, false)
+	v.AddPage("createForm", ext.WrapIntoModal(v.createForm, 40, 19), true, false)
 
 	// update form
 	v.updateForm = v.newForm("Update Transaction", v.submitUpdateForm, v.hideUpdateForm, dataProvider)
-	v.AddPage("updateForm", ext.WrapIntoModal(v.updateForm, 40, 15), true, false)
+	v.AddPage("updateForm", ext.WrapIntoModal(v.updateForm, 40, 19), true, false)
+
+	// transfer forms
+	v.transferCreateForm = v.newTransferForm("Create Transfer", v.submitTransferCreateForm, v.hideTransferCreateForm, dataProvider)
+	v.transferUpdateForm = v.newTransferForm("Update Transfer", v.submitTransferUpdateForm, v.hideTransferUpdateForm, dataProvider)
+	v.AddPage("transferCreateForm", ext.WrapIntoModal(v.transferCreateForm, 40, 19), true, false)
+	v.AddPage("transferUpdateForm", ext.WrapIntoModal(v.transferUpdateForm, 40, 19), true, false)
+
+	// split form
+	v.buildSplitForm(nil)
+
+	// register form
+	v.registerForm = v.newRegisterForm(dataProvider)
+	v.AddPage("registerForm", ext.WrapIntoModal(v.registerForm, 40, 7), true, false)
+
+	// attachments
+	v.attachmentDataProvider = NewAttachmentDataProvider(v.service, v.presenter)
+	v.attachmentTable = v.newAttachmentTable(v.attachmentDataProvider)
+	v.AddPage("attachmentTable", ext.WrapIntoModal(v.attachmentTable, 80, 12), true, false)
+	v.attachmentForm = v.newAttachmentForm(dataProvider)
+	v.AddPage("attachmentForm", ext.WrapIntoModal(v.attachmentForm, 60, 7), true, false)
+	v.attachmentDeleteModal = ext.NewAskModal("Are you sure?", v.submitAttachmentDeleteModal, v.hideAttachmentDeleteModal)
+	v.AddPage("attachmentDeleteModal", v.attachmentDeleteModal, true, false)
+
+	// reconciliation
+	v.reconcileDataProvider = NewReconcileDataProvider(v.service, v.presenter)
+	v.reconcileForm = v.newReconcileForm(dataProvider)
+	v.AddPage("reconcileForm", ext.WrapIntoModal(v.reconcileForm, 40, 11), true, false)
+	v.reconcileTable = v.newReconcileTable(v.reconcileDataProvider)
+	v.AddPage("reconcileTable", ext.WrapIntoModal(v.reconcileTable, 100, 20), true, false)
 
 	// delete modal
 	v.deleteModal = ext.NewAskModal("Are you sure?", v.submitDeleteModal, v.hideDeleteModal)
@@ -64,7 +144,12 @@ func New(service *service.Service, presenter *presenter.Presenter) *View {
 
 // ModalHasFocus returns true if any of modal is currently on focus.
 func (v *View) ModalHasFocus() bool {
-	for _, modal := range []tview.Primitive{v.createForm, v.updateForm, v.deleteModal, v.errorModal} {
+	for _, modal := range []tview.Primitive{
+		v.createForm, v.updateForm, v.transferCreateForm, v.transferUpdateForm, v.splitForm, v.registerForm, v.deleteModal,
+		v.attachmentTable, v.attachmentForm, v.attachmentDeleteModal,
+		v.reconcileForm, v.reconcileTable,
+		v.errorModal,
+	} {
 		if modal.HasFocus() {
 			return true
 		}
@@ -80,9 +165,39 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 				v.showCreateForm()
 			}
 
+			if event.Rune() == 't' {
+				if !v.basic {
+					v.showTransferCreateForm()
+				} else {
+					v.showError("Transfers aren't kept by this storage")
+				}
+			}
+
+			if event.Rune() == 'r' {
+				v.showRegisterForm()
+			}
+
+			if event.Rune() == 'e' {
+				v.showReconcileForm()
+			}
+
+			if event.Rune() == 'a' {
+				if v.basic {
+					v.showError("Attachments aren't kept by this storage")
+				} else if len(v.table.GetSelectedRef()) != 0 {
+					v.showAttachmentTable()
+				} else {
+					v.showError("Nothing to attach to")
+				}
+			}
+
 			if event.Rune() == 'u' {
         if len(v.table.GetSelectedRef()) != 0 {
-          v.showUpdateForm()
+          if v.getSelectedTransfer() != nil {
+            v.showTransferUpdateForm()
+          } else {
+            v.showUpdateForm()
+          }
         } else {
           v.showError("Nothing to update")
         }
@@ -104,8 +219,50 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 			}
 		}
 
+		if v.attachmentTable.HasFocus() {
+			if event.Rune() == 'a' {
+				v.showAttachmentForm()
+			}
+
+			if event.Rune() == 'd' {
+				if len(v.attachmentTable.GetSelectedRef()) != 0 {
+					v.showAttachmentDeleteModal()
+				} else {
+					v.showError("Nothing to delete")
+				}
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.attachmentTable.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
+		if v.reconcileTable.HasFocus() {
+			if event.Rune() == 'x' || event.Rune() == ' ' {
+				v.toggleCleared()
+			}
+
+			if event.Rune() == 'f' {
+				v.finishReconcile()
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.reconcileTable.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
 		// give control to the child view.
-		for _, modal := range []tview.Primitive{v.createForm, v.updateForm, v.deleteModal, v.errorModal} {
+		for _, modal := range []tview.Primitive{
+			v.createForm, v.updateForm, v.transferCreateForm, v.transferUpdateForm, v.splitForm, v.registerForm, v.deleteModal,
+			v.attachmentForm, v.attachmentDeleteModal, v.reconcileForm,
+		v.errorModal,
+		} {
 			if modal.HasFocus() {
 				if handler := modal.InputHandler(); handler != nil {
 					handler(event, setFocus)
@@ -118,28 +275,63 @@ func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 	})
 }
 
-// newForm returns new form with corresponding transaction fields.
+// newForm returns new form with corresponding transaction fields. Basic form has neither payee
+// and tags fields nor splits button.
 func (v *View) newForm(title string, submit func(), cancel func(), dataProvider *DataProvider) *ext.Form {
+	var res *ext.Form
+
+	// picking existing payee pre-fills its default category.
+	payeeField := ext.NewAutocompleteField().SetLabel("Payee")
+	payeeField.SetChangedFunc(func(text string) {
+		if payee := v.service.Payee().GetByName(text); payeeField.HasFocus() && payee != nil && payee.Category != nil {
+			res.SetField("Category", v.service.Category().Path(payee.Category))
+		}
+	})
 
 	form := tview.NewForm()
-	form = form.AddFormItem(ext.NewDateField().SetLabel("Date")).
-		AddDropDown("Category", nil, 0, nil).
+	form = form.AddFormItem(ext.NewDateField().SetLabel("Date"))
+	if !v.basic {
+		form = form.AddFormItem(payeeField)
+	}
+	form = form.AddDropDown("Category", nil, 0, nil).
 		AddDropDown("Account", nil, 0, nil).
-		AddInputField("Amount", "", 0, v.amountAccept(form), nil).
-		AddInputField("Note", "", 0, nil, nil).
-		AddButton(strings.Split(title, " ")[0], submit).
-		AddButton("Cancel", cancel)
+		AddInputField("Amount", "", 0, v.amountAccept(form, "Amount", v.precisionOf(form, "Account")), nil).
+		AddInputField("Note", "", 0, nil, nil)
+	if !v.basic {
+		form = form.AddFormItem(ext.NewTagField().SetLabel("Tags"))
+	}
+	form = form.AddButton(strings.Split(title, " ")[0], submit)
+	if !v.basic {
+		form = form.AddButton("Splits", func() { v.showSplitForm(res) })
+	}
+	form = form.AddButton("Cancel", cancel)
 
 	form.SetBorder(true)
 	form.SetTitle(title)
 	form.SetCancelFunc(cancel)
 
-	return ext.NewForm(form, dataProvider)
+	res = ext.NewForm(form, dataProvider)
+
+	return res
+}
+
+// precisionOf returns func which gives precision of currency of account chosen in dropdown with
+// given label, default precision is used until account is chosen.
+func (v *View) precisionOf(form *tview.Form, label string) func() int64 {
+	return func() int64 {
+		_, name := form.GetFormItemByLabel(label).(*tview.DropDown).GetCurrentOption()
+		if a := v.service.Account().GetByName(name); a != nil {
+			return a.Currency.Precision
+		}
+		return model.DefaultPrecision
+	}
 }
 
-func (v *View) amountAccept(form *tview.Form) func(string, rune) bool {
+// amountAccept returns accept func for amount input field with given label. Number of digits
+// after "." is limited by given precision.
+func (v *View) amountAccept(form *tview.Form, label string, precision func() int64) func(string, rune) bool {
 	return func(textToCheck string, lastChar rune) bool {
-		amountField := form.GetFormItem(3).(*tview.InputField)
+		amountField := form.GetFormItemByLabel(label).(*tview.InputField)
 		if lastChar == '-' || lastChar == '+' {
 			t := amountField.GetText()
 			if t != "" && (t[0] == '-' || t[0] == '+') {
@@ -150,7 +342,7 @@ func (v *View) amountAccept(form *tview.Form) func(string, rune) bool {
 		}
 		if strings.Count(textToCheck, ".") == 1 {
 			splitted := strings.Split(textToCheck, ".")
-			if len(splitted[1]) < 3 && (unicode.IsDigit(lastChar) || lastChar == '.') {
+			if p := precision(); p > 0 && int64(len(splitted[1])) <= p && (unicode.IsDigit(lastChar) || lastChar == '.') {
 				return true
 			}
 		} else if unicode.IsDigit(lastChar) {
@@ -163,8 +355,9 @@ func (v *View) amountAccept(form *tview.Form) func(string, rune) bool {
 // showCreateForm shows create form with initialized empty fields.
 func (v *View) showCreateForm() {
 	d := time.Now().Format("2006-01-02")
-	m := map[string]string{"Date": d, "Account": "", "Category": "", "Amount": "", "Note": ""}
+	m := map[string]string{"Date": d, "Payee": "", "Account": "", "Category": "", "Amount": "", "Note": "", "Tags": ""}
 
+	v.splits = nil
 	v.createForm.SetFields(m)
 	v.Pages.ShowPage("createForm")
 }
@@ -208,18 +401,33 @@ func (v *View) submitCreateForm() {
 		return
 	}
 
+	tr.Splits, err = v.presenter.Transaction().SplitsFromMaps(v.splits, tr.Account)
+	if err != nil {
+		v.showError("Error parse splits: \n" + err.Error())
+		return
+	}
+
 	if err := v.service.Transaction().Insert(tr); err != nil {
 		v.showError("Error insert transaction: \n" + err.Error())
 		return
 	}
 
-	v.table.Refresh()
+	v.Refresh()
 	v.hideCreateForm()
+	v.warnKind(tr)
 }
 
 // showUpdateForm shows update form with initialized with selected transaction fields.
 func (v *View) showUpdateForm() {
 	m := v.getSelectedRef()
+
+	tr, err := v.presenter.Transaction().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	v.splits = v.presenter.Transaction().SplitsToMaps(v.service.Transaction().GetByID(tr.ID).Splits, tr.Account)
 	v.updateForm.SetFields(m)
 	v.Pages.ShowPage("updateForm")
 }
@@ -245,13 +453,20 @@ func (v *View) submitUpdateForm() {
 		return
 	}
 
+	tr.Splits, err = v.presenter.Transaction().SplitsFromMaps(v.splits, tr.Account)
+	if err != nil {
+		v.showError("Error parse splits: \n" + err.Error())
+		return
+	}
+
 	if err := v.service.Transaction().Update(tr); err != nil {
 		v.showError("Error update transaction: \n" + err.Error())
 		return
 	}
 
-	v.table.Refresh()
+	v.Refresh()
 	v.hideUpdateForm()
+	v.warnKind(tr)
 }
 
 // showDeleteModal shows delete modal.
@@ -278,10 +493,53 @@ func (v *View) submitDeleteModal() {
 		return
 	}
 
-	v.table.Refresh()
+	v.Refresh()
 	v.hideDeleteModal()
 }
 
+// Refresh refreshes table and total of all transactions in the main currency along with totals of
+// income and expense categories. In account register mode the current balance of the account is
+// shown instead of totals.
+func (v *View) Refresh() {
+	v.table.Refresh()
+
+	if a := v.dataProvider.Account(); a != nil {
+		balance, err := v.service.Transaction().Balance(a)
+		if err != nil {
+			v.total.SetText("Balance: " + err.Error() + " ")
+			return
+		}
+
+		v.total.SetText("Balance: " + v.presenter.Account().ReprBalance(a, balance) + " ")
+		return
+	}
+
+	tt := v.service.Transaction().GetAll()
+
+	total, err := v.service.ExchangeRate().TotalInMain(tt)
+	if err != nil {
+		v.total.SetText("Total: " + err.Error() + " ")
+		return
+	}
+
+	totals, err := v.service.Transaction().TotalsByKind(tt)
+	if err != nil {
+		v.total.SetText("Total: " + err.Error() + " ")
+		return
+	}
+
+	v.total.SetText("Income: " + v.presenter.ExchangeRate().ReprInMain(totals[model.Income]) +
+		"  Expense: " + v.presenter.ExchangeRate().ReprInMain(totals[model.Expense]) +
+		"  Total: " + v.presenter.ExchangeRate().ReprInMain(total) + " ")
+}
+
+// warnKind shows warning if sign of saved transaction contradicts kind of its category.
+func (v *View) warnKind(tr *model.Transaction) {
+	if err := v.service.Transaction().CheckKind(tr); err != nil {
+		v.showError("Warning: \n" + err.Error())
+	}
+}
+
 // showError shows error modal.
 func (v *View) showError(text string) {
 	v.errorModal.SetText(text)
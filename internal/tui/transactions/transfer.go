@@ -0,0 +1,141 @@
+package transactions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/rivo/tview"
+)
+
+// newTransferForm returns new form with corresponding transfer fields.
+func (v *View) newTransferForm(title string, submit func(), cancel func(), dataProvider *DataProvider) *ext.Form {
+	form := tview.NewForm()
+	form = form.AddFormItem(ext.NewDateField().SetLabel("Date")).
+		AddDropDown("Category", nil, 0, nil).
+		AddDropDown("From", nil, 0, nil).
+		AddDropDown("To", nil, 0, nil).
+		AddInputField("Amount", "", 0, v.amountAccept(form, "Amount", v.precisionOf(form, "From")), nil).
+		AddInputField("To Amount", "", 0, v.amountAccept(form, "To Amount", v.precisionOf(form, "To")), nil).
+		AddInputField("Note", "", 0, nil, nil).
+		AddButton(strings.Split(title, " ")[0], submit).
+		AddButton("Cancel", cancel)
+
+	form.SetBorder(true)
+	form.SetTitle(title)
+	form.SetCancelFunc(cancel)
+
+	return ext.NewForm(form, dataProvider)
+}
+
+// showTransferCreateForm shows transfer create form with initialized empty fields.
+func (v *View) showTransferCreateForm() {
+	d := time.Now().Format("2006-01-02")
+	m := map[string]string{"Date": d, "Category": "", "From": "", "To": "", "Amount": "", "To Amount": "", "Note": ""}
+
+	v.transferCreateForm.SetFields(m)
+	v.Pages.ShowPage("transferCreateForm")
+}
+
+// hideTransferCreateForm hides transfer create form.
+func (v *View) hideTransferCreateForm() {
+	v.Pages.HidePage("transferCreateForm")
+}
+
+// isValidTransferForm checks if all necessary fields are filled.
+func (v *View) isValidTransferForm(m map[string]string) bool {
+	for _, label := range []string{"From", "To", "Category", "Amount"} {
+		if value, ok := m[label]; !ok || value == "" {
+			v.showError("Can't create transfer without " + strings.ToLower(label) + ".")
+			return false
+		}
+	}
+
+	if m["From"] == m["To"] {
+		v.showError("Can't transfer to the same account.")
+		return false
+	}
+
+	from := v.service.Account().GetByName(m["From"])
+	to := v.service.Account().GetByName(m["To"])
+	if from.Currency.ID != to.Currency.ID && m["To Amount"] == "" {
+		v.showError("Accounts have different currencies, please fill to amount.")
+		return false
+	}
+
+	return true
+}
+
+// submitTransferCreateForm transfer create form submit handler.
+func (v *View) submitTransferCreateForm() {
+	m := v.transferCreateForm.GetFields()
+	if !v.isValidTransferForm(m) {
+		return
+	}
+
+	tr, err := v.presenter.Transfer().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Transaction().InsertTransfer(tr); err != nil {
+		v.showError("Error insert transfer: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideTransferCreateForm()
+}
+
+// showTransferUpdateForm shows update form with initialized with selected transfer fields.
+func (v *View) showTransferUpdateForm() {
+	m := v.presenter.Transfer().ToMap(v.getSelectedTransfer())
+	v.transferUpdateForm.SetFields(m)
+	v.Pages.ShowPage("transferUpdateForm")
+}
+
+// hideTransferUpdateForm hides transfer update form.
+func (v *View) hideTransferUpdateForm() {
+	v.Pages.HidePage("transferUpdateForm")
+}
+
+// submitTransferUpdateForm transfer update form submit handler.
+func (v *View) submitTransferUpdateForm() {
+	m := v.transferUpdateForm.GetFields()
+	if !v.isValidTransferForm(m) {
+		return
+	}
+
+	selected := v.getSelectedTransfer()
+	m["ID"] = strconv.Itoa(int(selected.ID))
+
+	tr, err := v.presenter.Transfer().FromMap(m)
+	if err != nil {
+		v.showError("Error parse form: \n" + err.Error())
+		return
+	}
+	tr.From.ID, tr.To.ID = selected.From.ID, selected.To.ID
+
+	if err := v.service.Transaction().UpdateTransfer(tr); err != nil {
+		v.showError("Error update transfer: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+	v.hideTransferUpdateForm()
+}
+
+// getSelectedTransfer returns transfer which selected transaction is a leg of, or nil if selected
+// transaction isn't a part of transfer.
+func (v *View) getSelectedTransfer() *model.Transfer {
+	id, err := strconv.Atoi(v.table.GetSelectedRef()["ID"])
+	if err != nil {
+		return nil
+	}
+
+	return v.service.Transaction().GetTransferByTransactionID(int64(id))
+}
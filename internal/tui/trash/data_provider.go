@@ -0,0 +1,39 @@
+package trash
+
+import (
+	"github.com/kotlw/gentlemoney/internal/model"
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+)
+
+// DataProvider implements ext.TableDataProvider for interaction with items in trash.
+type DataProvider struct {
+	service   *service.Service
+	presenter *presenter.Presenter
+	err       error
+}
+
+// NewDataProvider returns new DataProvider.
+func NewDataProvider(service *service.Service, presenter *presenter.Presenter) *DataProvider {
+	return &DataProvider{service: service, presenter: presenter}
+}
+
+// GetAll returns slice of maps which represents items in trash. Error of the last call is kept
+// and could be obtained by Err.
+func (d *DataProvider) GetAll() []map[string]string {
+	var data []*model.TrashItem
+	data, d.err = d.service.Trash().GetAll()
+
+	res := make([]map[string]string, len(data))
+
+	for i, e := range data {
+		res[i] = d.presenter.Trash().ToMap(e)
+	}
+
+	return res
+}
+
+// Err returns error of the last GetAll call.
+func (d *DataProvider) Err() error {
+	return d.err
+}
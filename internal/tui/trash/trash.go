@@ -0,0 +1,158 @@
+package trash
+
+import (
+	"github.com/kotlw/gentlemoney/internal/presenter"
+	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/tui/ext"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// View is a trash view.
+type View struct {
+	*tview.Pages
+
+	service      *service.Service
+	presenter    *presenter.Presenter
+	dataProvider *DataProvider
+
+	table      *ext.Table
+	purgeModal *tview.Modal
+	errorModal *tview.Modal
+}
+
+// New returns new trash view.
+func New(service *service.Service, presenter *presenter.Presenter) *View {
+	v := &View{
+		Pages: tview.NewPages(),
+
+		service:   service,
+		presenter: presenter,
+	}
+
+	v.dataProvider = NewDataProvider(v.service, v.presenter)
+
+	// table
+	v.table = ext.NewTable([]string{"Kind", "Title", "Deleted"}, v.dataProvider).SetOrder("Deleted", true)
+	v.table.SetTitle("Trash")
+	v.table.Refresh()
+	v.AddPage("table", v.table, true, true)
+
+	// purge modal
+	v.purgeModal = ext.NewAskModal("Delete permanently?", v.submitPurgeModal, v.hidePurgeModal)
+	v.AddPage("purgeModal", v.purgeModal, true, false)
+
+	// error modal
+	v.errorModal = ext.NewErrorModal(v.hideError)
+	v.AddPage("errorModal", v.errorModal, true, false)
+
+	return v
+}
+
+// Refresh refreshes trash table.
+func (v *View) Refresh() {
+	v.table.Refresh()
+	if err := v.dataProvider.Err(); err != nil {
+		v.showError("Error load trash: \n" + err.Error())
+	}
+}
+
+// ModalHasFocus returns true if any of modal is currently on focus.
+func (v *View) ModalHasFocus() bool {
+	return v.purgeModal.HasFocus() || v.errorModal.HasFocus()
+}
+
+// InputHandler returns the handler for this primitive.
+func (v *View) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if v.table.HasFocus() {
+			switch event.Rune() {
+			case 'r':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.restore()
+				} else {
+					v.showError("Nothing to restore")
+				}
+			case 'd':
+				if len(v.table.GetSelectedRef()) != 0 {
+					v.showPurgeModal()
+				} else {
+					v.showError("Nothing to delete")
+				}
+			}
+
+			// if none of keys has pressed use standard table input handler.
+			if handler := v.table.InputHandler(); handler != nil {
+				handler(event, setFocus)
+
+				return
+			}
+		}
+
+		// give control to the child view.
+		for _, modal := range []tview.Primitive{v.purgeModal, v.errorModal} {
+			if modal.HasFocus() {
+				if handler := modal.InputHandler(); handler != nil {
+					handler(event, setFocus)
+
+					return
+				}
+			}
+		}
+	})
+}
+
+// restore brings selected item back from trash.
+func (v *View) restore() {
+	item, err := v.presenter.Trash().FromMap(v.table.GetSelectedRef())
+	if err != nil {
+		v.showError("Error parse item: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Trash().Restore(item); err != nil {
+		v.showError("Error restore item: \n" + err.Error())
+		return
+	}
+
+	v.Refresh()
+}
+
+// showPurgeModal shows purge modal.
+func (v *View) showPurgeModal() {
+	v.Pages.ShowPage("purgeModal")
+}
+
+// hidePurgeModal hides purge modal.
+func (v *View) hidePurgeModal() {
+	v.Pages.HidePage("purgeModal")
+}
+
+// submitPurgeModal purge modal submit handler.
+func (v *View) submitPurgeModal() {
+	item, err := v.presenter.Trash().FromMap(v.table.GetSelectedRef())
+	if err != nil {
+		v.showError("Error parse item: \n" + err.Error())
+		return
+	}
+
+	if err := v.service.Trash().Purge(item); err != nil {
+		v.showError("Error delete item: \n" + err.Error())
+		return
+	}
+
+	v.hidePurgeModal()
+	v.Refresh()
+}
+
+// showError shows error modal.
+func (v *View) showError(text string) {
+	v.errorModal.SetText(text)
+	v.Pages.ShowPage("errorModal")
+}
+
+// hideError hides error modal.
+func (v *View) hideError() {
+	v.Pages.HidePage("errorModal")
+}
@@ -3,21 +3,59 @@ package tui
 import (
 	"fmt"
 
+	"github.com/kotlw/gentlemoney/config"
 	"github.com/kotlw/gentlemoney/internal/presenter"
 	"github.com/kotlw/gentlemoney/internal/service"
+	"github.com/kotlw/gentlemoney/internal/tui/budgets"
+	"github.com/kotlw/gentlemoney/internal/tui/debts"
+	"github.com/kotlw/gentlemoney/internal/tui/goals"
+	"github.com/kotlw/gentlemoney/internal/tui/recurrences"
 	"github.com/kotlw/gentlemoney/internal/tui/settings"
 	"github.com/kotlw/gentlemoney/internal/tui/transactions"
+	"github.com/kotlw/gentlemoney/internal/tui/trash"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
-func New(service *service.Service, presenter *presenter.Presenter) *tview.Application {
-	app := tview.NewApplication()
-	root := NewRoot(app, service, presenter)
-	app.SetRoot(root, true).EnableMouse(false)
+// Profiles is a registry of separate ledgers, only one of them is open at a time.
+type Profiles interface {
+	List() ([]string, error)
+	Current() string
+	Backend() string
+	Open(name string) (*service.Service, *presenter.Presenter, error)
+}
+
+// New returns new tui application with open profile of given name, empty name means that profile
+// is picked by user at startup.
+func New(profiles Profiles, name string) (*tview.Application, error) {
+	app := tview.NewApplication().EnableMouse(false)
+
+	if name == "" {
+		picker := NewPicker(profiles, func(name string) error { return Open(app, profiles, name) }, app.Stop)
+		app.SetRoot(picker, true)
+		picker.Show()
+
+		return app, nil
+	}
+
+	if err := Open(app, profiles, name); err != nil {
+		return nil, fmt.Errorf("Open: %w", err)
+	}
+
+	return app, nil
+}
+
+// Open opens profile of given name and replaces root of the app with new views of its data.
+func Open(app *tview.Application, profiles Profiles, name string) error {
+	service, presenter, err := profiles.Open(name)
+	if err != nil {
+		return fmt.Errorf("profiles.Open: %w", err)
+	}
+
+	app.SetRoot(NewRoot(app, profiles, service, presenter), true)
 
-	return app
+	return nil
 }
 
 // Root is the root view of the app. It aggregates pages and navbar in flex.
@@ -25,29 +63,61 @@ type Root struct {
 	*tview.Flex
 
 	navbar       *tview.TextView
+	status       *tview.TextView
 	pages        *tview.Pages
+	history      *service.History
 	transactions *transactions.View
+	budgets      *budgets.View
+	recurrences  *recurrences.View
+	goals        *goals.View
+	debts        *debts.View
+	trash        *trash.View
 	settings     *settings.View
+	picker       *Picker
+	basic        bool
 }
 
 // New returns Root.
-func NewRoot(app *tview.Application, service *service.Service, presenter *presenter.Presenter) *Root {
+func NewRoot(app *tview.Application, profiles Profiles, service *service.Service, presenter *presenter.Presenter) *Root {
 	root := &Root{
 		Flex: tview.NewFlex().SetDirection(tview.FlexRow),
 		navbar: tview.NewTextView().
 			SetDynamicColors(true).
 			SetRegions(true).
 			SetWrap(false),
-		pages: tview.NewPages(),
+		status:  tview.NewTextView().SetDynamicColors(true),
+		pages:   tview.NewPages(),
+		history: service.History(),
+		// journal keeps only currencies, categories, accounts and transactions, so views and
+		// fields of the rest aren't offered.
+		basic: profiles.Backend() == config.JournalBackend,
 	}
 
 	root.AddItem(root.navbar, 1, 1, false)
 	root.AddItem(root.pages, 0, 16, true)
+	root.AddItem(root.status, 1, 1, false)
 
-	root.transactions = transactions.New(service, presenter)
-	root.settings = settings.New(app, service, presenter)
+	root.transactions = transactions.New(service, presenter, root.basic)
+	root.budgets = budgets.New(service, presenter)
+	root.recurrences = recurrences.New(service, presenter)
+	root.goals = goals.New(service, presenter)
+	root.debts = debts.New(service, presenter)
+	root.trash = trash.New(service, presenter)
+	root.settings = settings.New(app, service, presenter, root.basic)
 	root.AddView('1', "Transactions", root.transactions)
+	if !root.basic {
+		root.AddView('2', "Budgets", root.budgets)
+		root.AddView('3', "Recurring", root.recurrences)
+		root.AddView('4', "Goals", root.goals)
+		root.AddView('5', "Debts", root.debts)
+	}
+	root.AddView('6', "Trash", root.trash)
 	root.AddView('0', "Settings", root.settings)
+	fmt.Fprintf(root.navbar, `  [::d]Ctrl+P profile: %s[::-]`, profiles.Current())
+
+	// switching profile replaces the whole root, so nothing to restore after it.
+	root.picker = NewPicker(profiles, func(name string) error { return Open(app, profiles, name) }, root.hidePicker)
+	root.pages.AddPage("Picker", root.picker, true, false)
 
 	root.SwitchToView("Transactions")
 
@@ -67,9 +137,53 @@ func (r *Root) SwitchToView(name string) {
 	r.pages.SwitchToPage(name)
 }
 
+// undo reverts the latest operation, refreshes views and shows what was undone in status line.
+func (r *Root) undo() {
+	description, err := r.history.Undo()
+	r.showResult("Undone", description, err)
+}
+
+// redo applies the latest undone operation again, refreshes views and shows what was redone in
+// status line.
+func (r *Root) redo() {
+	description, err := r.history.Redo()
+	r.showResult("Redone", description, err)
+}
+
+// showResult refreshes views after undo or redo and shows its result in status line.
+func (r *Root) showResult(action, description string, err error) {
+	r.transactions.Refresh()
+	r.budgets.Refresh()
+	r.recurrences.Refresh()
+	r.goals.Refresh()
+	r.debts.Refresh()
+	r.trash.Refresh()
+	r.settings.Refresh()
+
+	r.status.Clear()
+	if err != nil {
+		fmt.Fprintf(r.status, "[red]%s", tview.Escape(err.Error()))
+		return
+	}
+	fmt.Fprintf(r.status, "%s: %s", action, tview.Escape(description))
+}
+
+// showPicker shows profile picker on top of current view.
+func (r *Root) showPicker() {
+	r.pages.ShowPage("Picker")
+	r.picker.Show()
+}
+
+// hidePicker hides profile picker.
+func (r *Root) hidePicker() {
+	r.pages.HidePage("Picker")
+}
+
 // IsModalOnTop check if modal of any child view is on top.
 func (r *Root) IsModalOnTop() bool {
-	return r.transactions.ModalHasFocus() || r.settings.ModalHasFocus()
+	return r.transactions.ModalHasFocus() || r.budgets.ModalHasFocus() || r.recurrences.ModalHasFocus() ||
+		r.goals.ModalHasFocus() || r.debts.ModalHasFocus() || r.trash.ModalHasFocus() || r.settings.ModalHasFocus() ||
+		r.picker.HasFocus()
 }
 
 // InputHandler returns the handler for this primitive.
@@ -77,18 +191,60 @@ func (r *Root) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 	return r.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
 		// modal shouldn't be on top, to perform switch view.
 		if !r.IsModalOnTop() {
+			if event.Key() == tcell.KeyCtrlP {
+				r.showPicker()
+				return
+			}
+
 			switch event.Rune() {
+			case 'z':
+				r.undo()
+				return
+			case 'Z':
+				r.redo()
+				return
 			case '1':
+				r.transactions.Refresh()
 				r.SwitchToView("Transactions")
 				return
+			case '2':
+				if !r.basic {
+					r.budgets.Refresh()
+					r.SwitchToView("Budgets")
+				}
+				return
+			case '3':
+				if !r.basic {
+					r.recurrences.Refresh()
+					r.SwitchToView("Recurring")
+				}
+				return
+			case '4':
+				if !r.basic {
+					r.goals.Refresh()
+					r.SwitchToView("Goals")
+				}
+				return
+			case '5':
+				if !r.basic {
+					r.debts.Refresh()
+					r.SwitchToView("Debts")
+				}
+				return
+			case '6':
+				r.trash.Refresh()
+				r.SwitchToView("Trash")
+				return
 			case '0':
+				r.settings.Refresh()
 				r.SwitchToView("Settings")
 				return
 			}
 		}
 
 		// if modal is active all other handlers should be ignored except modal handler.
-		for _, view := range []tview.Primitive{r.transactions, r.settings} {
+		for _, view := range []tview.Primitive{r.picker, r.transactions, r.budgets, r.recurrences, r.goals, r.debts, r.trash,
+			r.settings} {
 			if view.HasFocus() {
 				// give control to the child view.
 				if handler := view.InputHandler(); handler != nil {